@@ -0,0 +1,62 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package animation
+
+import (
+	"github.com/zhangshican/go-capcut/internal/animation"
+	"github.com/zhangshican/go-capcut/metadata"
+)
+
+// AnimationType 动画类型枚举
+type AnimationType = animation.AnimationType
+
+const (
+	AnimationTypeIn    = animation.AnimationTypeIn    // 入场动画
+	AnimationTypeOut   = animation.AnimationTypeOut   // 出场动画
+	AnimationTypeGroup = animation.AnimationTypeGroup // 组合动画
+	AnimationTypeLoop  = animation.AnimationTypeLoop  // 循环动画
+)
+
+// Animation 一个视频/文本动画效果
+// 对应Python的Animation类
+type Animation = animation.Animation
+
+// NewAnimation 创建新的动画
+func NewAnimation(animMeta metadata.AnimationMeta, start, duration int64, animType AnimationType, isVideo bool) *Animation {
+	return animation.NewAnimation(animMeta, start, duration, animType, isVideo)
+}
+
+// VideoAnimation 一个视频动画效果
+// 对应Python的Video_animation类
+type VideoAnimation = animation.VideoAnimation
+
+// VideoAnimationInput 视频动画输入接口
+type VideoAnimationInput = animation.VideoAnimationInput
+
+// NewVideoAnimation 创建新的视频动画
+func NewVideoAnimation(animType VideoAnimationInput, start, duration int64) (*VideoAnimation, error) {
+	return animation.NewVideoAnimation(animType, start, duration)
+}
+
+// TextAnimation 一个文本动画效果
+// 对应Python的Text_animation类
+type TextAnimation = animation.TextAnimation
+
+// TextAnimationInput 文本动画输入接口
+type TextAnimationInput = animation.TextAnimationInput
+
+// NewTextAnimation 创建新的文本动画
+func NewTextAnimation(animType TextAnimationInput, start, duration int64) (*TextAnimation, error) {
+	return animation.NewTextAnimation(animType, start, duration)
+}
+
+// SegmentAnimations 附加于某素材上的一系列动画
+// 对应Python的Segment_animations类
+//
+// 对视频片段：入场、出场或组合动画；对文本片段：入场、出场或循环动画
+type SegmentAnimations = animation.SegmentAnimations
+
+// NewSegmentAnimations 创建新的片段动画序列
+func NewSegmentAnimations() *SegmentAnimations {
+	return animation.NewSegmentAnimations()
+}
@@ -0,0 +1,4 @@
+// Package animation 提供视频和文本片段的入场、出场、组合及循环动画
+//
+// 动画元数据可从metadata包中的枚举获取
+package animation
@@ -0,0 +1,82 @@
+package capcut_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/zhangshican/go-capcut"
+	"github.com/zhangshican/go-capcut/metadata"
+	"github.com/zhangshican/go-capcut/segment"
+)
+
+// TestPublicAPI 测试仅通过公开包创建并导出草稿
+func TestPublicAPI(t *testing.T) {
+	sf, err := capcut.NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	sf.AddTrack(capcut.TrackTypeVideo, nil).AddTrack(capcut.TrackTypeText, nil)
+
+	videoMat := &capcut.VideoMaterial{
+		MaterialID:   "video_001",
+		Path:         "/path/to/video.mp4",
+		MaterialType: capcut.MaterialTypeVideo,
+		CropSettings: capcut.NewCropSettings(),
+	}
+	videoSeg := segment.NewVideoSegment(videoMat.MaterialID, capcut.MustTrange("0s", "5s"), capcut.MustTrange("0s", "5s"), 1.0, 1.0, nil)
+	videoSeg.MaterialInstance = videoMat
+	if err := videoSeg.Animations.AddVideoAnimation(metadata.IntroType渐显, 0, 500000); err != nil {
+		t.Fatalf("添加动画失败: %v", err)
+	}
+	if err := sf.AddSegment(videoSeg, nil); err != nil {
+		t.Fatalf("添加视频片段失败: %v", err)
+	}
+
+	textSeg := segment.NewTextSegmentSimple("你好", capcut.MustTrange("1s", "5s"))
+	if err := sf.AddSegment(textSeg, nil); err != nil {
+		t.Fatalf("添加文本片段失败: %v", err)
+	}
+
+	if sf.Duration != 6*capcut.SEC {
+		t.Errorf("期望草稿时长为%d，实际为%d", 6*capcut.SEC, sf.Duration)
+	}
+
+	output, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("导出草稿失败: %v", err)
+	}
+
+	var content map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &content); err != nil {
+		t.Fatalf("解析导出的草稿失败: %v", err)
+	}
+	tracks, ok := content["tracks"].([]interface{})
+	if !ok || len(tracks) != 2 {
+		t.Fatalf("期望导出2条轨道，实际为%v", content["tracks"])
+	}
+	materials := content["materials"].(map[string]interface{})
+	if videos := materials["videos"].([]interface{}); len(videos) != 1 {
+		t.Errorf("期望导出1个视频素材，实际为%d", len(videos))
+	}
+	if texts := materials["texts"].([]interface{}); len(texts) != 1 {
+		t.Errorf("期望导出1个文本素材，实际为%d", len(texts))
+	}
+}
+
+// TestPublicAPITypeIdentity 测试公开类型与各子包之间可以互相使用
+func TestPublicAPITypeIdentity(t *testing.T) {
+	var seg segment.SegmentInterface = segment.NewAudioSegment("audio_001", capcut.NewTimerange(0, capcut.SEC), nil, 1.0, 1.0)
+
+	trackType, err := capcut.TrackTypeOfSegment(seg)
+	if err != nil {
+		t.Fatalf("推断轨道类型失败: %v", err)
+	}
+	if trackType != capcut.TrackTypeAudio {
+		t.Errorf("期望轨道类型为audio，实际为%s", trackType)
+	}
+
+	err = capcut.NewSegmentOverlapError(0, 10, 5, 15)
+	if !capcut.IsSegmentOverlap(err) {
+		t.Error("公开的错误类型判断函数应识别对应错误")
+	}
+}
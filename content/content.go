@@ -0,0 +1,124 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package content
+
+import (
+	"encoding/json"
+	"github.com/zhangshican/go-capcut/internal/content"
+)
+
+// DraftContent 草稿文件的内容
+type DraftContent = content.DraftContent
+
+// CanvasConfig 画布配置
+type CanvasConfig = content.CanvasConfig
+
+// Materials 草稿中按类别存放的素材
+//
+// 常用类别的素材以专门的类型表示，其余类别的素材以通用的Material表示并存放在Others中。
+// 某个类别的素材无法解析为对应的类型时，该类别的列表为nil，导出时原样写回原始数据
+type Materials = content.Materials
+
+// Material 通用的素材，用于未以专门类型建模的素材类别
+type Material = content.Material
+
+// VideoMaterial 视频或图片素材
+type VideoMaterial = content.VideoMaterial
+
+// Crop 视频素材的裁剪设置，各值为裁剪框四个顶点的归一化坐标
+type Crop = content.Crop
+
+// AudioMaterial 音频素材
+type AudioMaterial = content.AudioMaterial
+
+// TextMaterial 文本素材
+type TextMaterial = content.TextMaterial
+
+// SpeedMaterial 变速素材
+type SpeedMaterial = content.SpeedMaterial
+
+// StickerMaterial 贴纸素材
+type StickerMaterial = content.StickerMaterial
+
+// EffectMaterial 特效类素材，用于滤镜、文字气泡、花字、视频特效及音频特效
+type EffectMaterial = content.EffectMaterial
+
+// TransitionMaterial 转场效果
+type TransitionMaterial = content.TransitionMaterial
+
+// AudioFadeMaterial 音频淡入淡出效果
+type AudioFadeMaterial = content.AudioFadeMaterial
+
+// CanvasMaterial 背景填充
+type CanvasMaterial = content.CanvasMaterial
+
+// MaskMaterial 蒙版
+type MaskMaterial = content.MaskMaterial
+
+// MaskConfig 蒙版参数，位置及尺寸以素材尺寸为单位
+type MaskConfig = content.MaskConfig
+
+// AnimationMaterial 片段动画，一个片段的所有动画存放在同一个素材中
+type AnimationMaterial = content.AnimationMaterial
+
+// Animation 单个动画
+type Animation = content.Animation
+
+// Track 轨道
+type Track = content.Track
+
+// Segment 片段
+type Segment = content.Segment
+
+// Timerange 时间范围，单位为微秒
+type Timerange = content.Timerange
+
+// Clip 片段的图像调节设置
+type Clip = content.Clip
+
+// Flip 翻转设置
+type Flip = content.Flip
+
+// Vector 二维向量，用于缩放和位移
+type Vector = content.Vector
+
+// KeyframeList 一个属性的关键帧列表
+type KeyframeList = content.KeyframeList
+
+// Keyframe 关键帧
+type Keyframe = content.Keyframe
+
+// Keyframes 草稿顶层按片段类型存放的关键帧列表
+//
+// 片段自身的关键帧存放在Segment.CommonKeyframes中，此处为剪映保存的另一份索引。
+// 某个类别的列表无法解析时该类别为nil，导出时原样写回原始数据
+type Keyframes = content.Keyframes
+
+// Fields 模型对象从JSON中解析得到的原始字段，嵌入在所有模型类型中
+//
+// 导出时，未建模的字段原样写回；已建模的字段若与原始值等价（数值相等即视为等价）则沿用原始写法，
+// 否则写入新值。原始数据中不存在的字段只有在值非零时才会写入。
+// 字段按原始数据中的顺序输出，新增的字段按字典序排在其后
+type Fields = content.Fields
+
+// MarshalIndent 以缩进格式编码通用的JSON值（如ToMap的结果），对象的键按layout中相同位置的对象的顺序输出
+//
+// layout通常为模型对象MarshalJSON的结果，数组元素按下标对应；layout中不存在的键按字典序排在后面
+func MarshalIndent(value interface{}, layout []byte, indent string) ([]byte, error) {
+	return content.MarshalIndent(value, layout, indent)
+}
+
+// FromMap 将map形式的JSON对象解析到模型对象中，map中未建模的字段会被保留
+func FromMap(m map[string]interface{}, target json.Unmarshaler) error {
+	return content.FromMap(m, target)
+}
+
+// ToMap 将模型对象转换为map形式的JSON对象，数值为json.Number类型
+func ToMap(source json.Marshaler) (map[string]interface{}, error) {
+	return content.ToMap(source)
+}
+
+// ToPlainMap 将模型对象转换为map形式的JSON对象，与encoding/json的默认解析结果相同，数值为float64类型
+func ToPlainMap(source json.Marshaler) (map[string]interface{}, error) {
+	return content.ToPlainMap(source)
+}
@@ -7,8 +7,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/zhangshican/go-capcut/internal/animation"
-	"github.com/zhangshican/go-capcut/internal/metadata"
+	"github.com/zhangshican/go-capcut/animation"
+	"github.com/zhangshican/go-capcut/metadata"
 )
 
 func main() {
@@ -12,7 +12,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/zhangshican/go-capcut/internal/draft"
+	"github.com/zhangshican/go-capcut"
 )
 
 func main() {
@@ -57,7 +57,7 @@ func demonstrateFolderManagement() {
 	fmt.Printf("📂 创建演示文件夹: %s\n", tempDir)
 
 	// 创建DraftFolder管理器
-	df, err := draft.NewDraftFolder(tempDir)
+	df, err := capcut.NewDraftFolder(tempDir)
 	if err != nil {
 		log.Fatalf("创建DraftFolder失败: %v", err)
 	}
@@ -68,7 +68,7 @@ func demonstrateFolderManagement() {
 	// 测试不存在的路径
 	fmt.Printf("\n🚫 测试不存在的路径:\n")
 	nonExistentPath := filepath.Join(tempDir, "non_existent")
-	_, err = draft.NewDraftFolder(nonExistentPath)
+	_, err = capcut.NewDraftFolder(nonExistentPath)
 	if err != nil {
 		fmt.Printf("   ✅ 正确处理不存在的路径: %v\n", err)
 	}
@@ -116,7 +116,7 @@ func demonstrateDraftListing() {
 	defer os.RemoveAll(tempDir)
 
 	// 创建DraftFolder管理器
-	df, err := draft.NewDraftFolder(tempDir)
+	df, err := capcut.NewDraftFolder(tempDir)
 	if err != nil {
 		log.Fatalf("创建DraftFolder失败: %v", err)
 	}
@@ -221,7 +221,7 @@ func demonstrateDraftDuplication() {
 	defer os.RemoveAll(tempDir)
 
 	// 创建DraftFolder管理器
-	df, err := draft.NewDraftFolder(tempDir)
+	df, err := capcut.NewDraftFolder(tempDir)
 	if err != nil {
 		log.Fatalf("创建DraftFolder失败: %v", err)
 	}
@@ -349,7 +349,7 @@ func demonstrateDraftDeletion() {
 	defer os.RemoveAll(tempDir)
 
 	// 创建DraftFolder管理器
-	df, err := draft.NewDraftFolder(tempDir)
+	df, err := capcut.NewDraftFolder(tempDir)
 	if err != nil {
 		log.Fatalf("创建DraftFolder失败: %v", err)
 	}
@@ -448,7 +448,7 @@ func demonstrateMaterialInspection() {
 	defer os.RemoveAll(tempDir)
 
 	// 创建DraftFolder管理器
-	df, err := draft.NewDraftFolder(tempDir)
+	df, err := capcut.NewDraftFolder(tempDir)
 	if err != nil {
 		log.Fatalf("创建DraftFolder失败: %v", err)
 	}
@@ -601,7 +601,7 @@ func demonstrateCompleteWorkflow() {
 
 	// 步骤1: 初始化草稿文件夹管理器
 	fmt.Printf("   📂 步骤1: 初始化草稿文件夹管理器\n")
-	df, err := draft.NewDraftFolder(tempDir)
+	df, err := capcut.NewDraftFolder(tempDir)
 	if err != nil {
 		log.Fatalf("创建DraftFolder失败: %v", err)
 	}
@@ -665,8 +665,8 @@ func demonstrateCompleteWorkflow() {
 	}
 
 	// 按类型分类显示
-	templates := make([]*draft.DraftInfo, 0)
-	activeProjects := make([]*draft.DraftInfo, 0)
+	templates := make([]*capcut.DraftInfo, 0)
+	activeProjects := make([]*capcut.DraftInfo, 0)
 
 	for _, info := range infos {
 		if info.Name == templateName {
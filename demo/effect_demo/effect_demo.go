@@ -7,10 +7,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/zhangshican/go-capcut/internal/metadata"
-	"github.com/zhangshican/go-capcut/internal/segment"
-	"github.com/zhangshican/go-capcut/internal/track"
-	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut"
+	"github.com/zhangshican/go-capcut/metadata"
+	"github.com/zhangshican/go-capcut/segment"
 )
 
 func main() {
@@ -69,7 +68,7 @@ func demonstrateEffectSegments() {
 	fmt.Printf("   - 参数数量: %d\n", len(effectMeta.Params))
 
 	// 创建时间范围
-	timerange := types.NewTimerange(2000000, 8000000) // 2-10秒
+	timerange := capcut.NewTimerange(2000000, 8000000) // 2-10秒
 
 	// 创建特效片段
 	params := []float64{70.0, 80.0} // 强度70%，半径80%
@@ -119,7 +118,7 @@ func demonstrateFilterSegments() {
 	fmt.Printf("   - 效果ID: %s\n", filterMeta.EffectID)
 
 	// 创建时间范围
-	timerange := types.NewTimerange(5000000, 12000000) // 5-17秒
+	timerange := capcut.NewTimerange(5000000, 12000000) // 5-17秒
 
 	// 创建滤镜片段
 	intensity := 85.0 // 85%强度
@@ -157,7 +156,7 @@ func demonstrateEffectTracks() {
 	fmt.Println("🛤️ === 特效轨道管理演示 ===")
 
 	// 创建特效轨道
-	effectTrack := track.NewTrack(track.TrackTypeEffect, "全局特效轨道", 0, false)
+	effectTrack := capcut.NewTrack(capcut.TrackTypeEffect, "全局特效轨道", 0, false)
 
 	fmt.Printf("🎬 特效轨道创建:\n")
 	fmt.Printf("   - 轨道ID: %s\n", effectTrack.GetTrackID())
@@ -194,7 +193,7 @@ func demonstrateEffectTracks() {
 		)
 
 		// 创建特效片段
-		timerange := types.NewTimerange(effect.start, effect.duration)
+		timerange := capcut.NewTimerange(effect.start, effect.duration)
 		effectSegment, err := segment.NewEffectSegment(effectMeta, timerange, effect.params)
 		if err != nil {
 			log.Fatalf("创建特效片段失败: %v", err)
@@ -228,7 +227,7 @@ func demonstrateEffectTracks() {
 	)
 
 	// 尝试添加与第一个片段重叠的特效
-	overlapTimerange := types.NewTimerange(1000000, 3000000) // 1-4秒，与第一个片段重叠
+	overlapTimerange := capcut.NewTimerange(1000000, 3000000) // 1-4秒，与第一个片段重叠
 	overlapSegment, err := segment.NewEffectSegment(overlapMeta, overlapTimerange, []float64{})
 	if err != nil {
 		log.Fatalf("创建重叠特效片段失败: %v", err)
@@ -247,7 +246,7 @@ func demonstrateFilterTracks() {
 	fmt.Println("🌈 === 滤镜轨道管理演示 ===")
 
 	// 创建滤镜轨道
-	filterTrack := track.NewTrack(track.TrackTypeFilter, "全局滤镜轨道", 0, false)
+	filterTrack := capcut.NewTrack(capcut.TrackTypeFilter, "全局滤镜轨道", 0, false)
 
 	fmt.Printf("🎨 滤镜轨道创建:\n")
 	fmt.Printf("   - 轨道ID: %s\n", filterTrack.GetTrackID())
@@ -279,7 +278,7 @@ func demonstrateFilterTracks() {
 		)
 
 		// 创建滤镜片段
-		timerange := types.NewTimerange(filter.start, filter.duration)
+		timerange := capcut.NewTimerange(filter.start, filter.duration)
 		filterSegment := segment.NewFilterSegment(filterMeta, timerange, filter.intensity)
 
 		// 添加到轨道
@@ -319,7 +318,7 @@ func demonstrateFilterTracks() {
 		[]metadata.EffectParam{},
 	)
 
-	wrongTimerange := types.NewTimerange(25000000, 2000000)
+	wrongTimerange := capcut.NewTimerange(25000000, 2000000)
 	wrongSegment, err := segment.NewEffectSegment(wrongMeta, wrongTimerange, []float64{})
 	if err != nil {
 		log.Fatalf("创建错误类型片段失败: %v", err)
@@ -376,7 +375,7 @@ func demonstrateComplexEffectParameters() {
 		{"全参数设置", []float64{70.0, 50.0, 85.0, 15.0, 60.0}, "所有参数自定义"},
 	}
 
-	timerange := types.NewTimerange(0, 5000000)
+	timerange := capcut.NewTimerange(0, 5000000)
 
 	fmt.Printf("\n🧪 参数组合测试:\n")
 	for i, paramSet := range paramSets {
@@ -432,10 +431,10 @@ func demonstrateTrackIntegrationAndExport() {
 	fmt.Println("📤 === 轨道集成和JSON导出演示 ===")
 
 	// 创建特效轨道
-	effectTrack := track.NewTrack(track.TrackTypeEffect, "主特效轨道", 10000, false)
+	effectTrack := capcut.NewTrack(capcut.TrackTypeEffect, "主特效轨道", 10000, false)
 
 	// 创建滤镜轨道
-	filterTrack := track.NewTrack(track.TrackTypeFilter, "主滤镜轨道", 11000, false)
+	filterTrack := capcut.NewTrack(capcut.TrackTypeFilter, "主滤镜轨道", 11000, false)
 
 	// 添加特效片段
 	effectMeta := metadata.NewEffectMeta(
@@ -449,7 +448,7 @@ func demonstrateTrackIntegrationAndExport() {
 		},
 	)
 
-	effectTimerange := types.NewTimerange(1000000, 6000000)
+	effectTimerange := capcut.NewTimerange(1000000, 6000000)
 	effectSegment, err := segment.NewEffectSegment(effectMeta, effectTimerange, []float64{80.0})
 	if err != nil {
 		log.Fatalf("创建特效片段失败: %v", err)
@@ -470,7 +469,7 @@ func demonstrateTrackIntegrationAndExport() {
 		[]metadata.EffectParam{},
 	)
 
-	filterTimerange := types.NewTimerange(2000000, 8000000)
+	filterTimerange := capcut.NewTimerange(2000000, 8000000)
 	filterSegment := segment.NewFilterSegment(filterMeta, filterTimerange, 75.0)
 
 	err = filterTrack.AddSegment(filterSegment)
@@ -537,8 +536,8 @@ func demonstrateCompleteEffectWorkflow() {
 
 	// 步骤1: 创建项目轨道
 	fmt.Printf("   📋 步骤1: 创建项目轨道\n")
-	effectTrack := track.NewTrack(track.TrackTypeEffect, "视频特效轨道", 0, false)
-	filterTrack := track.NewTrack(track.TrackTypeFilter, "视频滤镜轨道", 0, false)
+	effectTrack := capcut.NewTrack(capcut.TrackTypeEffect, "视频特效轨道", 0, false)
+	filterTrack := capcut.NewTrack(capcut.TrackTypeFilter, "视频滤镜轨道", 0, false)
 
 	fmt.Printf("     ✅ 特效轨道: %s (渲染索引: %d)\n", effectTrack.GetName(), effectTrack.GetRenderIndex())
 	fmt.Printf("     ✅ 滤镜轨道: %s (渲染索引: %d)\n", filterTrack.GetName(), filterTrack.GetRenderIndex())
@@ -569,7 +568,7 @@ func demonstrateCompleteEffectWorkflow() {
 			},
 		)
 
-		timerange := types.NewTimerange(
+		timerange := capcut.NewTimerange(
 			int64(effect.start*1e6),
 			int64(effect.duration*1e6),
 		)
@@ -611,7 +610,7 @@ func demonstrateCompleteEffectWorkflow() {
 			[]metadata.EffectParam{},
 		)
 
-		timerange := types.NewTimerange(
+		timerange := capcut.NewTimerange(
 			int64(filter.start*1e6),
 			int64(filter.duration*1e6),
 		)
@@ -657,7 +656,7 @@ func demonstrateCompleteEffectWorkflow() {
 			},
 		)
 
-		timerange := types.NewTimerange(
+		timerange := capcut.NewTimerange(
 			int64(effect.start*1e6),
 			int64(effect.duration*1e6),
 		)
@@ -4,14 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/zhangshican/go-capcut/internal/keyframe"
-	"github.com/zhangshican/go-capcut/internal/segment"
-	"github.com/zhangshican/go-capcut/internal/track"
-	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut"
+	"github.com/zhangshican/go-capcut/keyframe"
+	"github.com/zhangshican/go-capcut/segment"
 )
 
 func main() {
-	fmt.Println("=== Keyframe系统演示 ===\n")
+	fmt.Println("=== Keyframe系统演示 ===")
+	fmt.Println()
 
 	// 1. 演示关键帧创建
 	fmt.Println("1. 关键帧创建演示")
@@ -168,8 +168,8 @@ func main() {
 	fmt.Println("9. 与Segment系统集成演示")
 
 	// 创建视频片段
-	sourceRange, _ := types.Trange("0s", "10s")
-	targetRange, _ := types.Trange("2s", "8s")
+	sourceRange, _ := capcut.Trange("0s", "10s")
+	targetRange, _ := capcut.Trange("2s", "8s")
 
 	videoSeg := segment.NewVideoSegment("video_material_123", sourceRange, targetRange, 1.0, 0.8, nil)
 
@@ -200,7 +200,7 @@ func main() {
 	fmt.Println("10. 与Track系统集成演示")
 
 	// 创建视频轨道
-	videoTrack := track.NewTrack(track.TrackTypeVideo, "主视频轨道", 0, false)
+	videoTrack := capcut.NewTrack(capcut.TrackTypeVideo, "主视频轨道", 0, false)
 
 	// 添加片段到轨道
 	err = videoTrack.AddSegment(videoSeg)
@@ -309,7 +309,8 @@ func main() {
 	}
 	fmt.Println()
 
-	fmt.Println("=== Keyframe系统演示完成 ===\n")
+	fmt.Println("=== Keyframe系统演示完成 ===")
+	fmt.Println()
 
 	fmt.Println("已成功实现:")
 	fmt.Println("  - Keyframe: 单个关键帧，支持线性插值")
@@ -5,11 +5,12 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/zhangshican/go-capcut/internal/metadata"
+	"github.com/zhangshican/go-capcut/metadata"
 )
 
 func main() {
-	fmt.Println("=== CapCut Go Metadata系统演示 ===\n")
+	fmt.Println("=== CapCut Go Metadata系统演示 ===")
+	fmt.Println()
 
 	// 演示动画元数据
 	demonstrateAnimationMetadata()
@@ -9,9 +9,7 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/zhangshican/go-capcut/internal/material"
-	"github.com/zhangshican/go-capcut/internal/script"
-	"github.com/zhangshican/go-capcut/internal/track"
+	"github.com/zhangshican/go-capcut"
 )
 
 func main() {
@@ -47,7 +45,7 @@ func demonstrateScriptFileCreation() {
 	fmt.Println("🎬 === 草稿文件创建演示 ===")
 
 	// 创建1920x1080的草稿文件
-	sf, err := script.NewScriptFile(1920, 1080, 30)
+	sf, err := capcut.NewScriptFile(1920, 1080, 30)
 	if err != nil {
 		log.Fatalf("创建草稿文件失败: %v", err)
 	}
@@ -61,7 +59,7 @@ func demonstrateScriptFileCreation() {
 
 	// 创建不同规格的草稿文件
 	fmt.Printf("\n📱 创建竖屏草稿文件 (720x1280):\n")
-	verticalSF, err := script.NewScriptFile(720, 1280, 25)
+	verticalSF, err := capcut.NewScriptFile(720, 1280, 25)
 	if err != nil {
 		log.Fatalf("创建竖屏草稿文件失败: %v", err)
 	}
@@ -70,7 +68,7 @@ func demonstrateScriptFileCreation() {
 
 	// 创建使用默认帧率的草稿文件
 	fmt.Printf("\n🎥 创建4K草稿文件 (3840x2160, 默认30FPS):\n")
-	fourKSF, err := script.NewScriptFile(3840, 2160)
+	fourKSF, err := capcut.NewScriptFile(3840, 2160)
 	if err != nil {
 		log.Fatalf("创建4K草稿文件失败: %v", err)
 	}
@@ -82,19 +80,19 @@ func demonstrateScriptFileCreation() {
 func demonstrateMaterialManagement() {
 	fmt.Println("🎞️ === 素材管理演示 ===")
 
-	sf, err := script.NewScriptFile(1920, 1080)
+	sf, err := capcut.NewScriptFile(1920, 1080)
 	if err != nil {
 		log.Fatalf("创建草稿文件失败: %v", err)
 	}
 
 	// 创建视频素材
-	videoMaterial1 := &material.VideoMaterial{
+	videoMaterial1 := &capcut.VideoMaterial{
 		MaterialID: "video_001",
 		Path:       "/Users/demo/Videos/intro.mp4",
 		Width:      1920,
 		Height:     1080,
 		Duration:   5000000, // 5秒
-		CropSettings: &material.CropSettings{
+		CropSettings: &capcut.CropSettings{
 			UpperLeftX:  0.0,
 			UpperLeftY:  0.0,
 			LowerRightX: 1.0,
@@ -102,13 +100,13 @@ func demonstrateMaterialManagement() {
 		},
 	}
 
-	videoMaterial2 := &material.VideoMaterial{
+	videoMaterial2 := &capcut.VideoMaterial{
 		MaterialID: "video_002",
 		Path:       "/Users/demo/Videos/main_content.mp4",
 		Width:      1920,
 		Height:     1080,
 		Duration:   15000000, // 15秒
-		CropSettings: &material.CropSettings{
+		CropSettings: &capcut.CropSettings{
 			UpperLeftX:  0.0,
 			UpperLeftY:  0.0,
 			LowerRightX: 1.0,
@@ -117,13 +115,13 @@ func demonstrateMaterialManagement() {
 	}
 
 	// 创建音频素材
-	audioMaterial1 := &material.AudioMaterial{
+	audioMaterial1 := &capcut.AudioMaterial{
 		MaterialID: "audio_001",
 		Path:       "/Users/demo/Audio/background_music.mp3",
 		Duration:   20000000, // 20秒
 	}
 
-	audioMaterial2 := &material.AudioMaterial{
+	audioMaterial2 := &capcut.AudioMaterial{
 		MaterialID: "audio_002",
 		Path:       "/Users/demo/Audio/voice_over.wav",
 		Duration:   18000000, // 18秒
@@ -171,39 +169,39 @@ func demonstrateMaterialManagement() {
 func demonstrateTrackManagement() {
 	fmt.Println("🎚️ === 轨道管理演示 ===")
 
-	sf, err := script.NewScriptFile(1920, 1080, 30)
+	sf, err := capcut.NewScriptFile(1920, 1080, 30)
 	if err != nil {
 		log.Fatalf("创建草稿文件失败: %v", err)
 	}
 
 	// 添加主视频轨道
 	mainVideoTrack := "主视频轨道"
-	sf.AddTrack(track.TrackTypeVideo, &mainVideoTrack)
+	sf.AddTrack(capcut.TrackTypeVideo, &mainVideoTrack)
 	fmt.Printf("✅ 添加主视频轨道: %s\n", mainVideoTrack)
 
 	// 添加覆盖视频轨道（相对位置+1）
 	overlayVideoTrack := "覆盖视频轨道"
-	sf.AddTrack(track.TrackTypeVideo, &overlayVideoTrack, script.WithRelativeIndex(1))
+	sf.AddTrack(capcut.TrackTypeVideo, &overlayVideoTrack, capcut.WithRelativeIndex(1))
 	fmt.Printf("✅ 添加覆盖视频轨道: %s (相对层级+1)\n", overlayVideoTrack)
 
 	// 添加背景音乐轨道
 	bgMusicTrack := "背景音乐"
-	sf.AddTrack(track.TrackTypeAudio, &bgMusicTrack)
+	sf.AddTrack(capcut.TrackTypeAudio, &bgMusicTrack)
 	fmt.Printf("✅ 添加背景音乐轨道: %s\n", bgMusicTrack)
 
 	// 添加静音的语音轨道
 	voiceTrack := "语音轨道"
-	sf.AddTrack(track.TrackTypeAudio, &voiceTrack, script.WithMute(true))
+	sf.AddTrack(capcut.TrackTypeAudio, &voiceTrack, capcut.WithMute(true))
 	fmt.Printf("✅ 添加语音轨道: %s (静音状态)\n", voiceTrack)
 
 	// 添加文本轨道
 	textTrack := "字幕轨道"
-	sf.AddTrack(track.TrackTypeText, &textTrack, script.WithRelativeIndex(2))
+	sf.AddTrack(capcut.TrackTypeText, &textTrack, capcut.WithRelativeIndex(2))
 	fmt.Printf("✅ 添加字幕轨道: %s (相对层级+2)\n", textTrack)
 
 	// 添加特效轨道（使用绝对层级）
 	effectTrack := "特效轨道"
-	sf.AddTrack(track.TrackTypeEffect, &effectTrack, script.WithAbsoluteIndex(20000))
+	sf.AddTrack(capcut.TrackTypeEffect, &effectTrack, capcut.WithAbsoluteIndex(20000))
 	fmt.Printf("✅ 添加特效轨道: %s (绝对层级20000)\n", effectTrack)
 
 	// 显示轨道信息
@@ -235,7 +233,7 @@ func demonstrateTrackManagement() {
 	// 测试重复添加轨道
 	fmt.Printf("\n🔄 重复添加轨道测试:\n")
 	originalCount := len(sf.Tracks)
-	sf.AddTrack(track.TrackTypeVideo, &mainVideoTrack) // 重复添加
+	sf.AddTrack(capcut.TrackTypeVideo, &mainVideoTrack) // 重复添加
 	newCount := len(sf.Tracks)
 	fmt.Printf("   - 重复添加前轨道数量: %d\n", originalCount)
 	fmt.Printf("   - 重复添加后轨道数量: %d\n", newCount)
@@ -248,19 +246,19 @@ func demonstrateTrackManagement() {
 func demonstrateJSONOperations() {
 	fmt.Println("📄 === JSON操作演示 ===")
 
-	sf, err := script.NewScriptFile(1920, 1080, 25)
+	sf, err := capcut.NewScriptFile(1920, 1080, 25)
 	if err != nil {
 		log.Fatalf("创建草稿文件失败: %v", err)
 	}
 
 	// 添加一些内容
-	videoMaterial := &material.VideoMaterial{
+	videoMaterial := &capcut.VideoMaterial{
 		MaterialID: "demo_video_123",
 		Path:       "/demo/video.mp4",
 		Width:      1920,
 		Height:     1080,
 		Duration:   10000000, // 10秒
-		CropSettings: &material.CropSettings{
+		CropSettings: &capcut.CropSettings{
 			UpperLeftX:  0.0,
 			UpperLeftY:  0.0,
 			LowerRightX: 1.0,
@@ -269,7 +267,7 @@ func demonstrateJSONOperations() {
 	}
 	sf.AddMaterial(videoMaterial)
 
-	audioMaterial := &material.AudioMaterial{
+	audioMaterial := &capcut.AudioMaterial{
 		MaterialID: "demo_audio_456",
 		Path:       "/demo/audio.mp3",
 		Duration:   12000000, // 12秒
@@ -281,9 +279,9 @@ func demonstrateJSONOperations() {
 	audioTrack := "演示音频轨道"
 	textTrack := "演示文本轨道"
 
-	sf.AddTrack(track.TrackTypeVideo, &videoTrack)
-	sf.AddTrack(track.TrackTypeAudio, &audioTrack, script.WithMute(true))
-	sf.AddTrack(track.TrackTypeText, &textTrack, script.WithRelativeIndex(1))
+	sf.AddTrack(capcut.TrackTypeVideo, &videoTrack)
+	sf.AddTrack(capcut.TrackTypeAudio, &audioTrack, capcut.WithMute(true))
+	sf.AddTrack(capcut.TrackTypeText, &textTrack, capcut.WithRelativeIndex(1))
 
 	// 设置草稿时长
 	sf.Duration = 15000000 // 15秒
@@ -426,6 +424,10 @@ func demonstrateTemplateLoading() {
 							"start":    float64(0),
 							"duration": float64(5000000),
 						},
+						"source_timerange": map[string]interface{}{
+							"start":    float64(0),
+							"duration": float64(5000000),
+						},
 						"render_index": float64(0),
 					},
 				},
@@ -441,6 +443,10 @@ func demonstrateTemplateLoading() {
 							"start":    float64(0),
 							"duration": float64(8000000),
 						},
+						"source_timerange": map[string]interface{}{
+							"start":    float64(0),
+							"duration": float64(8000000),
+						},
 						"render_index": float64(0),
 					},
 				},
@@ -477,7 +483,7 @@ func demonstrateTemplateLoading() {
 
 	// 加载模板
 	fmt.Printf("\n📂 加载模板文件:\n")
-	sf, err := script.LoadTemplate(templateFile)
+	sf, err := capcut.LoadTemplate(templateFile)
 	if err != nil {
 		log.Fatalf("加载模板失败: %v", err)
 	}
@@ -490,18 +496,24 @@ func demonstrateTemplateLoading() {
 
 	// 显示导入的素材
 	fmt.Printf("\n📦 导入的素材:\n")
-	for materialType, materials := range sf.ImportedMaterials {
-		if len(materials) > 0 {
-			fmt.Printf("   - %s: %d个\n", materialType, len(materials))
-			for i, mat := range materials {
+	materials := sf.Content.Materials
+	for _, materialType := range materials.Kinds() {
+		count := materials.Len(materialType)
+		if count == 0 {
+			continue
+		}
+		fmt.Printf("   - %s: %d个\n", materialType, count)
+		switch materialType {
+		case "videos":
+			for i, mat := range materials.Videos {
 				if i < 2 { // 只显示前两个
-					if id, ok := mat["id"].(string); ok {
-						if path, ok := mat["path"].(string); ok {
-							fmt.Printf("     [%d] %s: %s\n", i+1, id, path)
-						} else {
-							fmt.Printf("     [%d] %s\n", i+1, id)
-						}
-					}
+					fmt.Printf("     [%d] %s: %s\n", i+1, mat.ID, mat.Path)
+				}
+			}
+		case "audios":
+			for i, mat := range materials.Audios {
+				if i < 2 {
+					fmt.Printf("     [%d] %s: %s\n", i+1, mat.ID, mat.Path)
 				}
 			}
 		}
@@ -545,7 +557,7 @@ func demonstrateCompleteWorkflow() {
 	fmt.Printf("🎬 创建新项目 - 制作一个简单的视频:\n")
 
 	// 第1步: 创建草稿文件
-	sf, err := script.NewScriptFile(1920, 1080, 30)
+	sf, err := capcut.NewScriptFile(1920, 1080, 30)
 	if err != nil {
 		log.Fatalf("创建草稿文件失败: %v", err)
 	}
@@ -554,13 +566,13 @@ func demonstrateCompleteWorkflow() {
 	// 第2步: 准备素材
 	fmt.Printf("   📁 步骤2: 准备素材\n")
 
-	introVideo := &material.VideoMaterial{
+	introVideo := &capcut.VideoMaterial{
 		MaterialID: "intro_clip",
 		Path:       "/project/assets/intro.mp4",
 		Width:      1920,
 		Height:     1080,
 		Duration:   3000000, // 3秒
-		CropSettings: &material.CropSettings{
+		CropSettings: &capcut.CropSettings{
 			UpperLeftX:  0.0,
 			UpperLeftY:  0.0,
 			LowerRightX: 1.0,
@@ -568,13 +580,13 @@ func demonstrateCompleteWorkflow() {
 		},
 	}
 
-	mainVideo := &material.VideoMaterial{
+	mainVideo := &capcut.VideoMaterial{
 		MaterialID: "main_content",
 		Path:       "/project/assets/main_video.mp4",
 		Width:      1920,
 		Height:     1080,
 		Duration:   20000000, // 20秒
-		CropSettings: &material.CropSettings{
+		CropSettings: &capcut.CropSettings{
 			UpperLeftX:  0.0,
 			UpperLeftY:  0.0,
 			LowerRightX: 1.0,
@@ -582,13 +594,13 @@ func demonstrateCompleteWorkflow() {
 		},
 	}
 
-	outroVideo := &material.VideoMaterial{
+	outroVideo := &capcut.VideoMaterial{
 		MaterialID: "outro_clip",
 		Path:       "/project/assets/outro.mp4",
 		Width:      1920,
 		Height:     1080,
 		Duration:   2000000, // 2秒
-		CropSettings: &material.CropSettings{
+		CropSettings: &capcut.CropSettings{
 			UpperLeftX:  0.0,
 			UpperLeftY:  0.0,
 			LowerRightX: 1.0,
@@ -596,13 +608,13 @@ func demonstrateCompleteWorkflow() {
 		},
 	}
 
-	backgroundMusic := &material.AudioMaterial{
+	backgroundMusic := &capcut.AudioMaterial{
 		MaterialID: "bg_music",
 		Path:       "/project/assets/background.mp3",
 		Duration:   30000000, // 30秒
 	}
 
-	voiceOver := &material.AudioMaterial{
+	voiceOver := &capcut.AudioMaterial{
 		MaterialID: "voice_narration",
 		Path:       "/project/assets/narration.wav",
 		Duration:   18000000, // 18秒
@@ -627,11 +639,11 @@ func demonstrateCompleteWorkflow() {
 	titleTrack := "标题轨道"
 	effectTrack := "特效轨道"
 
-	sf.AddTrack(track.TrackTypeVideo, &mainVideoTrack)                                // 主视频轨道
-	sf.AddTrack(track.TrackTypeAudio, &bgMusicTrack)                                  // 背景音乐
-	sf.AddTrack(track.TrackTypeAudio, &voiceTrack, script.WithRelativeIndex(1))       // 语音轨道 (层级+1)
-	sf.AddTrack(track.TrackTypeText, &titleTrack, script.WithRelativeIndex(2))        // 标题轨道 (层级+2)
-	sf.AddTrack(track.TrackTypeEffect, &effectTrack, script.WithAbsoluteIndex(20000)) // 特效轨道 (绝对层级)
+	sf.AddTrack(capcut.TrackTypeVideo, &mainVideoTrack)                                // 主视频轨道
+	sf.AddTrack(capcut.TrackTypeAudio, &bgMusicTrack)                                  // 背景音乐
+	sf.AddTrack(capcut.TrackTypeAudio, &voiceTrack, capcut.WithRelativeIndex(1))       // 语音轨道 (层级+1)
+	sf.AddTrack(capcut.TrackTypeText, &titleTrack, capcut.WithRelativeIndex(2))        // 标题轨道 (层级+2)
+	sf.AddTrack(capcut.TrackTypeEffect, &effectTrack, capcut.WithAbsoluteIndex(20000)) // 特效轨道 (绝对层级)
 
 	fmt.Printf("     - 创建了%d个轨道\n", len(sf.Tracks))
 
@@ -674,7 +686,7 @@ func demonstrateCompleteWorkflow() {
 	fmt.Printf("   🔍 步骤7: 验证项目文件\n")
 
 	// 重新加载项目验证
-	loadedSF, err := script.LoadTemplate(outputFile)
+	loadedSF, err := capcut.LoadTemplate(outputFile)
 	if err != nil {
 		fmt.Printf("     ❌ 验证失败: %v\n", err)
 	} else {
@@ -682,7 +694,7 @@ func demonstrateCompleteWorkflow() {
 		fmt.Printf("       - 分辨率匹配: %v\n", loadedSF.Width == sf.Width && loadedSF.Height == sf.Height)
 		fmt.Printf("       - 帧率匹配: %v\n", loadedSF.FPS == sf.FPS)
 		fmt.Printf("       - 时长匹配: %v\n", loadedSF.Duration == sf.Duration)
-		fmt.Printf("       - 导入素材: %d类型\n", len(loadedSF.ImportedMaterials))
+		fmt.Printf("       - 导入素材: %d类型\n", len(loadedSF.Content.Materials.Kinds()))
 		fmt.Printf("       - 导入轨道: %d个\n", len(loadedSF.ImportedTracks))
 	}
 
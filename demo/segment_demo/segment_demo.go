@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/zhangshican/go-capcut/internal/segment"
-	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut"
+	"github.com/zhangshican/go-capcut/segment"
 )
 
 func main() {
@@ -14,7 +14,7 @@ func main() {
 
 	// 演示基础片段
 	fmt.Println("\n1. 基础片段 (BaseSegment)")
-	timerange, _ := types.Trange("2s", "5s")
+	timerange, _ := capcut.Trange("2s", "5s")
 	baseSegment := segment.NewBaseSegment("base_material", timerange)
 	fmt.Printf("   片段ID: %s\n", baseSegment.SegmentID)
 	fmt.Printf("   素材ID: %s\n", baseSegment.MaterialID)
@@ -23,8 +23,8 @@ func main() {
 
 	// 演示视频片段
 	fmt.Println("\n2. 视频片段 (VideoSegment)")
-	sourceRange, _ := types.Trange("0s", "30s")
-	targetRange, _ := types.Trange("5s", "10s")
+	sourceRange, _ := capcut.Trange("0s", "30s")
+	targetRange, _ := capcut.Trange("5s", "10s")
 
 	// 创建自定义的图像调节设置
 	clipSettings := segment.NewClipSettingsWithParams(
@@ -48,7 +48,7 @@ func main() {
 	videoSegment.AddEffect("炫光特效", "glitch_001", "glitch_res", "video_effect", 0)
 	videoSegment.AddFilter("复古滤镜", "vintage_001", "vintage_res", 0.7, 0)
 
-	transitionDuration, _ := types.Tim("1s")
+	transitionDuration, _ := capcut.Tim("1s")
 	videoSegment.AddTransition("淡入淡出", "fade_001", "fade_res", transitionDuration)
 	videoSegment.SetBackgroundFilling("canvas_blur", 10.0, "")
 
@@ -59,15 +59,15 @@ func main() {
 
 	// 演示音频片段
 	fmt.Println("\n3. 音频片段 (AudioSegment)")
-	audioTargetRange, _ := types.Trange("0s", "15s")
+	audioTargetRange, _ := capcut.Trange("0s", "15s")
 	audioSegment := segment.NewAudioSegment("audio_material", audioTargetRange, nil, 1.2, 0.8)
 	fmt.Printf("   音频片段ID: %s\n", audioSegment.SegmentID)
 	fmt.Printf("   播放速度: %.1fx\n", audioSegment.Speed.Value)
 	fmt.Printf("   音量: %.1f\n", audioSegment.Volume)
 
 	// 添加淡入淡出和音效
-	inDuration, _ := types.Tim("2s")
-	outDuration, _ := types.Tim("1s")
+	inDuration, _ := capcut.Tim("2s")
+	outDuration, _ := capcut.Tim("1s")
 	audioSegment.Fade = segment.NewAudioFade(inDuration, outDuration)
 
 	audioSegment.AddEffect("回声", "echo_res", segment.AudioEffectCategorySoundEffect)
@@ -79,7 +79,7 @@ func main() {
 
 	// 演示文本片段
 	fmt.Println("\n4. 文本片段 (TextSegment)")
-	textRange, _ := types.Trange("3s", "8s")
+	textRange, _ := capcut.Trange("3s", "8s")
 
 	// 创建文本样式
 	textStyle := segment.NewTextStyleWithParams(
@@ -2,14 +2,15 @@ package main
 
 import (
 	"fmt"
-	"github.com/zhangshican/go-capcut/internal/types"
+
+	"github.com/zhangshican/go-capcut"
 )
 
 func main() {
 	fmt.Println("测试时间工具")
 
 	// 简单测试
-	result, err := types.Tim("5s")
+	result, err := capcut.Tim("5s")
 	if err != nil {
 		fmt.Printf("错误: %v\n", err)
 	} else {
@@ -17,6 +18,6 @@ func main() {
 	}
 
 	// 测试时间范围
-	tr := types.NewTimerange(1000000, 2000000)
+	tr := capcut.NewTimerange(1000000, 2000000)
 	fmt.Printf("时间范围: %s\n", tr)
 }
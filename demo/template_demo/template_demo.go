@@ -7,9 +7,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/zhangshican/go-capcut/internal/material"
-	"github.com/zhangshican/go-capcut/internal/template"
-	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut"
+	"github.com/zhangshican/go-capcut/template"
 )
 
 func main() {
@@ -297,7 +296,7 @@ func demonstrateTimerangeProcessing() {
 
 	// 演示缩短处理 - 将第一个片段从3秒缩短到2秒
 	fmt.Printf("\n🔧 缩短处理演示 (cut_tail模式):\n")
-	shortTimerange := types.NewTimerange(0, 2000000) // 缩短到2秒
+	shortTimerange := capcut.NewTimerange(0, 2000000) // 缩短到2秒
 	err = mediaTrack.ProcessTimerange(0, shortTimerange, template.ShrinkModeCutTail, nil)
 	if err != nil {
 		log.Fatalf("缩短处理失败: %v", err)
@@ -311,7 +310,7 @@ func demonstrateTimerangeProcessing() {
 
 	// 演示延长处理 - 将第一个片段从2秒延长到3.5秒
 	fmt.Printf("\n🔧 延长处理演示 (extend_tail模式):\n")
-	longTimerange := types.NewTimerange(0, 3500000) // 延长到3.5秒
+	longTimerange := capcut.NewTimerange(0, 3500000) // 延长到3.5秒
 	err = mediaTrack.ProcessTimerange(0, longTimerange, template.ShrinkModeCutTail, []template.ExtendMode{template.ExtendModeExtendTail})
 	if err != nil {
 		log.Fatalf("延长处理失败: %v", err)
@@ -325,7 +324,7 @@ func demonstrateTimerangeProcessing() {
 
 	// 演示push_tail模式 - 延长到会与下一个片段重叠的长度
 	fmt.Printf("\n🔧 推移处理演示 (push_tail模式):\n")
-	pushTimerange := types.NewTimerange(0, 5000000) // 延长到5秒，会与下个片段重叠
+	pushTimerange := capcut.NewTimerange(0, 5000000) // 延长到5秒，会与下个片段重叠
 	err = mediaTrack.ProcessTimerange(0, pushTimerange, template.ShrinkModeCutTail, []template.ExtendMode{template.ExtendModePushTail})
 	if err != nil {
 		log.Fatalf("推移处理失败: %v", err)
@@ -371,8 +370,8 @@ func demonstrateMaterialTypeCheck() {
 	}
 
 	// 创建测试素材
-	videoMaterial := &material.VideoMaterial{}
-	audioMaterial := &material.AudioMaterial{}
+	videoMaterial := &capcut.VideoMaterial{}
+	audioMaterial := &capcut.AudioMaterial{}
 
 	fmt.Printf("📹 视频轨道类型检查:\n")
 	fmt.Printf("   - 接受视频素材: %v\n", videoTrack.CheckMaterialType(videoMaterial))
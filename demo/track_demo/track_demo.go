@@ -5,9 +5,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/zhangshican/go-capcut/internal/segment"
-	"github.com/zhangshican/go-capcut/internal/track"
-	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut"
+	"github.com/zhangshican/go-capcut/segment"
 )
 
 func main() {
@@ -15,12 +14,12 @@ func main() {
 
 	// 演示轨道类型
 	fmt.Println("\n1. 轨道类型系统")
-	trackTypes := []track.TrackType{
-		track.TrackTypeVideo,
-		track.TrackTypeAudio,
-		track.TrackTypeText,
-		track.TrackTypeEffect,
-		track.TrackTypeFilter,
+	trackTypes := []capcut.TrackType{
+		capcut.TrackTypeVideo,
+		capcut.TrackTypeAudio,
+		capcut.TrackTypeText,
+		capcut.TrackTypeEffect,
+		capcut.TrackTypeFilter,
 	}
 
 	for _, tt := range trackTypes {
@@ -31,7 +30,7 @@ func main() {
 
 	// 演示创建视频轨道
 	fmt.Println("\n2. 创建视频轨道")
-	videoTrack := track.NewTrack(track.TrackTypeVideo, "主视频轨道", 0, false)
+	videoTrack := capcut.NewTrack(capcut.TrackTypeVideo, "主视频轨道", 0, false)
 	fmt.Printf("   轨道ID: %s\n", videoTrack.GetTrackID())
 	fmt.Printf("   轨道名称: %s\n", videoTrack.GetName())
 	fmt.Printf("   轨道类型: %s\n", videoTrack.GetTrackType().String())
@@ -42,9 +41,9 @@ func main() {
 	fmt.Println("\n3. 添加视频片段")
 
 	// 创建几个视频片段
-	timerange1, _ := types.Trange("0s", "5s")
-	timerange2, _ := types.Trange("6s", "4s")
-	timerange3, _ := types.Trange("11s", "3s")
+	timerange1, _ := capcut.Trange("0s", "5s")
+	timerange2, _ := capcut.Trange("6s", "4s")
+	timerange3, _ := capcut.Trange("11s", "3s")
 
 	segment1 := segment.NewVideoSegment("video1", nil, timerange1, 1.0, 0.8, nil)
 	segment2 := segment.NewVideoSegment("video2", nil, timerange2, 1.2, 0.9, nil)
@@ -76,7 +75,7 @@ func main() {
 
 	// 演示重叠检测
 	fmt.Println("\n4. 重叠检测演示")
-	overlapTimerange, _ := types.Trange("4s", "3s") // 与第一个片段重叠
+	overlapTimerange, _ := capcut.Trange("4s", "3s") // 与第一个片段重叠
 	overlapSegment := segment.NewVideoSegment("overlap_video", nil, overlapTimerange, 1.0, 1.0, nil)
 
 	err = videoTrack.AddSegment(overlapSegment)
@@ -88,10 +87,10 @@ func main() {
 
 	// 演示音频轨道
 	fmt.Println("\n5. 创建音频轨道")
-	audioTrack := track.NewTrack(track.TrackTypeAudio, "背景音乐", 0, false)
+	audioTrack := capcut.NewTrack(capcut.TrackTypeAudio, "背景音乐", 0, false)
 
 	// 添加音频片段
-	audioTimerange, _ := types.Trange("0s", "15s")
+	audioTimerange, _ := capcut.Trange("0s", "15s")
 	audioSegment := segment.NewAudioSegment("background_music", audioTimerange, nil, 1.0, 0.7)
 
 	// 添加音频特效
@@ -108,11 +107,11 @@ func main() {
 
 	// 演示文本轨道
 	fmt.Println("\n6. 创建文本轨道")
-	textTrack := track.NewTrack(track.TrackTypeText, "字幕轨道", 0, false)
+	textTrack := capcut.NewTrack(capcut.TrackTypeText, "字幕轨道", 0, false)
 
 	// 创建文本片段
-	textTimerange1, _ := types.Trange("2s", "3s")
-	textTimerange2, _ := types.Trange("8s", "4s")
+	textTimerange1, _ := capcut.Trange("2s", "3s")
+	textTimerange2, _ := capcut.Trange("8s", "4s")
 
 	textSegment1 := segment.NewTextSegmentSimple("欢迎观看视频", textTimerange1)
 	textSegment2 := segment.NewTextSegmentSimple("感谢您的观看", textTimerange2)
@@ -147,7 +146,7 @@ func main() {
 
 	// 演示多轨道系统
 	fmt.Println("\n8. 多轨道系统")
-	allTracks := []*track.Track{videoTrack, audioTrack, textTrack}
+	allTracks := []*capcut.Track{videoTrack, audioTrack, textTrack}
 
 	fmt.Printf("   总轨道数: %d\n", len(allTracks))
 	for i, t := range allTracks {
@@ -7,7 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut"
 )
 
 func main() {
@@ -73,7 +73,7 @@ func demonstrateTimeParsing() {
 	fmt.Printf("🔄 时间解析测试:\n")
 	successCount := 0
 	for i, test := range timeTests {
-		result, err := types.Tim(test.input)
+		result, err := capcut.Tim(test.input)
 		if test.shouldErr {
 			if err != nil {
 				fmt.Printf("   [%d] ✅ %s: 正确捕获错误 - %v\n", i+1, test.desc, err)
@@ -85,7 +85,7 @@ func demonstrateTimeParsing() {
 			if err != nil {
 				fmt.Printf("   [%d] ❌ %s: 解析失败 - %v\n", i+1, test.desc, err)
 			} else {
-				formatted := types.FormatDuration(result)
+				formatted := capcut.FormatDuration(result)
 				fmt.Printf("   [%d] ✅ %s: %v -> %s (%d微秒)\n", i+1, test.desc, test.input, formatted, result)
 				successCount++
 			}
@@ -106,39 +106,39 @@ func demonstrateTimerange() {
 	fmt.Printf("🏗️ 时间范围创建:\n")
 
 	// 基础时间范围
-	tr1 := types.NewTimerange(1000000, 2000000) // 1秒开始，持续2秒
+	tr1 := capcut.NewTimerange(1000000, 2000000) // 1秒开始，持续2秒
 	fmt.Printf("   1. 基础时间范围: %s\n", tr1)
-	fmt.Printf("      开始时间: %s\n", types.FormatDuration(tr1.Start))
-	fmt.Printf("      持续时间: %s\n", types.FormatDuration(tr1.Duration))
-	fmt.Printf("      结束时间: %s\n", types.FormatDuration(tr1.End()))
+	fmt.Printf("      开始时间: %s\n", capcut.FormatDuration(tr1.Start))
+	fmt.Printf("      持续时间: %s\n", capcut.FormatDuration(tr1.Duration))
+	fmt.Printf("      结束时间: %s\n", capcut.FormatDuration(tr1.End()))
 
 	// 使用便利函数创建
-	tr2, err := types.Trange("5s", "10s")
+	tr2, err := capcut.Trange("5s", "10s")
 	if err != nil {
 		fmt.Printf("   2. ❌ 便利函数创建失败: %v\n", err)
 	} else {
 		fmt.Printf("   2. 便利函数创建: %s\n", tr2)
-		fmt.Printf("      开始时间: %s\n", types.FormatDuration(tr2.Start))
-		fmt.Printf("      持续时间: %s\n", types.FormatDuration(tr2.Duration))
-		fmt.Printf("      结束时间: %s\n", types.FormatDuration(tr2.End()))
+		fmt.Printf("      开始时间: %s\n", capcut.FormatDuration(tr2.Start))
+		fmt.Printf("      持续时间: %s\n", capcut.FormatDuration(tr2.Duration))
+		fmt.Printf("      结束时间: %s\n", capcut.FormatDuration(tr2.End()))
 	}
 
 	// 使用MustTrange创建（不返回错误）
-	tr3 := types.MustTrange("0s", "30s")
+	tr3 := capcut.MustTrange("0s", "30s")
 	fmt.Printf("   3. MustTrange创建: %s\n", tr3)
-	fmt.Printf("      开始时间: %s\n", types.FormatDuration(tr3.Start))
-	fmt.Printf("      持续时间: %s\n", types.FormatDuration(tr3.Duration))
-	fmt.Printf("      结束时间: %s\n", types.FormatDuration(tr3.End()))
+	fmt.Printf("      开始时间: %s\n", capcut.FormatDuration(tr3.Start))
+	fmt.Printf("      持续时间: %s\n", capcut.FormatDuration(tr3.Duration))
+	fmt.Printf("      结束时间: %s\n", capcut.FormatDuration(tr3.End()))
 
 	// 复杂时间范围
-	tr4, err := types.Trange("1h30m45s", "2m15.5s")
+	tr4, err := capcut.Trange("1h30m45s", "2m15.5s")
 	if err != nil {
 		fmt.Printf("   4. ❌ 复杂时间范围创建失败: %v\n", err)
 	} else {
 		fmt.Printf("   4. 复杂时间范围: %s\n", tr4)
-		fmt.Printf("      开始时间: %s\n", types.FormatDuration(tr4.Start))
-		fmt.Printf("      持续时间: %s\n", types.FormatDuration(tr4.Duration))
-		fmt.Printf("      结束时间: %s\n", types.FormatDuration(tr4.End()))
+		fmt.Printf("      开始时间: %s\n", capcut.FormatDuration(tr4.Start))
+		fmt.Printf("      持续时间: %s\n", capcut.FormatDuration(tr4.Duration))
+		fmt.Printf("      结束时间: %s\n", capcut.FormatDuration(tr4.End()))
 	}
 
 	fmt.Printf("\n💡 时间范围应用场景:\n")
@@ -175,7 +175,7 @@ func demonstrateSrtTimestamp() {
 	fmt.Printf("🔄 SRT时间戳解析测试:\n")
 	successCount := 0
 	for i, test := range srtTests {
-		result, err := types.SrtTimestamp(test.timestamp)
+		result, err := capcut.SrtTimestamp(test.timestamp)
 		if test.shouldErr {
 			if err != nil {
 				fmt.Printf("   [%d] ✅ %s: 正确捕获错误 - %v\n", i+1, test.desc, err)
@@ -187,7 +187,7 @@ func demonstrateSrtTimestamp() {
 			if err != nil {
 				fmt.Printf("   [%d] ❌ %s: 解析失败 - %v\n", i+1, test.desc, err)
 			} else {
-				formatted := types.FormatDuration(result)
+				formatted := capcut.FormatDuration(result)
 				fmt.Printf("   [%d] ✅ %s: %s -> %s (%d微秒)\n", i+1, test.desc, test.timestamp, formatted, result)
 				successCount++
 			}
@@ -229,8 +229,8 @@ func demonstrateTimeFormatting() {
 
 	fmt.Printf("🔄 时间格式化测试:\n")
 	for i, test := range formatTests {
-		formatted := types.FormatDuration(test.micros)
-		seconds := types.MicrosecondsToSeconds(test.micros)
+		formatted := capcut.FormatDuration(test.micros)
+		seconds := capcut.MicrosecondsToSeconds(test.micros)
 		fmt.Printf("   [%d] %s: %d微秒 -> %s (%.3f秒)\n", i+1, test.desc, test.micros, formatted, seconds)
 	}
 
@@ -246,8 +246,8 @@ func demonstrateTimeFormatting() {
 	}
 
 	for i, micros := range precisionTests {
-		formatted := types.FormatDuration(micros)
-		seconds := types.MicrosecondsToSeconds(micros)
+		formatted := capcut.FormatDuration(micros)
+		seconds := capcut.MicrosecondsToSeconds(micros)
 		fmt.Printf("   [%d] %d微秒 -> %s (%.6f秒)\n", i+1, micros, formatted, seconds)
 	}
 
@@ -273,7 +273,7 @@ func demonstrateTimeConversion() {
 	}
 
 	for i, micros := range microsTests {
-		seconds := types.MicrosecondsToSeconds(micros)
+		seconds := capcut.MicrosecondsToSeconds(micros)
 		fmt.Printf("   [%d] %d微秒 -> %.6f秒\n", i+1, micros, seconds)
 	}
 
@@ -289,7 +289,7 @@ func demonstrateTimeConversion() {
 	}
 
 	for i, seconds := range secondsTests {
-		micros := types.SecondsToMicroseconds(seconds)
+		micros := capcut.SecondsToMicroseconds(seconds)
 		fmt.Printf("   [%d] %.6f秒 -> %d微秒\n", i+1, seconds, micros)
 	}
 
@@ -297,8 +297,8 @@ func demonstrateTimeConversion() {
 	fmt.Printf("\n🎯 往返转换精度测试:\n")
 	originalSeconds := []float64{0.0, 1.0, 1.5, 3661.0, -30.0, 0.000001}
 	for i, original := range originalSeconds {
-		micros := types.SecondsToMicroseconds(original)
-		convertedBack := types.MicrosecondsToSeconds(micros)
+		micros := capcut.SecondsToMicroseconds(original)
+		convertedBack := capcut.MicrosecondsToSeconds(micros)
 		diff := original - convertedBack
 		fmt.Printf("   [%d] %.6f秒 -> %d微秒 -> %.6f秒 (误差: %.10f)\n",
 			i+1, original, micros, convertedBack, diff)
@@ -316,15 +316,15 @@ func demonstrateJSONSerialization() {
 	fmt.Println("📄 === JSON序列化功能演示 ===")
 
 	// 创建测试时间范围
-	tr, err := types.Trange("1h30m45s", "2m15.5s")
+	tr, err := capcut.Trange("1h30m45s", "2m15.5s")
 	if err != nil {
 		fmt.Printf("❌ 创建时间范围失败: %v\n", err)
 		return
 	}
 
 	fmt.Printf("🏗️ 原始时间范围: %s\n", tr)
-	fmt.Printf("   开始时间: %s (%d微秒)\n", types.FormatDuration(tr.Start), tr.Start)
-	fmt.Printf("   持续时间: %s (%d微秒)\n", types.FormatDuration(tr.Duration), tr.Duration)
+	fmt.Printf("   开始时间: %s (%d微秒)\n", capcut.FormatDuration(tr.Start), tr.Start)
+	fmt.Printf("   持续时间: %s (%d微秒)\n", capcut.FormatDuration(tr.Duration), tr.Duration)
 
 	// 导出为JSON
 	fmt.Printf("\n📤 JSON导出:\n")
@@ -338,7 +338,7 @@ func demonstrateJSONSerialization() {
 
 	// 从JSON导入
 	fmt.Printf("\n📥 JSON导入:\n")
-	newTr := &types.Timerange{}
+	newTr := &capcut.Timerange{}
 	// 将map[string]int64转换为map[string]interface{}
 	jsonInterface := make(map[string]interface{})
 	for k, v := range jsonData {
@@ -351,8 +351,8 @@ func demonstrateJSONSerialization() {
 	}
 
 	fmt.Printf("   导入的时间范围: %s\n", newTr)
-	fmt.Printf("   开始时间: %s (%d微秒)\n", types.FormatDuration(newTr.Start), newTr.Start)
-	fmt.Printf("   持续时间: %s (%d微秒)\n", types.FormatDuration(newTr.Duration), newTr.Duration)
+	fmt.Printf("   开始时间: %s (%d微秒)\n", capcut.FormatDuration(newTr.Start), newTr.Start)
+	fmt.Printf("   持续时间: %s (%d微秒)\n", capcut.FormatDuration(newTr.Duration), newTr.Duration)
 
 	// 验证数据一致性
 	fmt.Printf("\n✅ 数据一致性验证:\n")
@@ -371,7 +371,7 @@ func demonstrateJSONSerialization() {
 	}
 
 	for i, jsonFormat := range jsonFormats {
-		testTr := &types.Timerange{}
+		testTr := &capcut.Timerange{}
 		err := testTr.ImportFromJSON(jsonFormat)
 		if err != nil {
 			fmt.Printf("   [%d] ❌ 格式%d导入失败: %v\n", i+1, i+1, err)
@@ -392,10 +392,10 @@ func demonstrateTimerangeOperations() {
 	fmt.Println("⚙️ === 时间范围操作演示 ===")
 
 	// 创建测试时间范围
-	tr1 := types.MustTrange("0s", "10s") // 0-10秒
-	tr2 := types.MustTrange("5s", "10s") // 5-15秒
-	tr3 := types.MustTrange("15s", "5s") // 15-20秒
-	tr4 := types.MustTrange("8s", "4s")  // 8-12秒
+	tr1 := capcut.MustTrange("0s", "10s") // 0-10秒
+	tr2 := capcut.MustTrange("5s", "10s") // 5-15秒
+	tr3 := capcut.MustTrange("15s", "5s") // 15-20秒
+	tr4 := capcut.MustTrange("8s", "4s")  // 8-12秒
 
 	fmt.Printf("🏗️ 测试时间范围:\n")
 	fmt.Printf("   tr1: %s (0-10秒)\n", tr1)
@@ -405,7 +405,7 @@ func demonstrateTimerangeOperations() {
 
 	// 测试相等性
 	fmt.Printf("\n🔍 相等性测试:\n")
-	tr1Copy := types.MustTrange("0s", "10s")
+	tr1Copy := capcut.MustTrange("0s", "10s")
 	fmt.Printf("   tr1 == tr1Copy: %v\n", tr1.Equals(tr1Copy))
 	fmt.Printf("   tr1 == tr2: %v\n", tr1.Equals(tr2))
 	fmt.Printf("   tr1 == nil: %v\n", tr1.Equals(nil))
@@ -413,7 +413,7 @@ func demonstrateTimerangeOperations() {
 	// 测试重叠检测
 	fmt.Printf("\n🔗 重叠检测测试:\n")
 	overlapTests := []struct {
-		tr1, tr2 *types.Timerange
+		tr1, tr2 *capcut.Timerange
 		desc     string
 	}{
 		{tr1, tr2, "tr1与tr2 (0-10秒 vs 5-15秒)"},
@@ -432,13 +432,13 @@ func demonstrateTimerangeOperations() {
 	// 测试边界情况
 	fmt.Printf("\n🎯 边界情况测试:\n")
 	boundaryTests := []struct {
-		tr1, tr2 *types.Timerange
+		tr1, tr2 *capcut.Timerange
 		desc     string
 	}{
-		{types.MustTrange("0s", "5s"), types.MustTrange("5s", "5s"), "相邻时间范围"},
-		{types.MustTrange("0s", "5s"), types.MustTrange("4s", "2s"), "部分重叠"},
-		{types.MustTrange("0s", "10s"), types.MustTrange("2s", "6s"), "完全包含"},
-		{types.MustTrange("2s", "6s"), types.MustTrange("0s", "10s"), "被完全包含"},
+		{capcut.MustTrange("0s", "5s"), capcut.MustTrange("5s", "5s"), "相邻时间范围"},
+		{capcut.MustTrange("0s", "5s"), capcut.MustTrange("4s", "2s"), "部分重叠"},
+		{capcut.MustTrange("0s", "10s"), capcut.MustTrange("2s", "6s"), "完全包含"},
+		{capcut.MustTrange("2s", "6s"), capcut.MustTrange("0s", "10s"), "被完全包含"},
 	}
 
 	for i, test := range boundaryTests {
@@ -471,52 +471,52 @@ func demonstrateCompleteWorkflow() {
 		"fade_out_time":    "3s",
 	}
 
-	projectDuration, err := types.Tim(timeConfig["project_duration"])
+	projectDuration, err := capcut.Tim(timeConfig["project_duration"])
 	if err != nil {
 		fmt.Printf("     ❌ 项目时长解析失败: %v\n", err)
 		return
 	}
 
-	introDuration, err := types.Tim(timeConfig["intro_duration"])
+	introDuration, err := capcut.Tim(timeConfig["intro_duration"])
 	if err != nil {
 		fmt.Printf("     ❌ 片头时长解析失败: %v\n", err)
 		return
 	}
 
-	outroDuration, err := types.Tim(timeConfig["outro_duration"])
+	outroDuration, err := capcut.Tim(timeConfig["outro_duration"])
 	if err != nil {
 		fmt.Printf("     ❌ 片尾时长解析失败: %v\n", err)
 		return
 	}
 
-	transitionTime, err := types.Tim(timeConfig["transition_time"])
+	transitionTime, err := capcut.Tim(timeConfig["transition_time"])
 	if err != nil {
 		fmt.Printf("     ❌ 转场时间解析失败: %v\n", err)
 		return
 	}
 
 	fmt.Printf("     ✅ 时间配置解析成功:\n")
-	fmt.Printf("       - 项目总时长: %s\n", types.FormatDuration(projectDuration))
-	fmt.Printf("       - 片头时长: %s\n", types.FormatDuration(introDuration))
-	fmt.Printf("       - 片尾时长: %s\n", types.FormatDuration(outroDuration))
-	fmt.Printf("       - 转场时间: %s\n", types.FormatDuration(transitionTime))
+	fmt.Printf("       - 项目总时长: %s\n", capcut.FormatDuration(projectDuration))
+	fmt.Printf("       - 片头时长: %s\n", capcut.FormatDuration(introDuration))
+	fmt.Printf("       - 片尾时长: %s\n", capcut.FormatDuration(outroDuration))
+	fmt.Printf("       - 转场时间: %s\n", capcut.FormatDuration(transitionTime))
 
 	// 步骤2: 创建时间轴片段
 	fmt.Printf("   🎞️ 步骤2: 创建时间轴片段\n")
 
 	// 片头片段
-	introRange := types.NewTimerange(0, introDuration)
+	introRange := capcut.NewTimerange(0, introDuration)
 	fmt.Printf("     ✅ 片头片段: %s\n", introRange)
 
 	// 主内容片段
 	mainContentStart := introDuration + transitionTime
 	mainContentDuration := projectDuration - introDuration - outroDuration - transitionTime*2
-	mainContentRange := types.NewTimerange(mainContentStart, mainContentDuration)
+	mainContentRange := capcut.NewTimerange(mainContentStart, mainContentDuration)
 	fmt.Printf("     ✅ 主内容片段: %s\n", mainContentRange)
 
 	// 片尾片段
 	outroStart := mainContentStart + mainContentDuration + transitionTime
-	outroRange := types.NewTimerange(outroStart, outroDuration)
+	outroRange := capcut.NewTimerange(outroStart, outroDuration)
 	fmt.Printf("     ✅ 片尾片段: %s\n", outroRange)
 
 	// 步骤3: 验证时间轴完整性
@@ -538,10 +538,10 @@ func demonstrateCompleteWorkflow() {
 	// 检查总时长
 	calculatedTotal := introRange.Duration + mainContentRange.Duration + outroRange.Duration + transitionTime*2
 	if calculatedTotal == projectDuration {
-		fmt.Printf("     ✅ 总时长计算正确: %s\n", types.FormatDuration(calculatedTotal))
+		fmt.Printf("     ✅ 总时长计算正确: %s\n", capcut.FormatDuration(calculatedTotal))
 	} else {
 		fmt.Printf("     ❌ 总时长计算错误: 期望%s, 实际%s\n",
-			types.FormatDuration(projectDuration), types.FormatDuration(calculatedTotal))
+			capcut.FormatDuration(projectDuration), capcut.FormatDuration(calculatedTotal))
 	}
 
 	// 步骤4: 处理SRT字幕时间轴
@@ -558,11 +558,11 @@ func demonstrateCompleteWorkflow() {
 
 	fmt.Printf("     📋 SRT时间戳解析:\n")
 	for i, timestamp := range srtTimestamps {
-		micros, err := types.SrtTimestamp(timestamp)
+		micros, err := capcut.SrtTimestamp(timestamp)
 		if err != nil {
 			fmt.Printf("       [%d] ❌ %s: 解析失败 - %v\n", i+1, timestamp, err)
 		} else {
-			formatted := types.FormatDuration(micros)
+			formatted := capcut.FormatDuration(micros)
 			fmt.Printf("       [%d] ✅ %s: %s\n", i+1, timestamp, formatted)
 		}
 	}
@@ -572,11 +572,11 @@ func demonstrateCompleteWorkflow() {
 
 	// 创建项目时间配置结构
 	type ProjectTimeConfig struct {
-		IntroRange     *types.Timerange `json:"intro_range"`
-		MainRange      *types.Timerange `json:"main_range"`
-		OutroRange     *types.Timerange `json:"outro_range"`
-		TransitionTime int64            `json:"transition_time"`
-		TotalDuration  int64            `json:"total_duration"`
+		IntroRange     *capcut.Timerange `json:"intro_range"`
+		MainRange      *capcut.Timerange `json:"main_range"`
+		OutroRange     *capcut.Timerange `json:"outro_range"`
+		TransitionTime int64             `json:"transition_time"`
+		TotalDuration  int64             `json:"total_duration"`
 	}
 
 	_ = &ProjectTimeConfig{
@@ -610,17 +610,17 @@ func demonstrateCompleteWorkflow() {
 	testCount := 10000
 	fmt.Printf("     🔄 执行%d次时间解析测试...\n", testCount)
 
-	startTime := types.SecondsToMicroseconds(0) // 这里应该使用实际的时间测量，但为了演示使用固定值
+	startTime := capcut.SecondsToMicroseconds(0) // 这里应该使用实际的时间测量，但为了演示使用固定值
 	successCount := 0
 
 	for i := 0; i < testCount; i++ {
-		_, err := types.Tim("1h30m45s")
+		_, err := capcut.Tim("1h30m45s")
 		if err == nil {
 			successCount++
 		}
 	}
 
-	endTime := types.SecondsToMicroseconds(0) // 这里应该使用实际的时间测量
+	endTime := capcut.SecondsToMicroseconds(0) // 这里应该使用实际的时间测量
 	duration := endTime - startTime
 
 	fmt.Printf("     📊 性能测试结果:\n")
@@ -9,7 +9,7 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/zhangshican/go-capcut/internal/util"
+	"github.com/zhangshican/go-capcut"
 )
 
 func main() {
@@ -65,7 +65,7 @@ func demonstrateReflectionUtils() {
 
 	// 获取结构体类型的默认值
 	structType := reflect.TypeOf(VideoConfig{})
-	defaults, err := util.ProvideCtorDefaults(structType)
+	defaults, err := capcut.ProvideCtorDefaults(structType)
 	if err != nil {
 		log.Fatalf("获取默认值失败: %v", err)
 	}
@@ -124,7 +124,7 @@ func demonstrateJSONHelpers() {
 
 	// 使用AssignAttrWithJSON赋值
 	attrs := []string{"ID", "Name", "Age", "Score", "Active", "Settings"}
-	err := util.AssignAttrWithJSON(user, attrs, jsonData)
+	err := capcut.AssignAttrWithJSON(user, attrs, jsonData)
 	if err != nil {
 		log.Fatalf("JSON赋值失败: %v", err)
 	}
@@ -140,7 +140,7 @@ func demonstrateJSONHelpers() {
 	// 演示反向导出
 	fmt.Printf("\n📤 属性导出演示:\n")
 	exportAttrs := []string{"ID", "Name", "Age", "Score", "Active"}
-	exportedData, err := util.ExportAttrToJSON(user, exportAttrs)
+	exportedData, err := capcut.ExportAttrToJSON(user, exportAttrs)
 	if err != nil {
 		log.Fatalf("属性导出失败: %v", err)
 	}
@@ -175,7 +175,7 @@ func demonstrateColorUtils() {
 
 	fmt.Printf("🎨 颜色转换演示:\n")
 	for _, test := range colorTests {
-		r, g, b, err := util.HexToRGB(test.hex)
+		r, g, b, err := capcut.HexToRGB(test.hex)
 		if err != nil {
 			fmt.Printf("   ❌ %s (%s): 转换失败 - %v\n", test.name, test.hex, err)
 			continue
@@ -191,7 +191,7 @@ func demonstrateColorUtils() {
 	fmt.Printf("\n🚫 错误处理演示:\n")
 	invalidColors := []string{"#GGG", "#12", "#1234567", "invalid"}
 	for _, invalid := range invalidColors {
-		_, _, _, err := util.HexToRGB(invalid)
+		_, _, _, err := capcut.HexToRGB(invalid)
 		if err != nil {
 			fmt.Printf("   ❌ '%s': %v\n", invalid, err)
 		}
@@ -229,7 +229,7 @@ func demonstratePathUtils() {
 	unixCount := 0
 
 	for _, test := range pathTests {
-		isWindows := util.IsWindowsPath(test.path)
+		isWindows := capcut.IsWindowsPath(test.path)
 		pathType := "Unix/Linux"
 		if isWindows {
 			pathType = "Windows"
@@ -273,7 +273,7 @@ func demonstrateHashUtils() {
 	hashMap := make(map[string]string)
 
 	for i, test := range urlTests {
-		hash := util.URLToHash(test.url, test.length)
+		hash := capcut.URLToHash(test.url, test.length)
 		fmt.Printf("   [%d] %s\n", i+1, test.description)
 		fmt.Printf("       URL: %s\n", test.url)
 		fmt.Printf("       哈希: %s (长度: %d)\n", hash, len(hash))
@@ -301,7 +301,7 @@ func demonstrateHashUtils() {
 
 	hashes := make([]string, len(testURLs))
 	for i, url := range testURLs {
-		hashes[i] = util.URLToHash(url, 16)
+		hashes[i] = capcut.URLToHash(url, 16)
 	}
 
 	// 检查是否有重复
@@ -334,38 +334,38 @@ func demonstrateErrorHandling() {
 	fmt.Printf("🚨 错误类型演示:\n")
 
 	// 1. 轨道相关错误
-	trackNotFound := util.NewTrackNotFoundError("name=主视频轨道")
+	trackNotFound := capcut.NewTrackNotFoundError("name=主视频轨道")
 	fmt.Printf("   1. 轨道未找到: %v\n", trackNotFound)
-	fmt.Printf("      类型检查: IsTrackNotFound = %v\n", util.IsTrackNotFound(trackNotFound))
+	fmt.Printf("      类型检查: IsTrackNotFound = %v\n", capcut.IsTrackNotFound(trackNotFound))
 
-	ambiguousTrack := util.NewAmbiguousTrackError("type=video", 3)
+	ambiguousTrack := capcut.NewAmbiguousTrackError("type=video", 3)
 	fmt.Printf("   2. 轨道模糊: %v\n", ambiguousTrack)
-	fmt.Printf("      类型检查: IsAmbiguousTrack = %v\n", util.IsAmbiguousTrack(ambiguousTrack))
+	fmt.Printf("      类型检查: IsAmbiguousTrack = %v\n", capcut.IsAmbiguousTrack(ambiguousTrack))
 
 	// 2. 片段相关错误
-	segmentOverlap := util.NewSegmentOverlapError(1000000, 5000000, 3000000, 7000000)
+	segmentOverlap := capcut.NewSegmentOverlapError(1000000, 5000000, 3000000, 7000000)
 	fmt.Printf("   3. 片段重叠: %v\n", segmentOverlap)
-	fmt.Printf("      类型检查: IsSegmentOverlap = %v\n", util.IsSegmentOverlap(segmentOverlap))
+	fmt.Printf("      类型检查: IsSegmentOverlap = %v\n", capcut.IsSegmentOverlap(segmentOverlap))
 
 	// 3. 素材相关错误
-	materialNotFound := util.NewMaterialNotFoundError("path=/videos/test.mp4")
+	materialNotFound := capcut.NewMaterialNotFoundError("path=/videos/test.mp4")
 	fmt.Printf("   4. 素材未找到: %v\n", materialNotFound)
-	fmt.Printf("      类型检查: IsMaterialNotFound = %v\n", util.IsMaterialNotFound(materialNotFound))
+	fmt.Printf("      类型检查: IsMaterialNotFound = %v\n", capcut.IsMaterialNotFound(materialNotFound))
 
 	// 4. 草稿相关错误
-	draftNotFound := util.NewDraftNotFoundErrorByName("我的项目")
+	draftNotFound := capcut.NewDraftNotFoundErrorByName("我的项目")
 	fmt.Printf("   5. 草稿未找到: %v\n", draftNotFound)
-	fmt.Printf("      类型检查: IsDraftNotFound = %v\n", util.IsDraftNotFound(draftNotFound))
+	fmt.Printf("      类型检查: IsDraftNotFound = %v\n", capcut.IsDraftNotFound(draftNotFound))
 
 	// 5. 自动化相关错误
-	automationError := util.NewAutomationError("export_video", "剪映窗口未响应")
+	automationError := capcut.NewAutomationError("export_video", "剪映窗口未响应")
 	fmt.Printf("   6. 自动化错误: %v\n", automationError)
-	fmt.Printf("      类型检查: IsAutomationError = %v\n", util.IsAutomationError(automationError))
+	fmt.Printf("      类型检查: IsAutomationError = %v\n", capcut.IsAutomationError(automationError))
 
 	// 6. 验证错误
-	validationError := util.NewValidationError("duration", -100, "持续时间不能为负数")
+	validationError := capcut.NewValidationError("duration", -100, "持续时间不能为负数")
 	fmt.Printf("   7. 验证错误: %v\n", validationError)
-	fmt.Printf("      类型检查: IsValidationError = %v\n", util.IsValidationError(validationError))
+	fmt.Printf("      类型检查: IsValidationError = %v\n", capcut.IsValidationError(validationError))
 
 	// 演示错误处理流程
 	fmt.Printf("\n🔄 错误处理流程演示:\n")
@@ -378,13 +378,13 @@ func demonstrateErrorHandling() {
 
 	for i, err := range errors {
 		fmt.Printf("   错误[%d]: 处理结果 = ", i+1)
-		if util.IsTrackNotFound(err) {
+		if capcut.IsTrackNotFound(err) {
 			fmt.Printf("重新搜索轨道\n")
-		} else if util.IsSegmentOverlap(err) {
+		} else if capcut.IsSegmentOverlap(err) {
 			fmt.Printf("调整片段时间\n")
-		} else if util.IsMaterialNotFound(err) {
+		} else if capcut.IsMaterialNotFound(err) {
 			fmt.Printf("提示用户选择素材\n")
-		} else if util.IsValidationError(err) {
+		} else if capcut.IsValidationError(err) {
 			fmt.Printf("显示验证错误信息\n")
 		} else {
 			fmt.Printf("通用错误处理\n")
@@ -426,7 +426,7 @@ func demonstrateTypeConversion() {
 	settings := &VideoSettings{}
 	attrs := []string{"Width", "Height", "Framerate", "Enabled", "Quality"}
 
-	err := util.AssignAttrWithJSON(settings, attrs, configData)
+	err := capcut.AssignAttrWithJSON(settings, attrs, configData)
 	if err != nil {
 		fmt.Printf("       ❌ 配置解析失败: %v\n", err)
 	} else {
@@ -459,7 +459,7 @@ func demonstrateTypeConversion() {
 	userInfo := &UserInfo{}
 	userAttrs := []string{"UserID", "Username", "Score", "IsPremium", "Level"}
 
-	err = util.AssignAttrWithJSON(userInfo, userAttrs, apiResponse)
+	err = capcut.AssignAttrWithJSON(userInfo, userAttrs, apiResponse)
 	if err != nil {
 		fmt.Printf("       ❌ API响应处理失败: %v\n", err)
 	} else {
@@ -486,7 +486,7 @@ func demonstrateTypeConversion() {
 	invalidStruct := &InvalidStruct{}
 	invalidAttrs := []string{"Number", "Flag"}
 
-	err = util.AssignAttrWithJSON(invalidStruct, invalidAttrs, invalidData)
+	err = capcut.AssignAttrWithJSON(invalidStruct, invalidAttrs, invalidData)
 	if err != nil {
 		fmt.Printf("       ✅ 正确捕获转换错误: %v\n", err)
 	} else {
@@ -538,7 +538,7 @@ func demonstrateCompleteWorkflow() {
 	configAttrs := []string{"Name", "Width", "Height", "Framerate", "Duration",
 		"Background", "OutputPath", "EnableEffects", "Quality"}
 
-	err := util.AssignAttrWithJSON(config, configAttrs, projectConfigJSON)
+	err := capcut.AssignAttrWithJSON(config, configAttrs, projectConfigJSON)
 	if err != nil {
 		fmt.Printf("     ❌ 配置解析失败: %v\n", err)
 		return
@@ -552,7 +552,7 @@ func demonstrateCompleteWorkflow() {
 
 	// 步骤2: 处理背景颜色
 	fmt.Printf("   🎨 步骤2: 处理背景颜色\n")
-	r, g, b, err := util.HexToRGB(config.Background)
+	r, g, b, err := capcut.HexToRGB(config.Background)
 	if err != nil {
 		fmt.Printf("     ❌ 颜色解析失败: %v\n", err)
 	} else {
@@ -562,7 +562,7 @@ func demonstrateCompleteWorkflow() {
 
 	// 步骤3: 验证输出路径
 	fmt.Printf("   📁 步骤3: 验证输出路径\n")
-	isWindows := util.IsWindowsPath(config.OutputPath)
+	isWindows := capcut.IsWindowsPath(config.OutputPath)
 	pathType := "Unix/Linux"
 	if isWindows {
 		pathType = "Windows"
@@ -574,7 +574,7 @@ func demonstrateCompleteWorkflow() {
 	projectURL := fmt.Sprintf("project://%s/%dx%d@%.1f",
 		strings.ReplaceAll(config.Name, " ", "_"),
 		config.Width, config.Height, config.Framerate)
-	projectHash := util.URLToHash(projectURL, 16)
+	projectHash := capcut.URLToHash(projectURL, 16)
 	fmt.Printf("     ✅ 项目哈希ID: %s (基于: %s)\n", projectHash, projectURL)
 
 	// 步骤5: 构建默认设置
@@ -591,7 +591,7 @@ func demonstrateCompleteWorkflow() {
 	}
 
 	effectType := reflect.TypeOf(EffectSettings{})
-	defaults, err := util.ProvideCtorDefaults(effectType)
+	defaults, err := capcut.ProvideCtorDefaults(effectType)
 	if err != nil {
 		fmt.Printf("     ❌ 默认设置生成失败: %v\n", err)
 	} else {
@@ -605,7 +605,7 @@ func demonstrateCompleteWorkflow() {
 	fmt.Printf("   📤 步骤6: 导出项目摘要\n")
 
 	exportAttrs := []string{"Name", "Width", "Height", "Framerate", "Duration", "Quality"}
-	summary, err := util.ExportAttrToJSON(config, exportAttrs)
+	summary, err := capcut.ExportAttrToJSON(config, exportAttrs)
 	if err != nil {
 		fmt.Printf("     ❌ 项目摘要导出失败: %v\n", err)
 	} else {
@@ -618,20 +618,20 @@ func demonstrateCompleteWorkflow() {
 
 	// 模拟各种可能的错误
 	possibleErrors := []error{
-		util.NewValidationError("duration", -10, "持续时间不能为负数"),
-		util.NewMaterialNotFoundError("background_music.mp3"),
-		util.NewConfigurationError("video_encoder", "bitrate", "比特率超出范围"),
+		capcut.NewValidationError("duration", -10, "持续时间不能为负数"),
+		capcut.NewMaterialNotFoundError("background_music.mp3"),
+		capcut.NewConfigurationError("video_encoder", "bitrate", "比特率超出范围"),
 	}
 
 	for i, err := range possibleErrors {
 		fmt.Printf("     错误[%d]: %v\n", i+1, err)
 
 		// 根据错误类型提供解决方案
-		if util.IsValidationError(err) {
+		if capcut.IsValidationError(err) {
 			fmt.Printf("       解决方案: 使用默认值或提示用户重新输入\n")
-		} else if util.IsMaterialNotFound(err) {
+		} else if capcut.IsMaterialNotFound(err) {
 			fmt.Printf("       解决方案: 提示用户选择替代素材\n")
-		} else if util.IsConfigurationError(err) {
+		} else if capcut.IsConfigurationError(err) {
 			fmt.Printf("       解决方案: 重置为默认配置\n")
 		}
 	}
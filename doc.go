@@ -0,0 +1,40 @@
+// Package capcut 是go-capcut的公开API，用于生成和编辑剪映/CapCut草稿
+//
+// 根包提供草稿文件（ScriptFile）、草稿文件夹（DraftFolder）、轨道、素材、
+// 时间范围（Timerange）以及错误类型；片段、动画、关键帧、模板、草稿内容模型、媒体探测、响度测量和特效元数据
+// 分别位于以下子包中：
+//
+//	github.com/zhangshican/go-capcut/segment    片段及其附属效果的构造函数
+//	github.com/zhangshican/go-capcut/animation  视频/文本动画
+//	github.com/zhangshican/go-capcut/keyframe   关键帧
+//	github.com/zhangshican/go-capcut/template   模板导入与素材替换模式
+//	github.com/zhangshican/go-capcut/content    草稿内容的结构化模型
+//	github.com/zhangshican/go-capcut/probe      媒体文件的时长、分辨率探测
+//	github.com/zhangshican/go-capcut/loudness   音频的积分响度及峰值测量
+//	github.com/zhangshican/go-capcut/metadata   特效、滤镜、动画、字体等元数据枚举
+//
+// 基本用法：
+//
+//	sf, err := capcut.NewScriptFile(1920, 1080)
+//	if err != nil {
+//		return err
+//	}
+//	sf.AddTrack(capcut.TrackTypeVideo, nil)
+//	seg := segment.NewVideoSegment(mat.MaterialID, nil, capcut.MustTrange("0s", "5s"), 1.0, 1.0, nil)
+//	if err := sf.AddSegment(seg, nil); err != nil {
+//		return err
+//	}
+//	err = sf.Dump("draft_content.json")
+//
+// # 兼容性承诺
+//
+// 本模块遵循语义化版本。在同一主版本内，根包及上述子包中导出的标识符
+// 不会被删除或重命名，函数签名和导出字段不会以不兼容的方式修改；
+// 新增函数、类型、字段或常量不视为不兼容的修改。
+//
+// 公开包中的类型均为internal下对应类型的别名，因此可以与任何公开包混合使用。
+// internal目录下的包不属于公开API，可能随时修改，外部模块也无法直接导入。
+// 生成的草稿JSON格式跟随剪映版本，其中未被本库建模的字段不在兼容性承诺范围内。
+package capcut
+
+//go:generate go run ./internal/cmd/apigen
@@ -0,0 +1,21 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package capcut
+
+import (
+	"github.com/zhangshican/go-capcut/internal/draft"
+)
+
+// DraftFolder 管理一个文件夹及其内的一系列草稿
+// 对应Python的Draft_folder类
+type DraftFolder = draft.DraftFolder
+
+// NewDraftFolder 创建新的草稿文件夹管理器
+// 对应Python的__init__方法
+func NewDraftFolder(folderPath string) (*DraftFolder, error) {
+	return draft.NewDraftFolder(folderPath)
+}
+
+// DraftInfo 草稿基本信息
+// 扩展结构，提供草稿的详细信息
+type DraftInfo = draft.DraftInfo
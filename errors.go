@@ -0,0 +1,190 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package capcut
+
+import (
+	"github.com/zhangshican/go-capcut/internal/util"
+)
+
+// TrackNotFoundError 未找到满足条件的轨道
+// 对应Python的TrackNotFound异常
+type TrackNotFoundError = util.TrackNotFoundError
+
+// NewTrackNotFoundError 创建轨道未找到错误
+func NewTrackNotFoundError(condition string) *TrackNotFoundError {
+	return util.NewTrackNotFoundError(condition)
+}
+
+// AmbiguousTrackError 找到多个满足条件的轨道
+// 对应Python的AmbiguousTrack异常
+type AmbiguousTrackError = util.AmbiguousTrackError
+
+// NewAmbiguousTrackError 创建轨道模糊错误
+func NewAmbiguousTrackError(condition string, count int) *AmbiguousTrackError {
+	return util.NewAmbiguousTrackError(condition, count)
+}
+
+// SegmentOverlapError 新片段与已有的轨道片段重叠
+// 对应Python的SegmentOverlap异常
+type SegmentOverlapError = util.SegmentOverlapError
+
+// NewSegmentOverlapError 创建片段重叠错误
+func NewSegmentOverlapError(newStart, newEnd, existingStart, existingEnd int64) *SegmentOverlapError {
+	return util.NewSegmentOverlapError(newStart, newEnd, existingStart, existingEnd)
+}
+
+// MaterialNotFoundError 未找到满足条件的素材
+// 对应Python的MaterialNotFound异常
+type MaterialNotFoundError = util.MaterialNotFoundError
+
+// NewMaterialNotFoundError 创建素材未找到错误
+func NewMaterialNotFoundError(condition string) *MaterialNotFoundError {
+	return util.NewMaterialNotFoundError(condition)
+}
+
+// AmbiguousMaterialError 找到多个满足条件的素材
+// 对应Python的AmbiguousMaterial异常
+type AmbiguousMaterialError = util.AmbiguousMaterialError
+
+// NewAmbiguousMaterialError 创建素材模糊错误
+func NewAmbiguousMaterialError(condition string, count int) *AmbiguousMaterialError {
+	return util.NewAmbiguousMaterialError(condition, count)
+}
+
+// ExtensionFailedError 替换素材时延伸片段失败
+// 对应Python的ExtensionFailed异常
+type ExtensionFailedError = util.ExtensionFailedError
+
+// NewExtensionFailedError 创建延伸失败错误
+func NewExtensionFailedError(reason, segmentID, materialID string) *ExtensionFailedError {
+	return util.NewExtensionFailedError(reason, segmentID, materialID)
+}
+
+// DraftNotFoundError 未找到草稿
+// 对应Python的DraftNotFound异常
+type DraftNotFoundError = util.DraftNotFoundError
+
+// NewDraftNotFoundError 创建草稿未找到错误
+func NewDraftNotFoundError(path string) *DraftNotFoundError {
+	return util.NewDraftNotFoundError(path)
+}
+
+// NewDraftNotFoundErrorByName 根据名称创建草稿未找到错误
+func NewDraftNotFoundErrorByName(name string) *DraftNotFoundError {
+	return util.NewDraftNotFoundErrorByName(name)
+}
+
+// AutomationError 自动化操作失败
+// 对应Python的AutomationError异常
+type AutomationError = util.AutomationError
+
+// NewAutomationError 创建自动化错误
+func NewAutomationError(operation, reason string) *AutomationError {
+	return util.NewAutomationError(operation, reason)
+}
+
+// ExportTimeoutError 导出超时
+// 对应Python的ExportTimeout异常
+type ExportTimeoutError = util.ExportTimeoutError
+
+// NewExportTimeoutError 创建导出超时错误
+func NewExportTimeoutError(duration int64, filePath string) *ExportTimeoutError {
+	return util.NewExportTimeoutError(duration, filePath)
+}
+
+// ValidationError 数据验证错误
+type ValidationError = util.ValidationError
+
+// NewValidationError 创建验证错误
+func NewValidationError(field string, value interface{}, reason string) *ValidationError {
+	return util.NewValidationError(field, value, reason)
+}
+
+// JSONProcessingError JSON处理错误
+type JSONProcessingError = util.JSONProcessingError
+
+// NewJSONProcessingError 创建JSON处理错误
+func NewJSONProcessingError(operation, data, reason string) *JSONProcessingError {
+	return util.NewJSONProcessingError(operation, data, reason)
+}
+
+// TypeConversionError 类型转换错误
+type TypeConversionError = util.TypeConversionError
+
+// NewTypeConversionError 创建类型转换错误
+func NewTypeConversionError(sourceType, targetType string, value interface{}) *TypeConversionError {
+	return util.NewTypeConversionError(sourceType, targetType, value)
+}
+
+// ConfigurationError 配置错误
+type ConfigurationError = util.ConfigurationError
+
+// NewConfigurationError 创建配置错误
+func NewConfigurationError(component, setting, reason string) *ConfigurationError {
+	return util.NewConfigurationError(component, setting, reason)
+}
+
+// IsTrackNotFound 检查是否为轨道未找到错误
+func IsTrackNotFound(err error) bool {
+	return util.IsTrackNotFound(err)
+}
+
+// IsAmbiguousTrack 检查是否为轨道模糊错误
+func IsAmbiguousTrack(err error) bool {
+	return util.IsAmbiguousTrack(err)
+}
+
+// IsSegmentOverlap 检查是否为片段重叠错误
+func IsSegmentOverlap(err error) bool {
+	return util.IsSegmentOverlap(err)
+}
+
+// IsMaterialNotFound 检查是否为素材未找到错误
+func IsMaterialNotFound(err error) bool {
+	return util.IsMaterialNotFound(err)
+}
+
+// IsAmbiguousMaterial 检查是否为素材模糊错误
+func IsAmbiguousMaterial(err error) bool {
+	return util.IsAmbiguousMaterial(err)
+}
+
+// IsExtensionFailed 检查是否为延伸失败错误
+func IsExtensionFailed(err error) bool {
+	return util.IsExtensionFailed(err)
+}
+
+// IsDraftNotFound 检查是否为草稿未找到错误
+func IsDraftNotFound(err error) bool {
+	return util.IsDraftNotFound(err)
+}
+
+// IsAutomationError 检查是否为自动化错误
+func IsAutomationError(err error) bool {
+	return util.IsAutomationError(err)
+}
+
+// IsExportTimeout 检查是否为导出超时错误
+func IsExportTimeout(err error) bool {
+	return util.IsExportTimeout(err)
+}
+
+// IsValidationError 检查是否为验证错误
+func IsValidationError(err error) bool {
+	return util.IsValidationError(err)
+}
+
+// IsJSONProcessingError 检查是否为JSON处理错误
+func IsJSONProcessingError(err error) bool {
+	return util.IsJSONProcessingError(err)
+}
+
+// IsTypeConversionError 检查是否为类型转换错误
+func IsTypeConversionError(err error) bool {
+	return util.IsTypeConversionError(err)
+}
+
+// IsConfigurationError 检查是否为配置错误
+func IsConfigurationError(err error) bool {
+	return util.IsConfigurationError(err)
+}
@@ -8,8 +8,7 @@ import (
 
 	"github.com/zhangshican/go-capcut/internal/metadata"
 	"github.com/zhangshican/go-capcut/internal/types"
-
-	"github.com/google/uuid"
+	"github.com/zhangshican/go-capcut/internal/util"
 )
 
 // AnimationType 动画类型枚举
@@ -166,7 +165,7 @@ type SegmentAnimations struct {
 // NewSegmentAnimations 创建新的片段动画序列
 func NewSegmentAnimations() *SegmentAnimations {
 	return &SegmentAnimations{
-		AnimationID: strings.ReplaceAll(uuid.New().String(), "-", ""),
+		AnimationID: strings.ReplaceAll(util.NewID(), "-", ""),
 		Animations:  make([]*Animation, 0),
 	}
 }
@@ -0,0 +1,342 @@
+// apigen 根据internal下的包生成公开API的别名文件
+//
+// 在仓库根目录执行 go generate 即可重新生成所有公开包，
+// 生成的文件只包含类型别名、常量/变量别名和函数转发，不要手动修改
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	modulePath   = "github.com/zhangshican/go-capcut"
+	internalPath = modulePath + "/internal/"
+	rootPackage  = "capcut"
+)
+
+// output 一个生成的公开API文件
+type output struct {
+	File    string   // 输出文件路径，相对于仓库根目录
+	Package string   // 输出文件的包名
+	Source  string   // 被导出的内部包名
+	Files   []string // 被导出的内部包源文件，按顺序生成
+	Skip    []string // 不导出的标识符
+}
+
+// rootSources 在根包capcut中导出的内部包，其余内部包导出到同名子包
+var rootSources = map[string]bool{
+	"script":   true,
+	"draft":    true,
+	"track":    true,
+	"material": true,
+	"types":    true,
+	"util":     true,
+}
+
+var outputs = []output{
+	{File: "script.go", Package: rootPackage, Source: "script", Files: []string{"script.go", "srt.go", "validate.go", "relink.go", "paths.go", "fit.go", "loudness.go", "edit.go", "ripple.go", "append.go", "gaps.go"}},
+	{File: "draft.go", Package: rootPackage, Source: "draft", Files: []string{"draft_folder.go", "draft_info.go", "pack.go"}},
+	{File: "track.go", Package: rootPackage, Source: "track", Files: []string{"track.go", "edit.go", "ripple.go", "append.go", "overlap.go", "gaps.go"}},
+	{File: "material.go", Package: rootPackage, Source: "material", Files: []string{"material.go", "detect.go", "fingerprint.go", "crop.go", "fetch.go", "loudness.go"}},
+	{File: "time.go", Package: rootPackage, Source: "types", Files: []string{"time.go"}},
+	{File: "errors.go", Package: rootPackage, Source: "util", Files: []string{"errors.go"}},
+	{File: "util.go", Package: rootPackage, Source: "util", Files: []string{"util.go", "id.go", "pathmap.go"}},
+	{File: "segment/segment.go", Package: "segment", Source: "segment",
+		Files: []string{"base.go", "video.go", "audio.go", "text.go", "effect_segment.go", "sticker.go", "edit.go", "place.go"}},
+	{File: "animation/animation.go", Package: "animation", Source: "animation", Files: []string{"animation.go"}},
+	{File: "keyframe/keyframe.go", Package: "keyframe", Source: "keyframe", Files: []string{"keyframe.go"}},
+	{File: "template/template.go", Package: "template", Source: "template", Files: []string{"template.go"}},
+	{File: "content/content.go", Package: "content", Source: "content",
+		Files: []string{"content.go", "materials.go", "track.go", "raw.go"}},
+	{File: "probe/probe.go", Package: "probe", Source: "probe", Files: []string{"probe.go"}},
+	{File: "loudness/loudness.go", Package: "loudness", Source: "loudness", Files: []string{"loudness.go", "wav.go"}},
+	{File: "metadata/metadata.go", Package: "metadata", Source: "metadata",
+		Files: []string{"base.go", "animation.go", "audio_effect.go", "capcut_animation.go", "capcut_audio_effect.go",
+			"filter.go", "font.go", "mask.go", "sticker.go", "transition.go", "video_effect.go"}},
+}
+
+func main() {
+	for _, out := range outputs {
+		if err := generate(out); err != nil {
+			log.Fatalf("生成 %s 失败: %v", out.File, err)
+		}
+	}
+}
+
+// generator 生成单个文件时的状态
+type generator struct {
+	out       output
+	typeNames map[string]bool   // 内部包中声明的所有类型名
+	imports   map[string]string // 输出文件需要的导入，包名 -> 导入路径
+	body      bytes.Buffer
+}
+
+// generate 生成一个公开API文件
+func generate(out output) error {
+	g := &generator{
+		out:       out,
+		typeNames: make(map[string]bool),
+		imports:   map[string]string{out.Source: internalPath + out.Source},
+	}
+
+	dir := filepath.Join("internal", out.Source)
+	if err := g.collectTypeNames(dir); err != nil {
+		return err
+	}
+
+	skip := make(map[string]bool)
+	for _, name := range out.Skip {
+		skip[name] = true
+	}
+
+	fset := token.NewFileSet()
+	for _, name := range out.Files {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		fileImports := make(map[string]string)
+		for _, imp := range file.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			pkgName := filepath.Base(path)
+			if imp.Name != nil {
+				pkgName = imp.Name.Name
+			}
+			fileImports[pkgName] = path
+		}
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil && d.Name.IsExported() && !skip[d.Name.Name] {
+					g.writeFunc(d, fileImports)
+				}
+			case *ast.GenDecl:
+				g.writeGenDecl(d, skip)
+			}
+		}
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by apigen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\nimport (\n", out.Package)
+	paths := make([]string, 0, len(g.imports))
+	for _, path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&src, "\t%q\n", path)
+	}
+	src.WriteString(")\n\n")
+	src.Write(g.body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("格式化失败: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(out.File), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(out.File, formatted, 0644)
+}
+
+// collectTypeNames 收集内部包中声明的所有类型名
+func (g *generator) collectTypeNames(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		for _, decl := range file.Decls {
+			if d, ok := decl.(*ast.GenDecl); ok && d.Tok == token.TYPE {
+				for _, spec := range d.Specs {
+					g.typeNames[spec.(*ast.TypeSpec).Name.Name] = true
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writeFunc 生成转发到内部包的同名函数
+func (g *generator) writeFunc(d *ast.FuncDecl, fileImports map[string]string) {
+	if d.Type.TypeParams != nil {
+		return
+	}
+	funcType := &ast.FuncType{
+		Params:  g.rewriteFields(d.Type.Params, fileImports),
+		Results: g.rewriteFields(d.Type.Results, fileImports),
+	}
+
+	var args []string
+	variadic := false
+	for _, field := range funcType.Params.List {
+		if len(field.Names) == 0 {
+			field.Names = []*ast.Ident{ast.NewIdent("")}
+		}
+		for _, name := range field.Names {
+			if name.Name == "" || name.Name == "_" {
+				name.Name = fmt.Sprintf("p%d", len(args))
+			}
+			args = append(args, name.Name)
+		}
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			variadic = true
+		}
+	}
+
+	call := fmt.Sprintf("%s.%s(%s", g.out.Source, d.Name.Name, strings.Join(args, ", "))
+	if variadic {
+		call += "..."
+	}
+	call += ")"
+	if funcType.Results != nil && len(funcType.Results.List) > 0 {
+		call = "return " + call
+	}
+
+	var signature bytes.Buffer
+	printer.Fprint(&signature, token.NewFileSet(), funcType)
+
+	writeDoc(&g.body, d.Doc)
+	fmt.Fprintf(&g.body, "func %s%s {\n\t%s\n}\n\n", d.Name.Name, strings.TrimPrefix(signature.String(), "func"), call)
+}
+
+// writeGenDecl 生成类型别名以及常量/变量别名
+func (g *generator) writeGenDecl(d *ast.GenDecl, skip map[string]bool) {
+	var values []string
+	for _, spec := range d.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			if !s.Name.IsExported() || skip[s.Name.Name] {
+				continue
+			}
+			doc := s.Doc
+			if doc == nil && len(d.Specs) == 1 {
+				doc = d.Doc
+			}
+			writeDoc(&g.body, doc)
+			fmt.Fprintf(&g.body, "type %s = %s.%s\n\n", s.Name.Name, g.out.Source, s.Name.Name)
+		case *ast.ValueSpec:
+			for _, name := range s.Names {
+				if !name.IsExported() || skip[name.Name] {
+					continue
+				}
+				var line bytes.Buffer
+				if s.Doc != nil {
+					for _, c := range s.Doc.List {
+						line.WriteString("\t" + c.Text + "\n")
+					}
+				}
+				fmt.Fprintf(&line, "\t%s = %s.%s", name.Name, g.out.Source, name.Name)
+				if s.Comment != nil {
+					for _, c := range s.Comment.List {
+						line.WriteString(" " + c.Text)
+					}
+				}
+				values = append(values, line.String())
+			}
+		}
+	}
+	if len(values) > 0 {
+		writeDoc(&g.body, d.Doc)
+		fmt.Fprintf(&g.body, "%s (\n%s\n)\n\n", d.Tok, strings.Join(values, "\n"))
+	}
+}
+
+// rewriteFields 复制参数/返回值列表，并把其中的内部类型替换为公开别名
+func (g *generator) rewriteFields(fields *ast.FieldList, fileImports map[string]string) *ast.FieldList {
+	if fields == nil {
+		return nil
+	}
+	result := &ast.FieldList{}
+	for _, field := range fields.List {
+		var names []*ast.Ident
+		for _, name := range field.Names {
+			names = append(names, ast.NewIdent(name.Name))
+		}
+		result.List = append(result.List, &ast.Field{Names: names, Type: g.rewriteType(field.Type, fileImports)})
+	}
+	return result
+}
+
+// rewriteType 把类型表达式中的内部类型替换为公开别名
+func (g *generator) rewriteType(expr ast.Expr, fileImports map[string]string) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if g.typeNames[t.Name] {
+			return g.publicType(g.out.Source, t.Name)
+		}
+		return t
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			path := fileImports[x.Name]
+			if strings.HasPrefix(path, internalPath) {
+				return g.publicType(strings.TrimPrefix(path, internalPath), t.Sel.Name)
+			}
+			g.imports[x.Name] = path
+		}
+		return t
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: g.rewriteType(t.X, fileImports)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: g.rewriteType(t.Elt, fileImports)}
+	case *ast.MapType:
+		return &ast.MapType{Key: g.rewriteType(t.Key, fileImports), Value: g.rewriteType(t.Value, fileImports)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: g.rewriteType(t.Elt, fileImports)}
+	case *ast.FuncType:
+		return &ast.FuncType{
+			Params:  g.rewriteFields(t.Params, fileImports),
+			Results: g.rewriteFields(t.Results, fileImports),
+		}
+	}
+	return expr
+}
+
+// publicType 返回内部类型在输出文件中的引用方式
+//
+// 同一公开包中的类型直接使用别名；根包不能导入子包（子包依赖根包中的Timerange等类型），
+// 因此根包引用子包类型时直接使用内部包中的原类型，两者是同一类型
+func (g *generator) publicType(source, name string) ast.Expr {
+	pkgName, path := source, modulePath+"/"+source
+	if rootSources[source] {
+		pkgName, path = rootPackage, modulePath
+	}
+	if pkgName == g.out.Package {
+		return ast.NewIdent(name)
+	}
+	if g.out.Package == rootPackage {
+		pkgName, path = source, internalPath+source
+	}
+	g.imports[pkgName] = path
+	return &ast.SelectorExpr{X: ast.NewIdent(pkgName), Sel: ast.NewIdent(name)}
+}
+
+// writeDoc 原样写出文档注释
+func writeDoc(buf *bytes.Buffer, doc *ast.CommentGroup) {
+	if doc == nil {
+		return
+	}
+	for _, c := range doc.List {
+		buf.WriteString(c.Text + "\n")
+	}
+}
@@ -0,0 +1,63 @@
+// Package content 定义草稿文件draft_content.json的结构化模型
+//
+// 模型覆盖画布配置、各类素材、轨道、片段及关键帧等常用字段，未建模的字段在解析时被保留，
+// 导出时原样写回，因此加载后未经修改的草稿能够无损导出
+package content
+
+// DraftContent 草稿文件的内容
+type DraftContent struct {
+	Fields
+	CanvasConfig *CanvasConfig `json:"canvas_config"` // 画布配置
+	Duration     int64         `json:"duration"`      // 草稿总时长，单位为微秒
+	FPS          float64       `json:"fps"`           // 帧率
+	ID           string        `json:"id"`            // 草稿id
+	Keyframes    *Keyframes    `json:"keyframes"`     // 按片段类型存放的关键帧
+	Materials    *Materials    `json:"materials"`     // 按类别存放的素材
+	Tracks       []*Track      `json:"tracks"`        // 轨道列表
+}
+
+// UnmarshalJSON 解析草稿内容，保留未建模的字段
+func (dc *DraftContent) UnmarshalJSON(data []byte) error {
+	type plain DraftContent
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*dc = DraftContent(value)
+	dc.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出草稿内容
+func (dc *DraftContent) MarshalJSON() ([]byte, error) {
+	type plain DraftContent
+	return encodeObject((*plain)(dc), dc.raw)
+}
+
+// CanvasConfig 画布配置
+type CanvasConfig struct {
+	Fields
+	Width  int    `json:"width"`  // 画布宽度，单位为像素
+	Height int    `json:"height"` // 画布高度，单位为像素
+	Ratio  string `json:"ratio"`  // 画布比例，如"original"
+}
+
+// UnmarshalJSON 解析画布配置，保留未建模的字段
+func (cc *CanvasConfig) UnmarshalJSON(data []byte) error {
+	type plain CanvasConfig
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*cc = CanvasConfig(value)
+	cc.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出画布配置
+func (cc *CanvasConfig) MarshalJSON() ([]byte, error) {
+	type plain CanvasConfig
+	return encodeObject((*plain)(cc), cc.raw)
+}
@@ -0,0 +1,268 @@
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleDraft = `{"canvas_config":{"height":1080,"ratio":"original","width":1920},"duration":5000000,"fps":30.0,` +
+	`"id":"D1","materials":{"audios":[],"stickers":[{"id":"S1","resource_id":"R1","unknown":{"b":1,"a":2}}],` +
+	`"texts":[{"content":"{\"text\":\"a<b\"}","id":"T1","type":"subtitle"}],"videos":[{"crop_scale":1.0,"duration":10,"height":1080,` +
+	`"id":"V1","material_name":"a.mp4","path":"/a.mp4","type":"video","width":1920}]},"new_version":"110.0.0",` +
+	`"tracks":[{"attribute":0,"flag":0,"id":"TR1","is_default_name":true,"name":"","segments":[{"clip":null,` +
+	`"id":"SEG1","material_id":"V1","render_index":0,"speed":1.0,"target_timerange":{"duration":5000000,"start":0},` +
+	`"visible":true,"volume":1.0}],"type":"video"}]}`
+
+// decodeSample 解析示例草稿
+func decodeSample(t *testing.T) *DraftContent {
+	t.Helper()
+
+	draft := &DraftContent{}
+	if err := json.Unmarshal([]byte(sampleDraft), draft); err != nil {
+		t.Fatalf("Failed to decode draft: %v", err)
+	}
+	return draft
+}
+
+// TestDraftContentRoundTrip 测试未修改的草稿内容无损导出
+func TestDraftContentRoundTrip(t *testing.T) {
+	draft := decodeSample(t)
+
+	if draft.CanvasConfig.Width != 1920 || draft.FPS != 30 || len(draft.Tracks) != 1 {
+		t.Errorf("Unexpected decoded values: %+v", draft)
+	}
+	if len(draft.Materials.Videos) != 1 || draft.Materials.Videos[0].MaterialName != "a.mp4" {
+		t.Errorf("Unexpected video materials: %+v", draft.Materials.Videos)
+	}
+	if stickers := draft.Materials.Stickers; len(stickers) != 1 || stickers[0].ResourceID != "R1" {
+		t.Errorf("Unexpected sticker materials: %+v", stickers)
+	}
+
+	output, err := marshal(draft)
+	if err != nil {
+		t.Fatalf("Failed to encode draft: %v", err)
+	}
+	if string(output) != sampleDraft {
+		t.Errorf("Round trip changed the draft:\n%s\n%s", sampleDraft, output)
+	}
+}
+
+// TestDraftContentModifiedFields 测试修改后的字段被重新写入，其余字段保持原样
+func TestDraftContentModifiedFields(t *testing.T) {
+	draft := decodeSample(t)
+	draft.FPS = 60
+	draft.Tracks[0].Segments[0].Volume = 0.5
+	draft.Materials.Videos[0].Path = "/b.mp4"
+
+	output, err := marshal(draft)
+	if err != nil {
+		t.Fatalf("Failed to encode draft: %v", err)
+	}
+	for _, expected := range []string{`"fps":60`, `"volume":0.5`, `"path":"/b.mp4"`,
+		`"speed":1.0`, `"crop_scale":1.0`, `"new_version":"110.0.0"`, `"unknown":{"b":1,"a":2}`} {
+		if !strings.Contains(string(output), expected) {
+			t.Errorf("Expected output to contain %s, got %s", expected, output)
+		}
+	}
+}
+
+// TestZeroFieldsOmitted 测试原始数据中不存在的零值字段不会被写入
+func TestZeroFieldsOmitted(t *testing.T) {
+	seg := &Segment{}
+	if err := json.Unmarshal([]byte(`{"id":"SEG1","material_id":"V1"}`), seg); err != nil {
+		t.Fatalf("Failed to decode segment: %v", err)
+	}
+	output, err := marshal(seg)
+	if err != nil {
+		t.Fatalf("Failed to encode segment: %v", err)
+	}
+	if string(output) != `{"id":"SEG1","material_id":"V1"}` {
+		t.Errorf("Expected no zero fields to be added, got %s", output)
+	}
+
+	seg.RenderIndex = 3
+	output, err = marshal(seg)
+	if err != nil {
+		t.Fatalf("Failed to encode segment: %v", err)
+	}
+	if !strings.Contains(string(output), `"render_index":3`) {
+		t.Errorf("Expected render_index to be written, got %s", output)
+	}
+
+	// 新建的对象写入所有非null字段
+	output, err = marshal(&Timerange{})
+	if err != nil {
+		t.Fatalf("Failed to encode timerange: %v", err)
+	}
+	if string(output) != `{"duration":0,"start":0}` {
+		t.Errorf("Expected all fields of a new object, got %s", output)
+	}
+}
+
+// TestMaterialsAppendAndClone 测试素材的追加与复制
+func TestMaterialsAppendAndClone(t *testing.T) {
+	draft := decodeSample(t)
+	materials := draft.Materials
+
+	clone := materials.Clone()
+	if err := clone.Append("videos", map[string]interface{}{"id": "V2", "path": "/c.mp4", "extra": true}); err != nil {
+		t.Fatalf("Failed to append video: %v", err)
+	}
+	if err := clone.Append("effects", map[string]interface{}{"id": "E1", "effect_id": "100"}); err != nil {
+		t.Fatalf("Failed to append effect: %v", err)
+	}
+
+	if len(materials.Videos) != 1 || materials.HasKind("effects") {
+		t.Error("Appending to a clone should not change the original materials")
+	}
+	if clone.Len("videos") != 2 || clone.Videos[1].Path != "/c.mp4" || string(clone.Videos[1].Field("extra")) != "true" {
+		t.Errorf("Unexpected appended video: %+v", clone.Videos)
+	}
+	if effects := clone.Effects; len(effects) != 1 || effects[0].EffectID != "100" {
+		t.Errorf("Unexpected appended effect: %+v", effects)
+	}
+
+	expectedKinds := []string{"audios", "effects", "stickers", "texts", "videos"}
+	if kinds := clone.Kinds(); !reflect.DeepEqual(kinds, expectedKinds) {
+		t.Errorf("Expected kinds %v, got %v", expectedKinds, kinds)
+	}
+}
+
+// TestFieldsAccess 测试未建模字段的读写
+func TestFieldsAccess(t *testing.T) {
+	mat := &VideoMaterial{}
+	if err := FromMap(map[string]interface{}{"id": "V1", "has_audio": true}, mat); err != nil {
+		t.Fatalf("Failed to decode material: %v", err)
+	}
+	if !mat.Has("has_audio") || mat.Has("crop") {
+		t.Error("Has should report the fields present in the original data")
+	}
+
+	original := mat.Fields
+	if err := mat.SetField("has_audio", false); err != nil {
+		t.Fatalf("Failed to set field: %v", err)
+	}
+	mat.DeleteField("id")
+	if string(original.Field("has_audio")) != "true" {
+		t.Error("SetField should not change copies of the fields")
+	}
+
+	result, err := ToMap(mat)
+	if err != nil {
+		t.Fatalf("Failed to convert material: %v", err)
+	}
+	if result["has_audio"] != false {
+		t.Errorf("Expected has_audio false, got %v", result["has_audio"])
+	}
+	if result["id"] != "V1" {
+		t.Errorf("Expected modelled id to be written, got %v", result["id"])
+	}
+}
+
+// TestFieldOrderPreserved 测试字段保持原始顺序，新增的字段排在其后
+func TestFieldOrderPreserved(t *testing.T) {
+	original := `{"type":"sticker","id":"S1","resource_id":"R1","unknown":{"b":1,"a":2}}`
+	mat := &Material{}
+	if err := json.Unmarshal([]byte(original), mat); err != nil {
+		t.Fatalf("Failed to decode material: %v", err)
+	}
+
+	output, err := marshal(mat)
+	if err != nil {
+		t.Fatalf("Failed to encode material: %v", err)
+	}
+	if string(output) != original {
+		t.Errorf("Round trip changed the field order:\n%s\n%s", original, output)
+	}
+
+	mat.ResourceID = "R2"
+	mat.Name = "sticker"
+	if err := mat.SetField("extra", 1); err != nil {
+		t.Fatalf("Failed to set field: %v", err)
+	}
+	output, err = marshal(mat)
+	if err != nil {
+		t.Fatalf("Failed to encode material: %v", err)
+	}
+	expected := `{"type":"sticker","id":"S1","resource_id":"R2","unknown":{"b":1,"a":2},"extra":1,"name":"sticker"}`
+	if string(output) != expected {
+		t.Errorf("Expected %s, got %s", expected, output)
+	}
+
+	// 转换为map后按原始顺序输出
+	generic, err := ToMap(mat)
+	if err != nil {
+		t.Fatalf("Failed to convert material: %v", err)
+	}
+	generic["added"] = true
+	indented, err := MarshalIndent(generic, output, "")
+	if err != nil {
+		t.Fatalf("Failed to encode map: %v", err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, indented); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	expected = `{"type":"sticker","id":"S1","resource_id":"R2","unknown":{"b":1,"a":2},"extra":1,"name":"sticker","added":true}`
+	if compact.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, compact.String())
+	}
+}
+
+// TestTypedMaterialsAndKeyframes 测试以专门类型建模的素材及关键帧
+func TestTypedMaterialsAndKeyframes(t *testing.T) {
+	original := `{"keyframes":{"adjusts":[],"videos":[{"id":"K1","keyframe_list":[{"curveType":"Line","graphID":"",` +
+		`"id":"KF1","left_control":{"x":0.0,"y":0.0},"right_control":{"x":0.0,"y":0.0},"time_offset":0,"values":[0.5]}],` +
+		`"material_id":"V1","property_type":"KFTypeAlpha"}],"unknown":{"a":1}},"materials":{` +
+		`"canvases":[{"color":"#FF0000FF","id":"C1","type":"canvas_color"}],` +
+		`"masks":[{"config":{"centerX":0.5,"centerY":0.0,"feather":0.1,"invert":true},"id":"M1","resource_type":"circle","type":"mask"}],` +
+		`"material_animations":[{"animations":[{"duration":500000,"name":"fade","start":0,"type":"in"}],"id":"A1"}],` +
+		`"speeds":"broken","transitions":[{"duration":400000,"id":"TR1","is_overlap":true}]}}`
+
+	draft := &DraftContent{}
+	if err := json.Unmarshal([]byte(original), draft); err != nil {
+		t.Fatalf("Failed to decode draft: %v", err)
+	}
+	materials := draft.Materials
+	if len(materials.Canvases) != 1 || materials.Canvases[0].Color != "#FF0000FF" {
+		t.Errorf("Unexpected canvases: %+v", materials.Canvases)
+	}
+	if len(materials.Masks) != 1 || materials.Masks[0].Config.CenterX != 0.5 || !materials.Masks[0].Config.Invert {
+		t.Errorf("Unexpected masks: %+v", materials.Masks)
+	}
+	if len(materials.MaterialAnimations) != 1 || materials.MaterialAnimations[0].Animations[0].Duration != 500000 {
+		t.Errorf("Unexpected animations: %+v", materials.MaterialAnimations)
+	}
+	if len(materials.Transitions) != 1 || !materials.Transitions[0].IsOverlap {
+		t.Errorf("Unexpected transitions: %+v", materials.Transitions)
+	}
+	if materials.Speeds != nil || !materials.Has("speeds") {
+		t.Error("Invalid material lists should only be kept as raw data")
+	}
+
+	keyframes := draft.Keyframes
+	if len(keyframes.Videos) != 1 || keyframes.Adjusts == nil || keyframes.Texts != nil {
+		t.Fatalf("Unexpected keyframes: %+v", keyframes)
+	}
+	if kf := keyframes.Videos[0].Keyframes[0]; kf.CurveType != "Line" || kf.LeftControl == nil || kf.Values[0] != 0.5 {
+		t.Errorf("Unexpected keyframe: %+v", kf)
+	}
+
+	output, err := marshal(draft)
+	if err != nil {
+		t.Fatalf("Failed to encode draft: %v", err)
+	}
+	if string(output) != original {
+		t.Errorf("Round trip changed the draft:\n%s\n%s", original, output)
+	}
+
+	if err := materials.Append("masks", map[string]interface{}{"id": "M2", "config": map[string]interface{}{"width": 0.3}}); err != nil {
+		t.Fatalf("Failed to append mask: %v", err)
+	}
+	if materials.Len("masks") != 2 || materials.Masks[1].Config.Width != 0.3 {
+		t.Errorf("Unexpected appended mask: %+v", materials.Masks)
+	}
+}
@@ -0,0 +1,635 @@
+package content
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Materials 草稿中按类别存放的素材
+//
+// 常用类别的素材以专门的类型表示，其余类别的素材以通用的Material表示并存放在Others中。
+// 某个类别的素材无法解析为对应的类型时，该类别的列表为nil，导出时原样写回原始数据
+type Materials struct {
+	Fields
+	AudioEffects       []*EffectMaterial     `json:"audio_effects"`       // 音频特效
+	AudioFades         []*AudioFadeMaterial  `json:"audio_fades"`         // 音频淡入淡出效果
+	Audios             []*AudioMaterial      `json:"audios"`              // 音频素材
+	Canvases           []*CanvasMaterial     `json:"canvases"`            // 背景填充
+	Effects            []*EffectMaterial     `json:"effects"`             // 滤镜、文字气泡及花字效果
+	MaterialAnimations []*AnimationMaterial  `json:"material_animations"` // 片段动画
+	Masks              []*MaskMaterial       `json:"masks"`               // 蒙版
+	Speeds             []*SpeedMaterial      `json:"speeds"`              // 变速素材
+	Stickers           []*StickerMaterial    `json:"stickers"`            // 贴纸素材
+	Texts              []*TextMaterial       `json:"texts"`               // 文本素材
+	Transitions        []*TransitionMaterial `json:"transitions"`         // 转场效果
+	VideoEffects       []*EffectMaterial     `json:"video_effects"`       // 视频特效
+	Videos             []*VideoMaterial      `json:"videos"`              // 视频及图片素材
+
+	Others map[string][]*Material `json:"-"` // 其余类别的素材，键为类别名，如"beats"、"chromas"
+}
+
+// typedKinds 以专门的类型建模的素材类别，值为Materials中对应字段的下标
+var typedKinds = func() map[string]int {
+	kinds := make(map[string]int)
+	t := reflect.TypeOf(Materials{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if name := field.Tag.Get("json"); field.Type.Kind() == reflect.Slice && name != "" && name != "-" {
+			kinds[name] = i
+		}
+	}
+	return kinds
+}()
+
+// typedList 返回指定类别对应的素材列表字段，该类别未以专门的类型建模时返回false
+func (m *Materials) typedList(kind string) (reflect.Value, bool) {
+	i, ok := typedKinds[kind]
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(m).Elem().Field(i), true
+}
+
+// UnmarshalJSON 解析素材，保留未建模的字段
+func (m *Materials) UnmarshalJSON(data []byte) error {
+	raw, err := decodeRawFields(data)
+	if err != nil {
+		return err
+	}
+	*m = Materials{Others: make(map[string][]*Material)}
+	m.raw = raw
+
+	// 无法解析为素材列表的值只保留原始数据
+	for kind, item := range raw.values {
+		if list, ok := m.typedList(kind); ok {
+			target := reflect.New(list.Type())
+			if err := json.Unmarshal(item, target.Interface()); err == nil {
+				list.Set(target.Elem())
+			}
+			continue
+		}
+		var list []*Material
+		if err := json.Unmarshal(item, &list); err == nil && list != nil {
+			m.Others[kind] = list
+		}
+	}
+	return nil
+}
+
+// MarshalJSON 导出素材
+func (m *Materials) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]json.RawMessage, len(typedKinds)+len(m.Others))
+	for kind := range typedKinds {
+		list, _ := m.typedList(kind)
+		if list.IsNil() {
+			continue
+		}
+		data, err := marshal(list.Interface())
+		if err != nil {
+			return nil, err
+		}
+		fields[kind] = data
+	}
+	for kind, list := range m.Others {
+		if list == nil {
+			continue
+		}
+		data, err := marshal(list)
+		if err != nil {
+			return nil, err
+		}
+		fields[kind] = data
+	}
+	return writeObject(fields, m.raw)
+}
+
+// Kinds 返回存在素材列表的所有类别，按字典序排列
+func (m *Materials) Kinds() []string {
+	kinds := make([]string, 0, len(typedKinds)+len(m.Others))
+	for kind := range typedKinds {
+		if m.HasKind(kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	for kind, list := range m.Others {
+		if list != nil {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// HasKind 返回是否存在指定类别的素材列表（列表可以为空）
+func (m *Materials) HasKind(kind string) bool {
+	if list, ok := m.typedList(kind); ok {
+		return !list.IsNil()
+	}
+	return m.Others[kind] != nil
+}
+
+// Len 返回指定类别的素材数量
+func (m *Materials) Len(kind string) int {
+	if list, ok := m.typedList(kind); ok {
+		return list.Len()
+	}
+	return len(m.Others[kind])
+}
+
+// Kind 返回以通用类型表示的指定类别的素材，以专门类型建模的类别应通过对应的字段访问
+func (m *Materials) Kind(kind string) []*Material {
+	return m.Others[kind]
+}
+
+// Append 将素材追加到指定类别的列表末尾，item可以是任意能够序列化为JSON对象的值
+func (m *Materials) Append(kind string, item interface{}) error {
+	data, err := marshal(item)
+	if err != nil {
+		return err
+	}
+
+	if list, ok := m.typedList(kind); ok {
+		mat := reflect.New(list.Type().Elem().Elem())
+		if err := json.Unmarshal(data, mat.Interface()); err != nil {
+			return err
+		}
+		list.Set(reflect.Append(list, mat))
+		return nil
+	}
+
+	mat := &Material{}
+	if err := mat.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	if m.Others == nil {
+		m.Others = make(map[string][]*Material)
+	}
+	m.Others[kind] = append(m.Others[kind], mat)
+	return nil
+}
+
+// Clone 复制素材表，各类别的列表被复制，列表中的素材对象与原素材表共享
+func (m *Materials) Clone() *Materials {
+	result := *m
+	for kind := range typedKinds {
+		list, _ := m.typedList(kind)
+		if list.IsNil() {
+			continue
+		}
+		copied := reflect.MakeSlice(list.Type(), list.Len(), list.Len())
+		reflect.Copy(copied, list)
+		target, _ := result.typedList(kind)
+		target.Set(copied)
+	}
+	result.Others = make(map[string][]*Material, len(m.Others))
+	for kind, list := range m.Others {
+		if list != nil {
+			result.Others[kind] = append(make([]*Material, 0, len(list)), list...)
+		}
+	}
+	return &result
+}
+
+// Material 通用的素材，用于未以专门类型建模的素材类别
+type Material struct {
+	Fields
+	ID         string `json:"id"`          // 素材id
+	Type       string `json:"type"`        // 素材类型
+	Name       string `json:"name"`        // 素材名称
+	EffectID   string `json:"effect_id"`   // 特效id
+	ResourceID string `json:"resource_id"` // 资源id
+}
+
+// UnmarshalJSON 解析素材，保留未建模的字段
+func (m *Material) UnmarshalJSON(data []byte) error {
+	type plain Material
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*m = Material(value)
+	m.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出素材
+func (m *Material) MarshalJSON() ([]byte, error) {
+	type plain Material
+	return encodeObject((*plain)(m), m.raw)
+}
+
+// VideoMaterial 视频或图片素材
+type VideoMaterial struct {
+	Fields
+	ID           string `json:"id"`            // 素材id
+	Type         string `json:"type"`          // 素材类型，"video"或"photo"
+	MaterialName string `json:"material_name"` // 素材名称
+	Path         string `json:"path"`          // 素材文件路径
+	Duration     int64  `json:"duration"`      // 素材时长，单位为微秒
+	Width        int    `json:"width"`         // 素材宽度，单位为像素
+	Height       int    `json:"height"`        // 素材高度，单位为像素
+	Crop         *Crop  `json:"crop"`          // 裁剪设置
+}
+
+// UnmarshalJSON 解析视频素材，保留未建模的字段
+func (vm *VideoMaterial) UnmarshalJSON(data []byte) error {
+	type plain VideoMaterial
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*vm = VideoMaterial(value)
+	vm.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出视频素材
+func (vm *VideoMaterial) MarshalJSON() ([]byte, error) {
+	type plain VideoMaterial
+	return encodeObject((*plain)(vm), vm.raw)
+}
+
+// Crop 视频素材的裁剪设置，各值为裁剪框四个顶点的归一化坐标
+type Crop struct {
+	Fields
+	UpperLeftX  float64 `json:"upper_left_x"`
+	UpperLeftY  float64 `json:"upper_left_y"`
+	UpperRightX float64 `json:"upper_right_x"`
+	UpperRightY float64 `json:"upper_right_y"`
+	LowerLeftX  float64 `json:"lower_left_x"`
+	LowerLeftY  float64 `json:"lower_left_y"`
+	LowerRightX float64 `json:"lower_right_x"`
+	LowerRightY float64 `json:"lower_right_y"`
+}
+
+// UnmarshalJSON 解析裁剪设置，保留未建模的字段
+func (c *Crop) UnmarshalJSON(data []byte) error {
+	type plain Crop
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*c = Crop(value)
+	c.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出裁剪设置
+func (c *Crop) MarshalJSON() ([]byte, error) {
+	type plain Crop
+	return encodeObject((*plain)(c), c.raw)
+}
+
+// AudioMaterial 音频素材
+type AudioMaterial struct {
+	Fields
+	ID       string `json:"id"`       // 素材id
+	Type     string `json:"type"`     // 素材类型
+	Name     string `json:"name"`     // 素材名称
+	Path     string `json:"path"`     // 素材文件路径
+	Duration int64  `json:"duration"` // 素材时长，单位为微秒
+}
+
+// UnmarshalJSON 解析音频素材，保留未建模的字段
+func (am *AudioMaterial) UnmarshalJSON(data []byte) error {
+	type plain AudioMaterial
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*am = AudioMaterial(value)
+	am.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出音频素材
+func (am *AudioMaterial) MarshalJSON() ([]byte, error) {
+	type plain AudioMaterial
+	return encodeObject((*plain)(am), am.raw)
+}
+
+// TextMaterial 文本素材
+type TextMaterial struct {
+	Fields
+	ID      string `json:"id"`      // 素材id
+	Type    string `json:"type"`    // 素材类型
+	Content string `json:"content"` // 序列化为JSON字符串的文本内容，包含文字及样式
+}
+
+// UnmarshalJSON 解析文本素材，保留未建模的字段
+func (tm *TextMaterial) UnmarshalJSON(data []byte) error {
+	type plain TextMaterial
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*tm = TextMaterial(value)
+	tm.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出文本素材
+func (tm *TextMaterial) MarshalJSON() ([]byte, error) {
+	type plain TextMaterial
+	return encodeObject((*plain)(tm), tm.raw)
+}
+
+// SpeedMaterial 变速素材
+type SpeedMaterial struct {
+	Fields
+	ID    string  `json:"id"`    // 素材id
+	Type  string  `json:"type"`  // 素材类型
+	Speed float64 `json:"speed"` // 播放速度
+	Mode  int     `json:"mode"`  // 变速模式，0为常规变速
+}
+
+// UnmarshalJSON 解析变速素材，保留未建模的字段
+func (sm *SpeedMaterial) UnmarshalJSON(data []byte) error {
+	type plain SpeedMaterial
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*sm = SpeedMaterial(value)
+	sm.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出变速素材
+func (sm *SpeedMaterial) MarshalJSON() ([]byte, error) {
+	type plain SpeedMaterial
+	return encodeObject((*plain)(sm), sm.raw)
+}
+
+// StickerMaterial 贴纸素材
+type StickerMaterial struct {
+	Fields
+	ID         string `json:"id"`          // 素材id
+	Type       string `json:"type"`        // 素材类型，"sticker"
+	Name       string `json:"name"`        // 贴纸名称
+	ResourceID string `json:"resource_id"` // 资源id
+	StickerID  string `json:"sticker_id"`  // 贴纸id，通常与资源id相同
+}
+
+// UnmarshalJSON 解析贴纸素材，保留未建模的字段
+func (sm *StickerMaterial) UnmarshalJSON(data []byte) error {
+	type plain StickerMaterial
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*sm = StickerMaterial(value)
+	sm.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出贴纸素材
+func (sm *StickerMaterial) MarshalJSON() ([]byte, error) {
+	type plain StickerMaterial
+	return encodeObject((*plain)(sm), sm.raw)
+}
+
+// EffectMaterial 特效类素材，用于滤镜、文字气泡、花字、视频特效及音频特效
+type EffectMaterial struct {
+	Fields
+	ID         string `json:"id"`          // 素材id
+	Type       string `json:"type"`        // 素材类型，如"filter"、"text_shape"、"video_effect"
+	Name       string `json:"name"`        // 特效名称
+	EffectID   string `json:"effect_id"`   // 特效id
+	ResourceID string `json:"resource_id"` // 资源id
+}
+
+// UnmarshalJSON 解析特效素材，保留未建模的字段
+func (em *EffectMaterial) UnmarshalJSON(data []byte) error {
+	type plain EffectMaterial
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*em = EffectMaterial(value)
+	em.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出特效素材
+func (em *EffectMaterial) MarshalJSON() ([]byte, error) {
+	type plain EffectMaterial
+	return encodeObject((*plain)(em), em.raw)
+}
+
+// TransitionMaterial 转场效果
+type TransitionMaterial struct {
+	Fields
+	ID         string `json:"id"`          // 素材id
+	Type       string `json:"type"`        // 素材类型，"transition"
+	Name       string `json:"name"`        // 转场名称
+	EffectID   string `json:"effect_id"`   // 特效id
+	ResourceID string `json:"resource_id"` // 资源id
+	Duration   int64  `json:"duration"`    // 转场时长，单位为微秒
+	IsOverlap  bool   `json:"is_overlap"`  // 转场是否与前后片段重叠
+}
+
+// UnmarshalJSON 解析转场效果，保留未建模的字段
+func (tm *TransitionMaterial) UnmarshalJSON(data []byte) error {
+	type plain TransitionMaterial
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*tm = TransitionMaterial(value)
+	tm.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出转场效果
+func (tm *TransitionMaterial) MarshalJSON() ([]byte, error) {
+	type plain TransitionMaterial
+	return encodeObject((*plain)(tm), tm.raw)
+}
+
+// AudioFadeMaterial 音频淡入淡出效果
+type AudioFadeMaterial struct {
+	Fields
+	ID              string `json:"id"`                // 素材id
+	Type            string `json:"type"`              // 素材类型，"audio_fade"
+	FadeInDuration  int64  `json:"fade_in_duration"`  // 淡入时长，单位为微秒
+	FadeOutDuration int64  `json:"fade_out_duration"` // 淡出时长，单位为微秒
+	FadeType        int    `json:"fade_type"`         // 淡入淡出类型
+}
+
+// UnmarshalJSON 解析音频淡入淡出效果，保留未建模的字段
+func (am *AudioFadeMaterial) UnmarshalJSON(data []byte) error {
+	type plain AudioFadeMaterial
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*am = AudioFadeMaterial(value)
+	am.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出音频淡入淡出效果
+func (am *AudioFadeMaterial) MarshalJSON() ([]byte, error) {
+	type plain AudioFadeMaterial
+	return encodeObject((*plain)(am), am.raw)
+}
+
+// CanvasMaterial 背景填充
+type CanvasMaterial struct {
+	Fields
+	ID    string  `json:"id"`    // 素材id
+	Type  string  `json:"type"`  // 填充类型，"canvas_color"或"canvas_blur"
+	Color string  `json:"color"` // 填充颜色，如"#FF0000FF"，仅颜色填充有效
+	Blur  float64 `json:"blur"`  // 模糊程度，仅模糊填充有效
+}
+
+// UnmarshalJSON 解析背景填充，保留未建模的字段
+func (cm *CanvasMaterial) UnmarshalJSON(data []byte) error {
+	type plain CanvasMaterial
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*cm = CanvasMaterial(value)
+	cm.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出背景填充
+func (cm *CanvasMaterial) MarshalJSON() ([]byte, error) {
+	type plain CanvasMaterial
+	return encodeObject((*plain)(cm), cm.raw)
+}
+
+// MaskMaterial 蒙版
+type MaskMaterial struct {
+	Fields
+	ID           string      `json:"id"`            // 素材id
+	Type         string      `json:"type"`          // 素材类型，"mask"
+	Name         string      `json:"name"`          // 蒙版名称
+	ResourceType string      `json:"resource_type"` // 蒙版形状，如"line"、"circle"
+	ResourceID   string      `json:"resource_id"`   // 资源id
+	Config       *MaskConfig `json:"config"`        // 蒙版参数
+}
+
+// UnmarshalJSON 解析蒙版，保留未建模的字段
+func (mm *MaskMaterial) UnmarshalJSON(data []byte) error {
+	type plain MaskMaterial
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*mm = MaskMaterial(value)
+	mm.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出蒙版
+func (mm *MaskMaterial) MarshalJSON() ([]byte, error) {
+	type plain MaskMaterial
+	return encodeObject((*plain)(mm), mm.raw)
+}
+
+// MaskConfig 蒙版参数，位置及尺寸以素材尺寸为单位
+type MaskConfig struct {
+	Fields
+	CenterX     float64 `json:"centerX"`     // 中心点x坐标
+	CenterY     float64 `json:"centerY"`     // 中心点y坐标
+	Width       float64 `json:"width"`       // 宽度
+	Height      float64 `json:"height"`      // 高度
+	AspectRatio float64 `json:"aspectRatio"` // 宽高比
+	Rotation    float64 `json:"rotation"`    // 顺时针旋转的角度
+	Feather     float64 `json:"feather"`     // 羽化程度
+	RoundCorner float64 `json:"roundCorner"` // 圆角程度，仅矩形蒙版有效
+	Invert      bool    `json:"invert"`      // 是否反转
+}
+
+// UnmarshalJSON 解析蒙版参数，保留未建模的字段
+func (mc *MaskConfig) UnmarshalJSON(data []byte) error {
+	type plain MaskConfig
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*mc = MaskConfig(value)
+	mc.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出蒙版参数
+func (mc *MaskConfig) MarshalJSON() ([]byte, error) {
+	type plain MaskConfig
+	return encodeObject((*plain)(mc), mc.raw)
+}
+
+// AnimationMaterial 片段动画，一个片段的所有动画存放在同一个素材中
+type AnimationMaterial struct {
+	Fields
+	ID         string       `json:"id"`         // 素材id
+	Type       string       `json:"type"`       // 素材类型，"sticker_animation"
+	Animations []*Animation `json:"animations"` // 动画列表
+}
+
+// UnmarshalJSON 解析片段动画，保留未建模的字段
+func (am *AnimationMaterial) UnmarshalJSON(data []byte) error {
+	type plain AnimationMaterial
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*am = AnimationMaterial(value)
+	am.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出片段动画
+func (am *AnimationMaterial) MarshalJSON() ([]byte, error) {
+	type plain AnimationMaterial
+	return encodeObject((*plain)(am), am.raw)
+}
+
+// Animation 单个动画
+type Animation struct {
+	Fields
+	ID         string `json:"id"`          // 动画id
+	Type       string `json:"type"`        // 动画类型，如"in"、"out"、"group"
+	Name       string `json:"name"`        // 动画名称
+	ResourceID string `json:"resource_id"` // 资源id
+	Start      int64  `json:"start"`       // 相对于片段开始时间的偏移，单位为微秒
+	Duration   int64  `json:"duration"`    // 动画时长，单位为微秒
+}
+
+// UnmarshalJSON 解析动画，保留未建模的字段
+func (a *Animation) UnmarshalJSON(data []byte) error {
+	type plain Animation
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*a = Animation(value)
+	a.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出动画
+func (a *Animation) MarshalJSON() ([]byte, error) {
+	type plain Animation
+	return encodeObject((*plain)(a), a.raw)
+}
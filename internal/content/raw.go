@@ -0,0 +1,470 @@
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// rawFields JSON对象的原始字段，保留字段在原始数据中的顺序
+type rawFields struct {
+	values map[string]json.RawMessage // 键为字段名，值为未经修改的原始JSON
+	keys   []string                   // 字段在原始数据中的顺序
+}
+
+// Fields 模型对象从JSON中解析得到的原始字段，嵌入在所有模型类型中
+//
+// 导出时，未建模的字段原样写回；已建模的字段若与原始值等价（数值相等即视为等价）则沿用原始写法，
+// 否则写入新值。原始数据中不存在的字段只有在值非零时才会写入。
+// 字段按原始数据中的顺序输出，新增的字段按字典序排在其后
+type Fields struct {
+	raw rawFields
+}
+
+// Has 返回对象中是否存在指定的字段，未从JSON解析得到的对象总是返回true
+func (f Fields) Has(key string) bool {
+	if f.raw.values == nil {
+		return true
+	}
+	_, ok := f.raw.values[key]
+	return ok
+}
+
+// Field 返回指定字段的原始JSON，字段不存在时返回nil
+func (f Fields) Field(key string) json.RawMessage {
+	return f.raw.values[key]
+}
+
+// SetField 设置未建模字段的值，value为nil时写入null
+//
+// 已建模的字段以结构体中的值为准，应直接修改对应的结构体字段
+func (f *Fields) SetField(key string, value interface{}) error {
+	data, err := marshal(value)
+	if err != nil {
+		return err
+	}
+	raw := rawFields{values: make(map[string]json.RawMessage, len(f.raw.values)+1)}
+	for k, v := range f.raw.values {
+		raw.values[k] = v
+	}
+	raw.keys = append(raw.keys, f.raw.keys...)
+	if _, ok := raw.values[key]; !ok {
+		raw.keys = append(raw.keys, key)
+	}
+	raw.values[key] = data
+	f.raw = raw
+	return nil
+}
+
+// DeleteField 删除未建模的字段
+func (f *Fields) DeleteField(key string) {
+	if _, ok := f.raw.values[key]; !ok {
+		return
+	}
+	raw := rawFields{values: make(map[string]json.RawMessage, len(f.raw.values))}
+	for _, k := range f.raw.keys {
+		if k != key {
+			raw.values[k] = f.raw.values[k]
+			raw.keys = append(raw.keys, k)
+		}
+	}
+	f.raw = raw
+}
+
+// decodeObject 将JSON对象解析到已建模的字段，并返回对象的所有原始字段
+//
+// target必须是不带UnmarshalJSON方法的类型（通常为模型类型的别名）的指针，以避免递归
+func decodeObject(data []byte, target interface{}) (rawFields, error) {
+	raw, err := decodeRawFields(data)
+	if err != nil {
+		return rawFields{}, err
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return rawFields{}, err
+	}
+	return raw, nil
+}
+
+// decodeRawFields 按原始顺序解析JSON对象的所有字段
+func decodeRawFields(data []byte) (rawFields, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return rawFields{}, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return rawFields{}, fmt.Errorf("expected a JSON object, got %s", bytes.TrimSpace(data))
+	}
+
+	raw := rawFields{values: make(map[string]json.RawMessage)}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return rawFields{}, err
+		}
+		key := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return rawFields{}, err
+		}
+		if _, ok := raw.values[key]; !ok {
+			raw.keys = append(raw.keys, key)
+		}
+		raw.values[key] = value
+	}
+	return raw, nil
+}
+
+// encodeObject 将已建模的字段与原始字段合并后编码为JSON对象
+func encodeObject(source interface{}, raw rawFields) ([]byte, error) {
+	fields, err := encodeFields(source)
+	if err != nil {
+		return nil, err
+	}
+	return writeObject(fields, raw)
+}
+
+// encodeFields 编码已建模的字段
+func encodeFields(source interface{}) (map[string]json.RawMessage, error) {
+	data, err := marshal(source)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// writeObject 合并字段并输出JSON对象，原始字段保持原有顺序，新增的字段按字典序排在其后
+func writeObject(fields map[string]json.RawMessage, raw rawFields) ([]byte, error) {
+	result := make(map[string]json.RawMessage, len(raw.values)+len(fields))
+	for k, v := range raw.values {
+		result[k] = v
+	}
+	var added []string
+	for k, v := range fields {
+		if old, ok := raw.values[k]; ok {
+			if equivalent(old, v) || (string(old) == "null" && isZero(v)) {
+				continue
+			}
+		} else if string(v) == "null" || (raw.values != nil && isZero(v)) {
+			continue
+		} else {
+			added = append(added, k)
+		}
+		result[k] = v
+	}
+	sort.Strings(added)
+	keys := append(append(make([]string, 0, len(result)), raw.keys...), added...)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(result[k])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalIndent 以缩进格式编码通用的JSON值（如ToMap的结果），对象的键按layout中相同位置的对象的顺序输出
+//
+// layout通常为模型对象MarshalJSON的结果，数组元素按下标对应；layout中不存在的键按字典序排在后面
+func MarshalIndent(value interface{}, layout []byte, indent string) ([]byte, error) {
+	node, err := readLayout(json.NewDecoder(bytes.NewReader(layout)))
+	if err != nil {
+		return nil, err
+	}
+	var compact bytes.Buffer
+	if err := writeOrdered(&compact, value, node); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, compact.Bytes(), "", indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// layoutNode JSON值中各层对象的键顺序
+type layoutNode struct {
+	keys   []string
+	fields map[string]*layoutNode
+	items  []*layoutNode
+}
+
+// field 返回对象中指定键对应的节点
+func (n *layoutNode) field(key string) *layoutNode {
+	if n == nil {
+		return nil
+	}
+	return n.fields[key]
+}
+
+// item 返回数组中指定下标对应的节点
+func (n *layoutNode) item(i int) *layoutNode {
+	if n == nil || i >= len(n.items) {
+		return nil
+	}
+	return n.items[i]
+}
+
+// order 返回对象m的键的输出顺序
+func (n *layoutNode) order(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	seen := make(map[string]bool, len(m))
+	if n != nil {
+		for _, k := range n.keys {
+			if _, ok := m[k]; ok {
+				keys = append(keys, k)
+				seen[k] = true
+			}
+		}
+	}
+	var added []string
+	for k := range m {
+		if !seen[k] {
+			added = append(added, k)
+		}
+	}
+	sort.Strings(added)
+	return append(keys, added...)
+}
+
+// readLayout 读取下一个JSON值的键顺序，标量值返回nil
+func readLayout(decoder *json.Decoder) (*layoutNode, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil, nil
+	}
+
+	node := &layoutNode{}
+	if delim == '{' {
+		node.fields = make(map[string]*layoutNode)
+	}
+	for decoder.More() {
+		if delim == '[' {
+			item, err := readLayout(decoder)
+			if err != nil {
+				return nil, err
+			}
+			node.items = append(node.items, item)
+			continue
+		}
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := token.(string)
+		child, err := readLayout(decoder)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := node.fields[key]; !ok {
+			node.keys = append(node.keys, key)
+		}
+		node.fields[key] = child
+	}
+	// 读取结束符
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// writeOrdered 以紧凑格式编码通用的JSON值，对象的键按node中的顺序输出
+func writeOrdered(buf *bytes.Buffer, value interface{}, node *layoutNode) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		buf.WriteByte('{')
+		for i, k := range node.order(v) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := marshal(k)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeOrdered(buf, v[k], node.field(k)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeOrdered(buf, item, node.item(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		data, err := marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	return nil
+}
+
+// marshal 编码JSON，不转义HTML字符
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// isZero 判断JSON值是否为零值：null、false、0、空字符串或空数组
+func isZero(data json.RawMessage) bool {
+	switch string(data) {
+	case "null", "false", `""`, "[]":
+		return true
+	}
+	value, err := decodeValue(data)
+	if err != nil {
+		return false
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return false
+	}
+	x, ok := new(big.Float).SetString(string(number))
+	return ok && x.Sign() == 0
+}
+
+// equivalent 判断两段JSON是否表示相同的值，数值按大小比较
+func equivalent(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, a); err == nil && bytes.Equal(compacted.Bytes(), b) {
+		return true
+	}
+
+	va, errA := decodeValue(a)
+	vb, errB := decodeValue(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return valuesEqual(va, vb)
+}
+
+// decodeValue 以json.Number解析任意JSON值
+func decodeValue(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	err := decoder.Decode(&value)
+	return value, err
+}
+
+// valuesEqual 深度比较两个解析后的JSON值
+func valuesEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			other, ok := bv[k]
+			if !ok || !valuesEqual(v, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !valuesEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if av == bv {
+			return true
+		}
+		x, okA := new(big.Float).SetPrec(256).SetString(string(av))
+		y, okB := new(big.Float).SetPrec(256).SetString(string(bv))
+		return okA && okB && x.Cmp(y) == 0
+	default:
+		return a == b
+	}
+}
+
+// FromMap 将map形式的JSON对象解析到模型对象中，map中未建模的字段会被保留
+func FromMap(m map[string]interface{}, target json.Unmarshaler) error {
+	data, err := marshal(m)
+	if err != nil {
+		return err
+	}
+	return target.UnmarshalJSON(data)
+}
+
+// ToMap 将模型对象转换为map形式的JSON对象，数值为json.Number类型
+func ToMap(source json.Marshaler) (map[string]interface{}, error) {
+	data, err := source.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeValue(data)
+	if err != nil {
+		return nil, err
+	}
+	result, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON object, got %s", data)
+	}
+	return result, nil
+}
+
+// ToPlainMap 将模型对象转换为map形式的JSON对象，与encoding/json的默认解析结果相同，数值为float64类型
+func ToPlainMap(source json.Marshaler) (map[string]interface{}, error) {
+	data, err := source.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("expected a JSON object, got %s", data)
+	}
+	return result, nil
+}
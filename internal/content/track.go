@@ -0,0 +1,300 @@
+package content
+
+import "encoding/json"
+
+// Track 轨道
+type Track struct {
+	Fields
+	Attribute     int        `json:"attribute"`       // 轨道属性，1表示静音
+	Flag          int        `json:"flag"`            // 轨道标志
+	ID            string     `json:"id"`              // 轨道id
+	IsDefaultName bool       `json:"is_default_name"` // 是否使用默认名称
+	Name          string     `json:"name"`            // 轨道名称
+	Type          string     `json:"type"`            // 轨道类型，如"video"、"text"
+	Segments      []*Segment `json:"segments"`        // 片段列表
+}
+
+// UnmarshalJSON 解析轨道，保留未建模的字段
+func (t *Track) UnmarshalJSON(data []byte) error {
+	type plain Track
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*t = Track(value)
+	t.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出轨道
+func (t *Track) MarshalJSON() ([]byte, error) {
+	type plain Track
+	return encodeObject((*plain)(t), t.raw)
+}
+
+// Segment 片段
+type Segment struct {
+	Fields
+	ID                string          `json:"id"`                  // 片段id
+	MaterialID        string          `json:"material_id"`         // 片段使用的素材id
+	TargetTimerange   *Timerange      `json:"target_timerange"`    // 片段在轨道上的时间范围
+	SourceTimerange   *Timerange      `json:"source_timerange"`    // 片段取用的素材时间范围，仅音视频片段有效
+	Speed             float64         `json:"speed"`               // 播放速度
+	Volume            float64         `json:"volume"`              // 音量
+	RenderIndex       int             `json:"render_index"`        // 渲染层级
+	ExtraMaterialRefs []string        `json:"extra_material_refs"` // 附加的素材id列表，用于链接动画/特效等
+	Clip              *Clip           `json:"clip"`                // 图像调节设置，音频片段为nil
+	CommonKeyframes   []*KeyframeList `json:"common_keyframes"`    // 关键帧列表
+}
+
+// UnmarshalJSON 解析片段，保留未建模的字段
+func (s *Segment) UnmarshalJSON(data []byte) error {
+	type plain Segment
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*s = Segment(value)
+	s.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出片段
+func (s *Segment) MarshalJSON() ([]byte, error) {
+	type plain Segment
+	return encodeObject((*plain)(s), s.raw)
+}
+
+// Timerange 时间范围，单位为微秒
+type Timerange struct {
+	Fields
+	Start    int64 `json:"start"`    // 开始时间
+	Duration int64 `json:"duration"` // 持续时长
+}
+
+// UnmarshalJSON 解析时间范围，保留未建模的字段
+func (tr *Timerange) UnmarshalJSON(data []byte) error {
+	type plain Timerange
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*tr = Timerange(value)
+	tr.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出时间范围
+func (tr *Timerange) MarshalJSON() ([]byte, error) {
+	type plain Timerange
+	return encodeObject((*plain)(tr), tr.raw)
+}
+
+// Clip 片段的图像调节设置
+type Clip struct {
+	Fields
+	Alpha     float64 `json:"alpha"`     // 不透明度
+	Rotation  float64 `json:"rotation"`  // 顺时针旋转的角度
+	Flip      *Flip   `json:"flip"`      // 翻转设置
+	Scale     *Vector `json:"scale"`     // 缩放比例
+	Transform *Vector `json:"transform"` // 位移，单位为半个画布宽/高
+}
+
+// UnmarshalJSON 解析图像调节设置，保留未建模的字段
+func (c *Clip) UnmarshalJSON(data []byte) error {
+	type plain Clip
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*c = Clip(value)
+	c.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出图像调节设置
+func (c *Clip) MarshalJSON() ([]byte, error) {
+	type plain Clip
+	return encodeObject((*plain)(c), c.raw)
+}
+
+// Flip 翻转设置
+type Flip struct {
+	Fields
+	Horizontal bool `json:"horizontal"` // 水平翻转
+	Vertical   bool `json:"vertical"`   // 垂直翻转
+}
+
+// UnmarshalJSON 解析翻转设置，保留未建模的字段
+func (f *Flip) UnmarshalJSON(data []byte) error {
+	type plain Flip
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*f = Flip(value)
+	f.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出翻转设置
+func (f *Flip) MarshalJSON() ([]byte, error) {
+	type plain Flip
+	return encodeObject((*plain)(f), f.raw)
+}
+
+// Vector 二维向量，用于缩放和位移
+type Vector struct {
+	Fields
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// UnmarshalJSON 解析二维向量，保留未建模的字段
+func (v *Vector) UnmarshalJSON(data []byte) error {
+	type plain Vector
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*v = Vector(value)
+	v.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出二维向量
+func (v *Vector) MarshalJSON() ([]byte, error) {
+	type plain Vector
+	return encodeObject((*plain)(v), v.raw)
+}
+
+// KeyframeList 一个属性的关键帧列表
+type KeyframeList struct {
+	Fields
+	ID           string      `json:"id"`            // 列表id
+	MaterialID   string      `json:"material_id"`   // 关联的素材id
+	PropertyType string      `json:"property_type"` // 关键帧控制的属性，如"KFTypeAlpha"
+	Keyframes    []*Keyframe `json:"keyframe_list"` // 按时间排列的关键帧
+}
+
+// UnmarshalJSON 解析关键帧列表，保留未建模的字段
+func (kl *KeyframeList) UnmarshalJSON(data []byte) error {
+	type plain KeyframeList
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*kl = KeyframeList(value)
+	kl.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出关键帧列表
+func (kl *KeyframeList) MarshalJSON() ([]byte, error) {
+	type plain KeyframeList
+	return encodeObject((*plain)(kl), kl.raw)
+}
+
+// Keyframe 关键帧
+type Keyframe struct {
+	Fields
+	ID           string    `json:"id"`            // 关键帧id
+	TimeOffset   int64     `json:"time_offset"`   // 相对于片段开始时间的偏移，单位为微秒
+	Values       []float64 `json:"values"`        // 关键帧的值
+	CurveType    string    `json:"curveType"`     // 插值曲线类型，如"Line"
+	GraphID      string    `json:"graphID"`       // 曲线id
+	LeftControl  *Vector   `json:"left_control"`  // 贝塞尔曲线的左控制点
+	RightControl *Vector   `json:"right_control"` // 贝塞尔曲线的右控制点
+}
+
+// UnmarshalJSON 解析关键帧，保留未建模的字段
+func (k *Keyframe) UnmarshalJSON(data []byte) error {
+	type plain Keyframe
+	var value plain
+	raw, err := decodeObject(data, &value)
+	if err != nil {
+		return err
+	}
+	*k = Keyframe(value)
+	k.raw = raw
+	return nil
+}
+
+// MarshalJSON 导出关键帧
+func (k *Keyframe) MarshalJSON() ([]byte, error) {
+	type plain Keyframe
+	return encodeObject((*plain)(k), k.raw)
+}
+
+// Keyframes 草稿顶层按片段类型存放的关键帧列表
+//
+// 片段自身的关键帧存放在Segment.CommonKeyframes中，此处为剪映保存的另一份索引。
+// 某个类别的列表无法解析时该类别为nil，导出时原样写回原始数据
+type Keyframes struct {
+	Fields
+	Adjusts    []*KeyframeList `json:"adjusts"`    // 调节片段的关键帧
+	Audios     []*KeyframeList `json:"audios"`     // 音频片段的关键帧
+	Effects    []*KeyframeList `json:"effects"`    // 特效片段的关键帧
+	Filters    []*KeyframeList `json:"filters"`    // 滤镜片段的关键帧
+	Handwrites []*KeyframeList `json:"handwrites"` // 手写片段的关键帧
+	Stickers   []*KeyframeList `json:"stickers"`   // 贴纸片段的关键帧
+	Texts      []*KeyframeList `json:"texts"`      // 文本片段的关键帧
+	Videos     []*KeyframeList `json:"videos"`     // 视频片段的关键帧
+}
+
+// lists 返回各类别名称到对应列表字段的映射
+func (k *Keyframes) lists() map[string]*[]*KeyframeList {
+	return map[string]*[]*KeyframeList{
+		"adjusts":    &k.Adjusts,
+		"audios":     &k.Audios,
+		"effects":    &k.Effects,
+		"filters":    &k.Filters,
+		"handwrites": &k.Handwrites,
+		"stickers":   &k.Stickers,
+		"texts":      &k.Texts,
+		"videos":     &k.Videos,
+	}
+}
+
+// UnmarshalJSON 解析关键帧索引，保留未建模的字段
+func (k *Keyframes) UnmarshalJSON(data []byte) error {
+	raw, err := decodeRawFields(data)
+	if err != nil {
+		return err
+	}
+	*k = Keyframes{}
+	k.raw = raw
+
+	// 无法解析为关键帧列表的值只保留原始数据
+	for kind, list := range k.lists() {
+		if item, ok := raw.values[kind]; ok {
+			if err := json.Unmarshal(item, list); err != nil {
+				*list = nil
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalJSON 导出关键帧索引
+func (k *Keyframes) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+	for kind, list := range k.lists() {
+		if *list == nil {
+			continue
+		}
+		data, err := marshal(*list)
+		if err != nil {
+			return nil, err
+		}
+		fields[kind] = data
+	}
+	return writeObject(fields, k.raw)
+}
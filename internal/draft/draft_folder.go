@@ -104,6 +104,28 @@ func (df *DraftFolder) LoadTemplate(draftName string) (*script.ScriptFile, error
 	return script.LoadTemplate(draftInfoPath)
 }
 
+// RelinkMaterials 在searchDirs中查找指定草稿中缺失的音视频素材，并将新的路径写回草稿文件
+//
+// 查找规则见ScriptFile.RelinkMaterials；有素材被重新链接且未指定script.WithDryRun时保存草稿
+func (df *DraftFolder) RelinkMaterials(draftName string, searchDirs []string, options ...script.RelinkOption) (*script.RelinkReport, error) {
+	scriptFile, err := df.LoadTemplate(draftName)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := scriptFile.RelinkMaterials(searchDirs, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(report.Relinked) > 0 && !report.DryRun {
+		if err := scriptFile.Save(); err != nil {
+			return nil, fmt.Errorf("保存草稿失败: %w", err)
+		}
+	}
+	return report, nil
+}
+
 // DuplicateAsTemplate 复制一份给定的草稿，并在复制出的新草稿上进行编辑
 // 对应Python的duplicate_as_template方法
 func (df *DraftFolder) DuplicateAsTemplate(templateName, newDraftName string, allowReplace bool) (*script.ScriptFile, error) {
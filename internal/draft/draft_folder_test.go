@@ -1,11 +1,15 @@
 package draft
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/zhangshican/go-capcut/internal/script"
 )
 
 // TestNewDraftFolder 测试创建草稿文件夹管理器
@@ -593,6 +597,300 @@ func TestDraftInfoString(t *testing.T) {
 	}
 }
 
+// TestDraftFolderRelinkMaterials 测试重新链接草稿中缺失的素材
+func TestDraftFolderRelinkMaterials(t *testing.T) {
+	tempDir := t.TempDir()
+	draftsDir := filepath.Join(tempDir, "drafts")
+	mediaDir := filepath.Join(tempDir, "media")
+
+	writeFile := func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("写入文件失败: %v", err)
+		}
+	}
+
+	// 素材文件：唯一的clip.mp4、两个相同的voice.wav副本、两个内容不同的dup.mp4
+	existing := filepath.Join(tempDir, "existing.mp4")
+	writeFile(existing, "existing")
+	writeFile(filepath.Join(mediaDir, "videos", "Clip.mp4"), "clip")
+	writeFile(filepath.Join(mediaDir, "a", "voice.wav"), "voice")
+	writeFile(filepath.Join(mediaDir, "b", "voice.wav"), "voice")
+	writeFile(filepath.Join(mediaDir, "a", "dup.mp4"), "dup")
+	writeFile(filepath.Join(mediaDir, "b", "dup.mp4"), "different dup")
+
+	draftInfo := map[string]interface{}{
+		"fps":           30,
+		"duration":      5000000,
+		"canvas_config": map[string]interface{}{"width": 1920, "height": 1080},
+		"materials": map[string]interface{}{
+			"videos": []interface{}{
+				map[string]interface{}{"id": "V1", "type": "video", "path": `D:\素材\clip.mp4`},
+				map[string]interface{}{"id": "V2", "type": "video", "path": "C:/media/dup.mp4"},
+				map[string]interface{}{"id": "V3", "type": "video", "path": "/old/gone.mp4"},
+				map[string]interface{}{"id": "V4", "type": "video", "path": existing},
+				map[string]interface{}{"id": "V5", "type": "video", "path": ""},
+			},
+			"audios": []interface{}{
+				map[string]interface{}{"id": "A1", "type": "extract_music", "path": "/old/voice.wav"},
+			},
+		},
+		"tracks": []interface{}{},
+	}
+	jsonBytes, err := json.Marshal(draftInfo)
+	if err != nil {
+		t.Fatalf("序列化draft_info失败: %v", err)
+	}
+	draftInfoPath := filepath.Join(draftsDir, "draft", "draft_info.json")
+	writeFile(draftInfoPath, string(jsonBytes))
+
+	df, err := NewDraftFolder(draftsDir)
+	if err != nil {
+		t.Fatalf("创建DraftFolder失败: %v", err)
+	}
+
+	// 试运行不修改草稿文件
+	report, err := df.RelinkMaterials("draft", []string{mediaDir}, script.WithDryRun())
+	if err != nil {
+		t.Fatalf("重新链接素材失败: %v", err)
+	}
+	if len(report.Relinked) != 2 {
+		t.Errorf("期望2个素材被重新链接，得到%d", len(report.Relinked))
+	}
+	if after, _ := os.ReadFile(draftInfoPath); string(after) != string(jsonBytes) {
+		t.Error("试运行不应修改草稿文件")
+	}
+
+	report, err = df.RelinkMaterials("draft", []string{mediaDir})
+	if err != nil {
+		t.Fatalf("重新链接素材失败: %v", err)
+	}
+
+	relinked := make(map[string]string)
+	for _, entry := range report.Relinked {
+		relinked[entry.MaterialID] = entry.NewPath
+	}
+	expected := map[string]string{
+		"V1": filepath.Join(mediaDir, "videos", "Clip.mp4"),
+		"A1": filepath.Join(mediaDir, "a", "voice.wav"),
+	}
+	for id, path := range expected {
+		if relinked[id] != path {
+			t.Errorf("素材 %s 应重新链接到 %s，得到 %s", id, path, relinked[id])
+		}
+	}
+	if len(report.Ambiguous) != 1 || report.Ambiguous[0].MaterialID != "V2" || len(report.Ambiguous[0].Candidates) != 2 {
+		t.Errorf("期望V2因存在两个不同的候选而无法确定，得到 %+v", report.Ambiguous)
+	}
+	if len(report.Missing) != 1 || report.Missing[0].MaterialID != "V3" {
+		t.Errorf("期望V3缺失，得到 %+v", report.Missing)
+	}
+
+	// 新路径已写入草稿文件
+	scriptFile, err := df.LoadTemplate("draft")
+	if err != nil {
+		t.Fatalf("加载模板失败: %v", err)
+	}
+	for _, video := range scriptFile.Content.Materials.Videos {
+		switch video.ID {
+		case "V1":
+			if video.Path != expected["V1"] {
+				t.Errorf("草稿中V1的路径应为 %s，得到 %s", expected["V1"], video.Path)
+			}
+		case "V2":
+			if video.Path != "C:/media/dup.mp4" {
+				t.Errorf("无法确定的素材路径不应改变，得到 %s", video.Path)
+			}
+		case "V4":
+			if video.Path != existing {
+				t.Errorf("有效的素材路径不应改变，得到 %s", video.Path)
+			}
+		}
+	}
+	if path := scriptFile.Content.Materials.Audios[0].Path; path != expected["A1"] {
+		t.Errorf("草稿中A1的路径应为 %s，得到 %s", expected["A1"], path)
+	}
+
+	if _, err := df.RelinkMaterials("draft", []string{filepath.Join(tempDir, "missing")}); err == nil {
+		t.Error("搜索目录不存在时应返回错误")
+	}
+}
+
+// TestDraftFolderPackUnpack 测试打包草稿及解压到新草稿
+func TestDraftFolderPackUnpack(t *testing.T) {
+	tempDir := t.TempDir()
+	draftsDir := filepath.Join(tempDir, "drafts")
+	writeFile := func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("写入文件失败: %v", err)
+		}
+	}
+
+	// 两个同名素材位于不同目录，音频与第一个视频共用同一文件
+	clipA := filepath.Join(tempDir, "a", "clip.mp4")
+	clipB := filepath.Join(tempDir, "b", "clip.mp4")
+	writeFile(clipA, "clip a")
+	writeFile(clipB, "clip b")
+	draftInfo := map[string]interface{}{
+		"fps":           30,
+		"duration":      5000000,
+		"canvas_config": map[string]interface{}{"width": 1920, "height": 1080},
+		"materials": map[string]interface{}{
+			"videos": []interface{}{
+				map[string]interface{}{"id": "V1", "type": "video", "path": clipA},
+				map[string]interface{}{"id": "V2", "type": "video", "path": clipB},
+			},
+			"audios": []interface{}{
+				map[string]interface{}{"id": "A1", "type": "extract_music", "path": clipA},
+			},
+		},
+		"tracks": []interface{}{},
+	}
+	jsonBytes, err := json.Marshal(draftInfo)
+	if err != nil {
+		t.Fatalf("序列化draft_info失败: %v", err)
+	}
+	writeFile(filepath.Join(draftsDir, "draft", "draft_info.json"), string(jsonBytes))
+	meta := `{"draft_name":"draft","draft_fold_path":"D:/old/draft","draft_root_path":"D:\\old","tm_draft_create":1700000000123456}`
+	writeFile(filepath.Join(draftsDir, "draft", "draft_meta_info.json"), meta)
+
+	df, err := NewDraftFolder(draftsDir)
+	if err != nil {
+		t.Fatalf("创建DraftFolder失败: %v", err)
+	}
+	zipPath := filepath.Join(tempDir, "draft.zip")
+	if err := df.Pack("draft", zipPath); err != nil {
+		t.Fatalf("打包草稿失败: %v", err)
+	}
+	if after, _ := os.ReadFile(filepath.Join(draftsDir, "draft", "draft_info.json")); string(after) != string(jsonBytes) {
+		t.Error("打包不应修改原草稿")
+	}
+
+	// 解压到另一个草稿根目录
+	otherDir := filepath.Join(tempDir, "other")
+	if err := os.Mkdir(otherDir, 0755); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+	other, err := NewDraftFolder(otherDir)
+	if err != nil {
+		t.Fatalf("创建DraftFolder失败: %v", err)
+	}
+	scriptFile, err := other.Unpack(zipPath, "copy")
+	if err != nil {
+		t.Fatalf("解压草稿失败: %v", err)
+	}
+
+	newDraftPath := filepath.Join(otherDir, "copy")
+	metaBytes, err := os.ReadFile(filepath.Join(newDraftPath, "draft_meta_info.json"))
+	if err != nil {
+		t.Fatalf("草稿文件夹中的其他文件应被一并打包: %v", err)
+	}
+	if after, _ := os.ReadFile(filepath.Join(draftsDir, "draft", "draft_meta_info.json")); string(after) != meta {
+		t.Error("解压不应修改原草稿的元数据")
+	}
+
+	// 元数据中的名称及位置指向新草稿，其他字段保持不变
+	var newMeta map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(metaBytes))
+	decoder.UseNumber()
+	if err := decoder.Decode(&newMeta); err != nil {
+		t.Fatalf("解析草稿元数据失败: %v", err)
+	}
+	expectedMeta := map[string]interface{}{
+		"draft_name":      "copy",
+		"draft_fold_path": filepath.ToSlash(newDraftPath),
+		"draft_root_path": otherDir,
+		"tm_draft_create": json.Number("1700000000123456"),
+	}
+	for key, value := range expectedMeta {
+		if newMeta[key] != value {
+			t.Errorf("草稿元数据中%s应为 %v，得到 %v", key, value, newMeta[key])
+		}
+	}
+	expected := map[string]string{
+		"V1": filepath.Join(newDraftPath, "packed_media", "clip.mp4"),
+		"V2": filepath.Join(newDraftPath, "packed_media", "clip_1.mp4"),
+	}
+	contents := map[string]string{"V1": "clip a", "V2": "clip b"}
+	for _, video := range scriptFile.Content.Materials.Videos {
+		if video.Path != expected[video.ID] {
+			t.Errorf("素材 %s 的路径应为 %s，得到 %s", video.ID, expected[video.ID], video.Path)
+			continue
+		}
+		if data, err := os.ReadFile(video.Path); err != nil || string(data) != contents[video.ID] {
+			t.Errorf("素材 %s 的文件内容不正确: %q, %v", video.ID, data, err)
+		}
+	}
+	if path := scriptFile.Content.Materials.Audios[0].Path; path != expected["V1"] {
+		t.Errorf("共用文件的素材应指向同一文件 %s，得到 %s", expected["V1"], path)
+	}
+
+	// 新路径已写入草稿文件
+	reloaded, err := other.LoadTemplate("copy")
+	if err != nil {
+		t.Fatalf("加载模板失败: %v", err)
+	}
+	if path := reloaded.Content.Materials.Videos[1].Path; path != expected["V2"] {
+		t.Errorf("草稿文件中的路径应为 %s，得到 %s", expected["V2"], path)
+	}
+
+	// 再次打包解压出的草稿，不会重复打包素材
+	repacked := filepath.Join(tempDir, "repacked.zip")
+	if err := other.Pack("copy", repacked); err != nil {
+		t.Fatalf("再次打包草稿失败: %v", err)
+	}
+	if _, err := df.Unpack(repacked, "copy"); err != nil {
+		t.Fatalf("解压再次打包的草稿失败: %v", err)
+	}
+
+	if _, err := other.Unpack(zipPath, "copy"); err == nil {
+		t.Error("新草稿已存在时应返回错误")
+	}
+	if err := os.Remove(clipB); err != nil {
+		t.Fatalf("删除文件失败: %v", err)
+	}
+	if err := df.Pack("draft", filepath.Join(tempDir, "broken.zip")); err == nil {
+		t.Error("素材文件不存在时应返回错误")
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "broken.zip")); !os.IsNotExist(err) {
+		t.Error("打包失败时不应留下压缩包")
+	}
+}
+
+// TestDraftFolderUnpackInvalidPath 测试拒绝解压指向草稿文件夹之外的条目
+func TestDraftFolderUnpackInvalidPath(t *testing.T) {
+	tempDir := t.TempDir()
+	zipPath := filepath.Join(tempDir, "evil.zip")
+	file, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatalf("创建压缩包失败: %v", err)
+	}
+	writer := zip.NewWriter(file)
+	for _, name := range []string{"draft_info.json", "../escaped.txt"} {
+		if _, err := writer.Create(name); err != nil {
+			t.Fatalf("写入压缩包失败: %v", err)
+		}
+	}
+	writer.Close()
+	file.Close()
+
+	df, err := NewDraftFolder(tempDir)
+	if err != nil {
+		t.Fatalf("创建DraftFolder失败: %v", err)
+	}
+	if _, err := df.Unpack(zipPath, "draft"); err == nil {
+		t.Error("应拒绝指向草稿文件夹之外的条目")
+	}
+	if df.DraftExists("draft") {
+		t.Error("解压失败时应删除新草稿文件夹")
+	}
+}
+
 // containsString 检查字符串是否包含子字符串
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr ||
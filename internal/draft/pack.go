@@ -0,0 +1,274 @@
+package draft
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/zhangshican/go-capcut/internal/script"
+	"github.com/zhangshican/go-capcut/internal/util"
+)
+
+// packedMediaDir 打包时素材文件在草稿文件夹中的存放目录
+const packedMediaDir = "packed_media"
+
+// draftInfoFile 草稿文件夹中的草稿内容文件
+const draftInfoFile = "draft_info.json"
+
+// draftMetaFile 草稿文件夹中的草稿元数据文件，记录草稿名称及所在位置
+const draftMetaFile = "draft_meta_info.json"
+
+// Pack 将指定草稿打包为zip文件，草稿引用的所有本地音视频素材都会被一并打包
+//
+// 素材文件存放在压缩包的packed_media目录下，草稿中的素材路径改写为以util.DraftPathPlaceholder开头的
+// 草稿相对路径，原草稿不受影响。引用的素材文件不存在时返回错误，可先调用RelinkMaterials修复
+func (df *DraftFolder) Pack(draftName, zipPath string) (err error) {
+	draftPath := filepath.Join(df.FolderPath, draftName)
+	scriptFile, err := df.LoadTemplate(draftName)
+	if err != nil {
+		return err
+	}
+
+	// 为每个素材文件分配压缩包中的名称，同一文件只打包一次
+	media := make(map[string]string)   // 压缩包中的名称 -> 素材文件路径
+	entries := make(map[string]string) // 素材文件路径 -> 压缩包中的名称
+	var names []string
+	err = scriptFile.RewriteMaterialPaths(func(kind, materialID, materialPath string) (string, error) {
+		if util.IsDraftRelativePath(materialPath) {
+			return materialPath, nil
+		}
+		name, ok := entries[materialPath]
+		if !ok {
+			if _, err := os.Stat(materialPath); err != nil {
+				return "", fmt.Errorf("素材 %s 的文件 %s 不存在，无法打包", materialID, materialPath)
+			}
+			name = uniqueEntryName(media, path.Join(packedMediaDir, filepath.Base(materialPath)))
+			media[name] = materialPath
+			entries[materialPath] = name
+			names = append(names, name)
+		}
+		return util.DraftPathPlaceholder + "/" + name, nil
+	})
+	if err != nil {
+		return err
+	}
+	draftContent, err := scriptFile.Dumps()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(zipPath)
+	if err != nil {
+		return fmt.Errorf("创建压缩包失败: %w", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(zipPath)
+		}
+	}()
+
+	writer := zip.NewWriter(file)
+	// 草稿文件夹中的文件，草稿内容使用改写路径后的版本，之前打包的素材目录由引用的素材重新生成
+	err = filepath.WalkDir(draftPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(draftPath, filePath)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		switch {
+		case d.IsDir():
+			if name == packedMediaDir {
+				return filepath.SkipDir
+			}
+			return nil
+		case !d.Type().IsRegular():
+			return nil
+		case name == draftInfoFile:
+			return writeZipEntry(writer, name, strings.NewReader(draftContent))
+		}
+		return writeZipFile(writer, name, filePath)
+	})
+	if err != nil {
+		return fmt.Errorf("打包草稿文件夹失败: %w", err)
+	}
+
+	for _, name := range names {
+		if err := writeZipFile(writer, name, media[name]); err != nil {
+			return fmt.Errorf("打包素材 %s 失败: %w", media[name], err)
+		}
+	}
+	return writer.Close()
+}
+
+// Unpack 将Pack生成的压缩包解压为文件夹中名为newDraftName的新草稿，并打开该草稿
+//
+// 草稿相对路径的素材路径会被改写为新草稿文件夹中的绝对路径，草稿元数据中的草稿名称及位置
+// (draft_name、draft_fold_path、draft_root_path)改写为新草稿的名称及位置。新草稿已存在时返回错误
+func (df *DraftFolder) Unpack(zipPath, newDraftName string) (sf *script.ScriptFile, err error) {
+	newDraftPath := filepath.Join(df.FolderPath, newDraftName)
+	if _, err := os.Stat(newDraftPath); err == nil {
+		return nil, fmt.Errorf("新草稿 %s 已存在", newDraftName)
+	}
+
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, fmt.Errorf("打开压缩包失败: %w", err)
+	}
+	defer reader.Close()
+
+	defer func() {
+		if err != nil {
+			os.RemoveAll(newDraftPath)
+		}
+	}()
+	if err := os.MkdirAll(newDraftPath, 0755); err != nil {
+		return nil, fmt.Errorf("创建草稿文件夹失败: %w", err)
+	}
+	for _, entry := range reader.File {
+		if err := extractZipEntry(entry, newDraftPath); err != nil {
+			return nil, err
+		}
+	}
+
+	draftInfoPath := filepath.Join(newDraftPath, draftInfoFile)
+	if _, err := os.Stat(draftInfoPath); err != nil {
+		return nil, fmt.Errorf("压缩包中没有 %s", draftInfoFile)
+	}
+	if err := rewriteDraftMeta(newDraftPath, newDraftName, df.FolderPath); err != nil {
+		return nil, err
+	}
+	sf, err = script.LoadTemplate(draftInfoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sf.RewriteMaterialPaths(func(kind, materialID, materialPath string) (string, error) {
+		if !util.IsDraftRelativePath(materialPath) {
+			return materialPath, nil
+		}
+		rel := strings.TrimPrefix(materialPath, util.DraftPathPlaceholder)
+		return filepath.Join(newDraftPath, filepath.FromSlash(rel)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := sf.Save(); err != nil {
+		return nil, fmt.Errorf("保存草稿失败: %w", err)
+	}
+	return sf, nil
+}
+
+// rewriteDraftMeta 将草稿文件夹draftPath中元数据记录的草稿名称及位置改为draftName及其所在的文件夹rootPath，其余字段保持不变
+//
+// 与剪映的写法一致，draft_fold_path使用/作为分隔符，draft_root_path使用系统的分隔符。没有元数据文件时不作处理
+func rewriteDraftMeta(draftPath, draftName, rootPath string) error {
+	metaPath := filepath.Join(draftPath, draftMetaFile)
+	data, err := os.ReadFile(metaPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("读取 %s 失败: %w", draftMetaFile, err)
+	}
+
+	// 保留数字的原始写法，避免时间戳等大整数被改写为浮点数
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var meta map[string]interface{}
+	if err := decoder.Decode(&meta); err != nil {
+		return fmt.Errorf("解析 %s 失败: %w", draftMetaFile, err)
+	}
+	if meta == nil {
+		meta = make(map[string]interface{})
+	}
+	meta["draft_name"] = draftName
+	meta["draft_fold_path"] = filepath.ToSlash(draftPath)
+	meta["draft_root_path"] = rootPath
+
+	if data, err = json.Marshal(meta); err != nil {
+		return fmt.Errorf("序列化 %s 失败: %w", draftMetaFile, err)
+	}
+	if err := os.WriteFile(metaPath, data, 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", draftMetaFile, err)
+	}
+	return nil
+}
+
+// uniqueEntryName 返回不与已有名称重复的压缩包条目名称，重复时在文件名后添加序号
+func uniqueEntryName(existing map[string]string, name string) string {
+	if _, ok := existing[name]; !ok {
+		return name
+	}
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s_%d%s", stem, i, ext)
+		if _, ok := existing[candidate]; !ok {
+			return candidate
+		}
+	}
+}
+
+// writeZipFile 将文件写入压缩包
+func writeZipFile(writer *zip.Writer, name, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return writeZipEntry(writer, name, file)
+}
+
+// writeZipEntry 将r中的内容写入压缩包中名为name的条目
+func writeZipEntry(writer *zip.Writer, name string, r io.Reader) error {
+	w, err := writer.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// extractZipEntry 将压缩包中的条目解压到dir中，拒绝指向dir之外的路径
+func extractZipEntry(entry *zip.File, dir string) error {
+	name := path.Clean(strings.ReplaceAll(entry.Name, `\`, "/"))
+	if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") || filepath.VolumeName(name) != "" {
+		return fmt.Errorf("压缩包中的路径 %s 无效", entry.Name)
+	}
+	target := filepath.Join(dir, filepath.FromSlash(name))
+
+	if entry.FileInfo().IsDir() {
+		return os.MkdirAll(target, 0755)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	src, err := entry.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
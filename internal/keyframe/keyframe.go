@@ -8,7 +8,7 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/google/uuid"
+	"github.com/zhangshican/go-capcut/internal/util"
 )
 
 // Keyframe 一个关键帧（关键点），目前只支持线性插值
@@ -22,7 +22,7 @@ type Keyframe struct {
 // NewKeyframe 创建新的关键帧
 func NewKeyframe(timeOffset int64, value float64) *Keyframe {
 	return &Keyframe{
-		KfID:       strings.ReplaceAll(uuid.New().String(), "-", ""),
+		KfID:       strings.ReplaceAll(util.NewID(), "-", ""),
 		TimeOffset: timeOffset,
 		Values:     []float64{value},
 	}
@@ -134,7 +134,7 @@ type KeyframeList struct {
 // NewKeyframeList 为给定的关键帧属性初始化关键帧列表
 func NewKeyframeList(keyframeProperty KeyframeProperty) *KeyframeList {
 	return &KeyframeList{
-		ListID:           strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ListID:           strings.ReplaceAll(util.NewID(), "-", ""),
 		KeyframeProperty: keyframeProperty,
 		Keyframes:        make([]*Keyframe, 0),
 		MaterialID:       "",
@@ -213,6 +213,36 @@ func (kfl *KeyframeList) GetValueAt(timeOffset int64) float64 {
 	return before.Values[0] + ratio*(after.Values[0]-before.Values[0])
 }
 
+// Slice 返回时间偏移量在[start, end]范围内的关键帧组成的新列表，新列表中的时间偏移量以start为起点
+//
+// 范围之外存在关键帧时，在相应的边界处插入由线性插值得到的关键帧，使属性在范围内的变化保持不变
+func (kfl *KeyframeList) Slice(start, end int64) *KeyframeList {
+	result := NewKeyframeList(kfl.KeyframeProperty)
+	result.MaterialID = kfl.MaterialID
+
+	before, after := false, false
+	for _, kf := range kfl.Keyframes {
+		switch {
+		case kf.TimeOffset < start:
+			before = true
+		case kf.TimeOffset > end:
+			after = true
+		default:
+			keyframe := NewKeyframe(kf.TimeOffset-start, 0)
+			keyframe.Values = append([]float64(nil), kf.Values...)
+			result.Keyframes = append(result.Keyframes, keyframe)
+		}
+	}
+
+	if before && (len(result.Keyframes) == 0 || result.Keyframes[0].TimeOffset != 0) {
+		result.Keyframes = append([]*Keyframe{NewKeyframe(0, kfl.GetValueAt(start))}, result.Keyframes...)
+	}
+	if after && (len(result.Keyframes) == 0 || result.Keyframes[len(result.Keyframes)-1].TimeOffset != end-start) {
+		result.Keyframes = append(result.Keyframes, NewKeyframe(end-start, kfl.GetValueAt(end)))
+	}
+	return result
+}
+
 // getDefaultValue 获取属性的默认值
 func (kfl *KeyframeList) getDefaultValue() float64 {
 	switch kfl.KeyframeProperty {
@@ -370,6 +400,17 @@ func (km *KeyframeManager) HasKeyframes() bool {
 	return len(km.keyframeLists) > 0
 }
 
+// Slice 返回只包含[start, end]范围内关键帧的新管理器，时间偏移量以start为起点，见KeyframeList.Slice
+func (km *KeyframeManager) Slice(start, end int64) *KeyframeManager {
+	result := NewKeyframeManager()
+	for property, list := range km.keyframeLists {
+		if len(list.Keyframes) > 0 {
+			result.keyframeLists[property] = list.Slice(start, end)
+		}
+	}
+	return result
+}
+
 // ExportJSON 导出所有关键帧列表为JSON格式
 func (km *KeyframeManager) ExportJSON() []map[string]interface{} {
 	result := make([]map[string]interface{}, 0, len(km.keyframeLists))
@@ -203,6 +203,72 @@ func TestKeyframeListGetValueAt(t *testing.T) {
 	}
 }
 
+func TestKeyframeListSlice(t *testing.T) {
+	kfl := NewKeyframeList(KeyframePropertyAlpha)
+	kfl.AddKeyframe(1000000, 0.0)
+	kfl.AddKeyframe(3000000, 1.0)
+
+	offsets := func(list *KeyframeList) ([]int64, []float64) {
+		var times []int64
+		var values []float64
+		for _, kf := range list.Keyframes {
+			times = append(times, kf.TimeOffset)
+			values = append(values, kf.Values[0])
+		}
+		return times, values
+	}
+
+	tests := []struct {
+		start, end int64
+		times      []int64
+		values     []float64
+	}{
+		// 在两个关键帧之间切开，边界处插入插值得到的关键帧
+		{0, 2000000, []int64{1000000, 2000000}, []float64{0.0, 0.5}},
+		{2000000, 4000000, []int64{0, 1000000}, []float64{0.5, 1.0}},
+		// 范围内没有关键帧时保留边界处的值
+		{3500000, 5000000, []int64{0}, []float64{1.0}},
+		{0, 500000, []int64{500000}, []float64{0.0}},
+		// 关键帧恰好位于边界上时不重复插入
+		{1000000, 3000000, []int64{0, 2000000}, []float64{0.0, 1.0}},
+		// 范围向前扩展时关键帧整体后移
+		{-1000000, 4000000, []int64{2000000, 4000000}, []float64{0.0, 1.0}},
+	}
+	for _, tt := range tests {
+		sliced := kfl.Slice(tt.start, tt.end)
+		times, values := offsets(sliced)
+		if len(times) != len(tt.times) {
+			t.Errorf("Slice(%d, %d): expected offsets %v, got %v", tt.start, tt.end, tt.times, times)
+			continue
+		}
+		for i := range times {
+			if times[i] != tt.times[i] || values[i] != tt.values[i] {
+				t.Errorf("Slice(%d, %d): expected %v %v, got %v %v", tt.start, tt.end, tt.times, tt.values, times, values)
+				break
+			}
+		}
+		if sliced.ListID == kfl.ListID || sliced.KeyframeProperty != kfl.KeyframeProperty {
+			t.Errorf("Slice(%d, %d) should create a new list for the same property", tt.start, tt.end)
+		}
+	}
+
+	if len(kfl.Keyframes) != 2 || kfl.Keyframes[0].TimeOffset != 1000000 {
+		t.Error("Slice should not modify the original list")
+	}
+
+	km := NewKeyframeManager()
+	km.AddKeyframe(KeyframePropertyAlpha, 1000000, 0.0)
+	km.AddKeyframe(KeyframePropertyAlpha, 3000000, 1.0)
+	km.AddKeyframe(KeyframePropertyRotation, 0, 90.0)
+	right := km.Slice(2000000, 4000000)
+	if len(right.GetAllKeyframeLists()) != 2 {
+		t.Fatalf("Expected 2 keyframe lists, got %d", len(right.GetAllKeyframeLists()))
+	}
+	if value := right.GetKeyframeList(KeyframePropertyRotation).GetValueAt(0); value != 90.0 {
+		t.Errorf("Expected rotation 90 at the start of the slice, got %f", value)
+	}
+}
+
 func TestKeyframeListRemoveKeyframe(t *testing.T) {
 	kfl := NewKeyframeList(KeyframePropertyAlpha)
 
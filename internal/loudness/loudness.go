@@ -0,0 +1,241 @@
+// Package loudness 测量音频的响度及峰值
+//
+// 积分响度按照EBU R128(ITU-R BS.1770)的方法计算：经K计权滤波后以400ms为块、100ms为步长求均方值，
+// 再经过-70 LUFS的绝对门限及-10 LU的相对门限。内置的解码器完全由Go实现，支持PCM整数及浮点编码的WAV文件
+package loudness
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// 门限参数
+const (
+	absoluteGate = -70.0 // 绝对门限，单位为LUFS
+	relativeGate = -10.0 // 相对门限，单位为LU
+)
+
+// Result 响度测量结果
+type Result struct {
+	Integrated float64 // 积分响度，单位为LUFS，音频过短或全部低于门限时为负无穷
+	Peak       float64 // 采样峰值，单位为dBFS，静音时为负无穷
+}
+
+// biquad 二阶IIR滤波器，使用转置直接II型结构
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+	z1, z2             float64
+}
+
+// process 对一个采样进行滤波
+func (f *biquad) process(x float64) float64 {
+	y := f.b0*x + f.z1
+	f.z1 = f.b1*x - f.a1*y + f.z2
+	f.z2 = f.b2*x - f.a2*y
+	return y
+}
+
+// kWeighting 根据采样率创建K计权滤波器的两级：高频搁架滤波器及高通滤波器
+//
+// 系数的推导与libebur128相同，可适用于任意采样率
+func kWeighting(sampleRate int) (shelf, highPass biquad) {
+	rate := float64(sampleRate)
+
+	f0, gain, q := 1681.974450955533, 3.999843853973347, 0.7071752369554196
+	k := math.Tan(math.Pi * f0 / rate)
+	vh := math.Pow(10, gain/20)
+	vb := math.Pow(vh, 0.4996667741545416)
+	a0 := 1 + k/q + k*k
+	shelf = biquad{
+		b0: (vh + vb*k/q + k*k) / a0,
+		b1: 2 * (k*k - vh) / a0,
+		b2: (vh - vb*k/q + k*k) / a0,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+
+	f0, q = 38.13547087602444, 0.5003270373238773
+	k = math.Tan(math.Pi * f0 / rate)
+	a0 = 1 + k/q + k*k
+	highPass = biquad{
+		b0: 1,
+		b1: -2,
+		b2: 1,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+	return shelf, highPass
+}
+
+// channelWeight 返回声道的权重，5.1声道中的LFE声道不计入响度，环绕声道的权重为1.41
+func channelWeight(channel, channels int) float64 {
+	if channels == 6 {
+		switch channel {
+		case 3:
+			return 0
+		case 4, 5:
+			return 1.41
+		}
+	}
+	return 1
+}
+
+// Meter 响度测量器，依次写入交错排列的采样后获取测量结果
+type Meter struct {
+	channels int
+	step     int // 每100ms的采样帧数
+	shelf    []biquad
+	highPass []biquad
+	weights  []float64
+
+	stepEnergy float64   // 当前步长内经计权的平方和
+	stepFrames int       // 当前步长内已写入的帧数
+	steps      []float64 // 最近的步长的平方和，用于组成400ms的块
+	blocks     []float64 // 各块经计权的均方值
+	peak       float64   // 采样峰值的绝对值
+}
+
+// NewMeter 创建指定采样率及声道数的响度测量器
+func NewMeter(sampleRate, channels int) (*Meter, error) {
+	if sampleRate < 1000 || channels <= 0 {
+		return nil, fmt.Errorf("invalid audio format: %d channels at %d Hz", channels, sampleRate)
+	}
+	m := &Meter{
+		channels: channels,
+		step:     int(math.Round(float64(sampleRate) / 10)),
+		shelf:    make([]biquad, channels),
+		highPass: make([]biquad, channels),
+		weights:  make([]float64, channels),
+	}
+	shelf, highPass := kWeighting(sampleRate)
+	for ch := 0; ch < channels; ch++ {
+		m.shelf[ch], m.highPass[ch] = shelf, highPass
+		m.weights[ch] = channelWeight(ch, channels)
+	}
+	return m, nil
+}
+
+// Write 写入交错排列的采样，len(samples)应为声道数的整数倍，多余的采样被忽略
+func (m *Meter) Write(samples []float64) {
+	frames := len(samples) / m.channels
+	for i := 0; i < frames; i++ {
+		frame := samples[i*m.channels : (i+1)*m.channels]
+		for ch, x := range frame {
+			if abs := math.Abs(x); abs > m.peak {
+				m.peak = abs
+			}
+			y := m.highPass[ch].process(m.shelf[ch].process(x))
+			m.stepEnergy += m.weights[ch] * y * y
+		}
+
+		m.stepFrames++
+		if m.stepFrames == m.step {
+			m.finishStep()
+		}
+	}
+}
+
+// finishStep 结束当前步长，凑满4个步长时记录一个块
+func (m *Meter) finishStep() {
+	m.steps = append(m.steps, m.stepEnergy)
+	m.stepEnergy, m.stepFrames = 0, 0
+	if len(m.steps) < 4 {
+		return
+	}
+	if len(m.steps) > 4 {
+		m.steps = m.steps[1:]
+	}
+
+	sum := 0.0
+	for _, energy := range m.steps {
+		sum += energy
+	}
+	m.blocks = append(m.blocks, sum/float64(4*m.step))
+}
+
+// Integrated 返回目前为止的积分响度，单位为LUFS
+func (m *Meter) Integrated() float64 {
+	absolute := energyOf(absoluteGate)
+	sum, count := 0.0, 0
+	for _, block := range m.blocks {
+		if block > absolute {
+			sum += block
+			count++
+		}
+	}
+	if count == 0 {
+		return math.Inf(-1)
+	}
+
+	relative := energyOf(loudnessOf(sum/float64(count)) + relativeGate)
+	sum, count = 0, 0
+	for _, block := range m.blocks {
+		if block > absolute && block > relative {
+			sum += block
+			count++
+		}
+	}
+	return loudnessOf(sum / float64(count))
+}
+
+// Peak 返回目前为止的采样峰值，单位为dBFS
+func (m *Meter) Peak() float64 {
+	return 20 * math.Log10(m.peak)
+}
+
+// Result 返回目前为止的测量结果
+func (m *Meter) Result() *Result {
+	return &Result{Integrated: m.Integrated(), Peak: m.Peak()}
+}
+
+// loudnessOf 将均方值转换为响度
+func loudnessOf(energy float64) float64 {
+	return -0.691 + 10*math.Log10(energy)
+}
+
+// energyOf 将响度转换为均方值
+func energyOf(loudness float64) float64 {
+	return math.Pow(10, (loudness+0.691)/10)
+}
+
+// MeasureWAV 测量WAV数据的积分响度及采样峰值
+func MeasureWAV(r io.Reader) (*Result, error) {
+	wr, err := NewWAVReader(r)
+	if err != nil {
+		return nil, err
+	}
+	meter, err := NewMeter(wr.SampleRate, wr.Channels)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]float64, 4096*wr.Channels)
+	for {
+		n, err := wr.Read(buf)
+		meter.Write(buf[:n])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode wav: %w", err)
+		}
+	}
+	return meter.Result(), nil
+}
+
+// MeasureFile 测量WAV文件的积分响度及采样峰值
+func MeasureFile(path string) (*Result, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result, err := MeasureWAV(file)
+	if err != nil {
+		return nil, fmt.Errorf("measure %s: %w", path, err)
+	}
+	return result, nil
+}
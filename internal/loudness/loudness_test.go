@@ -0,0 +1,246 @@
+package loudness
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// tone 一段正弦波，amplitude为峰值电平，单位为dBFS
+type tone struct {
+	seconds   float64
+	amplitude float64
+}
+
+// makeToneWAV 构造由若干段1kHz正弦波组成的WAV数据，各声道的信号相同
+func makeToneWAV(format uint16, bits, rate, channels int, tones ...tone) []byte {
+	var data bytes.Buffer
+	n := 0
+	for _, tn := range tones {
+		amplitude := math.Pow(10, tn.amplitude/20)
+		frames := int(tn.seconds * float64(rate))
+		for i := 0; i < frames; i++ {
+			x := amplitude * math.Sin(2*math.Pi*1000*float64(n)/float64(rate))
+			n++
+			for ch := 0; ch < channels; ch++ {
+				writeSample(&data, format, bits, x)
+			}
+		}
+	}
+
+	fmtChunk := make([]byte, 16)
+	binary.LittleEndian.PutUint16(fmtChunk[0:2], format)
+	binary.LittleEndian.PutUint16(fmtChunk[2:4], uint16(channels))
+	binary.LittleEndian.PutUint32(fmtChunk[4:8], uint32(rate))
+	binary.LittleEndian.PutUint32(fmtChunk[8:12], uint32(rate*channels*bits/8))
+	binary.LittleEndian.PutUint16(fmtChunk[12:14], uint16(channels*bits/8))
+	binary.LittleEndian.PutUint16(fmtChunk[14:16], uint16(bits))
+
+	chunk := func(id string, payload []byte) []byte {
+		header := make([]byte, 8)
+		copy(header, id)
+		binary.LittleEndian.PutUint32(header[4:8], uint32(len(payload)))
+		if len(payload)%2 == 1 {
+			payload = append(payload, 0)
+		}
+		return append(header, payload...)
+	}
+	body := bytes.Join([][]byte{
+		[]byte("WAVE"),
+		chunk("fmt ", fmtChunk),
+		chunk("LIST", []byte("odd")),
+		chunk("data", data.Bytes()),
+	}, nil)
+
+	header := []byte("RIFF\x00\x00\x00\x00")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(body)))
+	return append(header, body...)
+}
+
+// writeSample 按指定编码写入一个采样
+func writeSample(buf *bytes.Buffer, format uint16, bits int, x float64) {
+	if format == wavFormatFloat {
+		if bits == 32 {
+			binary.Write(buf, binary.LittleEndian, float32(x))
+		} else {
+			binary.Write(buf, binary.LittleEndian, x)
+		}
+		return
+	}
+	switch bits {
+	case 8:
+		buf.WriteByte(byte(math.Round(x*127) + 128))
+	case 16:
+		binary.Write(buf, binary.LittleEndian, int16(math.Round(x*32767)))
+	case 24:
+		v := int32(math.Round(x * 8388607))
+		buf.Write([]byte{byte(v), byte(v >> 8), byte(v >> 16)})
+	case 32:
+		binary.Write(buf, binary.LittleEndian, int32(math.Round(x*2147483647)))
+	}
+}
+
+func TestMeasureWAV(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     uint16
+		bits       int
+		rate       int
+		channels   int
+		tones      []tone
+		integrated float64
+		peak       float64
+		tolerance  float64
+	}{
+		// EBU Tech 3341中的测试信号：立体声1kHz正弦波，-23dBFS时应为-23LUFS
+		{"stereo 16bit", wavFormatPCM, 16, 48000, 2, []tone{{5, -23}}, -23, -23, 0.1},
+		{"stereo 24bit 44.1kHz", wavFormatPCM, 24, 44100, 2, []tone{{5, -33}}, -33, -33, 0.1},
+		{"stereo float", wavFormatFloat, 32, 48000, 2, []tone{{5, -23}}, -23, -23, 0.1},
+		{"stereo double", wavFormatFloat, 64, 48000, 2, []tone{{5, -23}}, -23, -23, 0.1},
+		{"stereo 32bit", wavFormatPCM, 32, 48000, 2, []tone{{5, -23}}, -23, -23, 0.1},
+		// 单声道的响度比立体声低约3dB
+		{"mono", wavFormatPCM, 16, 48000, 1, []tone{{5, -20}}, -23.01, -20, 0.1},
+		{"mono 8bit", wavFormatPCM, 8, 16000, 1, []tone{{5, -6}}, -9.0, -6, 0.2},
+		// 相对门限排除较安静的部分
+		{"relative gate", wavFormatPCM, 16, 48000, 2, []tone{{10, -20}, {10, -40}}, -20, -20, 0.1},
+		// 低于绝对门限的部分不计入
+		{"absolute gate", wavFormatPCM, 24, 48000, 2, []tone{{10, -23}, {10, -80}}, -23, -23, 0.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := makeToneWAV(tt.format, tt.bits, tt.rate, tt.channels, tt.tones...)
+			result, err := MeasureWAV(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("MeasureWAV failed: %v", err)
+			}
+			if math.Abs(result.Integrated-tt.integrated) > tt.tolerance {
+				t.Errorf("Expected integrated loudness %.2f LUFS, got %.2f", tt.integrated, result.Integrated)
+			}
+			if math.Abs(result.Peak-tt.peak) > tt.tolerance {
+				t.Errorf("Expected peak %.2f dBFS, got %.2f", tt.peak, result.Peak)
+			}
+		})
+	}
+}
+
+func TestMeasureSilence(t *testing.T) {
+	// 静音及短于一个块的音频没有积分响度
+	for _, tones := range [][]tone{{{2, math.Inf(-1)}}, {{0.3, -20}}} {
+		data := makeToneWAV(wavFormatPCM, 16, 48000, 2, tones...)
+		result, err := MeasureWAV(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("MeasureWAV failed: %v", err)
+		}
+		if !math.IsInf(result.Integrated, -1) {
+			t.Errorf("Expected -Inf integrated loudness for %v, got %.2f", tones, result.Integrated)
+		}
+	}
+}
+
+// countSamples 读取WAV数据中的全部采样并返回采样数
+func countSamples(t *testing.T, data []byte) int {
+	t.Helper()
+	reader, err := NewWAVReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("NewWAVReader failed: %v", err)
+	}
+	total := 0
+	buf := make([]float64, 1000)
+	for {
+		n, err := reader.Read(buf)
+		total += n
+		if err != nil {
+			return total
+		}
+	}
+}
+
+func TestWAVReaderDataSize(t *testing.T) {
+	// data块之后还有LIST块，RIFF块大小包括该块
+	data := makeToneWAV(wavFormatPCM, 16, 8000, 1, tone{1, -6})
+	data = append(data, []byte("LIST\x04\x00\x00\x00INFO")...)
+	binary.LittleEndian.PutUint32(data[4:8], uint32(len(data)-8))
+	if total := countSamples(t, data); total != 8000 {
+		t.Errorf("Expected 8000 samples, got %d", total)
+	}
+
+	// data块大小为0但RIFF块大小有效时不读取之后的块
+	dataSize := bytes.Index(data, []byte("data")) + 4
+	empty := append([]byte(nil), data...)
+	binary.LittleEndian.PutUint32(empty[dataSize:dataSize+4], 0)
+	if total := countSamples(t, empty); total != 0 {
+		t.Errorf("Expected no samples for an empty data chunk, got %d", total)
+	}
+
+	// 声明的大小超出RIFF块及文件时读取至文件末尾，不完整的帧被丢弃
+	overstated := append([]byte(nil), data...)
+	binary.LittleEndian.PutUint32(overstated[dataSize:dataSize+4], 0x7FFFFFF0)
+	if total := countSamples(t, overstated[:len(overstated)-12]); total != 8000 {
+		t.Errorf("Expected 8000 samples, got %d", total)
+	}
+
+	// RIFF块及data块都未填写大小的流式文件读取至文件末尾
+	streaming := makeToneWAV(wavFormatPCM, 16, 8000, 1, tone{1, -6})
+	binary.LittleEndian.PutUint32(streaming[4:8], 0)
+	binary.LittleEndian.PutUint32(streaming[dataSize:dataSize+4], 0)
+	if total := countSamples(t, append(streaming, 0, 0, 0, 0)); total != 8002 {
+		t.Errorf("Expected 8002 samples, got %d", total)
+	}
+}
+
+func TestWAVReader(t *testing.T) {
+	// 未填写data块大小的WAV读取至RIFF块末尾
+	data := makeToneWAV(wavFormatPCM, 16, 8000, 2, tone{1, -6})
+	dataSize := bytes.Index(data, []byte("data")) + 4
+	binary.LittleEndian.PutUint32(data[dataSize:dataSize+4], 0xFFFFFFFF)
+	reader, err := NewWAVReader(bytes.NewReader(append(data, 0x01)))
+	if err != nil {
+		t.Fatalf("NewWAVReader failed: %v", err)
+	}
+	if reader.SampleRate != 8000 || reader.Channels != 2 || reader.BitsPerSample != 16 {
+		t.Errorf("Unexpected format: %+v", reader)
+	}
+	total := 0
+	buf := make([]float64, 1000)
+	for {
+		n, err := reader.Read(buf)
+		total += n
+		if err != nil {
+			break
+		}
+	}
+	if total != 16000 {
+		t.Errorf("Expected 16000 samples, got %d", total)
+	}
+
+	// 不支持的编码
+	unsupported := makeToneWAV(2, 4, 8000, 1, tone{0.1, -6})
+	if _, err := NewWAVReader(bytes.NewReader(unsupported)); err == nil {
+		t.Error("Expected error for ADPCM wav")
+	}
+	if _, err := NewWAVReader(bytes.NewReader([]byte("RIFF\x00\x00\x00\x00AVI "))); err == nil {
+		t.Error("Expected error for non-wav data")
+	}
+}
+
+func TestMeasureFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tone.wav")
+	if err := os.WriteFile(path, makeToneWAV(wavFormatPCM, 16, 48000, 2, tone{3, -23}), 0644); err != nil {
+		t.Fatalf("Failed to write wav: %v", err)
+	}
+	result, err := MeasureFile(path)
+	if err != nil {
+		t.Fatalf("MeasureFile failed: %v", err)
+	}
+	if math.Abs(result.Integrated+23) > 0.1 {
+		t.Errorf("Expected -23 LUFS, got %.2f", result.Integrated)
+	}
+
+	if _, err := MeasureFile(filepath.Join(t.TempDir(), "missing.wav")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
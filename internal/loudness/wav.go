@@ -0,0 +1,177 @@
+package loudness
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// WAV格式标签
+const (
+	wavFormatPCM        = 0x0001
+	wavFormatFloat      = 0x0003
+	wavFormatExtensible = 0xFFFE
+)
+
+// ErrUnsupportedWAV 不支持的WAV编码，仅支持PCM整数及IEEE浮点编码
+var ErrUnsupportedWAV = errors.New("unsupported wav encoding")
+
+// WAVReader 逐块解码WAV文件中的PCM数据
+type WAVReader struct {
+	SampleRate    int // 采样率，单位为Hz
+	Channels      int // 声道数
+	BitsPerSample int // 每个采样的位数
+
+	r         io.Reader
+	float     bool
+	remaining int64 // data块中剩余的字节数，小于0表示读取至文件末尾，仅用于RIFF块及data块均未填写大小的流式文件
+	frame     []byte
+}
+
+// NewWAVReader 解析WAV文件头，返回定位到音频数据开头的解码器
+func NewWAVReader(r io.Reader) (*WAVReader, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("read wav header: %w", err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, errors.New("not a wav file")
+	}
+
+	// 流式写入的文件可能没有填写RIFF块的大小，此时无法据此判断data块的范围
+	riffSize := int64(binary.LittleEndian.Uint32(header[4:8]))
+	riffKnown := riffSize != 0 && riffSize != 0xFFFFFFFF
+	consumed := int64(4) // RIFF块中已读取的字节数，包括"WAVE"
+
+	wr := &WAVReader{r: r}
+	haveFormat := false
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			return nil, fmt.Errorf("read wav chunk: %w", err)
+		}
+		id := string(chunk[0:4])
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+		consumed += 8
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, fmt.Errorf("invalid wav fmt chunk size %d", size)
+			}
+			data := make([]byte, size+size%2)
+			if _, err := io.ReadFull(r, data); err != nil {
+				return nil, fmt.Errorf("read wav fmt chunk: %w", err)
+			}
+			if err := wr.parseFormat(data[:size]); err != nil {
+				return nil, err
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return nil, errors.New("wav data chunk before fmt chunk")
+			}
+			switch {
+			case riffKnown:
+				// 按声明的大小读取，但不超出RIFF块，避免将之后的LIST、id3等块当作采样解码
+				wr.remaining = max(min(size, riffSize-consumed), 0)
+			case size == 0 || size == 0xFFFFFFFF:
+				// 流式写入的文件没有填写大小，读取至文件末尾
+				wr.remaining = -1
+			default:
+				wr.remaining = size
+			}
+			return wr, nil
+		default:
+			if _, err := io.CopyN(io.Discard, r, size+size%2); err != nil {
+				return nil, fmt.Errorf("skip wav chunk %q: %w", id, err)
+			}
+		}
+		consumed += size + size%2
+	}
+}
+
+// parseFormat 解析fmt块
+func (wr *WAVReader) parseFormat(data []byte) error {
+	format := binary.LittleEndian.Uint16(data[0:2])
+	wr.Channels = int(binary.LittleEndian.Uint16(data[2:4]))
+	wr.SampleRate = int(binary.LittleEndian.Uint32(data[4:8]))
+	wr.BitsPerSample = int(binary.LittleEndian.Uint16(data[14:16]))
+	if format == wavFormatExtensible && len(data) >= 26 {
+		// 子格式GUID的前两个字节即为实际的格式标签
+		format = binary.LittleEndian.Uint16(data[24:26])
+	}
+
+	switch {
+	case format == wavFormatPCM && (wr.BitsPerSample == 8 || wr.BitsPerSample == 16 || wr.BitsPerSample == 24 || wr.BitsPerSample == 32):
+	case format == wavFormatFloat && (wr.BitsPerSample == 32 || wr.BitsPerSample == 64):
+		wr.float = true
+	default:
+		return fmt.Errorf("%w: format 0x%04x with %d bits", ErrUnsupportedWAV, format, wr.BitsPerSample)
+	}
+	if wr.Channels <= 0 || wr.SampleRate <= 0 {
+		return fmt.Errorf("invalid wav format: %d channels at %d Hz", wr.Channels, wr.SampleRate)
+	}
+	wr.frame = make([]byte, wr.Channels*wr.BitsPerSample/8)
+	return nil
+}
+
+// Read 读取交错排列的采样并归一化到[-1, 1)，len(buf)应为声道数的整数倍
+//
+// 返回实际读取的采样数，始终为声道数的整数倍；数据读完时返回io.EOF
+func (wr *WAVReader) Read(buf []float64) (int, error) {
+	frames := len(buf) / wr.Channels
+	if frames == 0 {
+		return 0, errors.New("buffer smaller than one frame")
+	}
+
+	n := 0
+	for i := 0; i < frames; i++ {
+		if wr.remaining >= 0 && wr.remaining < int64(len(wr.frame)) {
+			break
+		}
+		if _, err := io.ReadFull(wr.r, wr.frame); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				// 丢弃不完整的最后一帧
+				wr.remaining = 0
+				break
+			}
+			return n, err
+		}
+		if wr.remaining > 0 {
+			wr.remaining -= int64(len(wr.frame))
+		}
+		wr.decodeFrame(buf[n : n+wr.Channels])
+		n += wr.Channels
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// decodeFrame 将当前帧的各声道采样解码到out中
+func (wr *WAVReader) decodeFrame(out []float64) {
+	size := wr.BitsPerSample / 8
+	for ch := range out {
+		b := wr.frame[ch*size : (ch+1)*size]
+		switch {
+		case wr.float && size == 4:
+			out[ch] = float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		case wr.float:
+			out[ch] = math.Float64frombits(binary.LittleEndian.Uint64(b))
+		case size == 1:
+			// 8位PCM为无符号数
+			out[ch] = (float64(b[0]) - 128) / 128
+		case size == 2:
+			out[ch] = float64(int16(binary.LittleEndian.Uint16(b))) / (1 << 15)
+		case size == 3:
+			v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+			out[ch] = float64(v) / (1 << 23)
+		default:
+			out[ch] = float64(int32(binary.LittleEndian.Uint32(b))) / (1 << 31)
+		}
+	}
+}
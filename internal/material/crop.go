@@ -0,0 +1,124 @@
+package material
+
+import (
+	"fmt"
+	"math"
+)
+
+// CropAnchor 按宽高比裁剪时保留区域的对齐位置，X、Y均在0-1之间，(0, 0)为左上角，(1, 1)为右下角
+type CropAnchor struct {
+	X float64 // 水平位置，0为靠左，1为靠右
+	Y float64 // 垂直位置，0为靠上，1为靠下
+}
+
+// 常用的裁剪对齐位置
+var (
+	CropAnchorCenter      = CropAnchor{0.5, 0.5}
+	CropAnchorTop         = CropAnchor{0.5, 0}
+	CropAnchorBottom      = CropAnchor{0.5, 1}
+	CropAnchorLeft        = CropAnchor{0, 0.5}
+	CropAnchorRight       = CropAnchor{1, 0.5}
+	CropAnchorTopLeft     = CropAnchor{0, 0}
+	CropAnchorTopRight    = CropAnchor{1, 0}
+	CropAnchorBottomLeft  = CropAnchor{0, 1}
+	CropAnchorBottomRight = CropAnchor{1, 1}
+)
+
+// cropEpsilon 判断裁剪区域是否超出素材范围时允许的浮点误差
+const cropEpsilon = 1e-9
+
+// NewCropSettingsFromRect 创建保留矩形区域的裁剪设置，x、y为区域左上角，w、h为区域的宽和高，均以素材宽高为单位
+func NewCropSettingsFromRect(x, y, w, h float64) (*CropSettings, error) {
+	if w <= 0 || h <= 0 || x < 0 || y < 0 || x+w > 1+cropEpsilon || y+h > 1+cropEpsilon {
+		return nil, fmt.Errorf("裁剪区域 (%g, %g, %g, %g) 超出素材范围", x, y, w, h)
+	}
+	right, bottom := math.Min(x+w, 1), math.Min(y+h, 1)
+	return NewCropSettingsWithParams(x, y, right, y, x, bottom, right, bottom), nil
+}
+
+// Rect 返回裁剪区域的外接矩形，x、y为左上角，w、h为宽和高，均以素材宽高为单位
+func (cs *CropSettings) Rect() (x, y, w, h float64) {
+	left := math.Min(cs.UpperLeftX, cs.LowerLeftX)
+	right := math.Max(cs.UpperRightX, cs.LowerRightX)
+	top := math.Min(cs.UpperLeftY, cs.UpperRightY)
+	bottom := math.Max(cs.LowerLeftY, cs.LowerRightY)
+	return left, top, right - left, bottom - top
+}
+
+// WithinAspectRatio 在当前裁剪区域的外接矩形中选取宽高比为ratio(宽/高)的最大区域，返回新的裁剪设置
+//
+// width、height为素材的像素尺寸，anchor指定选取的区域在当前裁剪区域中的位置
+func (cs *CropSettings) WithinAspectRatio(width, height int, ratio float64, anchor CropAnchor) (*CropSettings, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("无效的素材尺寸: %dx%d", width, height)
+	}
+	if ratio <= 0 || math.IsInf(ratio, 0) || math.IsNaN(ratio) {
+		return nil, fmt.Errorf("无效的宽高比: %g", ratio)
+	}
+	if anchor.X < 0 || anchor.X > 1 || anchor.Y < 0 || anchor.Y > 1 {
+		return nil, fmt.Errorf("无效的裁剪对齐位置: %+v", anchor)
+	}
+
+	x, y, w, h := cs.Rect()
+	pixelW, pixelH := w*float64(width), h*float64(height)
+	if pixelW/pixelH > ratio {
+		newW := pixelH * ratio / float64(width)
+		x += (w - newW) * anchor.X
+		w = newW
+	} else {
+		newH := pixelW / ratio / float64(height)
+		y += (h - newH) * anchor.Y
+		h = newH
+	}
+	return NewCropSettingsFromRect(x, y, w, h)
+}
+
+// CropToRect 将素材裁剪为以像素表示的矩形区域，x、y为区域左上角，w、h为区域的宽和高
+func (vm *VideoMaterial) CropToRect(x, y, w, h int) error {
+	if err := vm.checkSize(); err != nil {
+		return err
+	}
+	if w <= 0 || h <= 0 || x < 0 || y < 0 || x+w > vm.Width || y+h > vm.Height {
+		return fmt.Errorf("裁剪区域 (%d, %d, %d, %d) 超出素材尺寸 %dx%d", x, y, w, h, vm.Width, vm.Height)
+	}
+	width, height := float64(vm.Width), float64(vm.Height)
+	crop, err := NewCropSettingsFromRect(float64(x)/width, float64(y)/height, float64(w)/width, float64(h)/height)
+	if err != nil {
+		return err
+	}
+	vm.CropSettings = crop
+	return nil
+}
+
+// CropToAspectRatio 将素材裁剪为宽高比为ratio(宽/高)的最大区域，anchor指定保留区域在素材中的位置
+//
+// 裁剪基于完整的素材画面，原有的裁剪设置会被替换
+func (vm *VideoMaterial) CropToAspectRatio(ratio float64, anchor CropAnchor) error {
+	if err := vm.checkSize(); err != nil {
+		return err
+	}
+	crop, err := NewCropSettings().WithinAspectRatio(vm.Width, vm.Height, ratio, anchor)
+	if err != nil {
+		return err
+	}
+	vm.CropSettings = crop
+	return nil
+}
+
+// CroppedSize 返回裁剪后的素材尺寸，单位为像素
+func (vm *VideoMaterial) CroppedSize() (width, height float64) {
+	crop := vm.CropSettings
+	if crop == nil {
+		crop = NewCropSettings()
+	}
+	_, _, w, h := crop.Rect()
+	return w * float64(vm.Width), h * float64(vm.Height)
+}
+
+// checkSize 检查素材的尺寸是否已知
+func (vm *VideoMaterial) checkSize() error {
+	if vm.Width <= 0 || vm.Height <= 0 {
+		return fmt.Errorf("素材 %s 的尺寸未知", vm.MaterialName)
+	}
+	return nil
+}
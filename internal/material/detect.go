@@ -0,0 +1,83 @@
+package material
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zhangshican/go-capcut/internal/probe"
+)
+
+// ErrAudioOnlyMaterial 文件只包含音频，不能作为视频或图片素材
+var ErrAudioOnlyMaterial = errors.New("文件只包含音频，请使用 NewAudioMaterial 创建音频素材")
+
+// 无法通过文件内容识别格式时，根据扩展名判断素材类型
+var (
+	photoExtensions = map[string]bool{
+		".jpg": true, ".jpeg": true, ".png": true, ".bmp": true, ".webp": true,
+		".heic": true, ".heif": true, ".tif": true, ".tiff": true,
+	}
+	videoExtensions = map[string]bool{
+		".mp4": true, ".mov": true, ".m4v": true, ".mkv": true, ".webm": true, ".avi": true,
+		".flv": true, ".wmv": true, ".mpg": true, ".mpeg": true, ".ts": true, ".3gp": true,
+	}
+	audioExtensions = map[string]bool{
+		".mp3": true, ".wav": true, ".aac": true, ".m4a": true, ".flac": true,
+		".ogg": true, ".opus": true, ".wma": true,
+	}
+)
+
+// DetectMaterialType 根据文件内容判断视觉素材的类型
+//
+// 静态图片为MaterialTypePhoto，视频及GIF动图为MaterialTypeVideo；只包含音频的文件返回ErrAudioOnlyMaterial。
+// 全局探测器无法识别文件格式时，根据扩展名判断
+func DetectMaterialType(path string) (MaterialType, error) {
+	info, err := probe.Probe(path)
+	if errors.Is(err, probe.ErrUnsupportedFormat) {
+		ext := strings.ToLower(filepath.Ext(path))
+		switch {
+		case photoExtensions[ext]:
+			return MaterialTypePhoto, nil
+		case videoExtensions[ext]:
+			return MaterialTypeVideo, nil
+		case audioExtensions[ext]:
+			return "", fmt.Errorf("%s: %w", path, ErrAudioOnlyMaterial)
+		}
+		return "", fmt.Errorf("无法识别素材类型: %w", err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("无法获取素材的媒体信息: %w", err)
+	}
+
+	switch {
+	case info.IsImage:
+		return MaterialTypePhoto, nil
+	case info.HasVideo:
+		return MaterialTypeVideo, nil
+	case info.HasAudio:
+		return "", fmt.Errorf("%s: %w", path, ErrAudioOnlyMaterial)
+	}
+	return "", fmt.Errorf("文件 %s 不包含视频或图片", path)
+}
+
+// NewVisualMaterial 创建本地视觉素材，素材类型由DetectMaterialType根据文件内容自动判断，
+// 时长及宽高通过探测文件获取
+//
+// 仅凭扩展名识别的文件（如BMP、HEIC、AVI、MPEG-TS）无法探测媒体信息，使用与NewVideoMaterial相同的默认值，
+// 需要准确信息时请使用NewVideoMaterial并显式提供时长及宽高
+func NewVisualMaterial(path string, replacePath, materialName *string, cropSettings *CropSettings) (*VideoMaterial, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("无法获取绝对路径: %w", err)
+	}
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("找不到文件: %s", absPath)
+	}
+	materialType, err := DetectMaterialType(absPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewVideoMaterial(materialType, &absPath, replacePath, materialName, nil, cropSettings, nil, nil, nil)
+}
@@ -0,0 +1,302 @@
+package material
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/zhangshican/go-capcut/internal/util"
+)
+
+// Fetcher 远程素材的获取器，可替换为自定义的实现以支持其他协议或鉴权方式
+type Fetcher interface {
+	// Open 读取url指向的资源。offset大于0时为续传，应从第offset个字节开始读取，
+	// validator为中断的下载返回的资源版本标识，用于确认资源在此期间未被修改。
+	// 不支持从中间读取、无法确认资源未被修改或资源已被修改时应从头读取，并令Resumed为false
+	Open(ctx context.Context, url string, offset int64, validator string) (*FetchResponse, error)
+}
+
+// FetchResponse 获取器打开的资源
+type FetchResponse struct {
+	Body      io.ReadCloser // 资源的内容
+	Resumed   bool          // 是否从offset处继续读取，为false时Body从资源的开头读取
+	Validator string        // 资源的版本标识，如ETag或Last-Modified，续传时传回Open；为空时中断的下载只能从头开始
+}
+
+// FetcherFunc 将函数适配为Fetcher
+type FetcherFunc func(ctx context.Context, url string, offset int64, validator string) (*FetchResponse, error)
+
+// Open 调用函数本身
+func (f FetcherFunc) Open(ctx context.Context, url string, offset int64, validator string) (*FetchResponse, error) {
+	return f(ctx, url, offset, validator)
+}
+
+// HTTPFetcher 通过HTTP(S)获取远程素材，使用Range请求续传
+//
+// 续传时以If-Range携带资源的ETag或Last-Modified，资源已被修改时服务器返回完整的内容；
+// 服务器返回的Content-Range与请求的位置不一致时从头下载
+type HTTPFetcher struct {
+	Client *http.Client // 使用的HTTP客户端，为nil时使用http.DefaultClient
+}
+
+// Open 实现Fetcher接口
+func (f *HTTPFetcher) Open(ctx context.Context, url string, offset int64, validator string) (*FetchResponse, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// 无法确认资源未被修改时不续传
+	resume := offset > 0 && validator != ""
+	if resume {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		request.Header.Set("If-Range", validator)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	result := &FetchResponse{Body: response.Body, Validator: responseValidator(response)}
+	switch {
+	case response.StatusCode == http.StatusPartialContent && resume:
+		if start, ok := contentRangeStart(response.Header.Get("Content-Range")); ok && start == offset {
+			result.Resumed = true
+			if result.Validator == "" {
+				result.Validator = validator
+			}
+			return result, nil
+		}
+		// 返回的范围与已下载的部分不衔接，从头下载
+		response.Body.Close()
+		return f.Open(ctx, url, 0, "")
+	case response.StatusCode == http.StatusRequestedRangeNotSatisfiable && resume:
+		// 已下载的部分与服务器上的资源不一致，从头下载
+		response.Body.Close()
+		return f.Open(ctx, url, 0, "")
+	case response.StatusCode == http.StatusOK:
+		return result, nil
+	}
+	response.Body.Close()
+	return nil, fmt.Errorf("下载 %s 失败: %s", url, response.Status)
+}
+
+// responseValidator 返回可用于If-Range的资源版本标识，优先使用强ETag，其次使用Last-Modified
+func responseValidator(response *http.Response) string {
+	if etag := response.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return response.Header.Get("Last-Modified")
+}
+
+// contentRangeStart 解析"bytes start-end/total"形式的Content-Range，返回范围的开始位置
+func contentRangeStart(contentRange string) (int64, bool) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(contentRange), "bytes ")
+	if !ok {
+		return 0, false
+	}
+	first, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(strings.TrimSpace(first), 10, 64)
+	if err != nil || start < 0 {
+		return 0, false
+	}
+	return start, true
+}
+
+// CacheConfig 远程素材缓存的配置
+type CacheConfig struct {
+	Fetcher Fetcher // 远程素材的获取器
+}
+
+// CacheOption 远程素材缓存的选项函数类型
+type CacheOption func(*CacheConfig)
+
+// WithFetcher 使用自定义的获取器下载远程素材，默认使用HTTPFetcher
+func WithFetcher(fetcher Fetcher) CacheOption {
+	return func(c *CacheConfig) {
+		c.Fetcher = fetcher
+	}
+}
+
+// MaterialCache 远程素材的本地缓存
+//
+// 每个URL对应缓存目录中以其哈希命名的文件，已下载的URL不会重复下载。
+// 下载中断时已下载的部分保留在同名的.part文件中，资源的版本标识保存在.part.validator文件中，
+// 下次获取时确认资源未被修改后从中断处继续，否则从头下载。
+// 同一缓存目录不应被多个进程同时写入
+type MaterialCache struct {
+	Dir     string  // 缓存目录
+	Fetcher Fetcher // 远程素材的获取器
+}
+
+// NewMaterialCache 创建以dir为缓存目录的远程素材缓存，目录不存在时在首次下载时创建
+func NewMaterialCache(dir string, options ...CacheOption) *MaterialCache {
+	config := &CacheConfig{Fetcher: &HTTPFetcher{}}
+	for _, option := range options {
+		option(config)
+	}
+	return &MaterialCache{Dir: dir, Fetcher: config.Fetcher}
+}
+
+// CachePath 返回url在缓存中对应的文件路径，文件名为URL的哈希，并保留URL中的扩展名
+func (c *MaterialCache) CachePath(rawURL string) string {
+	name := util.URLToHash(rawURL, 32)
+	if parsed, err := url.Parse(rawURL); err == nil {
+		if ext := path.Ext(parsed.Path); len(ext) > 1 && len(ext) <= 6 && !strings.ContainsAny(ext, `\/:`) {
+			name += strings.ToLower(ext)
+		}
+	}
+	return filepath.Join(c.Dir, name)
+}
+
+// Fetch 获取url对应的本地文件，未缓存时下载到缓存目录中，返回文件的绝对路径
+func (c *MaterialCache) Fetch(ctx context.Context, rawURL string) (string, error) {
+	if rawURL == "" {
+		return "", fmt.Errorf("远程素材的URL不能为空")
+	}
+	target, err := filepath.Abs(c.CachePath(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("无法获取绝对路径: %w", err)
+	}
+	if _, err := os.Stat(target); err == nil {
+		return target, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", fmt.Errorf("创建缓存目录失败: %w", err)
+	}
+
+	// 已下载的部分及其资源版本标识，没有版本标识时无法确认资源未被修改，从头下载
+	partPath := target + ".part"
+	validatorPath := partPath + ".validator"
+	var offset int64
+	var validator string
+	if info, err := os.Stat(partPath); err == nil {
+		if data, err := os.ReadFile(validatorPath); err == nil && len(data) > 0 {
+			offset, validator = info.Size(), string(data)
+		}
+	}
+
+	fetcher := c.Fetcher
+	if fetcher == nil {
+		fetcher = &HTTPFetcher{}
+	}
+	response, err := fetcher.Open(ctx, rawURL, offset, validator)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if offset > 0 && response.Resumed {
+		flags = os.O_WRONLY | os.O_APPEND
+	}
+	if err := writeValidator(validatorPath, response.Validator); err != nil {
+		return "", err
+	}
+	file, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return "", fmt.Errorf("写入缓存文件失败: %w", err)
+	}
+	if _, err := io.Copy(file, response.Body); err != nil {
+		file.Close()
+		return "", fmt.Errorf("下载 %s 失败: %w", rawURL, err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("写入缓存文件失败: %w", err)
+	}
+	if err := os.Rename(partPath, target); err != nil {
+		return "", fmt.Errorf("写入缓存文件失败: %w", err)
+	}
+	os.Remove(validatorPath)
+	return target, nil
+}
+
+// writeValidator 保存已下载部分的资源版本标识，validator为空时删除已保存的标识
+func writeValidator(path, validator string) error {
+	if validator == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("写入缓存文件失败: %w", err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(validator), 0644); err != nil {
+		return fmt.Errorf("写入缓存文件失败: %w", err)
+	}
+	return nil
+}
+
+// FetchVideoMaterial 下载视频或图片素材的远程文件，并根据下载的文件设置素材的路径、内容指纹及媒体信息
+//
+// 素材id保持不变。视频素材的时长、宽高及是否包含音轨以探测结果为准，图片素材只更新宽高；
+// 探测器无法识别文件格式时保留素材原有的媒体信息
+func (c *MaterialCache) FetchVideoMaterial(ctx context.Context, vm *VideoMaterial) error {
+	if vm.RemoteURL == nil || *vm.RemoteURL == "" {
+		return errors.New("素材没有远程URL")
+	}
+	localPath, err := c.Fetch(ctx, *vm.RemoteURL)
+	if err != nil {
+		return err
+	}
+	info, err := probeLocal(localPath)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := Fingerprint(localPath)
+	if err != nil {
+		return fmt.Errorf("无法计算素材的内容指纹: %w", err)
+	}
+
+	vm.Path = localPath
+	vm.Fingerprint = fingerprint
+	if info == nil {
+		return nil
+	}
+	vm.Width, vm.Height = info.Width, info.Height
+	if vm.MaterialType != MaterialTypePhoto {
+		vm.Duration = info.Duration
+		vm.HasAudio = info.HasAudio
+	}
+	return nil
+}
+
+// FetchAudioMaterial 下载音频素材的远程文件，并根据下载的文件设置素材的路径、内容指纹及时长，素材id保持不变
+//
+// 探测器无法识别文件格式时保留素材原有的时长
+func (c *MaterialCache) FetchAudioMaterial(ctx context.Context, am *AudioMaterial) error {
+	if am.RemoteURL == nil || *am.RemoteURL == "" {
+		return errors.New("素材没有远程URL")
+	}
+	localPath, err := c.Fetch(ctx, *am.RemoteURL)
+	if err != nil {
+		return err
+	}
+	info, err := probeLocal(localPath)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := Fingerprint(localPath)
+	if err != nil {
+		return fmt.Errorf("无法计算素材的内容指纹: %w", err)
+	}
+
+	am.Path = localPath
+	am.Fingerprint = fingerprint
+	if info != nil {
+		am.Duration = info.Duration
+	}
+	return nil
+}
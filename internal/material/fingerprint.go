@@ -0,0 +1,49 @@
+package material
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// fingerprintSampleSize 计算内容指纹时每个采样块的大小
+const fingerprintSampleSize = 64 * 1024
+
+// Fingerprint 计算文件的内容指纹，用于判断两个路径是否指向相同的素材
+//
+// 指纹由文件大小及文件开头、中间、结尾各64KB内容的SHA-256哈希组成，不超过192KB的文件对全部内容计算哈希。
+// 内容相同的文件指纹相同，与文件名及路径无关
+func Fingerprint(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	size := stat.Size()
+
+	hash := sha256.New()
+	if err := binary.Write(hash, binary.BigEndian, size); err != nil {
+		return "", err
+	}
+	if size <= 3*fingerprintSampleSize {
+		if _, err := io.Copy(hash, file); err != nil {
+			return "", err
+		}
+	} else {
+		for _, offset := range []int64{0, (size - fingerprintSampleSize) / 2, size - fingerprintSampleSize} {
+			if _, err := io.Copy(hash, io.NewSectionReader(file, offset, fingerprintSampleSize)); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return fmt.Sprintf("%d-%s", size, hex.EncodeToString(hash.Sum(nil)[:16])), nil
+}
@@ -0,0 +1,20 @@
+package material
+
+import (
+	"fmt"
+
+	"github.com/zhangshican/go-capcut/internal/loudness"
+)
+
+// AnalyzeLoudness 解码本地的WAV文件，测量音频素材的积分响度及采样峰值，结果保存在Loudness中
+func (am *AudioMaterial) AnalyzeLoudness() error {
+	if am.Path == "" {
+		return fmt.Errorf("素材 %s 没有本地文件，请先下载远程素材", am.MaterialName)
+	}
+	result, err := loudness.MeasureFile(am.Path)
+	if err != nil {
+		return fmt.Errorf("无法测量素材 %s 的响度: %w", am.MaterialName, err)
+	}
+	am.Loudness = result
+	return nil
+}
@@ -3,12 +3,15 @@
 package material
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/zhangshican/go-capcut/internal/loudness"
+	"github.com/zhangshican/go-capcut/internal/probe"
 )
 
 // CropSettings 素材的裁剪设置，各属性均在0-1之间，注意素材的坐标原点在左上角
@@ -79,20 +82,26 @@ const (
 // VideoMaterial 本地视频素材（视频或图片），一份素材可以在多个片段中使用
 // 对应Python的Video_material类
 type VideoMaterial struct {
-	MaterialID      string        `json:"id"`                // 素材全局id，自动生成
-	LocalMaterialID string        `json:"local_material_id"` // 素材本地id，意义暂不明确
-	MaterialName    string        `json:"material_name"`     // 素材名称
-	Path            string        `json:"path"`              // 素材文件路径
-	RemoteURL       *string       `json:"remote_url"`        // 远程URL地址
-	Duration        int64         `json:"duration"`          // 素材时长，单位为微秒
-	Height          int           `json:"height"`            // 素材高度
-	Width           int           `json:"width"`             // 素材宽度
-	CropSettings    *CropSettings `json:"crop_settings"`     // 素材裁剪设置
-	MaterialType    MaterialType  `json:"type"`              // 素材类型：视频或图片
-	ReplacePath     *string       `json:"replace_path"`      // 替换路径，如果设置了这个值，在导出json时会用这个路径替代原始path
+	MaterialID      string           `json:"id"`                // 素材全局id，自动生成
+	LocalMaterialID string           `json:"local_material_id"` // 素材本地id，意义暂不明确
+	MaterialName    string           `json:"material_name"`     // 素材名称
+	Path            string           `json:"path"`              // 素材文件路径
+	RemoteURL       *string          `json:"remote_url"`        // 远程URL地址
+	Duration        int64            `json:"duration"`          // 素材时长，单位为微秒
+	Height          int              `json:"height"`            // 素材高度
+	Width           int              `json:"width"`             // 素材宽度
+	HasAudio        bool             `json:"has_audio"`         // 视频是否包含音轨
+	CropSettings    *CropSettings    `json:"crop_settings"`     // 素材裁剪设置
+	MaterialType    MaterialType     `json:"type"`              // 素材类型：视频或图片
+	ReplacePath     *string          `json:"replace_path"`      // 替换路径，如果设置了这个值，在导出json时会用这个路径替代原始path
+	Fingerprint     string           `json:"-"`                 // 本地文件的内容指纹，远程素材为空
+	Loudness        *loudness.Result `json:"-"`                 // 音轨的响度测量结果，内置解码器不支持视频文件，需由外部测量后设置
 }
 
 // NewVideoMaterial 创建新的视频素材
+//
+// 本地文件未提供全部媒体信息时通过探测获取，探测器无法识别文件格式时使用默认值（30秒，1920x1080），
+// 读取文件失败时返回错误
 func NewVideoMaterial(materialType MaterialType, path, replacePath, materialName, remoteURL *string,
 	cropSettings *CropSettings, duration *float64, width, height *int) (*VideoMaterial, error) {
 
@@ -104,6 +113,7 @@ func NewVideoMaterial(materialType MaterialType, path, replacePath, materialName
 	var finalPath string
 	var finalRemoteURL *string
 	var finalMaterialName string
+	var fingerprint string
 
 	// 处理远程URL情况
 	if remoteURL != nil && *remoteURL != "" {
@@ -130,10 +140,11 @@ func NewVideoMaterial(materialType MaterialType, path, replacePath, materialName
 		} else {
 			finalMaterialName = filepath.Base(absPath)
 		}
-	}
 
-	// 生成素材ID
-	materialID := generateMaterialID(finalMaterialName)
+		if fingerprint, err = Fingerprint(absPath); err != nil {
+			return nil, fmt.Errorf("无法计算素材的内容指纹: %w", err)
+		}
+	}
 
 	// 设置默认裁剪设置
 	if cropSettings == nil {
@@ -142,7 +153,6 @@ func NewVideoMaterial(materialType MaterialType, path, replacePath, materialName
 
 	// 创建素材对象
 	material := &VideoMaterial{
-		MaterialID:      materialID,
 		LocalMaterialID: "",
 		MaterialName:    finalMaterialName,
 		Path:            finalPath,
@@ -150,56 +160,70 @@ func NewVideoMaterial(materialType MaterialType, path, replacePath, materialName
 		CropSettings:    cropSettings,
 		MaterialType:    materialType,
 		ReplacePath:     replacePath,
+		Fingerprint:     fingerprint,
 	}
 
-	// 如果是photo类型，跳过媒体信息获取
-	if materialType == MaterialTypePhoto {
-		material.Duration = 10800000000 // 静态图片默认3小时（微秒）
-		// 使用默认宽高，实际使用时可能需要获取真实尺寸
-		if width != nil {
-			material.Width = *width
-		} else {
-			material.Width = 1920
-		}
-		if height != nil {
-			material.Height = *height
-		} else {
-			material.Height = 1080
+	// 本地文件且未提供全部媒体信息时，探测文件获取真实信息，图片不需要时长
+	needProbe := width == nil || height == nil
+	if materialType != MaterialTypePhoto {
+		needProbe = needProbe || duration == nil
+	}
+	var info *probe.MediaInfo
+	if needProbe {
+		var err error
+		if info, err = probeLocal(finalPath); err != nil {
+			return nil, err
 		}
-		return material, nil
 	}
-
-	// 如果外部提供了所有参数，直接使用
-	if duration != nil && width != nil && height != nil {
-		material.Duration = int64(*duration * 1e6) // 转换为微秒
-		material.Width = *width
-		material.Height = *height
-		return material, nil
+	if info != nil {
+		material.HasAudio = info.HasAudio
+	} else {
+		// 远程素材及无法识别格式的文件使用默认值；未探测时假定视频包含音轨，以免声音被忽略
+		info = &probe.MediaInfo{Duration: 30000000, Width: 1920, Height: 1080}
+		material.HasAudio = materialType != MaterialTypePhoto
 	}
 
-	// TODO: 实现ffprobe媒体信息获取
-	// 目前使用默认值，实际项目中需要调用ffprobe获取真实信息
-	if duration != nil {
-		material.Duration = int64(*duration * 1e6)
+	if materialType == MaterialTypePhoto {
+		material.Duration = 10800000000 // 静态图片默认3小时（微秒）
+	} else if duration != nil {
+		material.Duration = int64(*duration * 1e6) // 转换为微秒
 	} else {
-		material.Duration = 30000000 // 默认30秒
+		material.Duration = info.Duration
 	}
 
 	if width != nil {
 		material.Width = *width
 	} else {
-		material.Width = 1920
+		material.Width = info.Width
 	}
 
 	if height != nil {
 		material.Height = *height
 	} else {
-		material.Height = 1080
+		material.Height = info.Height
 	}
 
+	// 根据内容及设置生成素材ID
+	material.MaterialID = generateMaterialID(material.IdentityKey())
+
 	return material, nil
 }
 
+// probeLocal 探测本地文件的媒体信息，path为空或探测器无法识别文件格式时返回nil
+func probeLocal(path string) (*probe.MediaInfo, error) {
+	if path == "" {
+		return nil, nil
+	}
+	info, err := probe.Probe(path)
+	if errors.Is(err, probe.ErrUnsupportedFormat) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("无法获取素材的媒体信息: %w", err)
+	}
+	return info, nil
+}
+
 // NewVideoMaterialFromDict 从字典创建视频素材对象
 func NewVideoMaterialFromDict(data map[string]interface{}) (*VideoMaterial, error) {
 	material := &VideoMaterial{}
@@ -259,6 +283,10 @@ func NewVideoMaterialFromDict(data map[string]interface{}) (*VideoMaterial, erro
 		}
 	}
 
+	if hasAudio, ok := data["has_audio"].(bool); ok {
+		material.HasAudio = hasAudio
+	}
+
 	// 裁剪设置
 	if cropData, ok := data["crop"].(map[string]interface{}); ok {
 		material.CropSettings = &CropSettings{}
@@ -330,6 +358,7 @@ func (vm *VideoMaterial) ExportJSON() map[string]interface{} {
 		"crop_ratio":        "free",
 		"crop_scale":        1.0,
 		"duration":          vm.Duration,
+		"has_audio":         vm.HasAudio,
 		"height":            vm.Height,
 		"id":                vm.MaterialID,
 		"local_material_id": vm.LocalMaterialID,
@@ -346,16 +375,20 @@ func (vm *VideoMaterial) ExportJSON() map[string]interface{} {
 // AudioMaterial 本地音频素材
 // 对应Python的Audio_material类
 type AudioMaterial struct {
-	MaterialID     string  `json:"id"`               // 素材全局id，自动生成
-	MaterialName   string  `json:"name"`             // 素材名称
-	Path           string  `json:"path"`             // 素材文件路径
-	RemoteURL      *string `json:"remote_url"`       // 远程URL地址
-	ReplacePath    *string `json:"replace_path"`     // 替换路径
-	HasAudioEffect bool    `json:"has_audio_effect"` // 是否有音频效果
-	Duration       int64   `json:"duration"`         // 素材时长，单位为微秒
+	MaterialID     string           `json:"id"`               // 素材全局id，自动生成
+	MaterialName   string           `json:"name"`             // 素材名称
+	Path           string           `json:"path"`             // 素材文件路径
+	RemoteURL      *string          `json:"remote_url"`       // 远程URL地址
+	ReplacePath    *string          `json:"replace_path"`     // 替换路径
+	HasAudioEffect bool             `json:"has_audio_effect"` // 是否有音频效果
+	Duration       int64            `json:"duration"`         // 素材时长，单位为微秒
+	Fingerprint    string           `json:"-"`                // 本地文件的内容指纹，远程素材为空
+	Loudness       *loudness.Result `json:"-"`                // 响度测量结果，未测量时为nil
 }
 
 // NewAudioMaterial 创建新的音频素材
+//
+// 本地文件未提供时长时通过探测获取，探测器无法识别文件格式时使用默认值（3分钟），读取文件失败时返回错误
 func NewAudioMaterial(path, replacePath, materialName, remoteURL *string, duration *float64) (*AudioMaterial, error) {
 	// 确保至少提供了path或remoteURL
 	if (path == nil || *path == "") && (remoteURL == nil || *remoteURL == "") {
@@ -365,6 +398,7 @@ func NewAudioMaterial(path, replacePath, materialName, remoteURL *string, durati
 	var finalPath string
 	var finalRemoteURL *string
 	var finalMaterialName string
+	var fingerprint string
 
 	// 处理路径和名称
 	if path != nil && *path != "" {
@@ -382,6 +416,10 @@ func NewAudioMaterial(path, replacePath, materialName, remoteURL *string, durati
 		} else {
 			finalMaterialName = filepath.Base(absPath)
 		}
+
+		if fingerprint, err = Fingerprint(absPath); err != nil {
+			return nil, fmt.Errorf("无法计算素材的内容指纹: %w", err)
+		}
 	}
 
 	if remoteURL != nil && *remoteURL != "" {
@@ -397,28 +435,30 @@ func NewAudioMaterial(path, replacePath, materialName, remoteURL *string, durati
 		}
 	}
 
-	// 生成素材ID
-	materialID := generateMaterialID(finalMaterialName)
-
 	// 创建音频素材对象
 	material := &AudioMaterial{
-		MaterialID:     materialID,
 		MaterialName:   finalMaterialName,
 		Path:           finalPath,
 		RemoteURL:      finalRemoteURL,
 		ReplacePath:    replacePath,
 		HasAudioEffect: false,
+		Fingerprint:    fingerprint,
 	}
 
 	// 设置时长
 	if duration != nil {
 		material.Duration = int64(*duration * 1e6) // 转换为微秒
+	} else if info, err := probeLocal(finalPath); err != nil {
+		return nil, err
+	} else if info != nil {
+		material.Duration = info.Duration
 	} else {
-		// TODO: 实现ffprobe音频信息获取
-		// 目前使用默认值，实际项目中需要调用ffprobe获取真实时长
-		material.Duration = 180000000 // 默认3分钟
+		material.Duration = 180000000 // 远程素材及无法识别格式的文件默认3分钟
 	}
 
+	// 根据内容及设置生成素材ID
+	material.MaterialID = generateMaterialID(material.IdentityKey())
+
 	return material, nil
 }
 
@@ -519,14 +559,54 @@ func (am *AudioMaterial) ExportJSON() map[string]interface{} {
 	}
 }
 
-// generateMaterialID 生成素材ID（基于UUID3）
-func generateMaterialID(materialName string) string {
-	// 使用DNS命名空间和素材名称生成UUID3
+// generateMaterialID 根据素材的标识生成素材ID（基于UUID3），标识相同的素材得到相同的ID
+func generateMaterialID(identityKey string) string {
+	// 使用DNS命名空间和素材标识生成UUID3
 	namespace := uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8") // DNS命名空间
-	id := uuid.NewMD5(namespace, []byte(materialName))
+	id := uuid.NewMD5(namespace, []byte(identityKey))
 	return strings.ReplaceAll(id.String(), "-", "")
 }
 
+// IdentityKey 返回判断素材是否相同的标识
+//
+// 标识由素材来源（见identitySource）及影响导出结果的设置组成，
+// 包括素材类型、裁剪设置、时长、宽高及替换路径；内容相同但设置不同的素材是不同的素材
+func (vm *VideoMaterial) IdentityKey() string {
+	source := identitySource(vm.Fingerprint, vm.RemoteURL, vm.MaterialName)
+	crop := vm.CropSettings
+	if crop == nil {
+		crop = NewCropSettings()
+	}
+	var replacePath string
+	if vm.ReplacePath != nil {
+		replacePath = *vm.ReplacePath
+	}
+	return fmt.Sprintf("%s|%s|%v|%d|%dx%d|%s", source, vm.MaterialType, *crop, vm.Duration, vm.Width, vm.Height, replacePath)
+}
+
+// IdentityKey 返回判断素材是否相同的标识，由素材来源（见identitySource）、时长及替换路径组成
+func (am *AudioMaterial) IdentityKey() string {
+	source := identitySource(am.Fingerprint, am.RemoteURL, am.MaterialName)
+	var replacePath string
+	if am.ReplacePath != nil {
+		replacePath = *am.ReplacePath
+	}
+	return fmt.Sprintf("%s|%d|%s", source, am.Duration, replacePath)
+}
+
+// identitySource 返回素材标识中的素材来源：本地素材为内容指纹，远程素材为URL，都没有时为素材名称
+//
+// 远程素材的名称取自URL的文件名，不同地址上的同名文件不能据此区分
+func identitySource(fingerprint string, remoteURL *string, name string) string {
+	if fingerprint != "" {
+		return fingerprint
+	}
+	if remoteURL != nil && *remoteURL != "" {
+		return "url:" + *remoteURL
+	}
+	return name
+}
+
 // MaterialInterface 素材接口，所有素材类型都应该实现
 type MaterialInterface interface {
 	ExportJSON() map[string]interface{}
@@ -1,8 +1,27 @@
 package material
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/zhangshican/go-capcut/internal/probe"
+	"github.com/zhangshican/go-capcut/internal/util"
 )
 
 func TestCropSettings(t *testing.T) {
@@ -445,10 +464,10 @@ func TestAudioMaterialExportJSON(t *testing.T) {
 
 func TestMaterialIDGeneration(t *testing.T) {
 	// 测试素材ID生成的一致性
-	materialName := "test_material.mp4"
+	key := "test_material.mp4|video"
 
-	id1 := generateMaterialID(materialName)
-	id2 := generateMaterialID(materialName)
+	id1 := generateMaterialID(key)
+	id2 := generateMaterialID(key)
 
 	if id1 != id2 {
 		t.Errorf("Expected consistent material ID generation, got '%s' and '%s'", id1, id2)
@@ -458,12 +477,52 @@ func TestMaterialIDGeneration(t *testing.T) {
 		t.Errorf("Expected material ID length 32, got %d", len(id1))
 	}
 
-	// 不同名称应该生成不同ID
-	differentName := "different_material.mp4"
-	differentID := generateMaterialID(differentName)
+	// 不同标识应该生成不同ID
+	if id1 == generateMaterialID("different_material.mp4|video") {
+		t.Error("Expected different IDs for different identity keys")
+	}
+
+	// 本地素材的标识取决于内容指纹及设置，与名称无关
+	a := &VideoMaterial{MaterialName: "a.mp4", Fingerprint: "42-abc", MaterialType: MaterialTypeVideo, Width: 1920, Height: 1080}
+	b := &VideoMaterial{MaterialName: "b.mp4", Fingerprint: "42-abc", MaterialType: MaterialTypeVideo, Width: 1920, Height: 1080, CropSettings: NewCropSettings()}
+	if a.IdentityKey() != b.IdentityKey() {
+		t.Errorf("Expected the same identity for the same fingerprint, got %q and %q", a.IdentityKey(), b.IdentityKey())
+	}
+	b.Fingerprint = "42-abd"
+	if a.IdentityKey() == b.IdentityKey() {
+		t.Error("Expected different identities for different fingerprints")
+	}
+
+	// 裁剪设置不同的素材是不同的素材
+	b.Fingerprint = a.Fingerprint
+	if err := b.CropToRect(0, 0, 960, 1080); err != nil {
+		t.Fatalf("Failed to crop: %v", err)
+	}
+	if a.IdentityKey() == b.IdentityKey() {
+		t.Error("Expected different identities for different crops")
+	}
 
-	if id1 == differentID {
-		t.Error("Expected different IDs for different material names")
+	// 远程素材以URL区分，不同地址上的同名文件是不同的素材
+	duration := 5.0
+	first, second := "https://a.example.com/x/voice.mp3", "https://b.example.com/y/voice.mp3"
+	audio1, err := NewAudioMaterial(nil, nil, nil, &first, &duration)
+	if err != nil {
+		t.Fatalf("Failed to create audio material: %v", err)
+	}
+	audio2, err := NewAudioMaterial(nil, nil, nil, &second, &duration)
+	if err != nil {
+		t.Fatalf("Failed to create audio material: %v", err)
+	}
+	if audio1.MaterialName != audio2.MaterialName {
+		t.Fatalf("Expected the same material name, got %q and %q", audio1.MaterialName, audio2.MaterialName)
+	}
+	if audio1.IdentityKey() == audio2.IdentityKey() || audio1.MaterialID == audio2.MaterialID {
+		t.Errorf("Expected different identities for different URLs, got %q", audio1.IdentityKey())
+	}
+	video1 := &VideoMaterial{MaterialName: "clip.mp4", RemoteURL: &first, MaterialType: MaterialTypeVideo}
+	video2 := &VideoMaterial{MaterialName: "clip.mp4", RemoteURL: &second, MaterialType: MaterialTypeVideo}
+	if video1.IdentityKey() == video2.IdentityKey() {
+		t.Error("Expected different identities for remote videos with different URLs")
 	}
 }
 
@@ -533,3 +592,517 @@ func TestMaterialInterface(t *testing.T) {
 		t.Errorf("Expected audio duration 30000000, got %d", audioInterface.GetDuration())
 	}
 }
+
+func TestLocalMaterialProbing(t *testing.T) {
+	dir := t.TempDir()
+
+	// 本地图片使用探测到的尺寸
+	imagePath := filepath.Join(dir, "image.png")
+	file, err := os.Create(imagePath)
+	if err != nil {
+		t.Fatalf("Failed to create image: %v", err)
+	}
+	if err := png.Encode(file, image.NewRGBA(image.Rect(0, 0, 640, 360))); err != nil {
+		t.Fatalf("Failed to encode image: %v", err)
+	}
+	file.Close()
+
+	photo, err := NewVideoMaterial(MaterialTypePhoto, &imagePath, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create photo material: %v", err)
+	}
+	if photo.Width != 640 || photo.Height != 360 || photo.Duration != 10800000000 || photo.HasAudio {
+		t.Errorf("Unexpected photo material: %+v", photo)
+	}
+
+	// 通过替换探测器验证视频及音频素材使用探测到的信息
+	mediaPath := filepath.Join(dir, "media.mp4")
+	if err := os.WriteFile(mediaPath, []byte("not really a video"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	previous := probe.SetProber(probe.ProberFunc(func(path string) (*probe.MediaInfo, error) {
+		if path != mediaPath {
+			t.Errorf("Expected absolute path %s, got %s", mediaPath, path)
+		}
+		return &probe.MediaInfo{Duration: 12345678, Width: 1080, Height: 1920, HasVideo: true, HasAudio: true}, nil
+	}))
+	defer probe.SetProber(previous)
+
+	video, err := NewVideoMaterial(MaterialTypeVideo, &mediaPath, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create video material: %v", err)
+	}
+	if video.Duration != 12345678 || video.Width != 1080 || video.Height != 1920 || !video.HasAudio {
+		t.Errorf("Unexpected video material: %+v", video)
+	}
+	if video.ExportJSON()["has_audio"] != true {
+		t.Error("Expected has_audio to be exported")
+	}
+
+	// 显式提供的值优先于探测结果
+	duration := 2.0
+	width := 720
+	video, err = NewVideoMaterial(MaterialTypeVideo, &mediaPath, nil, nil, nil, nil, &duration, &width, nil)
+	if err != nil {
+		t.Fatalf("Failed to create video material: %v", err)
+	}
+	if video.Duration != 2000000 || video.Width != 720 || video.Height != 1920 {
+		t.Errorf("Expected explicit values to take precedence, got %+v", video)
+	}
+
+	audio, err := NewAudioMaterial(&mediaPath, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create audio material: %v", err)
+	}
+	if audio.Duration != 12345678 {
+		t.Errorf("Expected probed duration 12345678, got %d", audio.Duration)
+	}
+
+	// 读取文件失败时返回错误
+	probe.SetProber(probe.ProberFunc(func(path string) (*probe.MediaInfo, error) {
+		return nil, os.ErrPermission
+	}))
+	if _, err := NewVideoMaterial(MaterialTypeVideo, &mediaPath, nil, nil, nil, nil, nil, nil, nil); !errors.Is(err, os.ErrPermission) {
+		t.Errorf("Expected ErrPermission, got %v", err)
+	}
+	if _, err := NewAudioMaterial(&mediaPath, nil, nil, nil, nil); !errors.Is(err, os.ErrPermission) {
+		t.Errorf("Expected ErrPermission, got %v", err)
+	}
+}
+
+func TestNewMaterialUnsupportedFormat(t *testing.T) {
+	// 内置探测器不支持AVI容器
+	aviPath := filepath.Join(t.TempDir(), "clip.avi")
+	if err := os.WriteFile(aviPath, []byte("RIFF\x24\x00\x00\x00AVI LIST\x00\x00\x00\x00"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := probe.Probe(aviPath); !errors.Is(err, probe.ErrUnsupportedFormat) {
+		t.Fatalf("Expected ErrUnsupportedFormat from the builtin prober, got %v", err)
+	}
+
+	video, err := NewVideoMaterial(MaterialTypeVideo, &aviPath, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create video material: %v", err)
+	}
+	if video.Duration != 30000000 || video.Width != 1920 || video.Height != 1080 || !video.HasAudio {
+		t.Errorf("Expected default media info, got %+v", video)
+	}
+
+	// 调用方提供的值优先于默认值
+	duration := 5.0
+	width, height := 640, 480
+	video, err = NewVideoMaterial(MaterialTypeVideo, &aviPath, nil, nil, nil, nil, &duration, &width, &height)
+	if err != nil {
+		t.Fatalf("Failed to create video material: %v", err)
+	}
+	if video.Duration != 5000000 || video.Width != 640 || video.Height != 480 {
+		t.Errorf("Expected explicit values, got %+v", video)
+	}
+	video, err = NewVideoMaterial(MaterialTypeVideo, &aviPath, nil, nil, nil, nil, nil, &width, nil)
+	if err != nil {
+		t.Fatalf("Failed to create video material: %v", err)
+	}
+	if video.Duration != 30000000 || video.Width != 640 || video.Height != 1080 {
+		t.Errorf("Expected explicit width with default duration and height, got %+v", video)
+	}
+
+	audio, err := NewAudioMaterial(&aviPath, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create audio material: %v", err)
+	}
+	if audio.Duration != 180000000 {
+		t.Errorf("Expected default duration 180000000, got %d", audio.Duration)
+	}
+}
+
+func TestDetectMaterialType(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		return path
+	}
+
+	var pngData, gifData bytes.Buffer
+	if err := png.Encode(&pngData, image.NewRGBA(image.Rect(0, 0, 320, 240))); err != nil {
+		t.Fatalf("Failed to encode image: %v", err)
+	}
+	frame := image.NewPaletted(image.Rect(0, 0, 100, 50), color.Palette{color.Black, color.White})
+	if err := gif.EncodeAll(&gifData, &gif.GIF{Image: []*image.Paletted{frame, frame}, Delay: []int{100, 100}}); err != nil {
+		t.Fatalf("Failed to encode gif: %v", err)
+	}
+	wav := []byte("RIFF\x24\x00\x00\x00WAVEfmt \x10\x00\x00\x00\x01\x00\x01\x00\x40\x1f\x00\x00\x40\x1f\x00\x00\x01\x00\x08\x00data\x00\x00\x00\x00")
+
+	// 文件内容优先于扩展名
+	imagePath := writeFile("image.mp4", pngData.Bytes())
+	gifPath := writeFile("animated.gif", gifData.Bytes())
+	audioPath := writeFile("audio.mp4", wav)
+
+	tests := []struct {
+		path     string
+		expected MaterialType
+	}{
+		{imagePath, MaterialTypePhoto},
+		{gifPath, MaterialTypeVideo},
+		{writeFile("unknown.heic", []byte("not parsed")), MaterialTypePhoto},
+		{writeFile("unknown.avi", []byte("not parsed")), MaterialTypeVideo},
+	}
+	for _, tt := range tests {
+		materialType, err := DetectMaterialType(tt.path)
+		if err != nil {
+			t.Errorf("Failed to detect %s: %v", filepath.Base(tt.path), err)
+		} else if materialType != tt.expected {
+			t.Errorf("Expected %s for %s, got %s", tt.expected, filepath.Base(tt.path), materialType)
+		}
+	}
+
+	for _, path := range []string{audioPath, writeFile("unknown.mp3", []byte("not parsed"))} {
+		if _, err := DetectMaterialType(path); !errors.Is(err, ErrAudioOnlyMaterial) {
+			t.Errorf("Expected ErrAudioOnlyMaterial for %s, got %v", filepath.Base(path), err)
+		}
+	}
+	if _, err := DetectMaterialType(writeFile("unknown.bin", []byte("not parsed"))); !errors.Is(err, probe.ErrUnsupportedFormat) {
+		t.Errorf("Expected ErrUnsupportedFormat, got %v", err)
+	}
+
+	// 自动判断类型创建素材
+	photo, err := NewVisualMaterial(imagePath, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create photo material: %v", err)
+	}
+	if photo.MaterialType != MaterialTypePhoto || photo.Width != 320 || photo.Height != 240 || photo.Duration != 10800000000 {
+		t.Errorf("Unexpected photo material: %+v", photo)
+	}
+
+	video, err := NewVisualMaterial(gifPath, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create gif material: %v", err)
+	}
+	if video.MaterialType != MaterialTypeVideo || video.Duration != 2000000 || video.Width != 100 || video.Height != 50 {
+		t.Errorf("Unexpected gif material: %+v", video)
+	}
+
+	// 仅凭扩展名识别的文件使用默认的媒体信息
+	extensionTests := []struct {
+		name     string
+		expected MaterialType
+		duration int64
+	}{
+		{"still.bmp", MaterialTypePhoto, 10800000000},
+		{"clip.avi", MaterialTypeVideo, 30000000},
+		{"stream.ts", MaterialTypeVideo, 30000000},
+	}
+	for _, tt := range extensionTests {
+		mat, err := NewVisualMaterial(writeFile(tt.name, []byte("not parsed")), nil, nil, nil)
+		if err != nil {
+			t.Errorf("Failed to create material from %s: %v", tt.name, err)
+			continue
+		}
+		if mat.MaterialType != tt.expected || mat.Duration != tt.duration || mat.Width != 1920 || mat.Height != 1080 {
+			t.Errorf("Unexpected material for %s: %+v", tt.name, mat)
+		}
+	}
+
+	if _, err := NewVisualMaterial(audioPath, nil, nil, nil); !errors.Is(err, ErrAudioOnlyMaterial) {
+		t.Errorf("Expected ErrAudioOnlyMaterial, got %v", err)
+	}
+	if _, err := NewVisualMaterial(filepath.Join(dir, "missing.mp4"), nil, nil, nil); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		return path
+	}
+
+	small := []byte("small file content")
+	large := bytes.Repeat([]byte("0123456789abcdef"), 20000)
+	changedMiddle := append([]byte{}, large...)
+	changedMiddle[len(large)/2] = 'x'
+
+	fingerprint := func(path string) string {
+		result, err := Fingerprint(path)
+		if err != nil {
+			t.Fatalf("Failed to fingerprint %s: %v", path, err)
+		}
+		return result
+	}
+
+	if fingerprint(writeFile("a/clip.mp4", small)) != fingerprint(writeFile("b/copy.mov", small)) {
+		t.Error("Expected files with the same content to have the same fingerprint")
+	}
+	if fingerprint(writeFile("a/large.mp4", large)) == fingerprint(writeFile("b/large.mp4", changedMiddle)) {
+		t.Error("Expected a change in the sampled middle block to change the fingerprint")
+	}
+	if fingerprint(writeFile("c/clip.mp4", small)) == fingerprint(writeFile("d/clip.mp4", []byte("other content!!!!!"))) {
+		t.Error("Expected files with the same name but different content to have different fingerprints")
+	}
+	if _, err := Fingerprint(filepath.Join(dir, "missing.mp4")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not exist error, got %v", err)
+	}
+}
+
+func TestCropHelpers(t *testing.T) {
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	checkRect := func(crop *CropSettings, x, y, w, h float64) {
+		t.Helper()
+		gotX, gotY, gotW, gotH := crop.Rect()
+		if !near(gotX, x) || !near(gotY, y) || !near(gotW, w) || !near(gotH, h) {
+			t.Errorf("Expected crop rect (%g, %g, %g, %g), got (%g, %g, %g, %g)", x, y, w, h, gotX, gotY, gotW, gotH)
+		}
+	}
+
+	video := &VideoMaterial{MaterialName: "clip.mp4", Width: 1920, Height: 1080, CropSettings: NewCropSettings()}
+
+	if err := video.CropToRect(480, 270, 960, 540); err != nil {
+		t.Fatalf("Failed to crop to rect: %v", err)
+	}
+	checkRect(video.CropSettings, 0.25, 0.25, 0.5, 0.5)
+	if w, h := video.CroppedSize(); !near(w, 960) || !near(h, 540) {
+		t.Errorf("Expected cropped size 960x540, got %gx%g", w, h)
+	}
+	if err := video.CropToRect(1000, 0, 1000, 100); err == nil {
+		t.Error("Expected error for rect outside the material")
+	}
+
+	// 16:9的素材裁剪为1:1，居中时保留中间部分
+	if err := video.CropToAspectRatio(1, CropAnchorCenter); err != nil {
+		t.Fatalf("Failed to crop to aspect ratio: %v", err)
+	}
+	checkRect(video.CropSettings, 0.21875, 0, 0.5625, 1)
+	if err := video.CropToAspectRatio(1, CropAnchorRight); err != nil {
+		t.Fatalf("Failed to crop to aspect ratio: %v", err)
+	}
+	checkRect(video.CropSettings, 0.4375, 0, 0.5625, 1)
+
+	// 裁剪为更宽的比例时裁掉上下部分
+	if err := video.CropToAspectRatio(32.0/9.0, CropAnchorTop); err != nil {
+		t.Fatalf("Failed to crop to aspect ratio: %v", err)
+	}
+	checkRect(video.CropSettings, 0, 0, 1, 0.5)
+
+	// 在已有的裁剪区域中选取
+	within, err := NewCropSettingsFromRect(0.5, 0, 0.5, 1)
+	if err != nil {
+		t.Fatalf("Failed to create crop settings: %v", err)
+	}
+	within, err = within.WithinAspectRatio(1920, 1080, 16.0/9.0, CropAnchorBottom)
+	if err != nil {
+		t.Fatalf("Failed to crop within region: %v", err)
+	}
+	checkRect(within, 0.5, 0.5, 0.5, 0.5)
+
+	if err := video.CropToAspectRatio(0, CropAnchorCenter); err == nil {
+		t.Error("Expected error for invalid ratio")
+	}
+	if err := video.CropToAspectRatio(1, CropAnchor{2, 0}); err == nil {
+		t.Error("Expected error for invalid anchor")
+	}
+	if err := (&VideoMaterial{}).CropToAspectRatio(1, CropAnchorCenter); err == nil {
+		t.Error("Expected error for material without size")
+	}
+	if _, err := NewCropSettingsFromRect(0.5, 0, 0.6, 1); err == nil {
+		t.Error("Expected error for rect outside the material")
+	}
+}
+
+func TestMaterialCacheFetch(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	var ranges, ifRanges []string
+	supportRange := true
+	etag := `"v1"`
+	badRangeStart := -1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/media/clip.MP4" {
+			http.NotFound(w, r)
+			return
+		}
+		ranges = append(ranges, r.Header.Get("Range"))
+		ifRanges = append(ifRanges, r.Header.Get("If-Range"))
+		if badRangeStart >= 0 && r.Header.Get("Range") != "" {
+			// 返回与请求不一致的范围
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", badRangeStart, len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(content[badRangeStart:])
+			return
+		}
+		if !supportRange {
+			r.Header.Del("Range")
+		}
+		w.Header().Set("ETag", etag)
+		http.ServeContent(w, r, "clip.mp4", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cache := NewMaterialCache(dir)
+	url := server.URL + "/media/clip.MP4"
+	target := cache.CachePath(url)
+	if filepath.Base(target) != util.URLToHash(url, 32)+".mp4" {
+		t.Errorf("Unexpected cache path %s", target)
+	}
+	writePart := func(data []byte, validator string) {
+		t.Helper()
+		os.Remove(target)
+		if err := os.WriteFile(target+".part", data, 0644); err != nil {
+			t.Fatalf("Failed to write partial file: %v", err)
+		}
+		os.Remove(target + ".part.validator")
+		if validator != "" {
+			if err := os.WriteFile(target+".part.validator", []byte(validator), 0644); err != nil {
+				t.Fatalf("Failed to write validator: %v", err)
+			}
+		}
+		ranges, ifRanges = nil, nil
+	}
+	checkFetch := func(expectedRange string) {
+		t.Helper()
+		path, err := cache.Fetch(context.Background(), url)
+		if err != nil {
+			t.Fatalf("Failed to fetch: %v", err)
+		}
+		if !filepath.IsAbs(path) {
+			t.Errorf("Expected absolute path, got %s", path)
+		}
+		if data, _ := os.ReadFile(path); !bytes.Equal(data, content) {
+			t.Error("Expected downloaded file to match the remote content")
+		}
+		if len(ranges) == 0 || ranges[0] != expectedRange {
+			t.Errorf("Expected first request with range %q, got %q", expectedRange, ranges)
+		}
+		for _, suffix := range []string{".part", ".part.validator"} {
+			if _, err := os.Stat(target + suffix); !os.IsNotExist(err) {
+				t.Errorf("Expected %s file to be removed", suffix)
+			}
+		}
+	}
+
+	// 资源未被修改时从中断处继续下载
+	writePart(content[:1234], etag)
+	checkFetch("bytes=1234-")
+	if len(ranges) != 1 || ifRanges[0] != etag {
+		t.Errorf("Expected a single request with If-Range %s, got %q %q", etag, ranges, ifRanges)
+	}
+
+	// 已缓存的URL不再下载
+	ranges = nil
+	if _, err := cache.Fetch(context.Background(), url); err != nil {
+		t.Fatalf("Failed to fetch: %v", err)
+	}
+	if len(ranges) != 0 {
+		t.Errorf("Expected cached file to be reused, got %d requests", len(ranges))
+	}
+
+	// 没有版本标识时无法确认资源未被修改，从头下载
+	writePart([]byte("stale"), "")
+	checkFetch("")
+
+	// 资源已被修改时服务器忽略Range返回完整内容
+	writePart([]byte("stale"), `"v0"`)
+	checkFetch("bytes=5-")
+	if len(ranges) != 1 {
+		t.Errorf("Expected a single request, got %q", ranges)
+	}
+
+	// 返回的范围与已下载的部分不衔接时从头下载
+	badRangeStart = 100
+	writePart(content[:1234], etag)
+	checkFetch("bytes=1234-")
+	if len(ranges) != 2 || ranges[1] != "" {
+		t.Errorf("Expected a second request without range, got %q", ranges)
+	}
+	badRangeStart = -1
+
+	// 服务器不支持续传时从头下载
+	supportRange = false
+	writePart([]byte("stale"), etag)
+	checkFetch("bytes=5-")
+
+	// 下载中断时保留已下载的部分及版本标识
+	failing := NewMaterialCache(dir, WithFetcher(FetcherFunc(func(ctx context.Context, url string, offset int64, validator string) (*FetchResponse, error) {
+		body := io.NopCloser(io.MultiReader(bytes.NewReader(content[:500]), iotest.ErrReader(errors.New("connection reset"))))
+		return &FetchResponse{Body: body, Validator: `"v1"`}, nil
+	})))
+	otherURL := server.URL + "/media/other.mp4"
+	if _, err := failing.Fetch(context.Background(), otherURL); err == nil {
+		t.Error("Expected error for interrupted download")
+	}
+	if info, err := os.Stat(failing.CachePath(otherURL) + ".part"); err != nil || info.Size() != 500 {
+		t.Errorf("Expected 500 bytes to be kept, got %v %v", info, err)
+	}
+	if data, err := os.ReadFile(failing.CachePath(otherURL) + ".part.validator"); err != nil || string(data) != `"v1"` {
+		t.Errorf("Expected validator to be kept, got %q %v", data, err)
+	}
+
+	if _, err := cache.Fetch(context.Background(), otherURL); err == nil {
+		t.Error("Expected error for missing remote file")
+	}
+}
+
+func TestMaterialCacheFetchMaterials(t *testing.T) {
+	var imageData bytes.Buffer
+	if err := png.Encode(&imageData, image.NewRGBA(image.Rect(0, 0, 320, 240))); err != nil {
+		t.Fatalf("Failed to encode image: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image.png":
+			w.Write(imageData.Bytes())
+		case "/song.mp3":
+			w.Write([]byte("fake audio"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	cache := NewMaterialCache(t.TempDir())
+
+	imageURL := server.URL + "/image.png"
+	name := "image.png"
+	photo, err := NewVideoMaterial(MaterialTypePhoto, nil, nil, &name, &imageURL, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create remote material: %v", err)
+	}
+	materialID := photo.MaterialID
+	if err := cache.FetchVideoMaterial(context.Background(), photo); err != nil {
+		t.Fatalf("Failed to fetch material: %v", err)
+	}
+	if photo.Path != cache.CachePath(imageURL) || photo.Width != 320 || photo.Height != 240 {
+		t.Errorf("Unexpected fetched material: %+v", photo)
+	}
+	if photo.MaterialID != materialID || photo.Fingerprint == "" || photo.Duration != 10800000000 {
+		t.Errorf("Unexpected fetched material: %+v", photo)
+	}
+
+	previous := probe.SetProber(probe.ProberFunc(func(path string) (*probe.MediaInfo, error) {
+		return &probe.MediaInfo{Duration: 4200000, HasAudio: true}, nil
+	}))
+	defer probe.SetProber(previous)
+	audioURL := server.URL + "/song.mp3"
+	audio, err := NewAudioMaterial(nil, nil, nil, &audioURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create remote material: %v", err)
+	}
+	if err := cache.FetchAudioMaterial(context.Background(), audio); err != nil {
+		t.Fatalf("Failed to fetch material: %v", err)
+	}
+	if audio.Path != cache.CachePath(audioURL) || audio.Duration != 4200000 {
+		t.Errorf("Unexpected fetched material: %+v", audio)
+	}
+
+	local := &VideoMaterial{Path: "/local.mp4"}
+	if err := cache.FetchVideoMaterial(context.Background(), local); err == nil {
+		t.Error("Expected error for material without remote URL")
+	}
+}
@@ -286,3 +286,27 @@ func TestErrorHandling(t *testing.T) {
 		t.Errorf("期望查找不存在的动画时返回错误")
 	}
 }
+
+// TestRegisterSticker 测试注册及查找贴纸
+func TestRegisterSticker(t *testing.T) {
+	RegisterSticker("测试 贴纸", "7226264929129287177")
+
+	sticker, err := FindStickerByName("测试_贴纸")
+	if err != nil {
+		t.Fatalf("查找贴纸失败: %v", err)
+	}
+	meta, ok := sticker.GetMeta().(StickerMeta)
+	if !ok {
+		t.Fatalf("期望贴纸元数据类型为 StickerMeta, 得到 %T", sticker.GetMeta())
+	}
+	if meta.ResourceID != "7226264929129287177" {
+		t.Errorf("期望贴纸资源ID为 '7226264929129287177', 得到 '%s'", meta.ResourceID)
+	}
+	if len(GetAllStickerTypes()) == 0 {
+		t.Errorf("期望至少有一个贴纸")
+	}
+
+	if _, err := FindStickerByName("不存在的贴纸"); err == nil {
+		t.Errorf("查找未注册的贴纸应返回错误")
+	}
+}
@@ -0,0 +1,40 @@
+// Package metadata/sticker 定义贴纸相关的元数据
+package metadata
+
+// StickerMeta 贴纸元数据
+type StickerMeta struct {
+	Name       string `json:"name"`        // 贴纸名称
+	ResourceID string `json:"resource_id"` // 资源ID，由剪映本身提供
+}
+
+// NewStickerMeta 创建新的贴纸元数据
+func NewStickerMeta(name, resourceID string) StickerMeta {
+	return StickerMeta{
+		Name:       name,
+		ResourceID: resourceID,
+	}
+}
+
+// StickerType 贴纸类型枚举
+type StickerType struct {
+	EffectEnum
+}
+
+// RegisterSticker 注册贴纸，注册后可通过FindStickerByName按名称查找
+//
+// 贴纸的资源ID可在剪映中使用该贴纸后，通过ScriptFile的InspectMaterial方法获取
+func RegisterSticker(name, resourceID string) StickerType {
+	sticker := StickerType{NewEffectEnum(name, NewStickerMeta(name, resourceID))}
+	RegisterEffect("sticker", sticker)
+	return sticker
+}
+
+// GetAllStickerTypes 获取所有已注册的贴纸
+func GetAllStickerTypes() []EffectEnumerable {
+	return GetAllEffects("sticker")
+}
+
+// FindStickerByName 根据名称查找已注册的贴纸，忽略大小写、空格和下划线
+func FindStickerByName(name string) (EffectEnumerable, error) {
+	return FindEffect("sticker", name)
+}
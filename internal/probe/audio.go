@@ -0,0 +1,308 @@
+package probe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// probeWAV 解析WAV文件，时长为data块的长度除以fmt块中的每秒字节数
+func probeWAV(r io.ReadSeeker) (*MediaInfo, error) {
+	if _, err := r.Seek(12, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var byteRate uint32
+	var dataSize int64 = -1
+	for byteRate == 0 || dataSize < 0 {
+		header, err := readFull(r, 8)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		size := int64(binary.LittleEndian.Uint32(header[4:8]))
+
+		switch string(header[:4]) {
+		case "fmt ":
+			if size < 12 {
+				return nil, fmt.Errorf("wav: truncated fmt chunk")
+			}
+			data, err := readFull(r, 12)
+			if err != nil {
+				return nil, err
+			}
+			byteRate = binary.LittleEndian.Uint32(data[8:12])
+			size -= 12
+		case "data":
+			dataSize = size
+			// 流式写入的文件中data块的长度可能未填写，此时取到文件末尾的长度
+			if size == 0 || size == 0xffffffff {
+				current, err := r.Seek(0, io.SeekCurrent)
+				if err != nil {
+					return nil, err
+				}
+				fileEnd, err := r.Seek(0, io.SeekEnd)
+				if err != nil {
+					return nil, err
+				}
+				dataSize = fileEnd - current
+				size = dataSize
+			}
+		}
+
+		// 块的长度为奇数时末尾有一个填充字节
+		if _, err := r.Seek(size+size%2, io.SeekCurrent); err != nil {
+			return nil, err
+		}
+	}
+	if byteRate == 0 || dataSize < 0 {
+		return nil, fmt.Errorf("wav: missing fmt or data chunk")
+	}
+
+	return &MediaInfo{
+		Format:   "wav",
+		Duration: scaleToMicros(uint64(dataSize), uint64(byteRate)),
+		HasAudio: true,
+	}, nil
+}
+
+// probeFLAC 解析FLAC文件，时长来自STREAMINFO中的采样率及总采样数
+func probeFLAC(r io.ReadSeeker) (*MediaInfo, error) {
+	if _, err := r.Seek(4, io.SeekStart); err != nil {
+		return nil, err
+	}
+	header, err := readFull(r, 4)
+	if err != nil {
+		return nil, err
+	}
+	if header[0]&0x7f != 0 {
+		return nil, fmt.Errorf("flac: first metadata block is not STREAMINFO")
+	}
+	data, err := readFull(r, 18)
+	if err != nil {
+		return nil, err
+	}
+
+	// 第10字节起依次为20位采样率、3位声道数、5位位深及36位总采样数
+	packed := binary.BigEndian.Uint64(data[10:18])
+	sampleRate := packed >> 44
+	totalSamples := packed & (1<<36 - 1)
+	return &MediaInfo{
+		Format:   "flac",
+		Duration: scaleToMicros(totalSamples, sampleRate),
+		HasAudio: true,
+	}, nil
+}
+
+// MPEG音频的比特率表，单位为kbps，下标依次为[MPEG1/MPEG2][层-1][比特率索引]
+var mpegBitrates = [2][3][16]int{
+	{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0},
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+	},
+	{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+	},
+}
+
+// MPEG1的采样率，MPEG2及MPEG2.5分别为其1/2和1/4
+var mpegSampleRates = [3]int{44100, 48000, 32000}
+
+// mpegFrameHeader MPEG音频帧头的信息
+type mpegFrameHeader struct {
+	version         int // 1为MPEG1，2为MPEG2，25为MPEG2.5
+	layer           int
+	bitrate         int // 单位为bps
+	sampleRate      int
+	mono            bool
+	samplesPerFrame int
+}
+
+// parseMPEGFrameHeader 解析4字节的MPEG音频帧头
+func parseMPEGFrameHeader(b []byte) (*mpegFrameHeader, bool) {
+	if len(b) < 4 || b[0] != 0xff || b[1]&0xe0 != 0xe0 {
+		return nil, false
+	}
+	versionBits := (b[1] >> 3) & 0x03
+	layerBits := (b[1] >> 1) & 0x03
+	bitrateIndex := b[2] >> 4
+	sampleRateIndex := (b[2] >> 2) & 0x03
+	if versionBits == 1 || layerBits == 0 || bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return nil, false
+	}
+
+	h := &mpegFrameHeader{layer: int(4 - layerBits), mono: b[3]>>6 == 3}
+	table := 1
+	h.sampleRate = mpegSampleRates[sampleRateIndex]
+	switch versionBits {
+	case 3:
+		h.version = 1
+		table = 0
+	case 2:
+		h.version = 2
+		h.sampleRate /= 2
+	case 0:
+		h.version = 25
+		h.sampleRate /= 4
+	}
+	h.bitrate = mpegBitrates[table][h.layer-1][bitrateIndex] * 1000
+
+	switch {
+	case h.layer == 1:
+		h.samplesPerFrame = 384
+	case h.layer == 3 && h.version != 1:
+		h.samplesPerFrame = 576
+	default:
+		h.samplesPerFrame = 1152
+	}
+	return h, true
+}
+
+// probeMPEGAudio 解析MP3等MPEG音频文件
+//
+// 存在Xing/Info或VBRI头时根据总帧数计算时长，否则按第一帧的比特率视为CBR计算
+func probeMPEGAudio(r io.ReadSeeker) (*MediaInfo, error) {
+	fileSize, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// 跳过ID3v2标签
+	var start int64
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if id3, err := readFull(r, 10); err == nil && string(id3[:3]) == "ID3" {
+		size := int64(id3[6]&0x7f)<<21 | int64(id3[7]&0x7f)<<14 | int64(id3[8]&0x7f)<<7 | int64(id3[9]&0x7f)
+		start = 10 + size
+		if id3[5]&0x10 != 0 {
+			start += 10
+		}
+	}
+
+	// 从标签之后查找第一个有效的帧头
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 64*1024)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	buf = buf[:n]
+
+	var header *mpegFrameHeader
+	frame := -1
+	for i := 0; i+4 <= len(buf); i++ {
+		if h, ok := parseMPEGFrameHeader(buf[i : i+4]); ok {
+			header, frame = h, i
+			break
+		}
+	}
+	if header == nil {
+		return nil, fmt.Errorf("mp3: no frame header found")
+	}
+
+	info := &MediaInfo{Format: "mp3", HasAudio: true}
+	if frames, ok := mpegFrameCount(buf[frame:], header); ok {
+		info.Duration = scaleToMicros(uint64(frames)*uint64(header.samplesPerFrame), uint64(header.sampleRate))
+		return info, nil
+	}
+
+	// 未找到VBR头，按CBR计算，排除末尾的ID3v1标签
+	audioSize := fileSize - start - int64(frame)
+	if fileSize >= 128 {
+		if _, err := r.Seek(fileSize-128, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if tag, err := readFull(r, 3); err == nil && string(tag) == "TAG" {
+			audioSize -= 128
+		}
+	}
+	info.Duration = scaleToMicros(uint64(audioSize)*8, uint64(header.bitrate))
+	return info, nil
+}
+
+// mpegFrameCount 从第一帧的Xing/Info或VBRI头中读取总帧数
+func mpegFrameCount(frame []byte, h *mpegFrameHeader) (uint32, bool) {
+	// Xing/Info头位于side information之后
+	offset := 4 + 32
+	switch {
+	case h.version == 1 && h.mono:
+		offset = 4 + 17
+	case h.version != 1 && !h.mono:
+		offset = 4 + 17
+	case h.version != 1 && h.mono:
+		offset = 4 + 9
+	}
+	if len(frame) >= offset+12 {
+		tag := frame[offset : offset+4]
+		flags := binary.BigEndian.Uint32(frame[offset+4 : offset+8])
+		if (bytes.Equal(tag, []byte("Xing")) || bytes.Equal(tag, []byte("Info"))) && flags&0x1 != 0 {
+			return binary.BigEndian.Uint32(frame[offset+8 : offset+12]), true
+		}
+	}
+
+	// VBRI头固定位于帧头之后32字节处
+	if len(frame) >= 4+32+18 && bytes.Equal(frame[36:40], []byte("VBRI")) {
+		return binary.BigEndian.Uint32(frame[50:54]), true
+	}
+	return 0, false
+}
+
+// AAC的采样率，下标为ADTS头中的采样率索引
+var adtsSampleRates = [16]int{96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000, 7350}
+
+// probeADTS 解析ADTS封装的AAC文件，遍历所有帧累计采样数
+func probeADTS(r io.ReadSeeker) (*MediaInfo, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var samples uint64
+	sampleRate := 0
+	for {
+		header, err := readFull(r, 7)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header[0] != 0xff || header[1]&0xf6 != 0xf0 {
+			break
+		}
+
+		rate := adtsSampleRates[(header[2]>>2)&0x0f]
+		if rate == 0 {
+			return nil, fmt.Errorf("aac: invalid sampling frequency index")
+		}
+		if sampleRate == 0 {
+			sampleRate = rate
+		}
+		frameLength := int64(header[3]&0x03)<<11 | int64(header[4])<<3 | int64(header[5])>>5
+		if frameLength < 7 {
+			break
+		}
+		samples += 1024 * uint64(header[6]&0x03+1)
+
+		if _, err := r.Seek(frameLength-7, io.SeekCurrent); err != nil {
+			return nil, err
+		}
+	}
+	if sampleRate == 0 {
+		return nil, fmt.Errorf("aac: no frame header found")
+	}
+
+	return &MediaInfo{
+		Format:   "aac",
+		Duration: scaleToMicros(samples, uint64(sampleRate)),
+		HasAudio: true,
+	}, nil
+}
@@ -0,0 +1,183 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+
+	// 注册PNG及JPEG的解码器，DecodeConfig只读取文件头
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// probeImage 解析PNG及JPEG图片的尺寸
+func probeImage(r io.ReadSeeker) (*MediaInfo, error) {
+	config, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	return &MediaInfo{
+		Format:   format,
+		Width:    config.Width,
+		Height:   config.Height,
+		HasVideo: true,
+		IsImage:  true,
+	}, nil
+}
+
+// probeWebP 解析WebP图片的尺寸，支持有损(VP8)、无损(VP8L)及扩展(VP8X)格式
+func probeWebP(r io.ReadSeeker) (*MediaInfo, error) {
+	if _, err := r.Seek(12, io.SeekStart); err != nil {
+		return nil, err
+	}
+	header, err := readFull(r, 8)
+	if err != nil {
+		return nil, err
+	}
+	size := binary.LittleEndian.Uint32(header[4:8])
+	if size < 10 {
+		return nil, fmt.Errorf("webp: truncated %q chunk", header[:4])
+	}
+	data, err := readFull(r, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &MediaInfo{Format: "webp", HasVideo: true, IsImage: true}
+	switch string(header[:4]) {
+	case "VP8 ":
+		// 3字节的帧标记之后为起始码及14位的宽高
+		if !bytes.Equal(data[3:6], []byte{0x9d, 0x01, 0x2a}) {
+			return nil, fmt.Errorf("webp: invalid VP8 start code")
+		}
+		info.Width = int(binary.LittleEndian.Uint16(data[6:8]) & 0x3fff)
+		info.Height = int(binary.LittleEndian.Uint16(data[8:10]) & 0x3fff)
+	case "VP8L":
+		// 签名之后依次为14位的宽减1及14位的高减1
+		if data[0] != 0x2f {
+			return nil, fmt.Errorf("webp: invalid VP8L signature")
+		}
+		packed := binary.LittleEndian.Uint32(data[1:5])
+		info.Width = int(packed&0x3fff) + 1
+		info.Height = int(packed>>14&0x3fff) + 1
+	case "VP8X":
+		// 4字节的标志之后依次为24位的宽减1及24位的高减1
+		info.Width = int(uint32(data[4])|uint32(data[5])<<8|uint32(data[6])<<16) + 1
+		info.Height = int(uint32(data[7])|uint32(data[8])<<8|uint32(data[9])<<16) + 1
+	default:
+		return nil, fmt.Errorf("webp: unknown chunk %q", header[:4])
+	}
+	return info, nil
+}
+
+// gifDefaultDelay 帧延迟为0或1时浏览器等播放器实际使用的延迟，单位为1/100秒
+const gifDefaultDelay = 10
+
+// probeGIF 解析GIF图片，只有一帧时视为静态图片，多帧时视为动图并累计各帧的延迟作为时长
+func probeGIF(r io.ReadSeeker) (*MediaInfo, error) {
+	br := bufio.NewReader(r)
+	header, err := readFull(br, 13)
+	if err != nil {
+		return nil, err
+	}
+	info := &MediaInfo{
+		Format:   "gif",
+		Width:    int(binary.LittleEndian.Uint16(header[6:8])),
+		Height:   int(binary.LittleEndian.Uint16(header[8:10])),
+		HasVideo: true,
+	}
+	// 全局颜色表
+	if header[10]&0x80 != 0 {
+		if _, err := br.Discard(3 << (header[10]&0x07 + 1)); err != nil {
+			return nil, err
+		}
+	}
+
+	frames := 0
+	var delay, totalDelay int64
+	for {
+		separator, err := br.ReadByte()
+		if err == io.EOF {
+			// 部分编码器不写入结束标记
+			separator = 0x3b
+		} else if err != nil {
+			return nil, err
+		}
+		switch separator {
+		case 0x21: // 扩展块
+			label, err := br.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if label == 0xf9 {
+				// 图形控制扩展，第2、3字节为下一帧的延迟
+				control, err := readFull(br, 6)
+				if err != nil {
+					return nil, err
+				}
+				delay = int64(binary.LittleEndian.Uint16(control[2:4]))
+				if control[5] != 0 {
+					return nil, fmt.Errorf("gif: invalid graphic control extension")
+				}
+				continue
+			}
+			if err := skipGIFSubBlocks(br); err != nil {
+				return nil, err
+			}
+		case 0x2c: // 图像描述符
+			descriptor, err := readFull(br, 9)
+			if err != nil {
+				return nil, err
+			}
+			// 局部颜色表及LZW最小码长
+			discard := 1
+			if descriptor[8]&0x80 != 0 {
+				discard += 3 << (descriptor[8]&0x07 + 1)
+			}
+			if _, err := br.Discard(discard); err != nil {
+				return nil, err
+			}
+			if err := skipGIFSubBlocks(br); err != nil {
+				return nil, err
+			}
+
+			frames++
+			if delay <= 1 {
+				delay = gifDefaultDelay
+			}
+			totalDelay += delay
+			delay = 0
+		case 0x3b: // 文件结束
+			if frames == 0 {
+				return nil, fmt.Errorf("gif: no image found")
+			}
+			if frames == 1 {
+				info.IsImage = true
+			} else {
+				info.Duration = totalDelay * 10000
+			}
+			return info, nil
+		default:
+			return nil, fmt.Errorf("gif: unknown block 0x%02x", separator)
+		}
+	}
+}
+
+// skipGIFSubBlocks 跳过以长度为0的子块结尾的数据子块序列
+func skipGIFSubBlocks(br *bufio.Reader) error {
+	for {
+		size, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if size == 0 {
+			return nil
+		}
+		if _, err := br.Discard(int(size)); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package probe
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// Matroska/WebM中用到的元素id
+const (
+	ebmlIDSegment       = 0x18538067
+	ebmlIDInfo          = 0x1549A966
+	ebmlIDTimecodeScale = 0x2AD7B1
+	ebmlIDDuration      = 0x4489
+	ebmlIDTracks        = 0x1654AE6B
+	ebmlIDTrackEntry    = 0xAE
+	ebmlIDTrackType     = 0x83
+	ebmlIDVideo         = 0xE0
+	ebmlIDPixelWidth    = 0xB0
+	ebmlIDPixelHeight   = 0xBA
+	ebmlIDCluster       = 0x1F43B675
+)
+
+// ebmlUnknownSize 长度未知的元素，一直延续到父元素的末尾
+const ebmlUnknownSize = -1
+
+// readEBMLVint 读取EBML的变长整数，keepMarker为true时保留长度标记位(用于元素id)
+func readEBMLVint(r io.Reader, keepMarker bool) (value int64, allOnes bool, err error) {
+	first, err := readFull(r, 1)
+	if err != nil {
+		return 0, false, err
+	}
+	length := bits.LeadingZeros8(first[0]) + 1
+	if length > 8 {
+		return 0, false, fmt.Errorf("matroska: invalid variable size integer")
+	}
+	rest, err := readFull(r, length-1)
+	if err != nil {
+		return 0, false, err
+	}
+
+	mask := byte(0xff >> length)
+	raw := uint64(first[0])
+	v := uint64(first[0] & mask)
+	allOnes = first[0]&mask == mask
+	for _, b := range rest {
+		raw = raw<<8 | uint64(b)
+		v = v<<8 | uint64(b)
+		allOnes = allOnes && b == 0xff
+	}
+	if keepMarker {
+		return int64(raw), false, nil
+	}
+	return int64(v), allOnes, nil
+}
+
+// readEBMLHeader 读取元素的id及内容长度，长度未知时size为ebmlUnknownSize
+func readEBMLHeader(r io.Reader) (id int64, size int64, err error) {
+	if id, _, err = readEBMLVint(r, true); err != nil {
+		return 0, 0, err
+	}
+	size, unknown, err := readEBMLVint(r, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	if unknown {
+		size = ebmlUnknownSize
+	}
+	return id, size, nil
+}
+
+// readEBMLUint 读取无符号整数元素的内容
+func readEBMLUint(r io.Reader, size int64) (uint64, error) {
+	if size > 8 {
+		return 0, fmt.Errorf("matroska: integer element too large")
+	}
+	data, err := readFull(r, int(size))
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, b := range data {
+		v = v<<8 | uint64(b)
+	}
+	return v, nil
+}
+
+// readEBMLFloat 读取浮点数元素的内容
+func readEBMLFloat(r io.Reader, size int64) (float64, error) {
+	data, err := readFull(r, int(size))
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), nil
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), nil
+	case 0:
+		return 0, nil
+	}
+	return 0, fmt.Errorf("matroska: invalid float element size %d", size)
+}
+
+// walkEBML 依次读取[start, end)范围内的元素并调用visit，end为-1时读取到文件末尾
+//
+// visit返回false时停止遍历；visit未读取的内容会被跳过
+func walkEBML(r io.ReadSeeker, start, end int64, visit func(id, offset, size int64) (bool, error)) error {
+	offset := start
+	for end < 0 || offset < end {
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+		id, size, err := readEBMLHeader(r)
+		if err == io.EOF && end < 0 {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		dataOffset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		if size == ebmlUnknownSize || (end >= 0 && dataOffset+size > end) {
+			size = end - dataOffset
+			if end < 0 {
+				fileEnd, err := r.Seek(0, io.SeekEnd)
+				if err != nil {
+					return err
+				}
+				size = fileEnd - dataOffset
+			}
+		}
+
+		more, err := visit(id, dataOffset, size)
+		if err != nil || !more {
+			return err
+		}
+		offset = dataOffset + size
+	}
+	return nil
+}
+
+// probeMatroska 解析Matroska/WebM文件，时长来自Segment/Info，分辨率来自第一个视频轨道
+func probeMatroska(r io.ReadSeeker) (*MediaInfo, error) {
+	info := &MediaInfo{Format: "matroska"}
+	foundSegment := false
+
+	err := walkEBML(r, 0, -1, func(id, offset, size int64) (bool, error) {
+		if id != ebmlIDSegment {
+			return true, nil
+		}
+		foundSegment = true
+		return false, probeMatroskaSegment(r, offset, offset+size, info)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !foundSegment {
+		return nil, fmt.Errorf("matroska: segment not found")
+	}
+	return info, nil
+}
+
+// probeMatroskaSegment 读取Segment中的Info及Tracks，遇到Cluster且二者均已读取时停止
+func probeMatroskaSegment(r io.ReadSeeker, start, end int64, info *MediaInfo) error {
+	var foundInfo, foundTracks bool
+	return walkEBML(r, start, end, func(id, offset, size int64) (bool, error) {
+		switch id {
+		case ebmlIDInfo:
+			foundInfo = true
+			duration, err := readMatroskaDuration(r, offset, offset+size)
+			if err != nil {
+				return false, err
+			}
+			info.Duration = duration
+		case ebmlIDTracks:
+			foundTracks = true
+			if err := readMatroskaTracks(r, offset, offset+size, info); err != nil {
+				return false, err
+			}
+		case ebmlIDCluster:
+			if foundInfo && foundTracks {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// readMatroskaDuration 读取Info中的时长并转换为微秒
+func readMatroskaDuration(r io.ReadSeeker, start, end int64) (int64, error) {
+	var timecodeScale uint64 = 1000000 // 默认为1毫秒
+	var duration float64
+	err := walkEBML(r, start, end, func(id, offset, size int64) (bool, error) {
+		var err error
+		switch id {
+		case ebmlIDTimecodeScale:
+			timecodeScale, err = readEBMLUint(r, size)
+		case ebmlIDDuration:
+			duration, err = readEBMLFloat(r, size)
+		}
+		return err == nil, err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(math.Round(duration * float64(timecodeScale) / 1000)), nil
+}
+
+// readMatroskaTracks 读取Tracks中各轨道的类型，并从第一个视频轨道中读取分辨率
+func readMatroskaTracks(r io.ReadSeeker, start, end int64, info *MediaInfo) error {
+	return walkEBML(r, start, end, func(id, offset, size int64) (bool, error) {
+		if id != ebmlIDTrackEntry {
+			return true, nil
+		}
+
+		var trackType uint64
+		var width, height uint64
+		err := walkEBML(r, offset, offset+size, func(id, offset, size int64) (bool, error) {
+			var err error
+			switch id {
+			case ebmlIDTrackType:
+				trackType, err = readEBMLUint(r, size)
+			case ebmlIDVideo:
+				err = walkEBML(r, offset, offset+size, func(id, offset, size int64) (bool, error) {
+					var err error
+					switch id {
+					case ebmlIDPixelWidth:
+						width, err = readEBMLUint(r, size)
+					case ebmlIDPixelHeight:
+						height, err = readEBMLUint(r, size)
+					}
+					return err == nil, err
+				})
+			}
+			return err == nil, err
+		})
+		if err != nil {
+			return false, err
+		}
+
+		switch trackType {
+		case 1:
+			if !info.HasVideo {
+				info.HasVideo = true
+				info.Width, info.Height = int(width), int(height)
+			}
+		case 2:
+			info.HasAudio = true
+		}
+		return true, nil
+	})
+}
@@ -0,0 +1,234 @@
+package probe
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// isMP4Box 判断是否为MP4/MOV文件中常见的顶层box类型
+func isMP4Box(boxType string) bool {
+	switch boxType {
+	case "ftyp", "moov", "mdat", "free", "skip", "wide", "pnot":
+		return true
+	}
+	return false
+}
+
+// mp4Box box的类型及内容的位置
+type mp4Box struct {
+	boxType string
+	offset  int64 // 内容的起始位置
+	size    int64 // 内容的长度
+}
+
+// readMP4Boxes 读取[start, end)范围内的所有box，end为-1时读取到文件末尾
+func readMP4Boxes(r io.ReadSeeker, start, end int64) ([]mp4Box, error) {
+	var boxes []mp4Box
+	offset := start
+	for end < 0 || offset+8 <= end {
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+		header, err := readFull(r, 8)
+		if err == io.EOF && end < 0 {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[:4]))
+		headerSize := int64(8)
+		switch size {
+		case 0:
+			// 一直延续到文件末尾
+			fileEnd, err := r.Seek(0, io.SeekEnd)
+			if err != nil {
+				return nil, err
+			}
+			size = fileEnd - offset
+		case 1:
+			large, err := readFull(r, 8)
+			if err != nil {
+				return nil, err
+			}
+			size = int64(binary.BigEndian.Uint64(large))
+			headerSize = 16
+		}
+		if size < headerSize || (end >= 0 && offset+size > end) {
+			return nil, fmt.Errorf("invalid mp4 box %q at %d", header[4:8], offset)
+		}
+
+		boxes = append(boxes, mp4Box{boxType: string(header[4:8]), offset: offset + headerSize, size: size - headerSize})
+		offset += size
+	}
+	return boxes, nil
+}
+
+// findMP4Box 返回第一个指定类型的box
+func findMP4Box(boxes []mp4Box, boxType string) (mp4Box, bool) {
+	for _, box := range boxes {
+		if box.boxType == boxType {
+			return box, true
+		}
+	}
+	return mp4Box{}, false
+}
+
+// readMP4BoxContent 读取box的内容，最多读取limit个字节
+func readMP4BoxContent(r io.ReadSeeker, box mp4Box, limit int64) ([]byte, error) {
+	if _, err := r.Seek(box.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	size := box.size
+	if size > limit {
+		size = limit
+	}
+	return readFull(r, int(size))
+}
+
+// probeMP4 解析MP4/MOV文件，时长来自mvhd，分辨率及旋转来自视频轨道的tkhd
+func probeMP4(r io.ReadSeeker) (*MediaInfo, error) {
+	boxes, err := readMP4Boxes(r, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	moov, ok := findMP4Box(boxes, "moov")
+	if !ok {
+		return nil, fmt.Errorf("mp4: moov box not found")
+	}
+	children, err := readMP4Boxes(r, moov.offset, moov.offset+moov.size)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &MediaInfo{Format: "mp4"}
+	var timescale uint64
+	if mvhd, ok := findMP4Box(children, "mvhd"); ok {
+		data, err := readMP4BoxContent(r, mvhd, 32)
+		if err != nil {
+			return nil, err
+		}
+		var duration uint64
+		timescale, duration, err = parseMP4Duration(data)
+		if err != nil {
+			return nil, err
+		}
+		info.Duration = scaleToMicros(duration, timescale)
+	}
+
+	for _, trak := range children {
+		if trak.boxType != "trak" {
+			continue
+		}
+		track, err := probeMP4Track(r, trak)
+		if err != nil {
+			return nil, err
+		}
+		switch track.handler {
+		case "vide":
+			if info.HasVideo {
+				continue
+			}
+			info.HasVideo = true
+			info.Width, info.Height, info.Rotation = track.width, track.height, track.rotation
+			if info.Rotation == 90 || info.Rotation == 270 {
+				info.Width, info.Height = info.Height, info.Width
+			}
+		case "soun":
+			info.HasAudio = true
+		}
+		// 分片的MP4文件中mvhd的时长可能为0，此时取轨道的最大时长
+		if d := scaleToMicros(track.duration, timescale); info.Duration == 0 && d > 0 {
+			info.Duration = d
+		}
+	}
+	return info, nil
+}
+
+// parseMP4Duration 解析mvhd或mdhd中的timescale及duration
+func parseMP4Duration(data []byte) (timescale, duration uint64, err error) {
+	if len(data) < 1 {
+		return 0, 0, fmt.Errorf("mp4: truncated header box")
+	}
+	if data[0] == 1 {
+		if len(data) < 32 {
+			return 0, 0, fmt.Errorf("mp4: truncated header box")
+		}
+		return uint64(binary.BigEndian.Uint32(data[20:24])), binary.BigEndian.Uint64(data[24:32]), nil
+	}
+	if len(data) < 20 {
+		return 0, 0, fmt.Errorf("mp4: truncated header box")
+	}
+	return uint64(binary.BigEndian.Uint32(data[12:16])), uint64(binary.BigEndian.Uint32(data[16:20])), nil
+}
+
+// mp4Track 轨道的信息
+type mp4Track struct {
+	handler  string // 轨道的处理类型，"vide"为视频，"soun"为音频
+	duration uint64 // 以影片的timescale为单位的时长
+	width    int
+	height   int
+	rotation int
+}
+
+// probeMP4Track 解析trak中的tkhd及mdia/hdlr
+func probeMP4Track(r io.ReadSeeker, trak mp4Box) (*mp4Track, error) {
+	children, err := readMP4Boxes(r, trak.offset, trak.offset+trak.size)
+	if err != nil {
+		return nil, err
+	}
+	track := &mp4Track{}
+
+	if tkhd, ok := findMP4Box(children, "tkhd"); ok {
+		data, err := readMP4BoxContent(r, tkhd, 92)
+		if err != nil {
+			return nil, err
+		}
+		// version 1的时间字段为64位，其后的字段整体后移12字节
+		shift := 0
+		if len(data) > 0 && data[0] == 1 {
+			shift = 12
+		}
+		if len(data) < 84+shift {
+			return nil, fmt.Errorf("mp4: truncated tkhd box")
+		}
+		if shift > 0 {
+			track.duration = binary.BigEndian.Uint64(data[28:36])
+		} else {
+			track.duration = uint64(binary.BigEndian.Uint32(data[20:24]))
+		}
+		matrix := data[40+shift : 76+shift]
+		track.rotation = matrixRotation(
+			int32(binary.BigEndian.Uint32(matrix[0:4])),
+			int32(binary.BigEndian.Uint32(matrix[4:8])))
+		track.width = int(binary.BigEndian.Uint32(data[76+shift:80+shift]) >> 16)
+		track.height = int(binary.BigEndian.Uint32(data[80+shift:84+shift]) >> 16)
+	}
+
+	if mdia, ok := findMP4Box(children, "mdia"); ok {
+		mdiaChildren, err := readMP4Boxes(r, mdia.offset, mdia.offset+mdia.size)
+		if err != nil {
+			return nil, err
+		}
+		if hdlr, ok := findMP4Box(mdiaChildren, "hdlr"); ok {
+			data, err := readMP4BoxContent(r, hdlr, 12)
+			if err != nil {
+				return nil, err
+			}
+			if len(data) == 12 {
+				track.handler = string(data[8:12])
+			}
+		}
+	}
+	return track, nil
+}
+
+// matrixRotation 根据变换矩阵的a、b两项计算顺时针旋转角度，结果取最接近的90度的整数倍
+func matrixRotation(a, b int32) int {
+	degrees := math.Atan2(float64(b), float64(a)) * 180 / math.Pi
+	rotation := int(math.Round(degrees/90)) * 90
+	return (rotation%360 + 360) % 360
+}
@@ -0,0 +1,141 @@
+// Package probe 读取媒体文件的头部信息，获取时长、分辨率及音视频流等信息
+//
+// 内置的探测器完全由Go实现，不依赖ffprobe等外部程序，支持MP4/MOV、WebM/MKV、WAV、MP3、AAC(ADTS)、FLAC
+// 以及PNG、JPEG、GIF、WebP格式；可通过SetProber替换为其他实现，如调用ffprobe的探测器
+package probe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// ErrUnsupportedFormat 无法识别的媒体格式
+var ErrUnsupportedFormat = errors.New("unsupported media format")
+
+// MediaInfo 媒体文件的信息
+type MediaInfo struct {
+	Format   string // 文件格式，如"mp4"、"matroska"、"wav"、"mp3"、"aac"、"flac"、"png"、"jpeg"、"gif"、"webp"
+	Duration int64  // 时长，单位为微秒，静态图片为0，GIF动图为一次播放的时长
+	Width    int    // 考虑旋转后的显示宽度，单位为像素，纯音频为0
+	Height   int    // 考虑旋转后的显示高度，单位为像素，纯音频为0
+	Rotation int    // 视频的顺时针旋转角度，取值为0、90、180或270
+	HasVideo bool   // 是否包含视频流，图片及GIF动图也视为包含视频流
+	HasAudio bool   // 是否包含音频流
+	IsImage  bool   // 是否为静态图片，GIF动图不属于静态图片
+}
+
+// Prober 媒体信息探测器
+type Prober interface {
+	Probe(path string) (*MediaInfo, error)
+}
+
+// ProberFunc 将普通函数适配为Prober
+type ProberFunc func(path string) (*MediaInfo, error)
+
+// Probe 调用函数本身
+func (f ProberFunc) Probe(path string) (*MediaInfo, error) {
+	return f(path)
+}
+
+// BuiltinProber 内置的探测器，根据文件头识别格式，为默认的探测器
+type BuiltinProber struct{}
+
+// Probe 探测指定文件的媒体信息
+func (BuiltinProber) Probe(path string) (*MediaInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := ProbeReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("probe %s: %w", path, err)
+	}
+	return info, nil
+}
+
+var (
+	prober   Prober = BuiltinProber{}
+	proberMu sync.RWMutex
+)
+
+// SetProber 设置全局的探测器并返回原来的探测器，传入nil时恢复为内置探测器
+func SetProber(p Prober) Prober {
+	if p == nil {
+		p = BuiltinProber{}
+	}
+
+	proberMu.Lock()
+	defer proberMu.Unlock()
+	previous := prober
+	prober = p
+	return previous
+}
+
+// Probe 使用全局的探测器获取媒体信息
+func Probe(path string) (*MediaInfo, error) {
+	proberMu.RLock()
+	p := prober
+	proberMu.RUnlock()
+	return p.Probe(path)
+}
+
+// ProbeReader 根据文件头识别格式并解析媒体信息
+func ProbeReader(r io.ReadSeeker) (*MediaInfo, error) {
+	header := make([]byte, 16)
+	n, err := io.ReadFull(r, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	header = header[:n]
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(header, []byte("\x89PNG")),
+		bytes.HasPrefix(header, []byte("\xff\xd8\xff")):
+		return probeImage(r)
+	case bytes.HasPrefix(header, []byte("GIF8")):
+		return probeGIF(r)
+	case len(header) >= 12 && string(header[:4]) == "RIFF" && string(header[8:12]) == "WEBP":
+		return probeWebP(r)
+	case len(header) >= 12 && string(header[:4]) == "RIFF" && string(header[8:12]) == "WAVE":
+		return probeWAV(r)
+	case bytes.HasPrefix(header, []byte("\x1a\x45\xdf\xa3")):
+		return probeMatroska(r)
+	case bytes.HasPrefix(header, []byte("fLaC")):
+		return probeFLAC(r)
+	case len(header) >= 8 && isMP4Box(string(header[4:8])):
+		return probeMP4(r)
+	case bytes.HasPrefix(header, []byte("ID3")):
+		return probeMPEGAudio(r)
+	case len(header) >= 2 && header[0] == 0xff && header[1]&0xf6 == 0xf0:
+		return probeADTS(r)
+	case len(header) >= 2 && header[0] == 0xff && header[1]&0xe0 == 0xe0:
+		return probeMPEGAudio(r)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// readFull 从r中读取n个字节
+func readFull(r io.Reader, n int) ([]byte, error) {
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// scaleToMicros 将以1/timescale秒为单位的时长转换为微秒
+func scaleToMicros(value, timescale uint64) int64 {
+	if timescale == 0 {
+		return 0
+	}
+	return int64(value/timescale*1e6 + value%timescale*1e6/timescale)
+}
@@ -0,0 +1,321 @@
+package probe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// makeMP4Box 构造MP4的box
+func makeMP4Box(boxType string, payloads ...[]byte) []byte {
+	content := bytes.Join(payloads, nil)
+	box := make([]byte, 8, 8+len(content))
+	binary.BigEndian.PutUint32(box[:4], uint32(8+len(content)))
+	copy(box[4:8], boxType)
+	return append(box, content...)
+}
+
+// makeMP4 构造包含一个视频轨道及一个音频轨道的MP4文件
+func makeMP4(timescale, duration uint32, width, height int, matrixA, matrixB int32) []byte {
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:16], timescale)
+	binary.BigEndian.PutUint32(mvhd[16:20], duration)
+
+	tkhd := make([]byte, 84)
+	binary.BigEndian.PutUint32(tkhd[20:24], duration)
+	binary.BigEndian.PutUint32(tkhd[40:44], uint32(matrixA))
+	binary.BigEndian.PutUint32(tkhd[44:48], uint32(matrixB))
+	binary.BigEndian.PutUint32(tkhd[76:80], uint32(width)<<16)
+	binary.BigEndian.PutUint32(tkhd[80:84], uint32(height)<<16)
+
+	hdlr := func(handler string) []byte {
+		data := make([]byte, 25)
+		copy(data[8:12], handler)
+		return makeMP4Box("hdlr", data)
+	}
+
+	return bytes.Join([][]byte{
+		makeMP4Box("ftyp", []byte("isom\x00\x00\x02\x00isomiso2mp41")),
+		makeMP4Box("moov",
+			makeMP4Box("mvhd", mvhd),
+			makeMP4Box("trak", makeMP4Box("tkhd", tkhd), makeMP4Box("mdia", hdlr("vide"))),
+			makeMP4Box("trak", makeMP4Box("tkhd", make([]byte, 84)), makeMP4Box("mdia", hdlr("soun"))),
+		),
+		makeMP4Box("mdat", make([]byte, 16)),
+	}, nil)
+}
+
+// makeEBML 构造EBML元素，size为-1时写入未知长度
+func makeEBML(id []byte, size int, payloads ...[]byte) []byte {
+	content := bytes.Join(payloads, nil)
+	if size >= 0 {
+		size = len(content)
+	}
+	header := append([]byte{}, id...)
+	sizeBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(sizeBytes, uint64(size)&(1<<56-1))
+	sizeBytes[0] = 0x01
+	return append(append(header, sizeBytes...), content...)
+}
+
+// ebmlUint 构造无符号整数元素的内容
+func ebmlUint(v uint64) []byte {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, v)
+	return data
+}
+
+// makeWAV 构造8000Hz、单声道、8位的WAV文件
+func makeWAV(samples int) []byte {
+	format := make([]byte, 16)
+	binary.LittleEndian.PutUint16(format[0:2], 1)
+	binary.LittleEndian.PutUint16(format[2:4], 1)
+	binary.LittleEndian.PutUint32(format[4:8], 8000)
+	binary.LittleEndian.PutUint32(format[8:12], 8000)
+	binary.LittleEndian.PutUint16(format[12:14], 1)
+	binary.LittleEndian.PutUint16(format[14:16], 8)
+
+	chunk := func(id string, data []byte) []byte {
+		header := make([]byte, 8)
+		copy(header, id)
+		binary.LittleEndian.PutUint32(header[4:8], uint32(len(data)))
+		if len(data)%2 == 1 {
+			data = append(data, 0)
+		}
+		return append(header, data...)
+	}
+	body := bytes.Join([][]byte{
+		[]byte("WAVE"),
+		chunk("fmt ", format),
+		chunk("LIST", []byte("odd")),
+		chunk("data", make([]byte, samples)),
+	}, nil)
+
+	header := []byte("RIFF\x00\x00\x00\x00")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(body)))
+	return append(header, body...)
+}
+
+// makeMP3Frame 构造MPEG1 Layer3、128kbps、44100Hz的立体声帧
+func makeMP3Frame() []byte {
+	frame := make([]byte, 417)
+	copy(frame, []byte{0xff, 0xfb, 0x90, 0x00})
+	return frame
+}
+
+// makeADTSFrame 构造44100Hz的AAC LC帧
+func makeADTSFrame(length int) []byte {
+	frame := make([]byte, length)
+	frame[0] = 0xff
+	frame[1] = 0xf1
+	frame[2] = 0x50
+	frame[3] = 0x80 | byte(length>>11)&0x03
+	frame[4] = byte(length >> 3)
+	frame[5] = byte(length&0x07)<<5 | 0x1f
+	frame[6] = 0xfc
+	return frame
+}
+
+// makeWebP 构造包含指定块的WebP文件
+func makeWebP(chunkID string, data []byte) []byte {
+	chunk := make([]byte, 8)
+	copy(chunk, chunkID)
+	binary.LittleEndian.PutUint32(chunk[4:8], uint32(len(data)))
+	body := append(append([]byte("WEBP"), chunk...), data...)
+
+	header := []byte("RIFF\x00\x00\x00\x00")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(body)))
+	return append(header, body...)
+}
+
+// TestProbeReader 测试各格式的媒体信息
+func TestProbeReader(t *testing.T) {
+	var pngData, jpegData, gifData, animatedData bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	if err := png.Encode(&pngData, img); err != nil {
+		t.Fatalf("Failed to encode png: %v", err)
+	}
+	if err := jpeg.Encode(&jpegData, img, nil); err != nil {
+		t.Fatalf("Failed to encode jpeg: %v", err)
+	}
+	if err := gif.Encode(&gifData, img, nil); err != nil {
+		t.Fatalf("Failed to encode gif: %v", err)
+	}
+	frame := image.NewPaletted(image.Rect(0, 0, 32, 16), color.Palette{color.Black, color.White})
+	animated := &gif.GIF{
+		Image: []*image.Paletted{frame, frame, frame},
+		Delay: []int{50, 0, 25},
+	}
+	if err := gif.EncodeAll(&animatedData, animated); err != nil {
+		t.Fatalf("Failed to encode animated gif: %v", err)
+	}
+
+	mkv := bytes.Join([][]byte{
+		makeEBML([]byte{0x1a, 0x45, 0xdf, 0xa3}, 0, makeEBML([]byte{0x42, 0x82}, 0, []byte("webm"))),
+		makeEBML([]byte{0x18, 0x53, 0x80, 0x67}, -1,
+			makeEBML([]byte{0x15, 0x49, 0xa9, 0x66}, 0,
+				makeEBML([]byte{0x2a, 0xd7, 0xb1}, 0, ebmlUint(1000000)),
+				makeEBML([]byte{0x44, 0x89}, 0, ebmlUint(math.Float64bits(2500)))),
+			makeEBML([]byte{0x16, 0x54, 0xae, 0x6b}, 0,
+				makeEBML([]byte{0xae}, 0, makeEBML([]byte{0x83}, 0, []byte{2})),
+				makeEBML([]byte{0xae}, 0,
+					makeEBML([]byte{0x83}, 0, []byte{1}),
+					makeEBML([]byte{0xe0}, 0,
+						makeEBML([]byte{0xb0}, 0, []byte{0x05, 0x00}),
+						makeEBML([]byte{0xba}, 0, []byte{0x02, 0xd0})))),
+			makeEBML([]byte{0x1f, 0x43, 0xb6, 0x75}, -1, make([]byte, 32))),
+	}, nil)
+
+	flac := make([]byte, 4+4+34)
+	copy(flac, "fLaC")
+	flac[4] = 0x80
+	flac[7] = 34
+	binary.BigEndian.PutUint64(flac[18:26], 44100<<44|1<<41|15<<36|110250)
+
+	id3 := []byte("ID3\x03\x00\x00\x00\x00\x00\x14")
+	cbr := append(append([]byte{}, id3...), make([]byte, 20)...)
+	for i := 0; i < 100; i++ {
+		cbr = append(cbr, makeMP3Frame()...)
+	}
+	cbr = append(cbr, append([]byte("TAG"), make([]byte, 125)...)...)
+
+	xing := makeMP3Frame()
+	copy(xing[36:], "Xing\x00\x00\x00\x01\x00\x00\x03\xe8")
+	vbr := append(xing, makeMP3Frame()...)
+
+	var adts []byte
+	for i := 0; i < 43; i++ {
+		adts = append(adts, makeADTSFrame(100)...)
+	}
+
+	vp8 := make([]byte, 10)
+	copy(vp8[3:6], []byte{0x9d, 0x01, 0x2a})
+	binary.LittleEndian.PutUint16(vp8[6:8], 320)
+	binary.LittleEndian.PutUint16(vp8[8:10], 240)
+	vp8l := make([]byte, 10)
+	vp8l[0] = 0x2f
+	binary.LittleEndian.PutUint32(vp8l[1:5], 99|199<<14)
+	vp8x := []byte{0x10, 0, 0, 0, 0x7f, 0x07, 0, 0x37, 0x04, 0}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected MediaInfo
+	}{
+		{"mp4", makeMP4(1000, 5000, 1920, 1080, 0x10000, 0),
+			MediaInfo{Format: "mp4", Duration: 5000000, Width: 1920, Height: 1080, HasVideo: true, HasAudio: true}},
+		{"mp4 rotated", makeMP4(600, 900, 1920, 1080, 0, 0x10000),
+			MediaInfo{Format: "mp4", Duration: 1500000, Width: 1080, Height: 1920, Rotation: 90, HasVideo: true, HasAudio: true}},
+		{"mp4 rotated 270", makeMP4(600, 900, 1280, 720, 0, -0x10000),
+			MediaInfo{Format: "mp4", Duration: 1500000, Width: 720, Height: 1280, Rotation: 270, HasVideo: true, HasAudio: true}},
+		{"matroska", mkv,
+			MediaInfo{Format: "matroska", Duration: 2500000, Width: 1280, Height: 720, HasVideo: true, HasAudio: true}},
+		{"wav", makeWAV(12001),
+			MediaInfo{Format: "wav", Duration: 1500125, HasAudio: true}},
+		{"flac", flac,
+			MediaInfo{Format: "flac", Duration: 2500000, HasAudio: true}},
+		{"mp3 cbr", cbr,
+			MediaInfo{Format: "mp3", Duration: 2606250, HasAudio: true}},
+		{"mp3 xing", vbr,
+			MediaInfo{Format: "mp3", Duration: 26122448, HasAudio: true}},
+		{"aac", adts,
+			MediaInfo{Format: "aac", Duration: 998458, HasAudio: true}},
+		{"png", pngData.Bytes(),
+			MediaInfo{Format: "png", Width: 64, Height: 48, HasVideo: true, IsImage: true}},
+		{"jpeg", jpegData.Bytes(),
+			MediaInfo{Format: "jpeg", Width: 64, Height: 48, HasVideo: true, IsImage: true}},
+		{"gif", gifData.Bytes(),
+			MediaInfo{Format: "gif", Width: 64, Height: 48, HasVideo: true, IsImage: true}},
+		{"animated gif", animatedData.Bytes(),
+			MediaInfo{Format: "gif", Duration: 850000, Width: 32, Height: 16, HasVideo: true}},
+		{"webp lossy", makeWebP("VP8 ", vp8),
+			MediaInfo{Format: "webp", Width: 320, Height: 240, HasVideo: true, IsImage: true}},
+		{"webp lossless", makeWebP("VP8L", vp8l),
+			MediaInfo{Format: "webp", Width: 100, Height: 200, HasVideo: true, IsImage: true}},
+		{"webp extended", makeWebP("VP8X", vp8x),
+			MediaInfo{Format: "webp", Width: 1920, Height: 1080, HasVideo: true, IsImage: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ProbeReader(bytes.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("Failed to probe: %v", err)
+			}
+			if *info != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, *info)
+			}
+		})
+	}
+}
+
+// TestProbeReaderErrors 测试无法识别或损坏的文件
+func TestProbeReaderErrors(t *testing.T) {
+	if _, err := ProbeReader(bytes.NewReader([]byte("plain text file"))); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Expected ErrUnsupportedFormat, got %v", err)
+	}
+	if _, err := ProbeReader(bytes.NewReader(nil)); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Expected ErrUnsupportedFormat for empty input, got %v", err)
+	}
+
+	truncated := makeMP4(1000, 5000, 1920, 1080, 0x10000, 0)[:60]
+	if _, err := ProbeReader(bytes.NewReader(truncated)); err == nil {
+		t.Error("Expected error for truncated mp4")
+	}
+	if _, err := ProbeReader(bytes.NewReader(makeMP4Box("ftyp", []byte("isom")))); err == nil {
+		t.Error("Expected error for mp4 without moov box")
+	}
+}
+
+// TestBuiltinProber 测试从文件探测媒体信息
+func TestBuiltinProber(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audio.wav")
+	if err := os.WriteFile(path, makeWAV(16000), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	info, err := Probe(path)
+	if err != nil {
+		t.Fatalf("Failed to probe: %v", err)
+	}
+	if info.Format != "wav" || info.Duration != 2000000 {
+		t.Errorf("Unexpected media info: %+v", info)
+	}
+
+	if _, err := Probe(filepath.Join(t.TempDir(), "missing.mp4")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not exist error, got %v", err)
+	}
+}
+
+// TestSetProber 测试替换全局探测器
+func TestSetProber(t *testing.T) {
+	custom := ProberFunc(func(path string) (*MediaInfo, error) {
+		return &MediaInfo{Format: "custom", Duration: 42}, nil
+	})
+	previous := SetProber(custom)
+	defer SetProber(previous)
+
+	if _, ok := previous.(BuiltinProber); !ok {
+		t.Errorf("Expected the builtin prober by default, got %T", previous)
+	}
+	info, err := Probe("/not/a/real/file.mp4")
+	if err != nil || info.Format != "custom" {
+		t.Errorf("Expected the custom prober to be used, got %+v, %v", info, err)
+	}
+
+	if replaced := SetProber(nil); replaced == nil {
+		t.Error("Expected the replaced prober to be returned")
+	}
+	if _, err := Probe("/not/a/real/file.mp4"); err == nil {
+		t.Error("Expected the builtin prober to be restored")
+	}
+}
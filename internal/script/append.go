@@ -0,0 +1,113 @@
+package script
+
+import (
+	"fmt"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/track"
+)
+
+// AppendSequence 将片段首尾相接地依次追加到名为trackName的轨道末尾
+//
+// 片段的时长按track.Track.Append计算，未设置MaterialInstance的视频、音频片段会按素材id在草稿的素材列表中查找素材，
+// 以便截取整个素材。片段所依赖的素材会自动加入素材列表。某个片段无法追加时返回错误，此前的片段保留在轨道上
+func (sf *ScriptFile) AppendSequence(trackName string, segments ...interface{}) error {
+	t, err := sf.trackByName(trackName)
+	if err != nil {
+		return err
+	}
+
+	for i, seg := range segments {
+		s, ok := seg.(segment.SegmentInterface)
+		if !ok || s == nil {
+			return fmt.Errorf("不支持的片段类型: %T", seg)
+		}
+		sf.resolveMaterialInstance(s)
+		if err := t.Append(s, 0); err != nil {
+			return fmt.Errorf("追加第%d个片段失败: %w", i+1, err)
+		}
+
+		if endTime := s.Start() + s.Duration(); endTime > sf.Duration {
+			sf.Duration = endTime
+		}
+		sf.addSegmentMaterials(s)
+	}
+	return nil
+}
+
+// AddSegmentToFreeTrack 将片段加入第一条在其时间范围内空闲的同类型轨道，返回所用轨道的名称
+//
+// 按渲染层级从低到高依次尝试已有的同类型新建轨道，均被占用时新建一条渲染层级更高的同类型轨道，
+// 名称为类型名加序号，如"video_2"。片段必须已设置目标时间范围
+func (sf *ScriptFile) AddSegmentToFreeTrack(seg interface{}) (string, error) {
+	s, ok := seg.(segment.SegmentInterface)
+	if !ok || s == nil {
+		return "", fmt.Errorf("不支持的片段类型: %T", seg)
+	}
+	if base := segmentBase(s); base == nil || base.TargetTimerange == nil {
+		return "", fmt.Errorf("片段未指定时间范围: %T", seg)
+	}
+	trackType, err := track.TrackTypeOfSegment(s)
+	if err != nil {
+		return "", err
+	}
+
+	t := sf.freeTrack(trackType, s.Start(), s.Start()+s.Duration())
+	return t.Name, sf.AddSegment(s, &t.Name)
+}
+
+// freeTrack 返回第一条在[start, end)范围内空闲的trackType类型新建轨道，均被占用时新建一条渲染层级更高的轨道
+func (sf *ScriptFile) freeTrack(trackType track.TrackType, start, end int64) *track.Track {
+	renderIndex, count := -1, 0
+	for _, t := range sf.sortedTracks() {
+		if t.TrackType != trackType || sf.Tracks[t.Name] != t {
+			continue
+		}
+		if t.IsFree(start, end) {
+			return t
+		}
+		renderIndex, count = max(renderIndex, t.RenderIndex), count+1
+	}
+
+	name := trackType.String()
+	if count > 0 {
+		for n := count + 1; ; n++ {
+			name = fmt.Sprintf("%s_%d", trackType, n)
+			if _, exists := sf.Tracks[name]; !exists {
+				break
+			}
+		}
+	}
+	var options []TrackOption
+	if renderIndex >= 0 {
+		options = append(options, WithAbsoluteIndex(renderIndex+1))
+	}
+	sf.AddTrack(trackType, &name, options...)
+	return sf.Tracks[name]
+}
+
+// resolveMaterialInstance 为未设置素材实例的视频、音频片段查找草稿中对应id的素材
+func (sf *ScriptFile) resolveMaterialInstance(s segment.SegmentInterface) {
+	switch v := s.(type) {
+	case *segment.VideoSegment:
+		if v.MaterialInstance != nil {
+			return
+		}
+		for _, mat := range sf.Materials.Videos {
+			if mat.MaterialID == v.MaterialID {
+				v.MaterialInstance = mat
+				return
+			}
+		}
+	case *segment.AudioSegment:
+		if v.MaterialInstance != nil {
+			return
+		}
+		for _, mat := range sf.Materials.Audios {
+			if mat.MaterialID == v.MaterialID {
+				v.MaterialInstance = mat
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package script
+
+import (
+	"fmt"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/track"
+)
+
+// SplitAt 将名为trackName的轨道上覆盖时间点at的片段从at处分为两部分，返回新建的后一部分
+//
+// 与track.Track.SplitAt相同，此外后一部分新建的变速、动画、淡入淡出、文本素材及复制的蒙版、特效、滤镜等会加入草稿的素材列表
+func (sf *ScriptFile) SplitAt(trackName string, at int64) (segment.SegmentInterface, error) {
+	t, err := sf.trackByName(trackName)
+	if err != nil {
+		return nil, err
+	}
+	right, err := t.SplitAt(at)
+	if err != nil {
+		return nil, err
+	}
+	sf.addSegmentMaterials(right)
+	return right, nil
+}
+
+// trackByName 返回名为trackName的新建轨道
+func (sf *ScriptFile) trackByName(trackName string) (*track.Track, error) {
+	t, ok := sf.Tracks[trackName]
+	if !ok {
+		return nil, fmt.Errorf("不存在名为 %s 的轨道", trackName)
+	}
+	return t, nil
+}
@@ -0,0 +1,76 @@
+package script
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/segment"
+)
+
+// FitMode 素材放入画布的方式
+type FitMode int
+
+const (
+	FitModeFit     FitMode = iota // 等比缩放至完整显示在画布内，画布可能留有空白
+	FitModeFill                   // 将素材裁剪为画布的宽高比后铺满画布
+	FitModeStretch                // 不保持宽高比，将素材拉伸至铺满画布
+)
+
+// String 返回放入方式的字符串表示
+func (m FitMode) String() string {
+	switch m {
+	case FitModeFit:
+		return "fit"
+	case FitModeFill:
+		return "fill"
+	case FitModeStretch:
+		return "stretch"
+	default:
+		return "unknown"
+	}
+}
+
+// FitMaterial 根据素材的宽高及草稿的画布尺寸，计算素材以mode方式放入画布所需的裁剪设置和图像调节设置
+//
+// 剪映在缩放比例为1时会将裁剪后的素材等比缩放至恰好完整显示在画布内，因此FitModeFit保持原有的裁剪设置，缩放比例为1；
+// FitModeFill在原有的裁剪区域中选取与画布宽高比相同的最大区域，anchor指定该区域的位置；
+// FitModeStretch保持原有的裁剪设置，并分别计算水平及垂直的缩放比例。
+// 素材本身不会被修改，返回的裁剪设置应赋给素材的CropSettings，图像调节设置用于创建使用该素材的片段
+func (sf *ScriptFile) FitMaterial(mat *material.VideoMaterial, mode FitMode, anchor material.CropAnchor) (*material.CropSettings, *segment.ClipSettings, error) {
+	if mat == nil {
+		return nil, nil, fmt.Errorf("素材不能为空")
+	}
+	if sf.Width <= 0 || sf.Height <= 0 {
+		return nil, nil, fmt.Errorf("无效的画布尺寸: %dx%d", sf.Width, sf.Height)
+	}
+	if mat.Width <= 0 || mat.Height <= 0 {
+		return nil, nil, fmt.Errorf("素材 %s 的尺寸未知", mat.MaterialName)
+	}
+
+	crop := material.NewCropSettings()
+	if mat.CropSettings != nil {
+		*crop = *mat.CropSettings
+	}
+	clip := segment.NewClipSettings()
+	canvasW, canvasH := float64(sf.Width), float64(sf.Height)
+
+	switch mode {
+	case FitModeFit:
+	case FitModeFill:
+		filled, err := crop.WithinAspectRatio(mat.Width, mat.Height, canvasW/canvasH, anchor)
+		if err != nil {
+			return nil, nil, err
+		}
+		crop = filled
+	case FitModeStretch:
+		_, _, w, h := crop.Rect()
+		croppedW, croppedH := w*float64(mat.Width), h*float64(mat.Height)
+		fitScale := math.Min(canvasW/croppedW, canvasH/croppedH)
+		clip.ScaleX = canvasW / (croppedW * fitScale)
+		clip.ScaleY = canvasH / (croppedH * fitScale)
+	default:
+		return nil, nil, fmt.Errorf("不支持的放入方式: %d", mode)
+	}
+	return crop, clip, nil
+}
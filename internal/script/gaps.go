@@ -0,0 +1,213 @@
+package script
+
+import (
+	"fmt"
+
+	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/track"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+// TrackGap 轨道上的一个空隙
+type TrackGap struct {
+	TrackName string           // 空隙所在的轨道名称
+	Timerange *types.Timerange // 空隙的时间范围
+}
+
+// GapConfig 检测及填补空隙的配置
+type GapConfig struct {
+	MinDuration int64                   // 只处理时长不小于此值的空隙，单位为微秒
+	Tracks      []string                // 处理的轨道名称，可包括导入的轨道，为空时为全部新建的视频轨道
+	Ripple      []RippleOption          // track.GapClose删除空隙时使用的波纹编辑选项，可使其他轨道一同推移
+	Placeholder *material.VideoMaterial // 占位片段使用的素材，通常为图片素材
+	FillType    string                  // 占位片段的背景填充类型，"canvas_color"或"canvas_blur"
+	Blur        float64                 // 模糊背景的模糊程度
+	Color       string                  // 纯色背景的颜色
+}
+
+// GapOption 检测及填补空隙的选项函数类型
+type GapOption func(*GapConfig)
+
+// WithMinGap 忽略时长小于minDuration(微秒)的空隙
+func WithMinGap(minDuration int64) GapOption {
+	return func(c *GapConfig) {
+		c.MinDuration = minDuration
+	}
+}
+
+// WithGapTracks 只处理指定名称的轨道
+func WithGapTracks(names ...string) GapOption {
+	return func(c *GapConfig) {
+		c.Tracks = append(c.Tracks, names...)
+	}
+}
+
+// WithGapRipple 删除空隙时按options对空隙所在轨道及其他轨道一同进行波纹编辑，
+// 例如WithGapRipple(WithRippleTracks("audio"))使音频轨道与视频轨道保持同步
+func WithGapRipple(options ...RippleOption) GapOption {
+	return func(c *GapConfig) {
+		c.Ripple = append(c.Ripple, options...)
+	}
+}
+
+// WithPlaceholderColor 使用mat作为占位片段的素材，并以纯色color(如"#000000")填充背景
+func WithPlaceholderColor(mat *material.VideoMaterial, color string) GapOption {
+	return func(c *GapConfig) {
+		c.Placeholder, c.FillType, c.Color = mat, "canvas_color", color
+	}
+}
+
+// WithPlaceholderBlur 使用mat作为占位片段的素材，并以模糊程度为blur的模糊画面填充背景，
+// blur取0.0625、0.375、0.75或1.0，分别对应剪映中的四档模糊
+func WithPlaceholderBlur(mat *material.VideoMaterial, blur float64) GapOption {
+	return func(c *GapConfig) {
+		c.Placeholder, c.FillType, c.Blur = mat, "canvas_blur", blur
+	}
+}
+
+// Gaps 返回所选轨道上的空隙，按轨道的导出顺序及时间顺序排列
+//
+// 空隙相对于草稿时长计算，轨道结束时间到草稿结束之间的部分也视为空隙，没有片段的轨道整条都是空隙
+func (sf *ScriptFile) Gaps(options ...GapOption) ([]*TrackGap, error) {
+	config := newGapConfig(options)
+	tracks, err := sf.gapTracks(config.Tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	var gaps []*TrackGap
+	for _, t := range tracks {
+		for _, gap := range sf.trackGaps(t, config.MinDuration) {
+			gaps = append(gaps, &TrackGap{TrackName: t.Name, Timerange: gap})
+		}
+	}
+	return gaps, nil
+}
+
+// FillGaps 按strategy填补所选轨道上的空隙，空隙的范围与Gaps相同
+//
+// track.GapClose通过RippleDelete删除轨道内部的空隙并向前推移之后的片段，轨道末尾的空隙不作处理，
+// 默认只推移空隙所在的轨道，通过WithGapRipple可使其他轨道一同推移；
+// track.GapExtend延长相邻的片段，轨道末尾的空隙由最后一个片段延长覆盖，导入的轨道上的片段无法延长，选中导入的轨道时返回错误；
+// track.GapPlaceholder在每个空隙处插入一个占位视频片段，需要通过WithPlaceholderColor或WithPlaceholderBlur提供素材。
+// 某个空隙无法填补时返回错误，此前已填补的空隙保持不变
+func (sf *ScriptFile) FillGaps(strategy track.GapStrategy, options ...GapOption) error {
+	config := newGapConfig(options)
+	tracks, err := sf.gapTracks(config.Tracks)
+	if err != nil {
+		return err
+	}
+	if strategy == track.GapPlaceholder && config.Placeholder == nil {
+		return fmt.Errorf("插入占位片段需要指定占位素材")
+	}
+	if strategy == track.GapExtend {
+		for _, t := range tracks {
+			if t.RawData != nil {
+				return fmt.Errorf("无法延长导入的轨道 %s 上的片段", t.Name)
+			}
+		}
+	}
+
+	for _, t := range tracks {
+		switch strategy {
+		case track.GapClose:
+			// 从后往前删除，避免推移影响尚未处理的空隙的位置
+			gaps := t.Gaps(config.MinDuration)
+			options := append([]RippleOption{withRippleTrack(t)}, config.Ripple...)
+			for i := len(gaps) - 1; i >= 0; i-- {
+				if err := sf.RippleDelete(gaps[i].Start, gaps[i].End(), options...); err != nil {
+					return err
+				}
+			}
+
+		case track.GapExtend:
+			end := t.EndTime()
+			if err := t.FillGaps(config.MinDuration, strategy); err != nil {
+				return err
+			}
+			if trailing := sf.Duration - end; len(t.Segments) > 0 && trailing > 0 && trailing >= config.MinDuration {
+				base := segmentBase(lastSegment(t))
+				if base == nil {
+					return fmt.Errorf("无法延长轨道 %s 上的最后一个片段", t.Name)
+				}
+				if err := t.TrimEnd(base.SegmentID, sf.Duration); err != nil {
+					return err
+				}
+			}
+
+		case track.GapPlaceholder:
+			for _, gap := range sf.trackGaps(t, config.MinDuration) {
+				if err := sf.addPlaceholder(t, gap, config); err != nil {
+					return err
+				}
+			}
+
+		default:
+			return fmt.Errorf("未知的空隙填补方式: %s", strategy)
+		}
+	}
+	return nil
+}
+
+// addPlaceholder 在轨道t的空隙gap处插入占位片段
+func (sf *ScriptFile) addPlaceholder(t *track.Track, gap *types.Timerange, config *GapConfig) error {
+	mat := config.Placeholder
+	if mat.MaterialType != material.MaterialTypePhoto && mat.Duration < gap.Duration {
+		return fmt.Errorf("占位素材 %s 的时长不足以填补轨道 %s 上的空隙 [%d, %d)", mat.MaterialName, t.Name, gap.Start, gap.End())
+	}
+
+	placeholder := segment.NewVideoSegment(mat.MaterialID, types.NewTimerange(0, gap.Duration),
+		types.NewTimerange(gap.Start, gap.Duration), 1.0, 1.0, nil)
+	placeholder.MaterialInstance = mat
+	placeholder.SetBackgroundFilling(config.FillType, config.Blur, config.Color)
+	if err := t.AddSegment(placeholder); err != nil {
+		return err
+	}
+	sf.addSegmentMaterials(placeholder)
+	return nil
+}
+
+// trackGaps 返回轨道上相对于草稿时长的空隙
+func (sf *ScriptFile) trackGaps(t *track.Track, minDuration int64) []*types.Timerange {
+	gaps := t.Gaps(minDuration)
+	end := t.EndTime()
+	if trailing := sf.Duration - end; trailing > 0 && trailing >= minDuration {
+		gaps = append(gaps, types.NewTimerange(end, trailing))
+	}
+	return gaps
+}
+
+// gapTracks 按名称查找检测空隙的轨道，names为空时返回全部新建的视频轨道
+func (sf *ScriptFile) gapTracks(names []string) ([]*track.Track, error) {
+	if len(names) > 0 {
+		return sf.rippleTracks(names, nil)
+	}
+	var tracks []*track.Track
+	for _, t := range sf.sortedTracks() {
+		if t.TrackType == track.TrackTypeVideo && t.RawData == nil {
+			tracks = append(tracks, t)
+		}
+	}
+	return tracks, nil
+}
+
+// newGapConfig 应用选项并返回空隙配置，默认以黑色填充占位片段的背景
+func newGapConfig(options []GapOption) *GapConfig {
+	config := &GapConfig{FillType: "canvas_color", Color: "#000000"}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// lastSegment 返回轨道上结束时间最晚的片段
+func lastSegment(t *track.Track) segment.SegmentInterface {
+	var last segment.SegmentInterface
+	for _, seg := range t.Segments {
+		if last == nil || seg.Start()+seg.Duration() > last.Start()+last.Duration() {
+			last = seg
+		}
+	}
+	return last
+}
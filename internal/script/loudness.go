@@ -0,0 +1,140 @@
+package script
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/zhangshican/go-capcut/internal/loudness"
+	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/segment"
+)
+
+// LoudnessConfig 统一响度的配置
+type LoudnessConfig struct {
+	PeakCeiling float64 // 调整后采样峰值的上限，单位为dBFS，为正无穷时不限制
+	MaxGain     float64 // 允许的最大增益，单位为dB
+}
+
+// LoudnessOption 统一响度的选项函数类型
+type LoudnessOption func(*LoudnessConfig)
+
+// WithPeakCeiling 限制调整后的采样峰值不超过ceiling(dBFS)，必要时片段达不到目标响度
+func WithPeakCeiling(ceiling float64) LoudnessOption {
+	return func(c *LoudnessConfig) {
+		c.PeakCeiling = ceiling
+	}
+}
+
+// WithMaxGain 限制最大增益为gain(dB)，默认为20dB，即剪映允许的最大音量
+func WithMaxGain(gain float64) LoudnessOption {
+	return func(c *LoudnessConfig) {
+		c.MaxGain = gain
+	}
+}
+
+// LoudnessEntry 一个片段的响度统一结果
+type LoudnessEntry struct {
+	SegmentID  string  // 片段id
+	MaterialID string  // 片段使用的素材id
+	Loudness   float64 // 素材的积分响度，单位为LUFS，未测量时为0
+	Volume     float64 // 调整后的音量，仅对已调整的片段有效
+	Reason     string  // 未调整的原因，仅对跳过的片段有效
+}
+
+// LoudnessReport 统一响度的报告，各列表按轨道的导出顺序及片段在轨道中的顺序排列
+type LoudnessReport struct {
+	Adjusted []*LoudnessEntry // 已调整音量的片段
+	Skipped  []*LoudnessEntry // 无法获取响度而跳过的片段
+}
+
+// NormalizeLoudness 调整新建轨道上音频片段及视频片段的音量，使各片段的积分响度达到target(LUFS)
+//
+// 片段的响度取自素材的Loudness，尚未测量的本地音频素材会先调用AnalyzeLoudness测量；
+// 视频素材需要预先设置Loudness，否则跳过。片段原有的音量被替换为达到目标响度所需的音量，
+// 并在草稿中记录剪映的响度统一设置。静音或无法测量的素材、不含音轨的视频素材不作调整
+func (sf *ScriptFile) NormalizeLoudness(target float64, options ...LoudnessOption) (*LoudnessReport, error) {
+	if math.IsNaN(target) || math.IsInf(target, 0) || target > 0 {
+		return nil, fmt.Errorf("无效的目标响度: %g LUFS", target)
+	}
+	config := &LoudnessConfig{PeakCeiling: math.Inf(1), MaxGain: 20}
+	for _, option := range options {
+		option(config)
+	}
+
+	audios := make(map[string]*material.AudioMaterial, len(sf.Materials.Audios))
+	for _, mat := range sf.Materials.Audios {
+		audios[mat.MaterialID] = mat
+	}
+	videos := make(map[string]*material.VideoMaterial, len(sf.Materials.Videos))
+	for _, mat := range sf.Materials.Videos {
+		videos[mat.MaterialID] = mat
+	}
+
+	report := &LoudnessReport{}
+	for _, t := range sf.sortedTracks() {
+		for _, seg := range t.Segments {
+			var media *segment.MediaSegment
+			var result *loudness.Result
+			var reason string
+			switch v := seg.(type) {
+			case *segment.AudioSegment:
+				media = v.MediaSegment
+				result, reason = audioLoudness(audios[v.MaterialID])
+			case *segment.VideoSegment:
+				media = v.MediaSegment
+				result, reason = videoLoudness(videos[v.MaterialID])
+			default:
+				continue
+			}
+
+			entry := &LoudnessEntry{SegmentID: media.SegmentID, MaterialID: media.MaterialID}
+			if result != nil {
+				entry.Loudness = result.Integrated
+				if math.IsInf(result.Integrated, -1) {
+					reason = "素材为静音"
+				}
+			}
+			if reason != "" {
+				entry.Reason = reason
+				report.Skipped = append(report.Skipped, entry)
+				continue
+			}
+
+			gain := math.Min(target-result.Integrated, config.MaxGain)
+			if headroom := config.PeakCeiling - result.Peak; headroom < gain {
+				gain = headroom
+			}
+			entry.Volume = math.Pow(10, gain/20)
+			media.SetLoudness(entry.Volume, target)
+			sf.addLoudness(media.Loudness)
+			report.Adjusted = append(report.Adjusted, entry)
+		}
+	}
+	return report, nil
+}
+
+// audioLoudness 返回音频素材的响度，尚未测量时先进行测量，无法获取时返回原因
+func audioLoudness(mat *material.AudioMaterial) (*loudness.Result, string) {
+	if mat == nil {
+		return nil, "素材不在草稿中"
+	}
+	if mat.Loudness == nil {
+		if err := mat.AnalyzeLoudness(); err != nil {
+			return nil, err.Error()
+		}
+	}
+	return mat.Loudness, ""
+}
+
+// videoLoudness 返回视频素材音轨的响度，无法获取时返回原因
+func videoLoudness(mat *material.VideoMaterial) (*loudness.Result, string) {
+	switch {
+	case mat == nil:
+		return nil, "素材不在草稿中"
+	case mat.MaterialType == material.MaterialTypePhoto || !mat.HasAudio:
+		return nil, "素材不含音轨"
+	case mat.Loudness == nil:
+		return nil, "素材的响度未测量"
+	}
+	return mat.Loudness, ""
+}
@@ -0,0 +1,75 @@
+package script
+
+import "github.com/zhangshican/go-capcut/internal/util"
+
+// RewriteMaterialPaths 依次对草稿中所有路径非空的音视频素材调用rewrite，并将素材路径改为其返回值
+//
+// 包括导入的素材和新增的素材，kind为"videos"或"audios"。rewrite返回错误时立即停止并返回该错误，
+// 此前已改写的路径保持改写后的值
+func (sf *ScriptFile) RewriteMaterialPaths(rewrite func(kind, materialID, path string) (string, error)) error {
+	for _, target := range sf.materialPaths() {
+		if *target.path == "" {
+			continue
+		}
+		newPath, err := rewrite(target.kind, target.id, *target.path)
+		if err != nil {
+			return err
+		}
+		*target.path = newPath
+	}
+	return nil
+}
+
+// materialPath 草稿中一个音视频素材的路径
+type materialPath struct {
+	id          string
+	kind        string
+	path        *string
+	fingerprint string // 新增素材的内容指纹，导入的素材为空
+}
+
+// materialPaths 返回草稿中所有音视频素材的路径，包括导入的素材和新增的素材
+func (sf *ScriptFile) materialPaths() []materialPath {
+	var paths []materialPath
+	imported := sf.importedMaterials()
+	for _, v := range imported.Videos {
+		paths = append(paths, materialPath{v.ID, "videos", &v.Path, ""})
+	}
+	for _, a := range imported.Audios {
+		paths = append(paths, materialPath{a.ID, "audios", &a.Path, ""})
+	}
+	for _, v := range sf.Materials.Videos {
+		paths = append(paths, materialPath{v.MaterialID, "videos", &v.Path, v.Fingerprint})
+	}
+	for _, a := range sf.Materials.Audios {
+		paths = append(paths, materialPath{a.MaterialID, "audios", &a.Path, a.Fingerprint})
+	}
+	return paths
+}
+
+// WithPathMapping 导出时按规则改写音视频素材的路径，草稿中的素材路径本身保持不变
+//
+// 用于在一个系统上生成供另一个系统上的剪映打开的草稿，如将"/srv/media"映射为`D:\media`，
+// 或使用DraftRelativeRule的规则将草稿文件夹中的素材改写为草稿相对路径。可多次使用，规则会累加
+func WithPathMapping(rules ...util.PathRule) DumpOption {
+	return func(c *DumpConfig) {
+		c.PathRules = append(c.PathRules, rules...)
+	}
+}
+
+// mapExportedPaths 按映射器改写导出内容中音视频素材的路径
+func mapExportedPaths(output map[string]interface{}, mapper *util.PathMapper) {
+	materials, _ := output["materials"].(map[string]interface{})
+	for _, kind := range []string{"videos", "audios"} {
+		items, _ := materials[kind].([]interface{})
+		for _, item := range items {
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if path, ok := obj["path"].(string); ok && path != "" {
+				obj["path"] = mapper.Map(path)
+			}
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package script
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/util"
+)
+
+// RelinkConfig 重新链接素材的配置
+type RelinkConfig struct {
+	HashCheck bool // 同名的候选文件是否还需要内容指纹相同才视为同一文件，默认只比较文件大小；素材记录了内容指纹时还需与其相同
+	DryRun    bool // 只生成报告，不修改素材路径
+}
+
+// RelinkOption 重新链接素材的选项函数类型
+type RelinkOption func(*RelinkConfig)
+
+// WithHashCheck 比较同名候选文件的内容指纹，而不只是文件大小，素材记录了内容指纹时只接受指纹相同的候选文件
+func WithHashCheck() RelinkOption {
+	return func(c *RelinkConfig) {
+		c.HashCheck = true
+	}
+}
+
+// WithDryRun 只生成报告，不修改素材路径
+func WithDryRun() RelinkOption {
+	return func(c *RelinkConfig) {
+		c.DryRun = true
+	}
+}
+
+// RelinkEntry 一个素材的重新链接结果
+type RelinkEntry struct {
+	MaterialID string   // 素材id
+	Kind       string   // 素材类别，"videos"或"audios"
+	OldPath    string   // 草稿中记录的原路径
+	NewPath    string   // 重新链接后的路径，仅对成功链接的素材有效
+	Candidates []string // 无法确定时的所有候选文件，或因内容指纹不符而未被接受的同名文件
+}
+
+// RelinkReport 重新链接素材的报告，各列表按素材在草稿中的顺序排列
+type RelinkReport struct {
+	Relinked  []*RelinkEntry // 已找到并重新链接的素材
+	Ambiguous []*RelinkEntry // 存在多个内容不同的同名文件，无法确定的素材
+	Missing   []*RelinkEntry // 未找到同名文件，或同名文件的内容指纹与素材不符的素材
+	DryRun    bool           // 是否为试运行，试运行时素材路径未被修改
+}
+
+// RelinkMaterials 在searchDirs及其子目录中查找草稿中缺失的音视频素材文件，并将素材路径改为找到的文件
+//
+// 文件名相同(不区分大小写)的文件视为候选。只有一个候选，或所有候选的大小(启用WithHashCheck时为内容指纹)
+// 都相同时重新链接到第一个候选文件，否则视为无法确定。启用WithHashCheck且素材记录了内容指纹(本次新增的本地素材)时，
+// 只有指纹与之相同的候选文件会被接受，包括唯一的候选。远程素材、草稿相对路径的素材及路径仍然有效的素材不受影响
+func (sf *ScriptFile) RelinkMaterials(searchDirs []string, options ...RelinkOption) (*RelinkReport, error) {
+	config := &RelinkConfig{}
+	for _, option := range options {
+		option(config)
+	}
+
+	report := &RelinkReport{DryRun: config.DryRun}
+	var missing []materialPath
+	for _, target := range sf.materialPaths() {
+		if *target.path == "" || util.IsDraftRelativePath(*target.path) {
+			continue
+		}
+		if _, err := os.Stat(*target.path); err == nil {
+			continue
+		}
+		missing = append(missing, target)
+	}
+	if len(missing) == 0 {
+		return report, nil
+	}
+
+	index, err := indexFiles(searchDirs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同一路径及指纹只需判断一次
+	resolved := make(map[string]*relinkResult)
+	for _, target := range missing {
+		entry := &RelinkEntry{MaterialID: target.id, Kind: target.kind, OldPath: *target.path}
+		key := entry.OldPath + "\x00" + target.fingerprint
+		result, ok := resolved[key]
+		if !ok {
+			if result, err = resolveMaterial(index[strings.ToLower(baseName(entry.OldPath))], target.fingerprint, config.HashCheck); err != nil {
+				return nil, err
+			}
+			resolved[key] = result
+		}
+
+		switch {
+		case result.path != "":
+			entry.NewPath = result.path
+			report.Relinked = append(report.Relinked, entry)
+			if !config.DryRun {
+				*target.path = result.path
+			}
+		case len(result.ambiguous) > 0:
+			entry.Candidates = result.ambiguous
+			report.Ambiguous = append(report.Ambiguous, entry)
+		default:
+			entry.Candidates = result.rejected
+			report.Missing = append(report.Missing, entry)
+		}
+	}
+	return report, nil
+}
+
+// relinkResult 一个缺失路径的查找结果，path、ambiguous及rejected至多一项不为空
+type relinkResult struct {
+	path      string   // 确定的文件
+	ambiguous []string // 内容不同而无法确定的候选文件
+	rejected  []string // 内容指纹与素材不符的同名文件
+}
+
+// resolveMaterial 从同名候选文件中确定素材对应的文件
+//
+// 启用hashCheck且fingerprint不为空时只接受指纹与之相同的候选文件，否则见resolveCandidates
+func resolveMaterial(candidates []string, fingerprint string, hashCheck bool) (*relinkResult, error) {
+	if hashCheck && fingerprint != "" {
+		for _, path := range candidates {
+			candidate, err := material.Fingerprint(path)
+			if err != nil {
+				return nil, fmt.Errorf("无法计算 %s 的内容指纹: %w", path, err)
+			}
+			if candidate == fingerprint {
+				return &relinkResult{path: path}, nil
+			}
+		}
+		return &relinkResult{rejected: candidates}, nil
+	}
+
+	resolved, err := resolveCandidates(candidates, hashCheck)
+	if err != nil {
+		return nil, err
+	}
+	switch len(resolved) {
+	case 0:
+		return &relinkResult{}, nil
+	case 1:
+		return &relinkResult{path: resolved[0]}, nil
+	default:
+		return &relinkResult{ambiguous: resolved}, nil
+	}
+}
+
+// indexFiles 递归列出目录中的所有文件，按小写的文件名分组，每组按路径排序
+func indexFiles(dirs []string) (map[string][]string, error) {
+	index := make(map[string][]string)
+	for _, dir := range dirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, fmt.Errorf("无法获取绝对路径: %w", err)
+		}
+		err = filepath.WalkDir(absDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if path == absDir {
+					return err
+				}
+				return nil // 跳过无法读取的子目录
+			}
+			if d.Type().IsRegular() {
+				name := strings.ToLower(d.Name())
+				index[name] = append(index[name], path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("搜索目录 %s 失败: %w", dir, err)
+		}
+	}
+	for _, paths := range index {
+		sort.Strings(paths)
+	}
+	return index, nil
+}
+
+// resolveCandidates 判断同名候选文件是否为同一文件，是则只返回第一个候选
+func resolveCandidates(candidates []string, hashCheck bool) ([]string, error) {
+	if len(candidates) <= 1 {
+		return candidates, nil
+	}
+
+	var first string
+	for i, path := range candidates {
+		var key string
+		if hashCheck {
+			fingerprint, err := material.Fingerprint(path)
+			if err != nil {
+				return nil, fmt.Errorf("无法计算 %s 的内容指纹: %w", path, err)
+			}
+			key = fingerprint
+		} else {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, err
+			}
+			key = fmt.Sprint(info.Size())
+		}
+		if i == 0 {
+			first = key
+		} else if key != first {
+			return candidates, nil
+		}
+	}
+	return candidates[:1], nil
+}
+
+// baseName 返回路径中的文件名，同时支持Windows及类Unix系统的路径分隔符
+func baseName(path string) string {
+	if i := strings.LastIndexAny(path, `/\`); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
@@ -0,0 +1,99 @@
+package script
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/material"
+)
+
+// TestScriptFileRelinkMaterialsHashCheck 测试比较内容指纹区分大小相同的同名文件
+func TestScriptFileRelinkMaterialsHashCheck(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"a/clip.mp4": "content1", "b/clip.mp4": "content2"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("写入文件失败: %v", err)
+		}
+	}
+
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	video := &material.VideoMaterial{MaterialID: "video_1", Path: "/moved/clip.mp4"}
+	sf.AddMaterial(video)
+
+	// 两个候选大小相同，只比较大小时视为同一文件
+	report, err := sf.RelinkMaterials([]string{dir}, WithHashCheck(), WithDryRun())
+	if err != nil {
+		t.Fatalf("重新链接素材失败: %v", err)
+	}
+	if len(report.Ambiguous) != 1 || len(report.Relinked) != 0 {
+		t.Errorf("比较内容指纹时应无法确定，得到 %+v", report)
+	}
+
+	report, err = sf.RelinkMaterials([]string{dir})
+	if err != nil {
+		t.Fatalf("重新链接素材失败: %v", err)
+	}
+	expected := filepath.Join(dir, "a", "clip.mp4")
+	if len(report.Relinked) != 1 || report.Relinked[0].NewPath != expected {
+		t.Errorf("只比较大小时应链接到 %s，得到 %+v", expected, report)
+	}
+	if video.Path != expected {
+		t.Errorf("素材路径应更新为 %s，得到 %s", expected, video.Path)
+	}
+}
+
+// TestScriptFileRelinkMaterialsFingerprint 测试素材记录了内容指纹时校验唯一的同名文件
+func TestScriptFileRelinkMaterialsFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	original := filepath.Join(dir, "original", "voice.wav")
+	other := filepath.Join(dir, "search", "voice.wav")
+	for path, content := range map[string]string{original: "voice", other: "other"} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("写入文件失败: %v", err)
+		}
+	}
+	fingerprint, err := material.Fingerprint(original)
+	if err != nil {
+		t.Fatalf("计算内容指纹失败: %v", err)
+	}
+
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	audio := &material.AudioMaterial{MaterialID: "audio_1", Path: "/moved/voice.wav", Fingerprint: fingerprint}
+	sf.AddMaterial(audio)
+
+	// 唯一的同名文件内容不同，不应被链接
+	search := filepath.Join(dir, "search")
+	report, err := sf.RelinkMaterials([]string{search}, WithHashCheck())
+	if err != nil {
+		t.Fatalf("重新链接素材失败: %v", err)
+	}
+	if len(report.Relinked) != 0 || len(report.Missing) != 1 || len(report.Missing[0].Candidates) != 1 {
+		t.Errorf("内容指纹不符时应视为缺失并列出被排除的文件，得到 %+v", report)
+	}
+	if audio.Path != "/moved/voice.wav" {
+		t.Errorf("素材路径不应改变，得到 %s", audio.Path)
+	}
+
+	// 指纹相同的文件被接受，即使另有同名文件
+	report, err = sf.RelinkMaterials([]string{dir}, WithHashCheck())
+	if err != nil {
+		t.Fatalf("重新链接素材失败: %v", err)
+	}
+	if len(report.Relinked) != 1 || audio.Path != original {
+		t.Errorf("应链接到指纹相同的 %s，得到 %+v", original, report)
+	}
+}
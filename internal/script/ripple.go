@@ -0,0 +1,135 @@
+package script
+
+import (
+	"fmt"
+
+	"github.com/zhangshican/go-capcut/internal/track"
+	"github.com/zhangshican/go-capcut/internal/util"
+)
+
+// RippleConfig 波纹编辑的配置
+type RippleConfig struct {
+	Tracks []string           // 参与编辑的轨道名称，可包括导入的轨道，为空时为全部轨道
+	Policy track.RipplePolicy // 跨越编辑点的片段的处理方式，默认为track.RippleSplit
+
+	tracks []*track.Track // 直接指定的轨道，与Tracks一同参与编辑，用于按轨道对象而非名称选择
+}
+
+// RippleOption 波纹编辑的选项函数类型
+type RippleOption func(*RippleConfig)
+
+// WithRippleTracks 只对指定名称的轨道进行波纹编辑，其余轨道保持不变
+func WithRippleTracks(names ...string) RippleOption {
+	return func(c *RippleConfig) {
+		c.Tracks = append(c.Tracks, names...)
+	}
+}
+
+// withRippleTrack 直接指定参与编辑的轨道，不受同名轨道的影响
+func withRippleTrack(t *track.Track) RippleOption {
+	return func(c *RippleConfig) {
+		c.tracks = append(c.tracks, t)
+	}
+}
+
+// WithRipplePolicy 设置跨越编辑点的片段的处理方式
+func WithRipplePolicy(policy track.RipplePolicy) RippleOption {
+	return func(c *RippleConfig) {
+		c.Policy = policy
+	}
+}
+
+// RippleInsert 在所选轨道的时间点at处插入时长为duration的空白，at之后的片段整体向后推移
+//
+// 编辑前会先检查所有所选轨道，任一轨道无法完成编辑时返回错误且不修改草稿。
+// 分割产生的新片段所需的素材会加入草稿的素材列表，详见track.Track.RippleInsert
+func (sf *ScriptFile) RippleInsert(at, duration int64, options ...RippleOption) error {
+	if at < 0 || duration <= 0 {
+		return fmt.Errorf("无效的插入范围: at=%d, duration=%d", at, duration)
+	}
+	return sf.ripple(at, at, options, func(t *track.Track, policy track.RipplePolicy) error {
+		created, err := t.RippleInsert(at, duration, policy)
+		for _, seg := range created {
+			sf.addSegmentMaterials(seg)
+		}
+		return err
+	})
+}
+
+// RippleDelete 删除所选轨道上[start, end)范围内的内容，end之后的片段整体向前推移
+//
+// 编辑前会先检查所有所选轨道，任一轨道无法完成编辑时返回错误且不修改草稿。
+// 被删除片段的素材仍保留在素材列表中，详见track.Track.RippleDelete
+func (sf *ScriptFile) RippleDelete(start, end int64, options ...RippleOption) error {
+	if start < 0 || end <= start {
+		return fmt.Errorf("无效的删除范围: [%d, %d)", start, end)
+	}
+	return sf.ripple(start, end, options, func(t *track.Track, policy track.RipplePolicy) error {
+		created, err := t.RippleDelete(start, end, policy)
+		for _, seg := range created {
+			sf.addSegmentMaterials(seg)
+		}
+		return err
+	})
+}
+
+// ripple 检查所选轨道后依次对其执行edit，并更新草稿时长
+func (sf *ScriptFile) ripple(start, end int64, options []RippleOption, edit func(*track.Track, track.RipplePolicy) error) error {
+	config := &RippleConfig{Policy: track.RippleSplit}
+	for _, option := range options {
+		option(config)
+	}
+
+	tracks, err := sf.rippleTracks(config.Tracks, config.tracks)
+	if err != nil {
+		return err
+	}
+	for _, t := range tracks {
+		if err := t.CheckRipple(start, end, config.Policy); err != nil {
+			return err
+		}
+	}
+
+	defer sf.updateDuration()
+	for _, t := range tracks {
+		if err := edit(t, config.Policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rippleTracks 按名称查找参与波纹编辑的轨道并加上直接指定的轨道，两者均为空时返回全部轨道
+//
+// 名称同时对应新建轨道及导入的轨道时无法确定所选轨道，返回错误
+func (sf *ScriptFile) rippleTracks(names []string, selected []*track.Track) ([]*track.Track, error) {
+	if len(names) == 0 && len(selected) == 0 {
+		return sf.sortedTracks(), nil
+	}
+
+	tracks := make([]*track.Track, 0, len(names)+len(selected))
+	seen := make(map[*track.Track]bool, len(names)+len(selected))
+	for _, name := range names {
+		t, ok := sf.Tracks[name]
+		imported, err := sf.importedTrackByName(name)
+		switch {
+		case ok && err == nil:
+			return nil, fmt.Errorf("名称 '%s' 同时对应新建的轨道及导入的轨道，无法确定参与编辑的轨道", name)
+		case !ok && err != nil:
+			return nil, util.NewTrackNotFoundError(fmt.Sprintf("名为 '%s' 的轨道", name))
+		case !ok:
+			t = imported
+		}
+		if !seen[t] {
+			seen[t] = true
+			tracks = append(tracks, t)
+		}
+	}
+	for _, t := range selected {
+		if !seen[t] {
+			seen[t] = true
+			tracks = append(tracks, t)
+		}
+	}
+	return tracks, nil
+}
@@ -0,0 +1,246 @@
+package script
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/template"
+	"github.com/zhangshican/go-capcut/internal/track"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+// decodeJSON 以json.Number解析JSON
+func decodeJSON(t *testing.T, data []byte) interface{} {
+	t.Helper()
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		t.Fatalf("解析JSON失败: %v", err)
+	}
+	return value
+}
+
+// compactJSON 去除JSON中无意义的空白，其余内容（键的顺序、数值及字符串的写法）保持不变
+func compactJSON(t *testing.T, data []byte) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		t.Fatalf("解析JSON失败: %v", err)
+	}
+	return buf.String()
+}
+
+// diffPaths 返回两个JSON值中所有不同节点的路径
+func diffPaths(a, b interface{}, path string) []string {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			return []string{path}
+		}
+		keys := make(map[string]bool)
+		for k := range av {
+			keys[k] = true
+		}
+		for k := range bv {
+			keys[k] = true
+		}
+		var diffs []string
+		for k := range keys {
+			diffs = append(diffs, diffPaths(av[k], bv[k], path+"."+k)...)
+		}
+		sort.Strings(diffs)
+		return diffs
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return []string{path}
+		}
+		var diffs []string
+		for i := range av {
+			diffs = append(diffs, diffPaths(av[i], bv[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return diffs
+	}
+	if !reflect.DeepEqual(a, b) {
+		return []string{path}
+	}
+	return nil
+}
+
+// goldenDrafts 返回testdata中的所有草稿文件
+func goldenDrafts(t *testing.T) []string {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("未找到测试草稿: %v", err)
+	}
+	return paths
+}
+
+// dumpDraft 加载草稿并立即导出
+func dumpDraft(t *testing.T, sf *ScriptFile) string {
+	t.Helper()
+
+	output, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("导出草稿失败: %v", err)
+	}
+	return output
+}
+
+// loadGoldenDraft 加载测试草稿，返回草稿及其原始内容
+func loadGoldenDraft(t *testing.T, path string) (*ScriptFile, []byte) {
+	t.Helper()
+
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("读取测试草稿失败: %v", err)
+	}
+	sf, err := LoadTemplate(path)
+	if err != nil {
+		t.Fatalf("加载测试草稿失败: %v", err)
+	}
+	return sf, original
+}
+
+// TestDumpsRoundTrip 测试未经修改的草稿导出后与原始内容一致
+func TestDumpsRoundTrip(t *testing.T) {
+	for _, path := range goldenDrafts(t) {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			sf, original := loadGoldenDraft(t, path)
+
+			// 除缩进外，导出内容与原始草稿逐字节相同
+			output := dumpDraft(t, sf)
+			if compactJSON(t, []byte(output)) != compactJSON(t, original) {
+				diffs := diffPaths(decodeJSON(t, original), decodeJSON(t, []byte(output)), "$")
+				t.Fatalf("导出内容与原始草稿不一致，差异节点: %v", diffs)
+			}
+
+			// 多次导出结果相同
+			if again := dumpDraft(t, sf); again != output {
+				t.Error("重复导出的结果不一致")
+			}
+		})
+	}
+}
+
+// TestDumpsEditsTouchOnlyAffectedNodes 测试修改只影响对应的节点
+func TestDumpsEditsTouchOnlyAffectedNodes(t *testing.T) {
+	path := filepath.Join("testdata", "jianying_draft.json")
+
+	tests := []struct {
+		name     string
+		edit     func(t *testing.T, sf *ScriptFile)
+		expected []string
+	}{
+		{
+			name: "替换文本",
+			edit: func(t *testing.T, sf *ScriptFile) {
+				if err := sf.ReplaceText("字幕", 1, "新的字幕"); err != nil {
+					t.Fatalf("替换文本失败: %v", err)
+				}
+			},
+			expected: []string{"$.materials.texts[1].content"},
+		},
+		{
+			name: "修改片段的不透明度",
+			edit: func(t *testing.T, sf *ScriptFile) {
+				seg := sf.ImportedTracks[0].Segments[1].(*template.ImportedMediaSegment)
+				seg.ClipSettings.Alpha = 0.5
+			},
+			expected: []string{"$.tracks[0].segments[1].clip.alpha"},
+		},
+		{
+			name: "修改片段时间",
+			edit: func(t *testing.T, sf *ScriptFile) {
+				seg := sf.ImportedTracks[2].Segments[0].(*template.ImportedSegment)
+				seg.TargetTimerange.Duration = 2000000
+			},
+			expected: []string{"$.tracks[2].segments[0].target_timerange.duration"},
+		},
+		{
+			name: "静音轨道",
+			edit: func(t *testing.T, sf *ScriptFile) {
+				sf.ImportedTracks[0].Mute = true
+			},
+			expected: []string{"$.tracks[0].attribute"},
+		},
+		{
+			name: "修改草稿时长",
+			edit: func(t *testing.T, sf *ScriptFile) {
+				sf.Duration = 9000000
+			},
+			expected: []string{"$.duration"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sf, original := loadGoldenDraft(t, path)
+			tt.edit(t, sf)
+
+			diffs := diffPaths(decodeJSON(t, original), decodeJSON(t, []byte(dumpDraft(t, sf))), "$")
+			if !reflect.DeepEqual(diffs, tt.expected) {
+				t.Errorf("期望差异节点为 %v，得到 %v", tt.expected, diffs)
+			}
+		})
+	}
+}
+
+// TestDumpsAppendsNewContent 测试向加载的草稿添加内容时，原有素材和轨道的顺序保持不变
+func TestDumpsAppendsNewContent(t *testing.T) {
+	sf, original := loadGoldenDraft(t, filepath.Join("testdata", "jianying_draft.json"))
+
+	trackName := "新字幕"
+	sf.AddTrack(track.TrackTypeText, &trackName)
+	if err := sf.AddSegment(segment.NewTextSegmentSimple("新增", types.NewTimerange(0, types.SEC)), &trackName); err != nil {
+		t.Fatalf("添加片段失败: %v", err)
+	}
+
+	before := decodeJSON(t, original).(map[string]interface{})
+	after := decodeJSON(t, []byte(dumpDraft(t, sf))).(map[string]interface{})
+
+	diffs := diffPaths(before, after, "$")
+	expected := []string{"$.materials.texts", "$.tracks"}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Fatalf("期望差异节点为 %v，得到 %v", expected, diffs)
+	}
+
+	// 原有的文本素材保持在前面，新素材追加在末尾
+	beforeTexts := before["materials"].(map[string]interface{})["texts"].([]interface{})
+	afterTexts := after["materials"].(map[string]interface{})["texts"].([]interface{})
+	if len(afterTexts) != len(beforeTexts)+1 {
+		t.Fatalf("期望新增一个文本素材，得到%d个", len(afterTexts)-len(beforeTexts))
+	}
+	if diffs := diffPaths(beforeTexts, afterTexts[:len(beforeTexts)], "texts"); len(diffs) > 0 {
+		t.Errorf("原有文本素材被修改: %v", diffs)
+	}
+
+	// 原有轨道保持原有顺序
+	beforeTracks := before["tracks"].([]interface{})
+	afterTracks := after["tracks"].([]interface{})
+	var importedTracks []interface{}
+	for _, item := range afterTracks {
+		if item.(map[string]interface{})["name"] != trackName {
+			importedTracks = append(importedTracks, item)
+		}
+	}
+	if len(afterTracks) != len(beforeTracks)+1 {
+		t.Fatalf("期望新增一个轨道，得到%d个", len(afterTracks)-len(beforeTracks))
+	}
+	if diffs := diffPaths(beforeTracks, importedTracks, "tracks"); len(diffs) > 0 {
+		t.Errorf("原有轨道被修改或重新排序: %v", diffs)
+	}
+}
@@ -3,29 +3,35 @@
 package script
 
 import (
+	"bytes"
+	_ "embed"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
-	"path/filepath"
 	"sort"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/zhangshican/go-capcut/internal/animation"
+	"github.com/zhangshican/go-capcut/internal/content"
 	"github.com/zhangshican/go-capcut/internal/material"
 	"github.com/zhangshican/go-capcut/internal/segment"
 	"github.com/zhangshican/go-capcut/internal/template"
 	"github.com/zhangshican/go-capcut/internal/track"
 	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut/internal/util"
 )
 
 // ScriptMaterial 草稿文件中的素材信息部分
 // 对应Python的Script_material类
 type ScriptMaterial struct {
 	// 基础素材
-	Audios   []*material.AudioMaterial `json:"audios"`   // 音频素材列表
-	Videos   []*material.VideoMaterial `json:"videos"`   // 视频素材列表
-	Stickers []map[string]interface{}  `json:"stickers"` // 贴纸素材列表
-	Texts    []map[string]interface{}  `json:"texts"`    // 文本素材列表
+	Audios   []*material.AudioMaterial  `json:"audios"`   // 音频素材列表
+	Videos   []*material.VideoMaterial  `json:"videos"`   // 视频素材列表
+	Stickers []*segment.StickerMaterial `json:"stickers"` // 贴纸素材列表
+	Texts    []*segment.TextSegment     `json:"texts"`    // 文本素材列表，以文本片段表示，导出其文本素材
 
 	// 效果素材
 	AudioEffects []*segment.AudioEffect         `json:"audio_effects"` // 音频特效列表
@@ -35,10 +41,11 @@ type ScriptMaterial struct {
 
 	// 其他素材
 	Speeds      []*segment.Speed             `json:"speeds"`      // 变速列表
-	Masks       []map[string]interface{}     `json:"masks"`       // 蒙版列表
+	Masks       []*segment.Mask              `json:"masks"`       // 蒙版列表
 	Transitions []*segment.Transition        `json:"transitions"` // 转场效果列表
 	Filters     []interface{}                `json:"filters"`     // 滤镜/文本花字/文本气泡列表
 	Canvases    []*segment.BackgroundFilling `json:"canvases"`    // 背景填充列表
+	Loudnesses  []*segment.LoudnessAdjust    `json:"loudnesses"`  // 响度统一设置列表
 }
 
 // NewScriptMaterial 创建新的草稿素材管理器
@@ -46,17 +53,18 @@ func NewScriptMaterial() *ScriptMaterial {
 	return &ScriptMaterial{
 		Audios:       make([]*material.AudioMaterial, 0),
 		Videos:       make([]*material.VideoMaterial, 0),
-		Stickers:     make([]map[string]interface{}, 0),
-		Texts:        make([]map[string]interface{}, 0),
+		Stickers:     make([]*segment.StickerMaterial, 0),
+		Texts:        make([]*segment.TextSegment, 0),
 		AudioEffects: make([]*segment.AudioEffect, 0),
 		AudioFades:   make([]*segment.AudioFade, 0),
 		Animations:   make([]*animation.SegmentAnimations, 0),
 		VideoEffects: make([]*segment.VideoEffect, 0),
 		Speeds:       make([]*segment.Speed, 0),
-		Masks:        make([]map[string]interface{}, 0),
+		Masks:        make([]*segment.Mask, 0),
 		Transitions:  make([]*segment.Transition, 0),
 		Filters:      make([]interface{}, 0),
 		Canvases:     make([]*segment.BackgroundFilling, 0),
+		Loudnesses:   make([]*segment.LoudnessAdjust, 0),
 	}
 }
 
@@ -64,18 +72,8 @@ func NewScriptMaterial() *ScriptMaterial {
 // 对应Python的__contains__方法
 func (sm *ScriptMaterial) Contains(item interface{}) bool {
 	switch v := item.(type) {
-	case *material.VideoMaterial:
-		for _, video := range sm.Videos {
-			if video.MaterialID == v.MaterialID {
-				return true
-			}
-		}
-	case *material.AudioMaterial:
-		for _, audio := range sm.Audios {
-			if audio.MaterialID == v.MaterialID {
-				return true
-			}
-		}
+	case *material.VideoMaterial, *material.AudioMaterial:
+		return sm.Find(v) != nil
 	case *segment.AudioFade:
 		for _, fade := range sm.AudioFades {
 			if fade.FadeID == v.FadeID {
@@ -94,6 +92,12 @@ func (sm *ScriptMaterial) Contains(item interface{}) bool {
 				return true
 			}
 		}
+	case *segment.StickerMaterial:
+		for _, sticker := range sm.Stickers {
+			if sticker.MaterialID == v.MaterialID {
+				return true
+			}
+		}
 	case *segment.VideoEffect:
 		for _, effect := range sm.VideoEffects {
 			if effect.GlobalID == v.GlobalID {
@@ -112,12 +116,96 @@ func (sm *ScriptMaterial) Contains(item interface{}) bool {
 				return true
 			}
 		}
+	case *segment.TextBubble:
+		for _, filter := range sm.Filters {
+			if b, ok := filter.(*segment.TextBubble); ok && b.GlobalID == v.GlobalID {
+				return true
+			}
+		}
+	case *segment.TextEffect:
+		for _, filter := range sm.Filters {
+			if e, ok := filter.(*segment.TextEffect); ok && e.GlobalID == v.GlobalID {
+				return true
+			}
+		}
+	case *segment.Speed:
+		for _, speed := range sm.Speeds {
+			if speed.GlobalID == v.GlobalID {
+				return true
+			}
+		}
+	case *segment.LoudnessAdjust:
+		for _, loudness := range sm.Loudnesses {
+			if loudness.GlobalID == v.GlobalID {
+				return true
+			}
+		}
+	case *segment.TextSegment:
+		for _, text := range sm.Texts {
+			if text.MaterialID == v.MaterialID {
+				return true
+			}
+		}
+	case *segment.Mask:
+		for _, mask := range sm.Masks {
+			if mask.GlobalID == v.GlobalID {
+				return true
+			}
+		}
+	case *segment.BackgroundFilling:
+		for _, canvas := range sm.Canvases {
+			if canvas.GlobalID == v.GlobalID {
+				return true
+			}
+		}
 	default:
 		return false
 	}
 	return false
 }
 
+// Find 返回与item相同的已有音视频素材，不存在或item不是音视频素材时返回nil
+//
+// 两者的标识(见material.VideoMaterial.IdentityKey)相同，且素材id相同或都是本地素材且内容指纹相同时视为同一素材；
+// 内容相同但裁剪等设置不同的素材不是同一素材
+func (sm *ScriptMaterial) Find(item interface{}) interface{} {
+	switch v := item.(type) {
+	case *material.VideoMaterial:
+		for _, video := range sm.Videos {
+			if video.IdentityKey() == v.IdentityKey() && (video.MaterialID == v.MaterialID || sameFingerprint(video.Fingerprint, v.Fingerprint)) {
+				return video
+			}
+		}
+	case *material.AudioMaterial:
+		for _, audio := range sm.Audios {
+			if audio.IdentityKey() == v.IdentityKey() && (audio.MaterialID == v.MaterialID || sameFingerprint(audio.Fingerprint, v.Fingerprint)) {
+				return audio
+			}
+		}
+	}
+	return nil
+}
+
+// hasMaterialID 检查是否已有id为materialID的音视频素材
+func (sm *ScriptMaterial) hasMaterialID(materialID string) bool {
+	for _, video := range sm.Videos {
+		if video.MaterialID == materialID {
+			return true
+		}
+	}
+	for _, audio := range sm.Audios {
+		if audio.MaterialID == materialID {
+			return true
+		}
+	}
+	return false
+}
+
+// sameFingerprint 判断两个内容指纹是否相同，空指纹不与任何指纹相同
+func sameFingerprint(a, b string) bool {
+	return a != "" && a == b
+}
+
 // ExportJSON 导出素材信息为JSON格式
 // 对应Python的export_json方法
 func (sm *ScriptMaterial) ExportJSON() map[string]interface{} {
@@ -133,6 +221,12 @@ func (sm *ScriptMaterial) ExportJSON() map[string]interface{} {
 		videos[i] = video.ExportJSON()
 	}
 
+	// 导出贴纸素材
+	stickers := make([]map[string]interface{}, len(sm.Stickers))
+	for i, sticker := range sm.Stickers {
+		stickers[i] = sticker.ExportJSON()
+	}
+
 	// 导出音频特效
 	audioEffects := make([]map[string]interface{}, len(sm.AudioEffects))
 	for i, effect := range sm.AudioEffects {
@@ -183,6 +277,24 @@ func (sm *ScriptMaterial) ExportJSON() map[string]interface{} {
 		canvases[i] = canvas.ExportJSON()
 	}
 
+	// 导出文本素材
+	texts := make([]map[string]interface{}, len(sm.Texts))
+	for i, text := range sm.Texts {
+		texts[i] = text.ExportMaterial()
+	}
+
+	// 导出蒙版
+	masks := make([]map[string]interface{}, len(sm.Masks))
+	for i, mask := range sm.Masks {
+		masks[i] = mask.ExportJSON()
+	}
+
+	// 导出响度统一设置
+	loudnesses := make([]map[string]interface{}, len(sm.Loudnesses))
+	for i, loudness := range sm.Loudnesses {
+		loudnesses[i] = loudness.ExportJSON()
+	}
+
 	result := map[string]interface{}{
 		"ai_translates":          []interface{}{},
 		"audio_balances":         []interface{}{},
@@ -203,7 +315,7 @@ func (sm *ScriptMaterial) ExportJSON() map[string]interface{} {
 		"hsl":                    []interface{}{},
 		"images":                 []interface{}{},
 		"log_color_wheels":       []interface{}{},
-		"loudnesses":             []interface{}{},
+		"loudnesses":             loudnesses,
 		"manual_deformations":    []interface{}{},
 		"material_animations":    animations,
 		"material_colors":        []interface{}{},
@@ -217,10 +329,10 @@ func (sm *ScriptMaterial) ExportJSON() map[string]interface{} {
 		"smart_relights":         []interface{}{},
 		"sound_channel_mappings": []interface{}{},
 		"speeds":                 speeds,
-		"stickers":               sm.Stickers,
+		"stickers":               stickers,
 		"tail_leaders":           []interface{}{},
 		"text_templates":         []interface{}{},
-		"texts":                  sm.Texts,
+		"texts":                  texts,
 		"time_marks":             []interface{}{},
 		"transitions":            transitions,
 		"video_effects":          videoEffects,
@@ -232,7 +344,7 @@ func (sm *ScriptMaterial) ExportJSON() map[string]interface{} {
 
 	// 根据环境决定使用common_mask还是masks
 	// TODO: 需要添加环境检测，暂时使用masks
-	result["masks"] = sm.Masks
+	result["masks"] = masks
 
 	return result
 }
@@ -240,8 +352,8 @@ func (sm *ScriptMaterial) ExportJSON() map[string]interface{} {
 // ScriptFile 剪映草稿文件，大部分接口定义在此
 // 对应Python的Script_file类
 type ScriptFile struct {
-	SavePath *string                `json:"save_path,omitempty"` // 草稿文件保存路径，仅在模板模式下有效
-	Content  map[string]interface{} `json:"content"`             // 草稿文件内容
+	SavePath *string               `json:"save_path,omitempty"` // 草稿文件保存路径，仅在模板模式下有效
+	Content  *content.DraftContent `json:"content"`             // 草稿文件内容，其中的素材即为导入的素材
 
 	Width    int   `json:"width"`    // 视频的宽度，单位为像素
 	Height   int   `json:"height"`   // 视频的高度，单位为像素
@@ -251,12 +363,25 @@ type ScriptFile struct {
 	Materials *ScriptMaterial         `json:"materials"` // 草稿文件中的素材信息部分
 	Tracks    map[string]*track.Track `json:"tracks"`    // 轨道信息
 
-	ImportedMaterials map[string][]map[string]interface{} `json:"imported_materials"` // 导入的素材信息
-	ImportedTracks    []*track.Track                      `json:"imported_tracks"`    // 导入的轨道信息
+	ImportedTracks []*track.Track `json:"imported_tracks"` // 导入的轨道信息
+
+	// ImportedMaterials 打开草稿时导入的素材信息，按类别存放
+	//
+	// Deprecated: 使用Content.Materials。此字段仅为创建或加载草稿时素材的副本，
+	// 之后对素材的修改不会反映在此处，对此字段的修改也不会被导出
+	ImportedMaterials map[string][]map[string]interface{} `json:"imported_materials"`
+
+	IDGenerator util.IDGenerator  `json:"-"` // 确定性id生成器，为nil时保留对象的随机id
+	idMapping   map[string]string // 对象原有id到生成id的映射
 }
 
 const TemplateFile = "draft_content_template.json"
 
+// draftContentTemplate 新建草稿使用的模板内容，编译时嵌入，不依赖运行时的工作目录
+//
+//go:embed draft_content_template.json
+var draftContentTemplate []byte
+
 // NewScriptFile 创建一个剪映草稿
 func NewScriptFile(width, height int, fps ...int) (*ScriptFile, error) {
 	frameRate := 30
@@ -265,54 +390,29 @@ func NewScriptFile(width, height int, fps ...int) (*ScriptFile, error) {
 	}
 
 	sf := &ScriptFile{
-		SavePath:          nil,
-		Width:             width,
-		Height:            height,
-		FPS:               frameRate,
-		Duration:          0,
-		Materials:         NewScriptMaterial(),
-		Tracks:            make(map[string]*track.Track),
-		ImportedMaterials: make(map[string][]map[string]interface{}),
-		ImportedTracks:    make([]*track.Track, 0),
-	}
-
-	// 加载模板文件
-	templatePath := filepath.Join("internal", "script", TemplateFile)
-	if _, err := os.Stat(templatePath); os.IsNotExist(err) {
-		// 如果模板文件不存在，创建默认内容
-		sf.Content = sf.createDefaultContent()
-	} else {
-		file, err := os.Open(templatePath)
-		if err != nil {
-			return nil, fmt.Errorf("无法打开模板文件: %v", err)
-		}
-		defer file.Close()
+		SavePath:       nil,
+		Width:          width,
+		Height:         height,
+		FPS:            frameRate,
+		Duration:       0,
+		Materials:      NewScriptMaterial(),
+		Tracks:         make(map[string]*track.Track),
+		ImportedTracks: make([]*track.Track, 0),
+	}
 
-		decoder := json.NewDecoder(file)
-		if err := decoder.Decode(&sf.Content); err != nil {
-			return nil, fmt.Errorf("无法解析模板文件: %v", err)
-		}
+	// 加载模板内容
+	if err := json.Unmarshal(draftContentTemplate, &sf.Content); err != nil {
+		return nil, fmt.Errorf("无法解析模板文件: %v", err)
 	}
+	importedMaterials, err := materialMaps(sf.Content.Materials)
+	if err != nil {
+		return nil, fmt.Errorf("无法解析模板文件: %v", err)
+	}
+	sf.ImportedMaterials = importedMaterials
 
 	return sf, nil
 }
 
-// createDefaultContent 创建默认的草稿内容
-func (sf *ScriptFile) createDefaultContent() map[string]interface{} {
-	return map[string]interface{}{
-		"version":   "1.0.0",
-		"materials": map[string]interface{}{},
-		"tracks":    []interface{}{},
-		"fps":       sf.FPS,
-		"duration":  sf.Duration,
-		"canvas_config": map[string]interface{}{
-			"width":  sf.Width,
-			"height": sf.Height,
-			"ratio":  "original",
-		},
-	}
-}
-
 // LoadTemplate 从JSON文件加载草稿模板
 // 对应Python的load_template静态方法
 func LoadTemplate(jsonPath string) (*ScriptFile, error) {
@@ -320,81 +420,52 @@ func LoadTemplate(jsonPath string) (*ScriptFile, error) {
 		return nil, fmt.Errorf("JSON文件 '%s' 不存在", jsonPath)
 	}
 
-	file, err := os.Open(jsonPath)
+	data, err := os.ReadFile(jsonPath)
 	if err != nil {
 		return nil, fmt.Errorf("无法打开JSON文件: %v", err)
 	}
-	defer file.Close()
 
-	var content map[string]interface{}
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&content); err != nil {
+	// 未建模的字段和未修改字段的原始写法均被保留，使草稿能够无损导出
+	draftContent := &content.DraftContent{}
+	if err := json.Unmarshal(data, draftContent); err != nil {
 		return nil, fmt.Errorf("无法解析JSON文件: %v", err)
 	}
+	if draftContent.Materials == nil {
+		draftContent.Materials = &content.Materials{}
+	}
 
 	sf := &ScriptFile{
-		SavePath:          &jsonPath,
-		Content:           content,
-		Materials:         NewScriptMaterial(),
-		Tracks:            make(map[string]*track.Track),
-		ImportedMaterials: make(map[string][]map[string]interface{}),
-		ImportedTracks:    make([]*track.Track, 0),
+		SavePath:       &jsonPath,
+		Content:        draftContent,
+		FPS:            int(math.Round(draftContent.FPS)),
+		Duration:       draftContent.Duration,
+		Materials:      NewScriptMaterial(),
+		Tracks:         make(map[string]*track.Track),
+		ImportedTracks: make([]*track.Track, 0),
 	}
 
-	// 提取基本属性
-	if fps, ok := content["fps"].(float64); ok {
-		sf.FPS = int(fps)
-	}
-	if duration, ok := content["duration"].(float64); ok {
-		sf.Duration = int64(duration)
+	importedMaterials, err := materialMaps(draftContent.Materials)
+	if err != nil {
+		return nil, fmt.Errorf("无法解析JSON文件: %v", err)
 	}
+	sf.ImportedMaterials = importedMaterials
 
 	// 提取画布配置
-	if canvasConfig, ok := content["canvas_config"].(map[string]interface{}); ok {
-		if width, ok := canvasConfig["width"].(float64); ok {
-			sf.Width = int(width)
-		}
-		if height, ok := canvasConfig["height"].(float64); ok {
-			sf.Height = int(height)
-		}
-	}
-
-	// 导入素材
-	if materials, ok := content["materials"].(map[string]interface{}); ok {
-		for key, value := range materials {
-			if materialList, ok := value.([]interface{}); ok {
-				materialMaps := make([]map[string]interface{}, len(materialList))
-				for i, item := range materialList {
-					if itemMap, ok := item.(map[string]interface{}); ok {
-						materialMaps[i] = itemMap
-					}
-				}
-				sf.ImportedMaterials[key] = materialMaps
-			}
-		}
+	if canvasConfig := draftContent.CanvasConfig; canvasConfig != nil {
+		sf.Width = canvasConfig.Width
+		sf.Height = canvasConfig.Height
 	}
 
 	// 导入轨道
-	if tracks, ok := content["tracks"].([]interface{}); ok {
-		for _, trackData := range tracks {
-			if trackMap, ok := trackData.(map[string]interface{}); ok {
-				// 转换 ImportedMaterials 为正确的类型
-				materialsMap := make(map[string]interface{})
-				for key, value := range sf.ImportedMaterials {
-					interfaceSlice := make([]interface{}, len(value))
-					for i, item := range value {
-						interfaceSlice[i] = item
-					}
-					materialsMap[key] = interfaceSlice
-				}
-
-				importedTrack, err := template.ImportTrack(trackMap, materialsMap)
-				if err != nil {
-					return nil, fmt.Errorf("导入轨道失败: %v", err)
-				}
-				sf.ImportedTracks = append(sf.ImportedTracks, importedTrack)
-			}
+	for i, trackData := range draftContent.Tracks {
+		if trackData == nil {
+			return nil, fmt.Errorf("导入轨道失败: 第%d个轨道为空", i)
+		}
+		importedTrack, err := template.ImportTrackFromContent(trackData, draftContent.Materials)
+		if err != nil {
+			return nil, fmt.Errorf("导入轨道失败: %v", err)
 		}
+		sf.ImportedTracks = append(sf.ImportedTracks, importedTrack)
 	}
 
 	return sf, nil
@@ -402,21 +473,40 @@ func LoadTemplate(jsonPath string) (*ScriptFile, error) {
 
 // AddMaterial 向草稿文件中添加一个素材
 // 对应Python的add_material方法
+//
+// 草稿中已有相同的素材时不会重复添加，需要得到草稿中对应的素材实例时使用FindOrAddMaterial
 func (sf *ScriptFile) AddMaterial(mat interface{}) *ScriptFile {
-	if sf.Materials.Contains(mat) {
-		return sf // 素材已存在
+	sf.FindOrAddMaterial(mat)
+	return sf
+}
+
+// FindOrAddMaterial 向草稿文件中添加一个素材，返回草稿中对应的素材实例
+//
+// 草稿中已有相同的素材(见ScriptMaterial.Find)时不会重复添加，而是返回已有的素材，
+// 此时引用该素材的片段应使用返回素材的MaterialID；其余情况返回mat本身，
+// mat的id已被其他素材占用时会被替换为新的id
+func (sf *ScriptFile) FindOrAddMaterial(mat interface{}) interface{} {
+	if existing := sf.Materials.Find(mat); existing != nil {
+		return existing // 素材已存在
 	}
 
+	// id已被设置不同的素材占用时（如创建后修改了裁剪设置），为新素材分配新的id
 	switch material := mat.(type) {
 	case *material.VideoMaterial:
+		if sf.Materials.hasMaterialID(material.MaterialID) {
+			material.MaterialID = util.NewID()
+		}
 		sf.Materials.Videos = append(sf.Materials.Videos, material)
 	case *material.AudioMaterial:
+		if sf.Materials.hasMaterialID(material.MaterialID) {
+			material.MaterialID = util.NewID()
+		}
 		sf.Materials.Audios = append(sf.Materials.Audios, material)
 	default:
 		// TODO: 可以添加日志记录不支持的素材类型
 	}
 
-	return sf
+	return mat
 }
 
 // AddTrack 向草稿文件中添加一个指定类型、指定名称的轨道
@@ -511,8 +601,9 @@ func (sf *ScriptFile) GetTrack(segmentType string, trackName *string) (*track.Tr
 	// 寻找唯一的同类型的轨道
 	var matchingTracks []*track.Track
 	for _, t := range sf.Tracks {
-		// TODO: 需要根据segmentType匹配轨道类型，这里暂时简化处理
-		matchingTracks = append(matchingTracks, t)
+		if t.TrackType.String() == segmentType {
+			matchingTracks = append(matchingTracks, t)
+		}
 	}
 
 	if len(matchingTracks) == 0 {
@@ -546,13 +637,15 @@ func (sf *ScriptFile) GetTrackAndImportedTrack(segmentType string, trackName *st
 			return nil, fmt.Errorf("不存在名为 '%s' 的轨道", *trackName)
 		}
 	} else {
-		// TODO: 根据segmentType查找匹配的轨道类型
-		// 这里需要更复杂的类型匹配逻辑
 		for _, t := range sf.Tracks {
-			resultTracks = append(resultTracks, t)
+			if t.TrackType.String() == segmentType {
+				resultTracks = append(resultTracks, t)
+			}
 		}
 		for _, t := range sf.ImportedTracks {
-			resultTracks = append(resultTracks, t)
+			if t.TrackType.String() == segmentType {
+				resultTracks = append(resultTracks, t)
+			}
 		}
 
 		if len(resultTracks) == 0 {
@@ -566,108 +659,893 @@ func (sf *ScriptFile) GetTrackAndImportedTrack(segmentType string, trackName *st
 	return resultTracks, nil
 }
 
+// SegmentConfig 添加片段的配置
+type SegmentConfig struct {
+	OverlapPolicy track.OverlapPolicy // 与轨道上已有片段重叠时的处理方式，默认为track.OverlapReject
+}
+
+// SegmentOption 添加片段的选项函数类型
+type SegmentOption func(*SegmentConfig)
+
+// WithOverlapPolicy 设置片段与轨道上已有片段重叠时的处理方式
+//
+// track.OverlapSpill将片段放到第一条在其时间范围内空闲的同类型轨道上，均被占用时新建一条，见AddSegmentToFreeTrack
+func WithOverlapPolicy(policy track.OverlapPolicy) SegmentOption {
+	return func(c *SegmentConfig) {
+		c.OverlapPolicy = policy
+	}
+}
+
 // AddSegment 向指定轨道中添加一个片段
 // 对应Python的add_segment方法
-func (sf *ScriptFile) AddSegment(seg interface{}, trackName *string) error {
-	// TODO: 根据片段类型找到合适的轨道
-	// 这里需要实现复杂的片段类型匹配逻辑
+//
+// 未指定轨道名称时，将片段添加到唯一的同类型轨道中；片段所依赖的素材（变速、动画、特效、滤镜、转场等）会自动加入素材列表。
+// 默认与已有片段重叠时返回*util.SegmentOverlapError，可通过WithOverlapPolicy改为覆盖、插入或放到其他轨道上
+func (sf *ScriptFile) AddSegment(seg interface{}, trackName *string, options ...SegmentOption) error {
+	s, ok := seg.(segment.SegmentInterface)
+	if !ok || s == nil {
+		return fmt.Errorf("不支持的片段类型: %T", seg)
+	}
+	config := &SegmentConfig{OverlapPolicy: track.OverlapReject}
+	for _, option := range options {
+		option(config)
+	}
+
+	trackType, err := track.TrackTypeOfSegment(s)
+	if err != nil {
+		return err
+	}
+
+	targetTrack, err := sf.GetTrack(trackType.String(), trackName)
+	if err != nil {
+		return err
+	}
+	if config.OverlapPolicy == track.OverlapSpill && !targetTrack.IsFree(s.Start(), s.Start()+s.Duration()) {
+		targetTrack = sf.freeTrack(trackType, s.Start(), s.Start()+s.Duration())
+	}
+
+	created, err := targetTrack.AddSegmentWithPolicy(s, config.OverlapPolicy)
+	for _, right := range created {
+		sf.addSegmentMaterials(right)
+	}
+	if err != nil {
+		return err
+	}
+
+	// 更新草稿时长，插入片段时轨道上其他片段也可能被推移
+	if endTime := targetTrack.EndTime(); endTime > sf.Duration {
+		sf.Duration = endTime
+	}
 
-	// 简化实现：假设我们有一个通用的片段接口
-	if segment, ok := seg.(interface{ GetTargetTimerange() *types.Timerange }); ok {
-		timerange := segment.GetTargetTimerange()
-		if timerange != nil {
-			endTime := timerange.Start + timerange.Duration
-			if endTime > sf.Duration {
-				sf.Duration = endTime
+	sf.addSegmentMaterials(s)
+	return nil
+}
+
+// addSegmentMaterials 将片段所依赖的素材（变速、动画、特效、滤镜、转场等）加入素材列表
+func (sf *ScriptFile) addSegmentMaterials(s segment.SegmentInterface) {
+	switch v := s.(type) {
+	case *segment.VideoSegment:
+		sf.addSegmentAnimations(v.BaseSegment, v.MediaSegment)
+		for _, effect := range v.Effects {
+			if !sf.Materials.Contains(effect) {
+				sf.Materials.VideoEffects = append(sf.Materials.VideoEffects, effect)
+			}
+		}
+		for _, filter := range v.Filters {
+			if !sf.Materials.Contains(filter) {
+				sf.Materials.Filters = append(sf.Materials.Filters, filter)
 			}
 		}
+		if v.Mask != nil {
+			sf.addMask(v.Mask)
+			addExtraMaterialRef(v.MediaSegment, v.Mask.GlobalID)
+		}
+		if v.Transition != nil && !sf.Materials.Contains(v.Transition) {
+			sf.Materials.Transitions = append(sf.Materials.Transitions, v.Transition)
+		}
+		if v.BackgroundFilling != nil {
+			if !sf.Materials.Contains(v.BackgroundFilling) {
+				sf.Materials.Canvases = append(sf.Materials.Canvases, v.BackgroundFilling)
+			}
+			addExtraMaterialRef(v.MediaSegment, v.BackgroundFilling.GlobalID)
+		}
+		sf.addSpeed(v.Speed)
+		sf.addLoudness(v.Loudness)
+		if mat, ok := v.MaterialInstance.(*material.VideoMaterial); ok && mat != nil {
+			canonical := sf.FindOrAddMaterial(mat).(*material.VideoMaterial)
+			v.MaterialID, v.MaterialInstance = canonical.MaterialID, canonical
+		}
+
+	case *segment.AudioSegment:
+		if v.Fade != nil && !sf.Materials.Contains(v.Fade) {
+			sf.Materials.AudioFades = append(sf.Materials.AudioFades, v.Fade)
+		}
+		for _, effect := range v.Effects {
+			if !sf.Materials.Contains(effect) {
+				sf.Materials.AudioEffects = append(sf.Materials.AudioEffects, effect)
+			}
+		}
+		sf.addSpeed(v.Speed)
+		sf.addLoudness(v.Loudness)
+		if mat, ok := v.MaterialInstance.(*material.AudioMaterial); ok && mat != nil {
+			canonical := sf.FindOrAddMaterial(mat).(*material.AudioMaterial)
+			v.MaterialID, v.MaterialInstance = canonical.MaterialID, canonical
+		}
+
+	case *segment.TextSegment:
+		sf.addSegmentAnimations(v.BaseSegment, v.MediaSegment)
+		if v.Bubble != nil {
+			if !sf.Materials.Contains(v.Bubble) {
+				sf.Materials.Filters = append(sf.Materials.Filters, v.Bubble)
+			}
+			addExtraMaterialRef(v.MediaSegment, v.Bubble.GlobalID)
+		}
+		if v.Effect != nil {
+			if !sf.Materials.Contains(v.Effect) {
+				sf.Materials.Filters = append(sf.Materials.Filters, v.Effect)
+			}
+			addExtraMaterialRef(v.MediaSegment, v.Effect.GlobalID)
+		}
+		sf.addText(v)
+
+	case *segment.StickerSegment:
+		sf.addSegmentAnimations(v.BaseSegment, v.MediaSegment)
+		sf.addSpeed(v.Speed)
+		if !sf.Materials.Contains(v.Material) {
+			sf.Materials.Stickers = append(sf.Materials.Stickers, v.Material)
+		}
+
+	case *segment.EffectSegment:
+		if !sf.Materials.Contains(v.EffectInst) {
+			sf.Materials.VideoEffects = append(sf.Materials.VideoEffects, v.EffectInst)
+		}
+
+	case *segment.FilterSegment:
+		if !sf.Materials.Contains(v.Material) {
+			sf.Materials.Filters = append(sf.Materials.Filters, v.Material)
+		}
+	}
+}
+
+// addSegmentAnimations 将片段的动画加入素材列表，并在片段中建立引用
+func (sf *ScriptFile) addSegmentAnimations(base *segment.BaseSegment, media *segment.MediaSegment) {
+	if base.Animations == nil || len(base.Animations.Animations) == 0 {
+		return
+	}
+	if !sf.Materials.Contains(base.Animations) {
+		sf.Materials.Animations = append(sf.Materials.Animations, base.Animations)
+	}
+	addExtraMaterialRef(media, base.Animations.AnimationID)
+}
+
+// addSpeed 将变速对象加入素材列表
+func (sf *ScriptFile) addSpeed(speed *segment.Speed) {
+	if speed != nil && !sf.Materials.Contains(speed) {
+		sf.Materials.Speeds = append(sf.Materials.Speeds, speed)
 	}
+}
+
+// addLoudness 将响度统一设置加入素材列表
+func (sf *ScriptFile) addLoudness(loudness *segment.LoudnessAdjust) {
+	if loudness != nil && !sf.Materials.Contains(loudness) {
+		sf.Materials.Loudnesses = append(sf.Materials.Loudnesses, loudness)
+	}
+}
+
+// addText 将文本片段的文本素材加入素材列表，素材id已存在时替换原有素材
+func (sf *ScriptFile) addText(text *segment.TextSegment) {
+	for i, existing := range sf.Materials.Texts {
+		if existing.MaterialID == text.MaterialID {
+			sf.Materials.Texts[i] = text
+			return
+		}
+	}
+	sf.Materials.Texts = append(sf.Materials.Texts, text)
+}
+
+// addMask 将蒙版加入素材列表，id已存在时替换原有素材
+func (sf *ScriptFile) addMask(mask *segment.Mask) {
+	for i, existing := range sf.Materials.Masks {
+		if existing.GlobalID == mask.GlobalID {
+			sf.Materials.Masks[i] = mask
+			return
+		}
+	}
+	sf.Materials.Masks = append(sf.Materials.Masks, mask)
+}
+
+// addExtraMaterialRef 向片段的附加素材引用列表中添加id，已存在时忽略
+func addExtraMaterialRef(media *segment.MediaSegment, id string) {
+	for _, ref := range media.ExtraMaterialRefs {
+		if ref == id {
+			return
+		}
+	}
+	media.ExtraMaterialRefs = append(media.ExtraMaterialRefs, id)
+}
+
+// ReplaceMaterialByName 替换模板中指定名称的视频或音频素材，素材类型由mat决定
+// 对应Python的replace_material_by_name方法
+//
+// 被替换素材的名称、路径和时长（视频素材还有宽高）改写为新素材的值，replaceCrop为true时同时替换视频素材的裁剪设置。
+// 素材id保持不变，因此所有引用该素材的片段都会使用新素材
+func (sf *ScriptFile) ReplaceMaterialByName(materialName string, mat interface{}, replaceCrop bool) error {
+	materials := sf.importedMaterials()
+	condition := fmt.Sprintf("名为 '%s', 类型为 '%T' 的素材", materialName, mat)
+
+	switch m := mat.(type) {
+	case *material.VideoMaterial:
+		if m == nil {
+			return fmt.Errorf("素材不能为空")
+		}
+		exported := &content.VideoMaterial{}
+		if err := content.FromMap(m.ExportJSON(), exported); err != nil {
+			return fmt.Errorf("无法导出素材: %v", err)
+		}
+
+		var target *content.VideoMaterial
+		count := 0
+		for _, item := range materials.Videos {
+			if item.MaterialName == materialName {
+				target = item
+				count++
+			}
+		}
+		if err := checkMaterialCount(condition, count); err != nil {
+			return err
+		}
 
-	// TODO: 实现具体的片段添加逻辑
-	// 需要根据片段类型添加相关素材到materials中
+		// 更新素材信息
+		target.MaterialName = exported.MaterialName
+		target.Path = exported.Path
+		target.Duration = exported.Duration
+		target.Width = exported.Width
+		target.Height = exported.Height
+		if replaceCrop {
+			target.Crop = exported.Crop
+		}
+
+	case *material.AudioMaterial:
+		if m == nil {
+			return fmt.Errorf("素材不能为空")
+		}
+		exported := &content.AudioMaterial{}
+		if err := content.FromMap(m.ExportJSON(), exported); err != nil {
+			return fmt.Errorf("无法导出素材: %v", err)
+		}
+
+		var target *content.AudioMaterial
+		count := 0
+		for _, item := range materials.Audios {
+			if item.Name == materialName {
+				target = item
+				count++
+			}
+		}
+		if err := checkMaterialCount(condition, count); err != nil {
+			return err
+		}
+
+		// 更新素材信息
+		target.Name = exported.Name
+		target.Path = exported.Path
+		target.Duration = exported.Duration
+
+	default:
+		return fmt.Errorf("不支持的素材类型: %T", mat)
+	}
 
 	return nil
 }
 
-// Dumps 将草稿文件内容导出为JSON字符串
-// 对应Python的dumps方法
-func (sf *ScriptFile) Dumps() (string, error) {
-	// 更新基本信息
-	sf.Content["fps"] = sf.FPS
-	sf.Content["duration"] = sf.Duration
-	sf.Content["canvas_config"] = map[string]interface{}{
-		"width":  sf.Width,
-		"height": sf.Height,
-		"ratio":  "original",
-	}
-	sf.Content["materials"] = sf.Materials.ExportJSON()
-
-	// 设置平台信息
-	platformInfo := map[string]interface{}{
-		"app_id":       359289,
-		"app_source":   "cc",
-		"app_version":  "6.5.0",
-		"device_id":    "c4ca4238a0b923820dcc509a6f75849b",
-		"hard_disk_id": "307563e0192a94465c0e927fbc482942",
-		"mac_address":  "c3371f2d4fb02791c067ce44d8fb4ed5",
-		"os":           "mac",
-		"os_version":   "15.5",
-	}
-	sf.Content["last_modified_platform"] = platformInfo
-	sf.Content["platform"] = platformInfo
-
-	// 合并导入的素材
-	if materials, ok := sf.Content["materials"].(map[string]interface{}); ok {
-		for materialType, materialList := range sf.ImportedMaterials {
-			if existingList, exists := materials[materialType]; exists {
-				if existingSlice, ok := existingList.([]interface{}); ok {
-					for _, item := range materialList {
-						existingSlice = append(existingSlice, item)
-					}
-					materials[materialType] = existingSlice
-				}
-			} else {
-				interfaceList := make([]interface{}, len(materialList))
-				for i, item := range materialList {
-					interfaceList[i] = item
-				}
-				materials[materialType] = interfaceList
+// checkMaterialCount 检查按条件查找到的素材数量是否恰好为1
+func checkMaterialCount(condition string, count int) error {
+	if count == 0 {
+		return util.NewMaterialNotFoundError(condition)
+	}
+	if count > 1 {
+		return util.NewAmbiguousMaterialError(condition, count)
+	}
+	return nil
+}
+
+// importedMaterials 返回草稿中导入的素材
+func (sf *ScriptFile) importedMaterials() *content.Materials {
+	if sf.Content == nil || sf.Content.Materials == nil {
+		return &content.Materials{}
+	}
+	return sf.Content.Materials
+}
+
+// materialMaps 将素材表转换为按类别存放的map形式的素材列表，忽略不是对象列表的类别
+func materialMaps(materials *content.Materials) (map[string][]map[string]interface{}, error) {
+	result := make(map[string][]map[string]interface{})
+	if materials == nil {
+		return result, nil
+	}
+	generic, err := content.ToPlainMap(materials)
+	if err != nil {
+		return nil, err
+	}
+	for kind, value := range generic {
+		list, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		items := make([]map[string]interface{}, 0, len(list))
+		for _, item := range list {
+			if obj, ok := item.(map[string]interface{}); ok {
+				items = append(items, obj)
 			}
 		}
+		result[kind] = items
+	}
+	return result, nil
+}
+
+// ReplaceMaterialBySeg 替换导入的音视频轨道上第segIndex个片段的素材，并处理素材时长变化
+// 对应Python的replace_material_by_seg方法
+//
+// sourceTimerange为nil时截取整个素材，图片素材则截取与原片段等长的范围；
+// shrinkMode为空时默认为ShrinkModeCutTail，extendModes为空时默认为ExtendModeCutMaterialTail。
+// 新素材会被加入草稿的素材列表，草稿时长随之更新
+func (sf *ScriptFile) ReplaceMaterialBySeg(trackName string, segIndex int, mat interface{}, sourceTimerange *types.Timerange,
+	shrinkMode template.ShrinkMode, extendModes []template.ExtendMode) error {
+	targetTrack, err := sf.importedTrackByName(trackName)
+	if err != nil {
+		return err
+	}
+
+	segments := make([]*template.ImportedMediaSegment, 0, len(targetTrack.Segments))
+	for _, seg := range targetTrack.Segments {
+		if mediaSegment, ok := seg.(*template.ImportedMediaSegment); ok {
+			segments = append(segments, mediaSegment)
+		}
+	}
+	if len(segments) == 0 || len(segments) != len(targetTrack.Segments) {
+		return fmt.Errorf("指定的轨道 '%s' (类型为 %s) 不支持素材替换", trackName, targetTrack.TrackType)
+	}
+	if segIndex < 0 || segIndex >= len(segments) {
+		return fmt.Errorf("片段下标 %d 超出 [0, %d) 的范围", segIndex, len(segments))
+	}
+	if !template.CheckMaterialType(targetTrack.TrackType, mat) {
+		return fmt.Errorf("指定的素材类型 %T 不匹配轨道类型 %s", mat, targetTrack.TrackType)
+	}
+
+	// 草稿中已有相同的素材时改用已有的素材
+	if existing := sf.Materials.Find(mat); existing != nil {
+		mat = existing
+	}
+
+	seg := segments[segIndex]
+	var materialID string
+	var materialDuration int64
+	switch m := mat.(type) {
+	case *material.VideoMaterial:
+		materialID, materialDuration = m.MaterialID, m.Duration
+		if m.MaterialType == material.MaterialTypePhoto {
+			materialDuration = int64(math.Round(float64(seg.TargetTimerange.Duration) * seg.Speed))
+		}
+	case *material.AudioMaterial:
+		materialID, materialDuration = m.MaterialID, m.Duration
+	}
+
+	srcTimerange := sourceTimerange
+	if srcTimerange == nil {
+		srcTimerange = types.NewTimerange(0, materialDuration)
+	}
+	if shrinkMode == "" {
+		shrinkMode = template.ShrinkModeCutTail
+	}
+	if len(extendModes) == 0 {
+		extendModes = []template.ExtendMode{template.ExtendModeCutMaterialTail}
+	}
+
+	// 处理时间变化
+	if err := template.ProcessTimerange(segments, segIndex, srcTimerange, shrinkMode, extendModes); err != nil {
+		if extensionErr, ok := err.(*util.ExtensionFailedError); ok {
+			extensionErr.MaterialID = materialID
+		}
+		return err
 	}
 
-	// 对轨道排序并导出
-	var trackList []*track.Track
+	// 最后替换素材链接，添加素材时id可能被替换
+	seg.MaterialID = sf.FindOrAddMaterial(mat).(material.MaterialInterface).GetMaterialID()
+	sf.updateDuration()
+
+	return nil
+}
+
+// updateDuration 根据所有轨道的结束时间重新计算草稿时长
+func (sf *ScriptFile) updateDuration() {
+	duration := int64(0)
 	for _, t := range sf.Tracks {
-		trackList = append(trackList, t)
+		if end := t.EndTime(); end > duration {
+			duration = end
+		}
+	}
+	for _, t := range sf.ImportedTracks {
+		if end := t.EndTime(); end > duration {
+			duration = end
+		}
+	}
+	sf.Duration = duration
+}
+
+// ReplaceText 替换导入的文本轨道上第segIndex个片段的文字内容
+//
+// 改写导入的文本素材的content，保留原有的样式，
+// 各样式的应用范围按新旧文本长度等比例缩放（长度以字符数计），以尽量维持原有的样式分布
+func (sf *ScriptFile) ReplaceText(trackName string, segIndex int, text string) error {
+	textContent, styles, mat, err := sf.importedTextContent(trackName, segIndex)
+	if err != nil {
+		return err
+	}
+
+	oldLen := utf8.RuneCountInString(textOf(textContent))
+	newLen := utf8.RuneCountInString(text)
+	textContent["text"] = text
+	if styles != nil {
+		textContent["styles"] = rescaleStyleRanges(styles, oldLen, newLen)
+	}
+
+	return writeTextContent(mat, textContent)
+}
+
+// ReplaceTextRanges 按样式分段替换导入的文本轨道上第segIndex个片段的文字内容
+//
+// parts的数量必须与原文本的样式数量相同，第i段文字使用第i个样式，新文本为各段文字依次拼接的结果
+func (sf *ScriptFile) ReplaceTextRanges(trackName string, segIndex int, parts []string) error {
+	textContent, styles, mat, err := sf.importedTextContent(trackName, segIndex)
+	if err != nil {
+		return err
+	}
+	if len(parts) != len(styles) {
+		return fmt.Errorf("文本分段数量 %d 与样式数量 %d 不一致", len(parts), len(styles))
 	}
-	trackList = append(trackList, sf.ImportedTracks...)
 
-	// 按渲染层级排序
-	sort.Slice(trackList, func(i, j int) bool {
-		return trackList[i].RenderIndex < trackList[j].RenderIndex
+	// 按原有的先后顺序为各样式分配新范围
+	order := make([]int, len(styles))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return styleRangeStart(styles[order[a]]) < styleRangeStart(styles[order[b]])
 	})
 
+	newStyles := make([]interface{}, len(styles))
+	var text strings.Builder
+	position := 0
+	for _, i := range order {
+		length := utf8.RuneCountInString(parts[i])
+		text.WriteString(parts[i])
+		newStyles[i] = withStyleRange(styles[i], position, position+length)
+		position += length
+	}
+	textContent["text"] = text.String()
+	textContent["styles"] = newStyles
+
+	return writeTextContent(mat, textContent)
+}
+
+// importedTextContent 查找导入的文本片段所引用的文本素材，返回解析后的content及其样式列表
+func (sf *ScriptFile) importedTextContent(trackName string, segIndex int) (map[string]interface{}, []map[string]interface{}, *content.TextMaterial, error) {
+	targetTrack, err := sf.importedTrackByName(trackName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if targetTrack.TrackType != track.TrackTypeText {
+		return nil, nil, nil, fmt.Errorf("指定的轨道 '%s' (类型为 %s) 不是文本轨道", trackName, targetTrack.TrackType)
+	}
+	if segIndex < 0 || segIndex >= len(targetTrack.Segments) {
+		return nil, nil, nil, fmt.Errorf("片段下标 %d 超出 [0, %d) 的范围", segIndex, len(targetTrack.Segments))
+	}
+	seg, ok := targetTrack.Segments[segIndex].(*template.ImportedSegment)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("片段 %d 不是导入的文本片段", segIndex)
+	}
+
+	var mat *content.TextMaterial
+	for _, item := range sf.importedMaterials().Texts {
+		if item.ID == seg.MaterialID {
+			mat = item
+			break
+		}
+	}
+	if mat == nil {
+		return nil, nil, nil, util.NewMaterialNotFoundError(fmt.Sprintf("id为 '%s' 的文本素材", seg.MaterialID))
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(mat.Content))
+	decoder.UseNumber()
+	var textContent map[string]interface{}
+	if err := decoder.Decode(&textContent); err != nil {
+		return nil, nil, nil, fmt.Errorf("无法解析文本素材 '%s' 的content: %v", seg.MaterialID, err)
+	}
+
+	var styles []map[string]interface{}
+	if rawStyles, ok := textContent["styles"].([]interface{}); ok {
+		styles = make([]map[string]interface{}, 0, len(rawStyles))
+		for _, rawStyle := range rawStyles {
+			style, ok := rawStyle.(map[string]interface{})
+			if !ok {
+				return nil, nil, nil, fmt.Errorf("文本素材 '%s' 的样式格式无效", seg.MaterialID)
+			}
+			styles = append(styles, style)
+		}
+	}
+
+	return textContent, styles, mat, nil
+}
+
+// importedTrackByName 按名称查找导入的轨道
+func (sf *ScriptFile) importedTrackByName(trackName string) (*track.Track, error) {
+	for _, t := range sf.ImportedTracks {
+		if t.Name == trackName {
+			return t, nil
+		}
+	}
+	return nil, util.NewTrackNotFoundError(fmt.Sprintf("名为 '%s' 的导入轨道", trackName))
+}
+
+// textOf 返回content中的文本
+func textOf(textContent map[string]interface{}) string {
+	text, _ := textContent["text"].(string)
+	return text
+}
+
+// rescaleStyleRanges 将样式范围从旧文本长度等比例缩放到新文本长度
+//
+// 缩放后为空的样式被移除，但至少保留一个样式；最后一个样式总是延伸到新文本末尾
+func rescaleStyleRanges(styles []map[string]interface{}, oldLen, newLen int) []interface{} {
+	scale := func(position int) int {
+		if oldLen == 0 {
+			return newLen
+		}
+		return int(math.Round(float64(position) * float64(newLen) / float64(oldLen)))
+	}
+
+	result := make([]interface{}, 0, len(styles))
+	lastIndex := -1
+	lastEnd := -1
+	for _, style := range styles {
+		start, end, ok := styleRange(style)
+		if !ok {
+			// 没有范围的样式原样保留
+			result = append(result, style)
+			continue
+		}
+		newStart, newEnd := scale(start), scale(end)
+		if oldLen == 0 {
+			newStart = 0
+		}
+		if newEnd <= newStart {
+			continue
+		}
+		result = append(result, withStyleRange(style, newStart, newEnd))
+		if newEnd >= lastEnd {
+			lastIndex, lastEnd = len(result)-1, newEnd
+		}
+	}
+
+	if lastIndex < 0 {
+		// 所有样式均为空，用第一个样式覆盖整个文本
+		for _, style := range styles {
+			if _, _, ok := styleRange(style); ok {
+				return append(result, withStyleRange(style, 0, newLen))
+			}
+		}
+		return result
+	}
+	if lastEnd != newLen {
+		start, _, _ := styleRange(result[lastIndex].(map[string]interface{}))
+		result[lastIndex] = withStyleRange(result[lastIndex].(map[string]interface{}), start, newLen)
+	}
+	return result
+}
+
+// styleRange 返回样式的应用范围 [start, end)
+func styleRange(style map[string]interface{}) (int, int, bool) {
+	rangeData, ok := style["range"].([]interface{})
+	if !ok || len(rangeData) != 2 {
+		return 0, 0, false
+	}
+	start, ok1 := util.ToInt64(rangeData[0])
+	end, ok2 := util.ToInt64(rangeData[1])
+	return int(start), int(end), ok1 && ok2
+}
+
+// styleRangeStart 返回样式范围的起点，没有范围时返回0
+func styleRangeStart(style map[string]interface{}) int {
+	start, _, _ := styleRange(style)
+	return start
+}
+
+// withStyleRange 复制样式并设置新的应用范围
+func withStyleRange(style map[string]interface{}, start, end int) map[string]interface{} {
+	result := make(map[string]interface{}, len(style))
+	for k, v := range style {
+		result[k] = v
+	}
+	result["range"] = []interface{}{start, end}
+	return result
+}
+
+// writeTextContent 将content序列化后写回文本素材
+func writeTextContent(mat *content.TextMaterial, textContent map[string]interface{}) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(textContent); err != nil {
+		return fmt.Errorf("无法序列化文本内容: %v", err)
+	}
+	mat.Content = strings.TrimSuffix(buf.String(), "\n")
+	return nil
+}
+
+// Dumps 将草稿文件内容导出为JSON字符串
+// 对应Python的dumps方法
+//
+// 对于加载的草稿，导出以原始数据为基础：未被修改的字段（包括数值的写法）、素材顺序和轨道顺序均保持不变，
+// 修改只影响对应的节点。JSON对象的键保持原始顺序，新增的键按字典序排在其后。可通过WithPathMapping改写导出的素材路径
+func (sf *ScriptFile) Dumps(options ...DumpOption) (string, error) {
+	config := newDumpConfig(options)
+
+	// 以草稿内容的副本为基础导出，未变化的值保持原样
+	draftContent := &content.DraftContent{}
+	if sf.Content != nil {
+		*draftContent = *sf.Content
+	}
+	draftContent.FPS = float64(sf.FPS)
+	draftContent.Duration = sf.Duration
+
+	canvasConfig := &content.CanvasConfig{}
+	if draftContent.CanvasConfig != nil {
+		*canvasConfig = *draftContent.CanvasConfig
+	}
+	canvasConfig.Width = sf.Width
+	canvasConfig.Height = sf.Height
+	if canvasConfig.Ratio == "" {
+		canvasConfig.Ratio = "original"
+	}
+	draftContent.CanvasConfig = canvasConfig
+
+	draftContent.Materials = sf.importedMaterials()
+
 	// 导出轨道
-	tracks := make([]map[string]interface{}, len(trackList))
+	trackList := sf.sortedTracks()
+	draftContent.Tracks = make([]*content.Track, len(trackList))
+	newTracks := make([]map[string]interface{}, 0, len(sf.Tracks))
 	for i, t := range trackList {
-		tracks[i] = t.ExportJSON()
+		trackData, err := t.ExportContent()
+		if err != nil {
+			return "", fmt.Errorf("导出轨道 '%s' 失败: %v", t.Name, err)
+		}
+		draftContent.Tracks[i] = trackData
+		if t.RawData == nil {
+			newTracks = append(newTracks, t.ExportJSON())
+		}
+	}
+
+	// 转换为通用的JSON结构，输出时对象的键按模型导出的顺序排列
+	layout, err := draftContent.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("JSON序列化失败: %v", err)
+	}
+	output, err := content.ToMap(draftContent)
+	if err != nil {
+		return "", fmt.Errorf("JSON序列化失败: %v", err)
+	}
+	newMaterials := sf.Materials.ExportJSON()
+	if err := exportMaterials(output, newMaterials); err != nil {
+		return "", fmt.Errorf("导出素材失败: %v", err)
+	}
+	if len(config.PathRules) > 0 {
+		mapExportedPaths(output, util.NewPathMapper(config.PathRules...))
+	}
+
+	// 确定性id模式下，替换新建对象的id
+	if sf.IDGenerator != nil {
+		if err := sf.remapIDs(output, newMaterials, newTracks); err != nil {
+			return "", err
+		}
 	}
-	sf.Content["tracks"] = tracks
 
 	// 序列化为JSON
-	jsonBytes, err := json.MarshalIndent(sf.Content, "", "    ")
+	data, err := content.MarshalIndent(output, layout, "    ")
 	if err != nil {
 		return "", fmt.Errorf("JSON序列化失败: %v", err)
 	}
+	return string(data), nil
+}
+
+// exportMaterials 将新增的素材写入导出内容
+//
+// 导入的素材保持原有的顺序，新增的素材追加在同类素材之后；
+// 原始草稿中不存在的素材类别只在有新增素材时才会写入
+func exportMaterials(output map[string]interface{}, newMaterials map[string]interface{}) error {
+	materials, ok := output["materials"].(map[string]interface{})
+	if !ok {
+		materials = make(map[string]interface{})
+		output["materials"] = materials
+	}
+
+	for key, value := range newMaterials {
+		generic, err := genericJSON(value)
+		if err != nil {
+			return err
+		}
+		newItems, _ := generic.([]interface{})
+		list, isList := materials[key].([]interface{})
+		if len(newItems) == 0 && !isList {
+			continue
+		}
+		materials[key] = append(append(make([]interface{}, 0, len(list)+len(newItems)), list...), newItems...)
+	}
+	return nil
+}
 
-	return string(jsonBytes), nil
+// SetIDGenerator 为草稿设置确定性id生成器，传入nil时恢复默认行为，即保留对象的随机id
+//
+// 设置后，导出时新建的素材、轨道、片段、关键帧等对象的id会按导出顺序替换为生成器生成的id，
+// 草稿中引用这些id的地方也会一并替换；导入的内容保持原有的id不变，新建对象对导入素材的引用也不会被替换。
+// 同一个对象在多次导出中总是得到相同的id，因此以相同的输入构建的草稿会导出相同的内容
+func (sf *ScriptFile) SetIDGenerator(generator util.IDGenerator) *ScriptFile {
+	sf.IDGenerator = generator
+	sf.idMapping = make(map[string]string)
+	return sf
+}
+
+// remapIDs 为新建对象的id分配生成器生成的id，返回替换了所有对这些id的引用的草稿内容
+func (sf *ScriptFile) remapIDs(output map[string]interface{}, newMaterials map[string]interface{}, newTracks []map[string]interface{}) error {
+	if sf.idMapping == nil {
+		sf.idMapping = make(map[string]string)
+	}
+
+	created, err := genericJSON(map[string]interface{}{"materials": newMaterials, "tracks": newTracks})
+	if err != nil {
+		return err
+	}
+	imported, err := sf.importedIDs()
+	if err != nil {
+		return err
+	}
+	collectIDs(created, func(id string) {
+		if _, ok := sf.idMapping[id]; !ok && !imported[id] {
+			sf.idMapping[id] = sf.IDGenerator.NewID()
+		}
+	})
+
+	for _, key := range []string{"materials", "tracks"} {
+		output[key] = replaceIDs(output[key], sf.idMapping)
+	}
+	return nil
+}
+
+// importedIDs 返回加载的草稿中已有的所有id，这些id不会被替换
+func (sf *ScriptFile) importedIDs() (map[string]bool, error) {
+	ids := make(map[string]bool)
+	if sf.Content == nil {
+		return ids, nil
+	}
+	existing, err := genericJSON(sf.Content)
+	if err != nil {
+		return nil, err
+	}
+	collectIDs(existing, func(id string) {
+		ids[id] = true
+	})
+	return ids, nil
+}
+
+// genericJSON 将任意可序列化的值转换为通用的JSON结构，数值保持为json.Number
+func genericJSON(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("JSON序列化失败: %v", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var result interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("JSON解析失败: %v", err)
+	}
+	return result, nil
+}
+
+// collectIDs 按确定的顺序（对象的键按字典序）遍历所有id及对id的引用
+func collectIDs(value interface{}, visit func(id string)) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			switch k {
+			case "id", "material_id":
+				if id, ok := v[k].(string); ok {
+					if id != "" {
+						visit(id)
+					}
+					continue
+				}
+			case "extra_material_refs":
+				if refs, ok := v[k].([]interface{}); ok {
+					for _, ref := range refs {
+						if id, ok := ref.(string); ok && id != "" {
+							visit(id)
+						}
+					}
+					continue
+				}
+			}
+			collectIDs(v[k], visit)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectIDs(item, visit)
+		}
+	}
+}
+
+// replaceIDs 替换JSON结构中所有等于已映射id的字符串
+func replaceIDs(value interface{}, mapping map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = replaceIDs(item, mapping)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = replaceIDs(item, mapping)
+		}
+	case string:
+		if id, ok := mapping[v]; ok {
+			return id
+		}
+	}
+	return value
+}
+
+// sortedTracks 返回按导出顺序排列的轨道
+//
+// 导入的轨道保持原有的顺序，新建的轨道按渲染层级排序后依次插入到渲染层级更高的导入轨道之前
+func (sf *ScriptFile) sortedTracks() []*track.Track {
+	newTracks := make([]*track.Track, 0, len(sf.Tracks))
+	for _, t := range sf.Tracks {
+		newTracks = append(newTracks, t)
+	}
+	sort.Slice(newTracks, func(i, j int) bool {
+		if newTracks[i].RenderIndex != newTracks[j].RenderIndex {
+			return newTracks[i].RenderIndex < newTracks[j].RenderIndex
+		}
+		return newTracks[i].Name < newTracks[j].Name
+	})
+
+	result := make([]*track.Track, 0, len(newTracks)+len(sf.ImportedTracks))
+	i := 0
+	for _, imported := range sf.ImportedTracks {
+		for i < len(newTracks) && newTracks[i].RenderIndex < imported.RenderIndex {
+			result = append(result, newTracks[i])
+			i++
+		}
+		result = append(result, imported)
+	}
+	return append(result, newTracks[i:]...)
 }
 
 // Dump 将草稿文件内容写入文件
 // 对应Python的dump方法
-func (sf *ScriptFile) Dump(filePath string) error {
-	jsonStr, err := sf.Dumps()
+//
+// 可通过WithValidation在写入前校验草稿，未通过校验时不会写入文件
+func (sf *ScriptFile) Dump(filePath string, options ...DumpOption) error {
+	if err := sf.checkDump(options); err != nil {
+		return err
+	}
+
+	jsonStr, err := sf.Dumps(options...)
 	if err != nil {
 		return err
 	}
@@ -688,66 +1566,34 @@ func (sf *ScriptFile) Dump(filePath string) error {
 
 // Save 保存草稿文件至打开时的路径，仅在模板模式下可用
 // 对应Python的save方法
-func (sf *ScriptFile) Save() error {
+func (sf *ScriptFile) Save(options ...DumpOption) error {
 	if sf.SavePath == nil {
 		return fmt.Errorf("没有设置保存路径，可能不在模板模式下")
 	}
-	return sf.Dump(*sf.SavePath)
+	return sf.Dump(*sf.SavePath, options...)
 }
 
 // InspectMaterial 输出草稿中导入的贴纸、文本气泡以及花字素材的元数据
 // 对应Python的inspect_material方法
 func (sf *ScriptFile) InspectMaterial() {
+	materials := sf.importedMaterials()
+
 	fmt.Println("贴纸素材:")
-	if stickers, ok := sf.ImportedMaterials["stickers"]; ok {
-		for _, sticker := range stickers {
-			resourceID := ""
-			name := ""
-			if rid, ok := sticker["resource_id"].(string); ok {
-				resourceID = rid
-			}
-			if n, ok := sticker["name"].(string); ok {
-				name = n
-			}
-			fmt.Printf("\tResource id: %s '%s'\n", resourceID, name)
-		}
+	for _, sticker := range materials.Stickers {
+		fmt.Printf("\tResource id: %s '%s'\n", sticker.ResourceID, sticker.Name)
 	}
 
 	fmt.Println("文字气泡效果:")
-	if effects, ok := sf.ImportedMaterials["effects"]; ok {
-		for _, effect := range effects {
-			if effectType, ok := effect["type"].(string); ok && effectType == "text_shape" {
-				effectID := ""
-				resourceID := ""
-				name := ""
-				if eid, ok := effect["effect_id"].(string); ok {
-					effectID = eid
-				}
-				if rid, ok := effect["resource_id"].(string); ok {
-					resourceID = rid
-				}
-				if n, ok := effect["name"].(string); ok {
-					name = n
-				}
-				fmt.Printf("\tEffect id: %s ,Resource id: %s '%s'\n", effectID, resourceID, name)
-			}
+	for _, effect := range materials.Effects {
+		if effect.Type == "text_shape" {
+			fmt.Printf("\tEffect id: %s ,Resource id: %s '%s'\n", effect.EffectID, effect.ResourceID, effect.Name)
 		}
 	}
 
 	fmt.Println("花字效果:")
-	if effects, ok := sf.ImportedMaterials["effects"]; ok {
-		for _, effect := range effects {
-			if effectType, ok := effect["type"].(string); ok && effectType == "text_effect" {
-				resourceID := ""
-				name := ""
-				if rid, ok := effect["resource_id"].(string); ok {
-					resourceID = rid
-				}
-				if n, ok := effect["name"].(string); ok {
-					name = n
-				}
-				fmt.Printf("\tResource id: %s '%s'\n", resourceID, name)
-			}
+	for _, effect := range materials.Effects {
+		if effect.Type == "text_effect" {
+			fmt.Printf("\tResource id: %s '%s'\n", effect.ResourceID, effect.Name)
 		}
 	}
 }
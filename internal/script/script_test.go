@@ -1,15 +1,27 @@
 package script
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/zhangshican/go-capcut/internal/animation"
+	"github.com/zhangshican/go-capcut/internal/content"
+	"github.com/zhangshican/go-capcut/internal/loudness"
 	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/metadata"
+	"github.com/zhangshican/go-capcut/internal/probe"
 	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/template"
 	"github.com/zhangshican/go-capcut/internal/track"
+	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut/internal/util"
 )
 
 // TestNewScriptMaterial 测试创建新的草稿素材管理器
@@ -296,16 +308,16 @@ func TestNewScriptFile(t *testing.T) {
 		t.Error("轨道映射未初始化")
 	}
 
-	if sf.ImportedMaterials == nil {
-		t.Error("导入素材映射未初始化")
-	}
-
 	if sf.ImportedTracks == nil {
 		t.Error("导入轨道列表未初始化")
 	}
 
-	if sf.Content == nil {
-		t.Error("内容映射未初始化")
+	if sf.ImportedMaterials == nil {
+		t.Error("导入素材映射未初始化")
+	}
+
+	if sf.Content == nil || sf.Content.Materials == nil {
+		t.Error("草稿内容未初始化")
 	}
 
 	if sf.SavePath != nil {
@@ -388,6 +400,159 @@ func TestScriptFileAddMaterial(t *testing.T) {
 	}
 }
 
+// TestScriptFileAddMaterialDedup 测试按内容指纹去重素材
+func TestScriptFileAddMaterialDedup(t *testing.T) {
+	previous := probe.SetProber(probe.ProberFunc(func(path string) (*probe.MediaInfo, error) {
+		return &probe.MediaInfo{Duration: 10 * types.SEC, Width: 1920, Height: 1080, HasVideo: true, HasAudio: true}, nil
+	}))
+	defer probe.SetProber(previous)
+
+	// 同一内容位于两个路径，另有一个同名但内容不同的文件
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "a", "clip.mp4"), filepath.Join(dir, "b", "copy.mp4"), filepath.Join(dir, "c", "clip.mp4")}
+	contents := []string{"same content", "same content", "other content"}
+	materials := make([]*material.VideoMaterial, len(paths))
+	for i, path := range paths {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(contents[i]), 0644); err != nil {
+			t.Fatalf("写入文件失败: %v", err)
+		}
+		mat, err := material.NewVideoMaterial(material.MaterialTypeVideo, &paths[i], nil, nil, nil, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("创建素材失败: %v", err)
+		}
+		materials[i] = mat
+	}
+	if materials[0].MaterialID != materials[1].MaterialID {
+		t.Error("内容相同的文件应得到相同的素材ID")
+	}
+	if materials[0].MaterialID == materials[2].MaterialID {
+		t.Error("同名但内容不同的文件不应得到相同的素材ID")
+	}
+
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	if canonical := sf.FindOrAddMaterial(materials[0]); canonical != materials[0] {
+		t.Error("首次添加时应返回素材本身")
+	}
+	if canonical := sf.FindOrAddMaterial(materials[1]); canonical != materials[0] {
+		t.Error("添加重复的素材时应返回已有的素材")
+	}
+	if canonical := sf.FindOrAddMaterial(materials[2]); canonical != materials[2] {
+		t.Error("内容不同的素材应被添加")
+	}
+	if len(sf.Materials.Videos) != 2 {
+		t.Errorf("期望视频素材数量为2，得到%d", len(sf.Materials.Videos))
+	}
+
+	// 不同地址上的同名远程素材是不同的素材，AddMaterial可以链式调用
+	duration := 5.0
+	first, second := "https://a.example.com/x/voice.mp3", "https://b.example.com/y/voice.mp3"
+	remote1, err := material.NewAudioMaterial(nil, nil, nil, &first, &duration)
+	if err != nil {
+		t.Fatalf("创建素材失败: %v", err)
+	}
+	remote2, err := material.NewAudioMaterial(nil, nil, nil, &second, &duration)
+	if err != nil {
+		t.Fatalf("创建素材失败: %v", err)
+	}
+	if sf.AddMaterial(remote1).AddMaterial(remote2) != sf || len(sf.Materials.Audios) != 2 {
+		t.Errorf("期望音频素材数量为2，得到%d", len(sf.Materials.Audios))
+	}
+
+	// id不同但指纹相同的素材，片段会改为引用已有的素材
+	duplicate := *materials[1]
+	duplicate.MaterialID = "custom_id"
+	sf.AddTrack(track.TrackTypeVideo, nil)
+	seg := segment.NewVideoSegment(duplicate.MaterialID, types.NewTimerange(0, types.SEC), types.NewTimerange(0, types.SEC), 1.0, 1.0, nil)
+	seg.MaterialInstance = &duplicate
+	if err := sf.AddSegment(seg, nil); err != nil {
+		t.Fatalf("添加片段失败: %v", err)
+	}
+	if seg.MaterialID != materials[0].MaterialID || seg.MaterialInstance != materials[0] {
+		t.Errorf("片段应引用已有的素材，得到 %s", seg.MaterialID)
+	}
+	if len(sf.Materials.Videos) != 2 {
+		t.Errorf("添加片段后期望视频素材数量仍为2，得到%d", len(sf.Materials.Videos))
+	}
+	if problems := sf.Validate(); len(problems) != 0 {
+		t.Errorf("去重后的草稿不应有问题，得到: %v", problems)
+	}
+}
+
+// TestScriptFileAddMaterialDifferentCrop 测试内容相同但裁剪不同的素材不会被合并
+func TestScriptFileAddMaterialDifferentCrop(t *testing.T) {
+	previous := probe.SetProber(probe.ProberFunc(func(path string) (*probe.MediaInfo, error) {
+		return &probe.MediaInfo{Duration: 10 * types.SEC, Width: 1920, Height: 1080, HasVideo: true}, nil
+	}))
+	defer probe.SetProber(previous)
+
+	path := filepath.Join(t.TempDir(), "clip.mp4")
+	if err := os.WriteFile(path, []byte("same content"), 0644); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+	left, err := material.NewCropSettingsFromRect(0, 0, 0.5, 1)
+	if err != nil {
+		t.Fatalf("创建裁剪设置失败: %v", err)
+	}
+	full, err := material.NewVideoMaterial(material.MaterialTypeVideo, &path, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("创建素材失败: %v", err)
+	}
+	cropped, err := material.NewVideoMaterial(material.MaterialTypeVideo, &path, nil, nil, nil, left, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("创建素材失败: %v", err)
+	}
+	if full.MaterialID == cropped.MaterialID {
+		t.Error("裁剪不同的素材不应得到相同的素材ID")
+	}
+
+	// 创建后才修改裁剪设置的素材与原有素材id相同，添加时分配新的id
+	later, err := material.NewVideoMaterial(material.MaterialTypeVideo, &path, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("创建素材失败: %v", err)
+	}
+	if err := later.CropToRect(960, 0, 960, 1080); err != nil {
+		t.Fatalf("裁剪素材失败: %v", err)
+	}
+
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	sf.AddTrack(track.TrackTypeVideo, nil)
+	var segs []*segment.VideoSegment
+	for i, mat := range []*material.VideoMaterial{full, cropped, later} {
+		seg := segment.NewVideoSegment(mat.MaterialID, types.NewTimerange(0, types.SEC), types.NewTimerange(int64(i)*types.SEC, types.SEC), 1.0, 1.0, nil)
+		seg.MaterialInstance = mat
+		if err := sf.AddSegment(seg, nil); err != nil {
+			t.Fatalf("添加片段失败: %v", err)
+		}
+		segs = append(segs, seg)
+	}
+
+	if len(sf.Materials.Videos) != 3 {
+		t.Fatalf("期望视频素材数量为3，得到%d", len(sf.Materials.Videos))
+	}
+	ids := make(map[string]bool)
+	for i, seg := range segs {
+		if seg.MaterialID != sf.Materials.Videos[i].MaterialID {
+			t.Errorf("片段%d应引用自己的素材，得到 %s", i, seg.MaterialID)
+		}
+		ids[seg.MaterialID] = true
+	}
+	if len(ids) != 3 {
+		t.Errorf("期望3个不同的素材ID，得到%v", ids)
+	}
+	if problems := sf.Validate(); len(problems) != 0 {
+		t.Errorf("草稿不应有问题，得到: %v", problems)
+	}
+}
+
 // TestScriptFileAddTrack 测试添加轨道
 func TestScriptFileAddTrack(t *testing.T) {
 	sf, err := NewScriptFile(1920, 1080)
@@ -509,6 +674,176 @@ func TestScriptFileGetTrack(t *testing.T) {
 	}
 }
 
+// TestScriptFileAddSegment 测试向草稿中添加片段
+func TestScriptFileAddSegment(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+
+	sf.AddTrack(track.TrackTypeVideo, nil)
+	sf.AddTrack(track.TrackTypeAudio, nil)
+
+	// 视频片段：添加到唯一的视频轨道
+	videoSeg := segment.NewVideoSegment("video_material", types.NewTimerange(0, 5*types.SEC), types.NewTimerange(0, 5*types.SEC), 1.0, 1.0, nil)
+	if err := sf.AddSegment(videoSeg, nil); err != nil {
+		t.Fatalf("添加视频片段失败: %v", err)
+	}
+
+	videoTrack, err := sf.GetTrack("video", nil)
+	if err != nil {
+		t.Fatalf("获取视频轨道失败: %v", err)
+	}
+	if len(videoTrack.Segments) != 1 {
+		t.Errorf("期望视频轨道有1个片段，实际为%d", len(videoTrack.Segments))
+	}
+	if sf.Duration != 5*types.SEC {
+		t.Errorf("期望草稿时长为%d，实际为%d", 5*types.SEC, sf.Duration)
+	}
+
+	// 音频片段：添加到唯一的音频轨道，不应进入视频轨道
+	audioSeg := segment.NewAudioSegment("audio_material", types.NewTimerange(types.SEC, 8*types.SEC), types.NewTimerange(0, 8*types.SEC), 1.0, 1.0)
+	if err := sf.AddSegment(audioSeg, nil); err != nil {
+		t.Fatalf("添加音频片段失败: %v", err)
+	}
+	if len(videoTrack.Segments) != 1 {
+		t.Error("音频片段不应被添加到视频轨道")
+	}
+	if sf.Duration != 9*types.SEC {
+		t.Errorf("期望草稿时长为%d，实际为%d", 9*types.SEC, sf.Duration)
+	}
+
+	// 重叠片段应返回错误
+	overlapSeg := segment.NewVideoSegment("video_material", types.NewTimerange(0, 2*types.SEC), types.NewTimerange(4*types.SEC, 2*types.SEC), 1.0, 1.0, nil)
+	if err := sf.AddSegment(overlapSeg, nil); err == nil {
+		t.Error("期望添加重叠片段时返回错误")
+	}
+
+	// 不存在对应类型的轨道时应返回错误
+	textSeg := segment.NewTextSegmentSimple("文本", types.NewTimerange(0, types.SEC))
+	if err := sf.AddSegment(textSeg, nil); err == nil {
+		t.Error("期望不存在文本轨道时返回错误")
+	}
+
+	// 指定名称的轨道类型不匹配时应返回错误
+	audioTrackName := "audio"
+	mismatchSeg := segment.NewVideoSegment("video_material", types.NewTimerange(0, types.SEC), types.NewTimerange(20*types.SEC, types.SEC), 1.0, 1.0, nil)
+	if err := sf.AddSegment(mismatchSeg, &audioTrackName); err == nil {
+		t.Error("期望片段类型与轨道类型不匹配时返回错误")
+	}
+
+	// 不支持的片段类型
+	if err := sf.AddSegment("not a segment", nil); err == nil {
+		t.Error("期望添加不支持的片段类型时返回错误")
+	}
+}
+
+// TestScriptFileAddSegmentMaterials 测试添加片段时自动添加相关素材
+func TestScriptFileAddSegmentMaterials(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+
+	sf.AddTrack(track.TrackTypeVideo, nil)
+	sf.AddTrack(track.TrackTypeAudio, nil)
+	sf.AddTrack(track.TrackTypeText, nil)
+
+	// 视频片段及其依赖的素材
+	videoMat := &material.VideoMaterial{
+		MaterialID:   "test_video_123",
+		Path:         "/path/to/video.mp4",
+		CropSettings: material.NewCropSettings(),
+	}
+
+	videoSeg := segment.NewVideoSegment(videoMat.MaterialID, types.NewTimerange(0, 5*types.SEC), types.NewTimerange(0, 5*types.SEC), 1.0, 1.0, nil)
+	videoSeg.MaterialInstance = videoMat
+	videoSeg.AddEffect("特效", "effect_id", "resource_id", "video_effect", 0)
+	videoSeg.AddFilter("滤镜", "filter_id", "resource_id", 0.8, 0)
+	videoSeg.AddTransition("转场", "transition_id", "resource_id", 500000)
+	videoSeg.AddMask("circle", "圆形", "circle", "mask_resource", 0, 0, 0.5, 0, 0, false, nil, nil)
+	videoSeg.BackgroundFilling = segment.NewBackgroundFilling("canvas_blur", 0.5, "")
+	animMeta := metadata.NewAnimationMeta("入场", false, 0.5, "anim_resource", "anim_id", "")
+	if err := videoSeg.Animations.AddAnimation(animation.NewAnimation(animMeta, 0, 500000, animation.AnimationTypeIn, true)); err != nil {
+		t.Fatalf("添加动画失败: %v", err)
+	}
+
+	if err := sf.AddSegment(videoSeg, nil); err != nil {
+		t.Fatalf("添加视频片段失败: %v", err)
+	}
+
+	if !sf.Materials.Contains(videoMat) {
+		t.Error("视频素材应被自动添加")
+	}
+	if len(sf.Materials.VideoEffects) != 1 || len(sf.Materials.Filters) != 1 || len(sf.Materials.Transitions) != 1 {
+		t.Error("视频片段的特效、滤镜和转场应被自动添加")
+	}
+	if len(sf.Materials.Masks) != 1 {
+		t.Errorf("期望有1个蒙版，实际为%d", len(sf.Materials.Masks))
+	}
+	if !sf.Materials.Contains(videoSeg.BackgroundFilling) {
+		t.Error("背景填充应被自动添加")
+	}
+	if !sf.Materials.Contains(videoSeg.Speed) {
+		t.Error("变速对象应被自动添加")
+	}
+	if !sf.Materials.Contains(videoSeg.Animations) {
+		t.Error("动画应被自动添加")
+	}
+	for _, id := range []string{videoSeg.Mask.GlobalID, videoSeg.BackgroundFilling.GlobalID, videoSeg.Animations.AnimationID} {
+		if !containsRef(videoSeg.ExtraMaterialRefs, id) {
+			t.Errorf("片段的附加素材引用中应包含 %s", id)
+		}
+	}
+
+	// 音频片段及其淡入淡出
+	audioSeg := segment.NewAudioSegment("audio_material", types.NewTimerange(0, 3*types.SEC), types.NewTimerange(0, 3*types.SEC), 1.0, 1.0)
+	if err := audioSeg.AddFade(100000, 100000); err != nil {
+		t.Fatalf("添加淡入淡出失败: %v", err)
+	}
+	if err := sf.AddSegment(audioSeg, nil); err != nil {
+		t.Fatalf("添加音频片段失败: %v", err)
+	}
+	if !sf.Materials.Contains(audioSeg.Fade) || !sf.Materials.Contains(audioSeg.Speed) {
+		t.Error("音频片段的淡入淡出和变速应被自动添加")
+	}
+
+	// 文本片段导出文本素材
+	textSeg := segment.NewTextSegmentSimple("你好", types.NewTimerange(0, 2*types.SEC))
+	textSeg.SetBubble("bubble_effect", "bubble_resource", "气泡")
+	if err := sf.AddSegment(textSeg, nil); err != nil {
+		t.Fatalf("添加文本片段失败: %v", err)
+	}
+	if len(sf.Materials.Texts) != 1 || sf.Materials.Texts[0].MaterialID != textSeg.MaterialID {
+		t.Error("文本素材应被自动添加")
+	}
+	if !sf.Materials.Contains(textSeg.Bubble) {
+		t.Error("文本气泡应被自动添加")
+	}
+	if !containsRef(textSeg.ExtraMaterialRefs, textSeg.Bubble.GlobalID) {
+		t.Error("文本片段的附加素材引用中应包含气泡id")
+	}
+
+	// 导出的JSON中应包含相应的素材
+	materials := sf.Materials.ExportJSON()
+	if canvases, ok := materials["canvases"].([]map[string]interface{}); !ok || len(canvases) != 1 {
+		t.Error("导出的素材中应包含背景填充")
+	}
+	if speeds, ok := materials["speeds"].([]map[string]interface{}); !ok || len(speeds) != 2 {
+		t.Error("导出的素材中应包含2个变速对象")
+	}
+}
+
+// containsRef 检查引用列表中是否包含指定id
+func containsRef(refs []string, id string) bool {
+	for _, ref := range refs {
+		if ref == id {
+			return true
+		}
+	}
+	return false
+}
+
 // TestScriptFileDumpsAndDump 测试JSON导出功能
 func TestScriptFileDumpsAndDump(t *testing.T) {
 	sf, err := NewScriptFile(1920, 1080, 25)
@@ -599,6 +934,155 @@ func TestScriptFileDumpsAndDump(t *testing.T) {
 	}
 }
 
+// buildIDTestDraft 构建用于测试确定性id的草稿
+func buildIDTestDraft(t *testing.T, generator util.IDGenerator) string {
+	t.Helper()
+
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	if generator != nil {
+		sf.SetIDGenerator(generator)
+	}
+
+	trackName := "字幕"
+	sf.AddTrack(track.TrackTypeText, &trackName)
+	first := segment.NewTextSegmentSimple("第一句", types.NewTimerange(0, types.SEC))
+	if err := first.AddKeyframe("alpha", int64(0), 0.5); err != nil {
+		t.Fatalf("添加关键帧失败: %v", err)
+	}
+	second := segment.NewTextSegmentSimple("第二句", types.NewTimerange(types.SEC, types.SEC))
+	for _, seg := range []*segment.TextSegment{first, second} {
+		if err := sf.AddSegment(seg, &trackName); err != nil {
+			t.Fatalf("添加片段失败: %v", err)
+		}
+	}
+
+	output, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("Dumps失败: %v", err)
+	}
+	again, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("Dumps失败: %v", err)
+	}
+	if generator != nil && again != output {
+		t.Error("同一草稿多次导出的结果应相同")
+	}
+	return output
+}
+
+// TestScriptFileSetIDGenerator 测试确定性id生成
+func TestScriptFileSetIDGenerator(t *testing.T) {
+	first := buildIDTestDraft(t, util.NewSeededIDGenerator("seed"))
+	second := buildIDTestDraft(t, util.NewSeededIDGenerator("seed"))
+	if first != second {
+		t.Error("相同输入与相同种子应导出完全相同的草稿")
+	}
+	if buildIDTestDraft(t, nil) == buildIDTestDraft(t, nil) {
+		t.Error("默认情况下应使用随机id")
+	}
+
+	// 片段引用的素材id与素材自身的id保持一致
+	var content struct {
+		Materials struct {
+			Texts []struct {
+				ID string `json:"id"`
+			} `json:"texts"`
+		} `json:"materials"`
+		Tracks []struct {
+			ID       string `json:"id"`
+			Segments []struct {
+				ID         string `json:"id"`
+				MaterialID string `json:"material_id"`
+			} `json:"segments"`
+		} `json:"tracks"`
+	}
+	if err := json.Unmarshal([]byte(first), &content); err != nil {
+		t.Fatalf("解析导出的JSON失败: %v", err)
+	}
+	if len(content.Tracks) != 1 || len(content.Tracks[0].Segments) != 2 || len(content.Materials.Texts) != 2 {
+		t.Fatalf("导出的草稿结构不正确: %s", first)
+	}
+
+	generated := make(map[string]bool)
+	expected := util.NewSeededIDGenerator("seed")
+	for i := 0; i < 10; i++ {
+		generated[expected.NewID()] = true
+	}
+	for i, seg := range content.Tracks[0].Segments {
+		if seg.MaterialID != content.Materials.Texts[i].ID {
+			t.Errorf("片段%d引用的素材id %s 与素材id %s 不一致", i, seg.MaterialID, content.Materials.Texts[i].ID)
+		}
+		if !generated[seg.ID] || !generated[seg.MaterialID] {
+			t.Errorf("片段%d的id未被替换为生成的id", i)
+		}
+	}
+	if !generated[content.Tracks[0].ID] {
+		t.Error("轨道id未被替换为生成的id")
+	}
+}
+
+// TestScriptFileSetIDGeneratorKeepsImportedIDs 测试确定性id不影响导入的内容
+func TestScriptFileSetIDGeneratorKeepsImportedIDs(t *testing.T) {
+	sf, original := loadGoldenDraft(t, filepath.Join("testdata", "jianying_draft.json"))
+	sf.SetIDGenerator(util.NewSeededIDGenerator("seed"))
+
+	output, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("Dumps失败: %v", err)
+	}
+	if compactJSON(t, []byte(output)) != compactJSON(t, original) {
+		t.Error("导入的内容不应被替换id")
+	}
+}
+
+// TestScriptFileSetIDGeneratorKeepsImportedRefs 测试新建片段对导入素材的引用不被替换
+func TestScriptFileSetIDGeneratorKeepsImportedRefs(t *testing.T) {
+	sf, original := loadGoldenDraft(t, filepath.Join("testdata", "jianying_draft.json"))
+	sf.SetIDGenerator(util.NewSeededIDGenerator("seed"))
+
+	trackName := "新视频"
+	sf.AddTrack(track.TrackTypeVideo, &trackName)
+	seg := segment.NewVideoSegment("V0000000-0001", types.NewTimerange(0, types.SEC), types.NewTimerange(0, types.SEC), 1.0, 1.0, nil)
+	if err := sf.AddSegment(seg, &trackName); err != nil {
+		t.Fatalf("添加片段失败: %v", err)
+	}
+
+	output, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("Dumps失败: %v", err)
+	}
+	after := decodeJSON(t, []byte(output)).(map[string]interface{})
+	before := decodeJSON(t, original).(map[string]interface{})
+	videos := func(draft map[string]interface{}) interface{} {
+		return draft["materials"].(map[string]interface{})["videos"]
+	}
+	if diffs := diffPaths(videos(before), videos(after), "videos"); len(diffs) > 0 {
+		t.Errorf("导入的视频素材被修改: %v", diffs)
+	}
+
+	found := false
+	for _, item := range after["tracks"].([]interface{}) {
+		trackData := item.(map[string]interface{})
+		if trackData["name"] != trackName {
+			continue
+		}
+		segData := trackData["segments"].([]interface{})[0].(map[string]interface{})
+		if segData["material_id"] != "V0000000-0001" {
+			t.Errorf("导入素材的引用不应被替换, 得到 %v", segData["material_id"])
+		}
+		if segData["id"] == seg.SegmentID {
+			t.Error("新建片段的id应被替换为生成的id")
+		}
+		found = true
+	}
+	if !found {
+		t.Fatal("未找到新建的轨道")
+	}
+}
+
 // TestScriptFileSave 测试保存功能
 func TestScriptFileSave(t *testing.T) {
 	// 测试没有保存路径的情况
@@ -694,13 +1178,16 @@ func TestLoadTemplate(t *testing.T) {
 	}
 
 	// 验证导入的素材
-	if videos, exists := sf.ImportedMaterials["videos"]; exists {
+	if videos := sf.Content.Materials.Videos; videos != nil {
 		if len(videos) != 1 {
 			t.Errorf("期望导入1个视频素材，得到%d", len(videos))
 		}
 	} else {
 		t.Error("未找到导入的视频素材")
 	}
+	if videos := sf.ImportedMaterials["videos"]; len(videos) != 1 || videos[0]["path"] != "/test/video.mp4" {
+		t.Errorf("ImportedMaterials中的视频素材不正确: %v", videos)
+	}
 
 	// 测试加载不存在的文件
 	_, err = LoadTemplate("/path/to/nonexistent/file.json")
@@ -709,89 +1196,474 @@ func TestLoadTemplate(t *testing.T) {
 	}
 }
 
-// TestScriptFileInspectMaterial 测试素材检查功能
-func TestScriptFileInspectMaterial(t *testing.T) {
-	sf, err := NewScriptFile(1920, 1080)
-	if err != nil {
-		t.Fatalf("创建ScriptFile失败: %v", err)
-	}
-
-	// 添加测试素材
-	sf.ImportedMaterials["stickers"] = []map[string]interface{}{
-		{
-			"resource_id": "sticker_123",
-			"name":        "测试贴纸",
+// TestLoadTemplateKeepsSegments 测试加载模板后导出时保留轨道上的片段
+func TestLoadTemplateKeepsSegments(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "draft_content.json")
+
+	segmentData := map[string]interface{}{
+		"id":                  "segment_1",
+		"material_id":         "test_video_1",
+		"target_timerange":    map[string]interface{}{"start": 0, "duration": 3000000},
+		"source_timerange":    map[string]interface{}{"start": 1000000, "duration": 3000000},
+		"extra_material_refs": []interface{}{"speed_1"},
+		"clip": map[string]interface{}{
+			"alpha":     1.0,
+			"flip":      map[string]interface{}{"horizontal": false, "vertical": false},
+			"rotation":  0.0,
+			"scale":     map[string]interface{}{"x": 1.0, "y": 1.0},
+			"transform": map[string]interface{}{"x": 0.0, "y": 0.0},
 		},
+		"common_keyframes": []interface{}{},
+		"render_index":     0,
 	}
-
-	sf.ImportedMaterials["effects"] = []map[string]interface{}{
-		{
-			"type":        "text_shape",
-			"effect_id":   "effect_456",
-			"resource_id": "bubble_789",
-			"name":        "文字气泡",
+	testContent := map[string]interface{}{
+		"fps":           30,
+		"duration":      3000000,
+		"canvas_config": map[string]interface{}{"width": 1920, "height": 1080},
+		"materials": map[string]interface{}{
+			"videos": []interface{}{map[string]interface{}{"id": "test_video_1", "path": "/test/video.mp4"}},
+			"speeds": []interface{}{map[string]interface{}{"id": "speed_1", "speed": 1.0}},
 		},
-		{
-			"type":        "text_effect",
-			"resource_id": "flower_101",
-			"name":        "花字效果",
+		"tracks": []interface{}{
+			map[string]interface{}{
+				"type":     "video",
+				"name":     "主轨道",
+				"id":       "track_1",
+				"segments": []interface{}{segmentData},
+			},
+			map[string]interface{}{
+				"type": "text",
+				"name": "字幕",
+				"id":   "track_2",
+				"segments": []interface{}{
+					map[string]interface{}{
+						"id":               "segment_2",
+						"material_id":      "text_1",
+						"target_timerange": map[string]interface{}{"start": 500000, "duration": 1000000},
+					},
+				},
+			},
 		},
 	}
 
-	// 测试InspectMaterial方法（这个方法主要是打印，我们只验证它不会panic）
-	defer func() {
-		if r := recover(); r != nil {
-			t.Errorf("InspectMaterial发生panic: %v", r)
-		}
-	}()
+	jsonBytes, err := json.Marshal(testContent)
+	if err != nil {
+		t.Fatalf("创建测试JSON失败: %v", err)
+	}
+	if err := os.WriteFile(tempFile, jsonBytes, 0644); err != nil {
+		t.Fatalf("写入测试文件失败: %v", err)
+	}
 
-	sf.InspectMaterial()
-}
+	sf, err := LoadTemplate(tempFile)
+	if err != nil {
+		t.Fatalf("LoadTemplate失败: %v", err)
+	}
 
-// TestScriptFileJSONSerialization 测试JSON序列化兼容性
-func TestScriptFileJSONSerialization(t *testing.T) {
-	sf, err := NewScriptFile(1920, 1080, 30)
+	output, err := sf.Dumps()
 	if err != nil {
-		t.Fatalf("创建ScriptFile失败: %v", err)
+		t.Fatalf("导出失败: %v", err)
 	}
 
-	// 添加复杂数据
-	videoMaterial := &material.VideoMaterial{
-		MaterialID: "complex_video_123",
-		Path:       "/path/to/complex.mp4",
-		Width:      1920,
-		Height:     1080,
-		Duration:   10000000,
-		CropSettings: &material.CropSettings{
-			UpperLeftX:  0.0,
-			UpperLeftY:  0.0,
-			LowerRightX: 1.0,
-			LowerRightY: 1.0,
-		},
+	var exported map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &exported); err != nil {
+		t.Fatalf("解析导出结果失败: %v", err)
 	}
-	sf.AddMaterial(videoMaterial)
 
-	audioMaterial := &material.AudioMaterial{
-		MaterialID: "complex_audio_456",
-		Path:       "/path/to/complex.mp3",
-		Duration:   8000000,
+	segments := make(map[string]map[string]interface{})
+	for _, trackData := range exported["tracks"].([]interface{}) {
+		for _, segData := range trackData.(map[string]interface{})["segments"].([]interface{}) {
+			seg := segData.(map[string]interface{})
+			segments[seg["id"].(string)] = seg
+		}
 	}
-	sf.AddMaterial(audioMaterial)
 
-	trackName1 := "复杂视频轨道"
-	trackName2 := "复杂音频轨道"
-	sf.AddTrack(track.TrackTypeVideo, &trackName1, WithRelativeIndex(2))
-	sf.AddTrack(track.TrackTypeAudio, &trackName2, WithMute(true))
+	if len(segments) != 2 {
+		t.Fatalf("期望导出2个片段，得到%d", len(segments))
+	}
 
-	sf.Duration = 15000000
+	videoSeg := segments["segment_1"]
+	source := videoSeg["source_timerange"].(map[string]interface{})
+	if source["start"] != float64(1000000) || source["duration"] != float64(3000000) {
+		t.Errorf("素材截取范围未被保留: %v", source)
+	}
+	if refs := videoSeg["extra_material_refs"].([]interface{}); len(refs) != 1 || refs[0] != "speed_1" {
+		t.Errorf("附加素材引用未被保留: %v", refs)
+	}
+	if _, ok := videoSeg["clip"].(map[string]interface{}); !ok {
+		t.Error("图像调节设置未被保留")
+	}
 
-	// 导出JSON
-	jsonStr, err := sf.Dumps()
-	if err != nil {
-		t.Fatalf("JSON导出失败: %v", err)
+	textTarget := segments["segment_2"]["target_timerange"].(map[string]interface{})
+	if textTarget["start"] != float64(500000) {
+		t.Errorf("文本片段时间范围未被保留: %v", textTarget)
 	}
+}
 
-	// 解析JSON验证结构
+// loadReplaceTestTemplate 创建并加载用于素材替换测试的模板，视频轨道上有两个相邻片段
+func loadReplaceTestTemplate(t *testing.T) *ScriptFile {
+	t.Helper()
+
+	mediaSegment := func(id, materialID string, start int64) map[string]interface{} {
+		return map[string]interface{}{
+			"id":               id,
+			"material_id":      materialID,
+			"target_timerange": map[string]interface{}{"start": start, "duration": 2000000},
+			"source_timerange": map[string]interface{}{"start": 0, "duration": 2000000},
+		}
+	}
+	content := map[string]interface{}{
+		"fps":           30,
+		"duration":      4000000,
+		"canvas_config": map[string]interface{}{"width": 1920, "height": 1080},
+		"materials": map[string]interface{}{
+			"videos": []interface{}{
+				map[string]interface{}{"id": "video_1", "material_name": "开场.mp4", "path": "/old/开场.mp4", "width": 1280, "height": 720, "duration": 2000000},
+				map[string]interface{}{"id": "video_2", "material_name": "重复.mp4", "path": "/old/a.mp4"},
+				map[string]interface{}{"id": "video_3", "material_name": "重复.mp4", "path": "/old/b.mp4"},
+			},
+			"texts": []interface{}{
+				map[string]interface{}{
+					"id":      "text_1",
+					"content": `{"styles":[{"fill":{"content":{"solid":{"color":[1,0,0]}}},"range":[0,2],"size":8},{"fill":{"content":{"solid":{"color":[0,0,1]}}},"range":[2,4],"size":12}],"text":"你好世界"}`,
+				},
+			},
+		},
+		"tracks": []interface{}{
+			map[string]interface{}{
+				"type": "video",
+				"name": "主轨道",
+				"id":   "track_1",
+				"segments": []interface{}{
+					mediaSegment("segment_1", "video_1", 0),
+					mediaSegment("segment_2", "video_2", 2000000),
+				},
+			},
+			map[string]interface{}{
+				"type": "text",
+				"name": "字幕",
+				"id":   "track_2",
+				"segments": []interface{}{
+					map[string]interface{}{
+						"id":               "segment_3",
+						"material_id":      "text_1",
+						"target_timerange": map[string]interface{}{"start": 0, "duration": 2000000},
+					},
+				},
+			},
+		},
+	}
+
+	jsonBytes, err := json.Marshal(content)
+	if err != nil {
+		t.Fatalf("创建测试JSON失败: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "draft_content.json")
+	if err := os.WriteFile(path, jsonBytes, 0644); err != nil {
+		t.Fatalf("写入测试文件失败: %v", err)
+	}
+
+	sf, err := LoadTemplate(path)
+	if err != nil {
+		t.Fatalf("LoadTemplate失败: %v", err)
+	}
+	return sf
+}
+
+// TestScriptFileReplaceMaterialByName 测试按名称替换模板素材
+func TestScriptFileReplaceMaterialByName(t *testing.T) {
+	sf := loadReplaceTestTemplate(t)
+
+	newMaterial := &material.VideoMaterial{
+		MaterialID:   "new_video",
+		MaterialName: "新视频.mp4",
+		Path:         "/new/新视频.mp4",
+		Duration:     5000000,
+		Width:        1920,
+		Height:       1080,
+		MaterialType: material.MaterialTypeVideo,
+		CropSettings: material.NewCropSettings(),
+	}
+
+	if err := sf.ReplaceMaterialByName("开场.mp4", newMaterial, false); err != nil {
+		t.Fatalf("替换素材失败: %v", err)
+	}
+
+	replaced := sf.Content.Materials.Videos[0]
+	if replaced.ID != "video_1" {
+		t.Error("替换素材时不应修改素材id")
+	}
+	if replaced.Path != "/new/新视频.mp4" || replaced.MaterialName != "新视频.mp4" {
+		t.Errorf("素材路径或名称未被替换: %+v", replaced)
+	}
+	if replaced.Width != 1920 || replaced.Height != 1080 {
+		t.Errorf("素材宽高未被替换: %+v", replaced)
+	}
+	if replaced.Crop != nil {
+		t.Error("replaceCrop为false时不应替换裁剪设置")
+	}
+
+	// 不存在的素材
+	err := sf.ReplaceMaterialByName("不存在.mp4", newMaterial, false)
+	if !util.IsMaterialNotFound(err) {
+		t.Errorf("期望返回MaterialNotFoundError，得到 %v", err)
+	}
+
+	// 多个同名素材
+	err = sf.ReplaceMaterialByName("重复.mp4", newMaterial, false)
+	if _, ok := err.(*util.AmbiguousMaterialError); !ok {
+		t.Errorf("期望返回AmbiguousMaterialError，得到 %v", err)
+	}
+
+	// 类型不匹配的素材不会在视频素材中查找
+	audio := &material.AudioMaterial{MaterialID: "audio", MaterialName: "开场.mp4"}
+	if err := sf.ReplaceMaterialByName("开场.mp4", audio, false); !util.IsMaterialNotFound(err) {
+		t.Errorf("期望在音频素材中找不到该素材，得到 %v", err)
+	}
+}
+
+// TestScriptFileReplaceMaterialBySeg 测试按片段替换模板素材
+func TestScriptFileReplaceMaterialBySeg(t *testing.T) {
+	newVideo := func(id string, duration int64) *material.VideoMaterial {
+		return &material.VideoMaterial{
+			MaterialID:   id,
+			MaterialName: id + ".mp4",
+			Path:         "/new/" + id + ".mp4",
+			Duration:     duration,
+			MaterialType: material.MaterialTypeVideo,
+			CropSettings: material.NewCropSettings(),
+		}
+	}
+
+	// 素材变短，默认裁剪尾部
+	sf := loadReplaceTestTemplate(t)
+	shortVideo := newVideo("short", 1000000)
+	if err := sf.ReplaceMaterialBySeg("主轨道", 1, shortVideo, nil, "", nil); err != nil {
+		t.Fatalf("替换素材失败: %v", err)
+	}
+	seg := sf.ImportedTracks[0].Segments[1].(*template.ImportedMediaSegment)
+	if seg.MaterialID != "short" {
+		t.Errorf("期望片段素材id为 'short'，得到 '%s'", seg.MaterialID)
+	}
+	if seg.TargetTimerange.Duration != 1000000 || seg.SourceTimerange.Duration != 1000000 {
+		t.Errorf("期望片段时长为1000000，得到 target=%d source=%d", seg.TargetTimerange.Duration, seg.SourceTimerange.Duration)
+	}
+	if !sf.Materials.Contains(shortVideo) {
+		t.Error("新素材应被加入草稿")
+	}
+	if sf.Duration != 3000000 {
+		t.Errorf("期望草稿时长更新为3000000，得到%d", sf.Duration)
+	}
+
+	// 素材变长且只允许延伸尾部时，与后续片段重叠应失败
+	sf = loadReplaceTestTemplate(t)
+	longVideo := newVideo("long", 3000000)
+	err := sf.ReplaceMaterialBySeg("主轨道", 0, longVideo, nil, "", []template.ExtendMode{template.ExtendModeExtendTail})
+	extensionErr, ok := err.(*util.ExtensionFailedError)
+	if !ok {
+		t.Fatalf("期望返回ExtensionFailedError，得到 %v", err)
+	}
+	if extensionErr.SegmentID != "segment_1" || extensionErr.MaterialID != "long" {
+		t.Errorf("错误信息中的片段或素材id不正确: %+v", extensionErr)
+	}
+
+	// 推移后续片段
+	if err := sf.ReplaceMaterialBySeg("主轨道", 0, longVideo, nil, "", []template.ExtendMode{template.ExtendModeExtendTail, template.ExtendModePushTail}); err != nil {
+		t.Fatalf("替换素材失败: %v", err)
+	}
+	next := sf.ImportedTracks[0].Segments[1].(*template.ImportedMediaSegment)
+	if next.TargetTimerange.Start != 3000000 {
+		t.Errorf("期望后续片段后移到3000000，得到%d", next.TargetTimerange.Start)
+	}
+
+	// 指定截取范围
+	sf = loadReplaceTestTemplate(t)
+	if err := sf.ReplaceMaterialBySeg("主轨道", 0, longVideo, types.NewTimerange(500000, 2000000), "", nil); err != nil {
+		t.Fatalf("替换素材失败: %v", err)
+	}
+	seg = sf.ImportedTracks[0].Segments[0].(*template.ImportedMediaSegment)
+	if seg.SourceTimerange.Start != 500000 || seg.TargetTimerange.Duration != 2000000 {
+		t.Errorf("截取范围处理错误: source=%v target=%v", seg.SourceTimerange, seg.TargetTimerange)
+	}
+
+	// 错误情况
+	if err := sf.ReplaceMaterialBySeg("不存在", 0, longVideo, nil, "", nil); !util.IsTrackNotFound(err) {
+		t.Errorf("期望返回TrackNotFoundError，得到 %v", err)
+	}
+	if err := sf.ReplaceMaterialBySeg("字幕", 0, longVideo, nil, "", nil); err == nil {
+		t.Error("期望文本轨道不支持素材替换")
+	}
+	if err := sf.ReplaceMaterialBySeg("主轨道", 5, longVideo, nil, "", nil); err == nil {
+		t.Error("期望片段下标越界时返回错误")
+	}
+	if err := sf.ReplaceMaterialBySeg("主轨道", 0, &material.AudioMaterial{MaterialID: "audio"}, nil, "", nil); err == nil {
+		t.Error("期望素材类型与轨道类型不匹配时返回错误")
+	}
+}
+
+// textContentOf 解析文本素材的content
+func textContentOf(t *testing.T, sf *ScriptFile) (string, [][2]int, []float64) {
+	t.Helper()
+
+	var content struct {
+		Text   string `json:"text"`
+		Styles []struct {
+			Range [2]int  `json:"range"`
+			Size  float64 `json:"size"`
+		} `json:"styles"`
+	}
+	if err := json.Unmarshal([]byte(sf.Content.Materials.Texts[0].Content), &content); err != nil {
+		t.Fatalf("解析文本内容失败: %v", err)
+	}
+	ranges := make([][2]int, len(content.Styles))
+	sizes := make([]float64, len(content.Styles))
+	for i, style := range content.Styles {
+		ranges[i] = style.Range
+		sizes[i] = style.Size
+	}
+	return content.Text, ranges, sizes
+}
+
+// TestScriptFileReplaceText 测试替换导入的文本片段的文字
+func TestScriptFileReplaceText(t *testing.T) {
+	sf := loadReplaceTestTemplate(t)
+
+	// 文本变长，样式范围等比例扩展
+	if err := sf.ReplaceText("字幕", 0, "今天天气真好"); err != nil {
+		t.Fatalf("替换文本失败: %v", err)
+	}
+	text, ranges, sizes := textContentOf(t, sf)
+	if text != "今天天气真好" {
+		t.Errorf("期望文本为 '今天天气真好'，得到 '%s'", text)
+	}
+	if len(ranges) != 2 || ranges[0] != [2]int{0, 3} || ranges[1] != [2]int{3, 6} {
+		t.Errorf("样式范围调整错误: %v", ranges)
+	}
+	if sizes[0] != 8 || sizes[1] != 12 {
+		t.Errorf("替换文本时应保留原有样式: %v", sizes)
+	}
+	if !strings.Contains(sf.Content.Materials.Texts[0].Content, `"color":[1,0,0]`) {
+		t.Error("替换文本时应保留样式中的其他属性")
+	}
+
+	// 文本过短时空样式被移除，剩余样式覆盖整个文本
+	if err := sf.ReplaceText("字幕", 0, "嗨"); err != nil {
+		t.Fatalf("替换文本失败: %v", err)
+	}
+	_, ranges, _ = textContentOf(t, sf)
+	if len(ranges) != 1 || ranges[0] != [2]int{0, 1} {
+		t.Errorf("样式范围调整错误: %v", ranges)
+	}
+
+	// 错误情况
+	if err := sf.ReplaceText("不存在", 0, "文本"); !util.IsTrackNotFound(err) {
+		t.Errorf("期望返回TrackNotFoundError，得到 %v", err)
+	}
+	if err := sf.ReplaceText("主轨道", 0, "文本"); err == nil {
+		t.Error("期望非文本轨道返回错误")
+	}
+	if err := sf.ReplaceText("字幕", 1, "文本"); err == nil {
+		t.Error("期望片段下标越界时返回错误")
+	}
+	sf.Content.Materials.Texts[0].ID = "other"
+	if err := sf.ReplaceText("字幕", 0, "文本"); !util.IsMaterialNotFound(err) {
+		t.Errorf("期望返回MaterialNotFoundError，得到 %v", err)
+	}
+}
+
+// TestScriptFileReplaceTextRanges 测试按样式分段替换导入的文本片段的文字
+func TestScriptFileReplaceTextRanges(t *testing.T) {
+	sf := loadReplaceTestTemplate(t)
+
+	if err := sf.ReplaceTextRanges("字幕", 0, []string{"Hello", "，世界"}); err != nil {
+		t.Fatalf("替换文本失败: %v", err)
+	}
+	text, ranges, sizes := textContentOf(t, sf)
+	if text != "Hello，世界" {
+		t.Errorf("期望文本为 'Hello，世界'，得到 '%s'", text)
+	}
+	if len(ranges) != 2 || ranges[0] != [2]int{0, 5} || ranges[1] != [2]int{5, 8} {
+		t.Errorf("样式范围调整错误: %v", ranges)
+	}
+	if sizes[0] != 8 || sizes[1] != 12 {
+		t.Errorf("替换文本时应保留原有样式: %v", sizes)
+	}
+
+	if err := sf.ReplaceTextRanges("字幕", 0, []string{"只有一段"}); err == nil {
+		t.Error("期望分段数量与样式数量不一致时返回错误")
+	}
+}
+
+// TestScriptFileInspectMaterial 测试素材检查功能
+func TestScriptFileInspectMaterial(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+
+	// 添加测试素材
+	sf.Content.Materials.Stickers = []*content.StickerMaterial{
+		{ResourceID: "sticker_123", Name: "测试贴纸"},
+	}
+
+	sf.Content.Materials.Effects = []*content.EffectMaterial{
+		{Type: "text_shape", EffectID: "effect_456", ResourceID: "bubble_789", Name: "文字气泡"},
+		{Type: "text_effect", ResourceID: "flower_101", Name: "花字效果"},
+	}
+
+	// 测试InspectMaterial方法（这个方法主要是打印，我们只验证它不会panic）
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("InspectMaterial发生panic: %v", r)
+		}
+	}()
+
+	sf.InspectMaterial()
+}
+
+// TestScriptFileJSONSerialization 测试JSON序列化兼容性
+func TestScriptFileJSONSerialization(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080, 30)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+
+	// 添加复杂数据
+	videoMaterial := &material.VideoMaterial{
+		MaterialID: "complex_video_123",
+		Path:       "/path/to/complex.mp4",
+		Width:      1920,
+		Height:     1080,
+		Duration:   10000000,
+		CropSettings: &material.CropSettings{
+			UpperLeftX:  0.0,
+			UpperLeftY:  0.0,
+			LowerRightX: 1.0,
+			LowerRightY: 1.0,
+		},
+	}
+	sf.AddMaterial(videoMaterial)
+
+	audioMaterial := &material.AudioMaterial{
+		MaterialID: "complex_audio_456",
+		Path:       "/path/to/complex.mp3",
+		Duration:   8000000,
+	}
+	sf.AddMaterial(audioMaterial)
+
+	trackName1 := "复杂视频轨道"
+	trackName2 := "复杂音频轨道"
+	sf.AddTrack(track.TrackTypeVideo, &trackName1, WithRelativeIndex(2))
+	sf.AddTrack(track.TrackTypeAudio, &trackName2, WithMute(true))
+
+	sf.Duration = 15000000
+
+	// 导出JSON
+	jsonStr, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("JSON导出失败: %v", err)
+	}
+
+	// 解析JSON验证结构
 	var parsedData map[string]interface{}
 	if err := json.Unmarshal([]byte(jsonStr), &parsedData); err != nil {
 		t.Fatalf("JSON解析失败: %v", err)
@@ -880,3 +1752,863 @@ func TestScriptFileErrorHandling(t *testing.T) {
 		t.Error("期望获取不存在类型的轨道时返回错误")
 	}
 }
+
+// TestScriptFileDumpsPathMapping 测试导出时改写素材路径
+func TestScriptFileDumpsPathMapping(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	video := &material.VideoMaterial{MaterialID: "video_1", Path: "/srv/media/clips/a.mp4", MaterialType: "video",
+		CropSettings: material.NewCropSettings()}
+	audio := &material.AudioMaterial{MaterialID: "audio_1", Path: "/srv/drafts/demo/music/b.mp3"}
+	sf.AddMaterial(video)
+	sf.AddMaterial(audio)
+
+	output, err := sf.Dumps(
+		WithPathMapping(util.PathRule{From: "/srv/media", To: `D:\media`}),
+		WithPathMapping(util.DraftRelativeRule("/srv/drafts/demo")),
+	)
+	if err != nil {
+		t.Fatalf("Dumps失败: %v", err)
+	}
+
+	var parsed struct {
+		Materials struct {
+			Videos []struct {
+				Path string `json:"path"`
+			} `json:"videos"`
+			Audios []struct {
+				Path string `json:"path"`
+			} `json:"audios"`
+		} `json:"materials"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("导出的JSON格式不正确: %v", err)
+	}
+	if len(parsed.Materials.Videos) != 1 || parsed.Materials.Videos[0].Path != `D:\media\clips\a.mp4` {
+		t.Errorf("视频素材路径映射不正确: %+v", parsed.Materials.Videos)
+	}
+	expected := util.DraftPathPlaceholder + "/music/b.mp3"
+	if len(parsed.Materials.Audios) != 1 || parsed.Materials.Audios[0].Path != expected {
+		t.Errorf("音频素材路径期望为 %s，得到 %+v", expected, parsed.Materials.Audios)
+	}
+
+	// 草稿中的素材路径不受影响
+	if video.Path != "/srv/media/clips/a.mp4" || audio.Path != "/srv/drafts/demo/music/b.mp3" {
+		t.Errorf("导出不应修改素材路径，得到 %s 和 %s", video.Path, audio.Path)
+	}
+}
+
+// TestScriptFileAddStickerSegment 测试添加贴纸片段
+func TestScriptFileAddStickerSegment(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	sf.AddTrack(track.TrackTypeSticker, nil)
+
+	sticker := segment.NewStickerSegment("7226264929129287177", types.NewTimerange(0, 3*types.SEC), nil)
+	if err := sticker.AddKeyframe("rotation", int64(0), 30); err != nil {
+		t.Fatalf("添加关键帧失败: %v", err)
+	}
+	if err := sticker.Animations.AddTextAnimation(metadata.TextIntro打字机, 0, types.SEC/2); err != nil {
+		t.Fatalf("添加动画失败: %v", err)
+	}
+	if err := sf.AddSegment(sticker, nil); err != nil {
+		t.Fatalf("添加贴纸片段失败: %v", err)
+	}
+	if problems := sf.Validate(); len(problems) != 0 {
+		t.Errorf("草稿不应存在问题，得到 %v", problems)
+	}
+
+	output, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("Dumps失败: %v", err)
+	}
+	var parsed struct {
+		Materials struct {
+			Stickers []struct {
+				ID         string `json:"id"`
+				ResourceID string `json:"resource_id"`
+			} `json:"stickers"`
+			Animations []struct {
+				ID string `json:"id"`
+			} `json:"material_animations"`
+		} `json:"materials"`
+		Tracks []struct {
+			Type     string `json:"type"`
+			Segments []struct {
+				MaterialID        string   `json:"material_id"`
+				ExtraMaterialRefs []string `json:"extra_material_refs"`
+			} `json:"segments"`
+		} `json:"tracks"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("导出的JSON格式不正确: %v", err)
+	}
+
+	stickers := parsed.Materials.Stickers
+	if len(stickers) != 1 || stickers[0].ResourceID != "7226264929129287177" {
+		t.Fatalf("贴纸素材导出不正确: %+v", stickers)
+	}
+	if len(parsed.Tracks) != 1 || parsed.Tracks[0].Type != "sticker" || len(parsed.Tracks[0].Segments) != 1 {
+		t.Fatalf("贴纸轨道导出不正确: %+v", parsed.Tracks)
+	}
+	seg := parsed.Tracks[0].Segments[0]
+	if seg.MaterialID != stickers[0].ID {
+		t.Errorf("片段应引用贴纸素材 %s，得到 %s", stickers[0].ID, seg.MaterialID)
+	}
+	if len(parsed.Materials.Animations) != 1 {
+		t.Fatalf("应导出1个动画素材，得到 %d", len(parsed.Materials.Animations))
+	}
+	found := false
+	for _, ref := range seg.ExtraMaterialRefs {
+		found = found || ref == parsed.Materials.Animations[0].ID
+	}
+	if !found {
+		t.Errorf("片段应引用动画素材，得到 %v", seg.ExtraMaterialRefs)
+	}
+}
+
+// TestScriptFileFitMaterial 测试计算素材放入画布的裁剪及缩放设置
+func TestScriptFileFitMaterial(t *testing.T) {
+	sf, err := NewScriptFile(1080, 1920) // 竖屏画布
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	video := &material.VideoMaterial{MaterialName: "clip.mp4", Width: 1920, Height: 1080, CropSettings: material.NewCropSettings()}
+
+	crop, clip, err := sf.FitMaterial(video, FitModeFit, material.CropAnchorCenter)
+	if err != nil {
+		t.Fatalf("计算失败: %v", err)
+	}
+	if *crop != *material.NewCropSettings() || clip.ScaleX != 1 || clip.ScaleY != 1 {
+		t.Errorf("fit方式不应裁剪或缩放，得到 %+v %+v", crop, clip)
+	}
+
+	crop, clip, err = sf.FitMaterial(video, FitModeFill, material.CropAnchorCenter)
+	if err != nil {
+		t.Fatalf("计算失败: %v", err)
+	}
+	x, y, w, h := crop.Rect()
+	if math.Abs(w*1920/(h*1080)-1080.0/1920.0) > 1e-9 || math.Abs(x-(1-w)/2) > 1e-9 || y != 0 || h != 1 {
+		t.Errorf("fill方式应居中裁剪为画布的宽高比，得到 (%g, %g, %g, %g)", x, y, w, h)
+	}
+	if clip.ScaleX != 1 || clip.ScaleY != 1 {
+		t.Errorf("fill方式不应缩放，得到 %+v", clip)
+	}
+	if *video.CropSettings != *material.NewCropSettings() {
+		t.Error("计算不应修改素材")
+	}
+
+	_, clip, err = sf.FitMaterial(video, FitModeStretch, material.CropAnchorCenter)
+	if err != nil {
+		t.Fatalf("计算失败: %v", err)
+	}
+	// 以宽度放入画布后，高度需拉伸为原来的 1920/(1080*1080/1920) 倍
+	if clip.ScaleX != 1 || math.Abs(clip.ScaleY-1920.0*1920.0/(1080.0*1080.0)) > 1e-9 {
+		t.Errorf("stretch方式的缩放比例不正确: %+v", clip)
+	}
+
+	if _, _, err := sf.FitMaterial(&material.VideoMaterial{}, FitModeFit, material.CropAnchorCenter); err == nil {
+		t.Error("素材尺寸未知时应返回错误")
+	}
+}
+
+// writeToneWAV 写入单声道16位、48kHz的1kHz正弦波WAV文件，amplitude为峰值电平，单位为dBFS
+func writeToneWAV(t *testing.T, path string, amplitude, seconds float64) {
+	t.Helper()
+	frames := int(seconds * 48000)
+	var data bytes.Buffer
+	for i := 0; i < frames; i++ {
+		x := math.Pow(10, amplitude/20) * math.Sin(2*math.Pi*1000*float64(i)/48000)
+		binary.Write(&data, binary.LittleEndian, int16(math.Round(x*32767)))
+	}
+
+	var wav bytes.Buffer
+	wav.WriteString("RIFF")
+	binary.Write(&wav, binary.LittleEndian, uint32(36+data.Len()))
+	wav.WriteString("WAVEfmt ")
+	for _, field := range []interface{}{uint32(16), uint16(1), uint16(1), uint32(48000), uint32(96000), uint16(2), uint16(16)} {
+		binary.Write(&wav, binary.LittleEndian, field)
+	}
+	wav.WriteString("data")
+	binary.Write(&wav, binary.LittleEndian, uint32(data.Len()))
+	wav.Write(data.Bytes())
+	if err := os.WriteFile(path, wav.Bytes(), 0644); err != nil {
+		t.Fatalf("写入WAV文件失败: %v", err)
+	}
+}
+
+// TestScriptFileNormalizeLoudness 测试统一片段的响度
+func TestScriptFileNormalizeLoudness(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	sf.AddTrack(track.TrackTypeAudio, nil).AddTrack(track.TrackTypeVideo, nil)
+
+	dir := t.TempDir()
+	quietPath, loudPath := filepath.Join(dir, "quiet.wav"), filepath.Join(dir, "loud.wav")
+	writeToneWAV(t, quietPath, -30, 2) // 单声道约为-33 LUFS
+	writeToneWAV(t, loudPath, -3, 2)   // 单声道约为-6 LUFS
+	quiet := &material.AudioMaterial{MaterialID: "quiet", MaterialName: "quiet.wav", Path: quietPath, Duration: 2 * types.SEC}
+	loud := &material.AudioMaterial{MaterialID: "loud", MaterialName: "loud.wav", Path: loudPath, Duration: 2 * types.SEC}
+	video := &material.VideoMaterial{MaterialID: "video", MaterialName: "clip.mp4", HasAudio: true,
+		CropSettings: material.NewCropSettings(), Loudness: &loudness.Result{Integrated: -20, Peak: -1}}
+	silentVideo := &material.VideoMaterial{MaterialID: "silent", MaterialName: "silent.mp4", CropSettings: material.NewCropSettings()}
+	for _, mat := range []interface{}{quiet, loud, video, silentVideo} {
+		sf.AddMaterial(mat)
+	}
+
+	quietSeg := segment.NewAudioSegment("quiet", types.NewTimerange(0, 2*types.SEC), nil, 1, 1)
+	loudSeg := segment.NewAudioSegment("loud", types.NewTimerange(2*types.SEC, 2*types.SEC), nil, 1, 1)
+	videoSeg := segment.NewVideoSegment("video", nil, types.NewTimerange(0, 2*types.SEC), 1, 1, nil)
+	silentSeg := segment.NewVideoSegment("silent", nil, types.NewTimerange(2*types.SEC, 2*types.SEC), 1, 1, nil)
+	for _, seg := range []interface{}{quietSeg, loudSeg, videoSeg, silentSeg} {
+		if err := sf.AddSegment(seg, nil); err != nil {
+			t.Fatalf("添加片段失败: %v", err)
+		}
+	}
+
+	report, err := sf.NormalizeLoudness(-14, WithPeakCeiling(-1))
+	if err != nil {
+		t.Fatalf("统一响度失败: %v", err)
+	}
+	if len(report.Adjusted) != 3 || len(report.Skipped) != 1 || report.Skipped[0].SegmentID != silentSeg.SegmentID {
+		t.Fatalf("报告不正确: 调整 %d 个，跳过 %d 个", len(report.Adjusted), len(report.Skipped))
+	}
+	if quiet.Loudness == nil || math.Abs(quiet.Loudness.Integrated+33) > 0.1 {
+		t.Errorf("未测量的音频素材应自动测量，得到 %+v", quiet.Loudness)
+	}
+
+	// 安静的素材需要+19dB，但峰值上限只允许+29dB以内，因此达到目标响度
+	if math.Abs(20*math.Log10(quietSeg.Volume)-(-14-quiet.Loudness.Integrated)) > 1e-9 {
+		t.Errorf("安静素材的音量不正确: %g", quietSeg.Volume)
+	}
+	// 响亮的素材需要-8dB
+	if math.Abs(20*math.Log10(loudSeg.Volume)-(-14-loud.Loudness.Integrated)) > 1e-9 {
+		t.Errorf("响亮素材的音量不正确: %g", loudSeg.Volume)
+	}
+	// 视频素材需要+6dB，但峰值上限只允许+0dB
+	if math.Abs(videoSeg.Volume-1) > 1e-9 {
+		t.Errorf("视频片段的音量应受峰值上限限制，得到 %g", videoSeg.Volume)
+	}
+	if silentSeg.Volume != 1 || silentSeg.Loudness != nil {
+		t.Error("不含音轨的视频片段不应被调整")
+	}
+
+	// 导出的响度统一设置
+	output, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("导出失败: %v", err)
+	}
+	var draft map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &draft); err != nil {
+		t.Fatalf("解析导出内容失败: %v", err)
+	}
+	loudnesses := draft["materials"].(map[string]interface{})["loudnesses"].([]interface{})
+	if len(loudnesses) != 3 {
+		t.Fatalf("应导出3个响度统一设置，得到 %d 个", len(loudnesses))
+	}
+	first := loudnesses[0].(map[string]interface{})
+	if first["target_loudness"] != -14.0 || first["enable"] != true {
+		t.Errorf("响度统一设置不正确: %v", first)
+	}
+	refs := strings.Join(quietSeg.ExtraMaterialRefs, ",")
+	if !strings.Contains(refs, quietSeg.Loudness.GlobalID) {
+		t.Errorf("片段应引用响度统一设置，得到 %v", quietSeg.ExtraMaterialRefs)
+	}
+	// 每个导出的设置都由导出的片段通过extra_material_refs引用
+	exportedRefs := make(map[string]bool)
+	for _, tr := range draft["tracks"].([]interface{}) {
+		for _, seg := range tr.(map[string]interface{})["segments"].([]interface{}) {
+			for _, ref := range seg.(map[string]interface{})["extra_material_refs"].([]interface{}) {
+				exportedRefs[ref.(string)] = true
+			}
+		}
+	}
+	for _, item := range loudnesses {
+		if id := item.(map[string]interface{})["id"].(string); !exportedRefs[id] {
+			t.Errorf("响度统一设置 %s 未被任何片段引用", id)
+		}
+	}
+
+	// 再次统一时复用已有的设置，音量按新的目标重新计算
+	if _, err := sf.NormalizeLoudness(-23); err != nil {
+		t.Fatalf("统一响度失败: %v", err)
+	}
+	if math.Abs(20*math.Log10(quietSeg.Volume)-(-23-quiet.Loudness.Integrated)) > 1e-9 {
+		t.Errorf("再次统一后安静素材的音量不正确: %g", quietSeg.Volume)
+	}
+	if len(sf.Materials.Loudnesses) != 3 || quietSeg.Loudness.TargetLoudness != -23 {
+		t.Errorf("再次统一时应更新已有的设置，得到 %d 个设置", len(sf.Materials.Loudnesses))
+	}
+	if len(quietSeg.ExtraMaterialRefs) != len(strings.Split(refs, ",")) {
+		t.Errorf("再次统一时不应重复添加引用: %v", quietSeg.ExtraMaterialRefs)
+	}
+
+	if _, err := sf.NormalizeLoudness(3); err == nil {
+		t.Error("目标响度大于0时应返回错误")
+	}
+}
+
+// TestScriptFileSplitAt 测试在草稿中分割片段
+func TestScriptFileSplitAt(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	sf.AddTrack(track.TrackTypeVideo, nil).AddTrack(track.TrackTypeAudio, nil).AddTrack(track.TrackTypeText, nil)
+
+	videoMat := &material.VideoMaterial{MaterialID: "video", MaterialName: "clip.mp4", MaterialType: material.MaterialTypeVideo,
+		Duration: 10 * types.SEC, CropSettings: material.NewCropSettings()}
+	audioMat := &material.AudioMaterial{MaterialID: "audio", MaterialName: "music.mp3", Duration: 10 * types.SEC}
+	sf.AddMaterial(videoMat)
+	sf.AddMaterial(audioMat)
+
+	video := segment.NewVideoSegment("video", types.NewTimerange(0, 4*types.SEC), types.NewTimerange(0, 4*types.SEC), 1, 1, nil)
+	if err := video.Animations.AddVideoAnimation(metadata.OutroType缩小, 3*types.SEC, 1*types.SEC); err != nil {
+		t.Fatalf("添加出场动画失败: %v", err)
+	}
+	video.AddFilter("暖色", "filter_1", "filter_resource", 0.8, 0)
+	video.AddMask("circle", "圆形", "image", "mask_resource", 0, 0, 1, 0, 0, false, nil, nil)
+	audio := segment.NewAudioSegment("audio", types.NewTimerange(0, 4*types.SEC), nil, 1, 1)
+	if err := audio.AddFade("1s", "1s"); err != nil {
+		t.Fatalf("添加淡入淡出失败: %v", err)
+	}
+	text := segment.NewTextSegment("字幕", types.NewTimerange(0, 4*types.SEC), "", nil, nil)
+	for _, seg := range []interface{}{video, audio, text} {
+		if err := sf.AddSegment(seg, nil); err != nil {
+			t.Fatalf("添加片段失败: %v", err)
+		}
+	}
+
+	right, err := sf.SplitAt("video", 2*types.SEC)
+	if err != nil {
+		t.Fatalf("分割视频片段失败: %v", err)
+	}
+	rightVideo := right.(*segment.VideoSegment)
+	if !sf.Materials.Contains(rightVideo.Speed) || !sf.Materials.Contains(rightVideo.Animations) {
+		t.Error("后一部分的变速及动画应加入素材列表")
+	}
+	if len(sf.Materials.Speeds) != 3 {
+		t.Errorf("期望3个变速，得到 %d 个", len(sf.Materials.Speeds))
+	}
+	if !sf.Materials.Contains(rightVideo.Filters[0]) || !sf.Materials.Contains(rightVideo.Mask) ||
+		len(sf.Materials.Filters) != 2 || len(sf.Materials.Masks) != 2 {
+		t.Error("后一部分复制的滤镜及蒙版应作为新素材加入素材列表")
+	}
+
+	rightAudio, err := sf.SplitAt("audio", 2*types.SEC)
+	if err != nil {
+		t.Fatalf("分割音频片段失败: %v", err)
+	}
+	if !sf.Materials.Contains(rightAudio.(*segment.AudioSegment).Fade) || len(sf.Materials.AudioFades) != 2 {
+		t.Error("后一部分的淡入淡出应加入素材列表")
+	}
+
+	for _, problem := range sf.Validate() {
+		if !strings.Contains(problem.Error(), "'text'") {
+			t.Errorf("视频及音频轨道不应存在问题，得到 %v", problem)
+		}
+	}
+
+	if _, err := sf.SplitAt("text", 1*types.SEC); err != nil {
+		t.Fatalf("分割文本片段失败: %v", err)
+	}
+	if len(sf.Materials.Texts) != 2 {
+		t.Errorf("期望2个文本素材，得到 %d 个", len(sf.Materials.Texts))
+	}
+
+	if _, err := sf.SplitAt("missing", 1*types.SEC); err == nil {
+		t.Error("分割不存在的轨道时应返回错误")
+	}
+	if _, err := sf.SplitAt("video", 4*types.SEC); err == nil {
+		t.Error("在片段边界处分割时应返回错误")
+	}
+
+	if _, err := sf.Dumps(); err != nil {
+		t.Fatalf("Dumps失败: %v", err)
+	}
+}
+
+// TestScriptFileSplitMaterialCounts 测试分割后导出的素材数量，每个导出的素材都由片段引用
+func TestScriptFileSplitMaterialCounts(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	sf.AddTrack(track.TrackTypeVideo, nil).AddTrack(track.TrackTypeAudio, nil)
+
+	video := segment.NewVideoSegment("video", types.NewTimerange(0, 4*types.SEC), types.NewTimerange(0, 4*types.SEC), 1, 1, nil)
+	intro, err := metadata.FindIntroByName("渐显")
+	if err != nil {
+		t.Fatalf("查找入场动画失败: %v", err)
+	}
+	if err := video.Animations.AddVideoAnimation(intro, 0, 1*types.SEC); err != nil {
+		t.Fatalf("添加入场动画失败: %v", err)
+	}
+	if err := video.Animations.AddVideoAnimation(metadata.OutroType缩小, 3*types.SEC, 1*types.SEC); err != nil {
+		t.Fatalf("添加出场动画失败: %v", err)
+	}
+	audio := segment.NewAudioSegment("audio", types.NewTimerange(0, 4*types.SEC), nil, 1, 1)
+	if err := audio.AddFade("0s", "1s"); err != nil {
+		t.Fatalf("添加淡入淡出失败: %v", err)
+	}
+	for _, seg := range []interface{}{video, audio} {
+		if err := sf.AddSegment(seg, nil); err != nil {
+			t.Fatalf("添加片段失败: %v", err)
+		}
+	}
+
+	if _, err := sf.SplitAt("video", 2*types.SEC); err != nil {
+		t.Fatalf("分割视频片段失败: %v", err)
+	}
+	if _, err := sf.SplitAt("audio", 2*types.SEC); err != nil {
+		t.Fatalf("分割音频片段失败: %v", err)
+	}
+
+	output, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("Dumps失败: %v", err)
+	}
+	var parsed struct {
+		Materials struct {
+			AudioFades []struct {
+				ID string `json:"id"`
+			} `json:"audio_fades"`
+			Animations []struct {
+				ID string `json:"id"`
+			} `json:"material_animations"`
+		} `json:"materials"`
+		Tracks []struct {
+			Segments []struct {
+				ExtraMaterialRefs []string `json:"extra_material_refs"`
+			} `json:"segments"`
+		} `json:"tracks"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("导出的JSON格式不正确: %v", err)
+	}
+
+	if len(parsed.Materials.AudioFades) != 1 {
+		t.Errorf("期望导出1个淡入淡出效果，得到 %d 个", len(parsed.Materials.AudioFades))
+	}
+	if len(parsed.Materials.Animations) != 2 {
+		t.Errorf("期望导出2个动画素材，得到 %d 个", len(parsed.Materials.Animations))
+	}
+	refs := make(map[string]bool)
+	for _, tr := range parsed.Tracks {
+		for _, seg := range tr.Segments {
+			for _, ref := range seg.ExtraMaterialRefs {
+				refs[ref] = true
+			}
+		}
+	}
+	for _, fade := range parsed.Materials.AudioFades {
+		if !refs[fade.ID] {
+			t.Errorf("淡入淡出效果 %s 未被任何片段引用", fade.ID)
+		}
+	}
+	for _, ani := range parsed.Materials.Animations {
+		if !refs[ani.ID] {
+			t.Errorf("动画素材 %s 未被任何片段引用", ani.ID)
+		}
+	}
+}
+
+// TestScriptFileRipple 测试跨轨道的波纹插入及删除
+func TestScriptFileRipple(t *testing.T) {
+	sf := loadReplaceTestTemplate(t)
+	sf.AddTrack(track.TrackTypeAudio, nil)
+	audio := segment.NewAudioSegment("audio", types.NewTimerange(1*types.SEC, 3*types.SEC), nil, 1, 1)
+	if err := audio.AddFade("0.5s", "0.5s"); err != nil {
+		t.Fatalf("添加淡入淡出失败: %v", err)
+	}
+	if err := sf.AddSegment(audio, nil); err != nil {
+		t.Fatalf("添加片段失败: %v", err)
+	}
+	video := sf.ImportedTracks[0]
+	subtitle := sf.ImportedTracks[1]
+
+	// 任一轨道无法完成编辑时返回错误，所有轨道均保持不变
+	if err := sf.RippleInsert(1*types.SEC, 1*types.SEC, WithRipplePolicy(track.RippleError)); err == nil {
+		t.Error("导入的片段跨越编辑点时应返回错误")
+	}
+	if audio.Start() != 1*types.SEC || len(sf.Tracks["audio"].Segments) != 1 {
+		t.Error("出错时不应修改任何轨道")
+	}
+
+	// 在主轨道两个片段之间插入1s，音频片段被分割，字幕轨道不参与编辑
+	if err := sf.RippleInsert(2*types.SEC, 1*types.SEC, WithRippleTracks("主轨道", "audio")); err != nil {
+		t.Fatalf("波纹插入失败: %v", err)
+	}
+	if video.Segments[0].Start() != 0 || video.Segments[1].Start() != 3*types.SEC {
+		t.Errorf("主轨道的片段未正确推移: %d, %d", video.Segments[0].Start(), video.Segments[1].Start())
+	}
+	if subtitle.Segments[0].Start() != 0 || subtitle.Segments[0].Duration() != 2*types.SEC {
+		t.Error("未选中的轨道不应被修改")
+	}
+	audioSegments := sf.Tracks["audio"].Segments
+	if len(audioSegments) != 2 || audioSegments[1].Start() != 3*types.SEC || audioSegments[1].Duration() != 2*types.SEC {
+		t.Fatalf("音频片段未正确分割")
+	}
+	if !sf.Materials.Contains(audioSegments[1].(*segment.AudioSegment).Fade) {
+		t.Error("分割产生的淡入淡出应加入素材列表")
+	}
+	if sf.Duration != 5*types.SEC {
+		t.Errorf("期望草稿时长为5s，得到 %d", sf.Duration)
+	}
+
+	// 删除插入的空白，音频片段整体推移
+	if err := sf.RippleDelete(2*types.SEC, 3*types.SEC, WithRipplePolicy(track.RippleError)); err != nil {
+		t.Fatalf("波纹删除失败: %v", err)
+	}
+	if video.Segments[1].Start() != 2*types.SEC || audioSegments[1].Start() != 2*types.SEC {
+		t.Error("删除后片段未正确推移")
+	}
+	if subtitle.Segments[0].Duration() != 2*types.SEC || sf.Duration != 4*types.SEC {
+		t.Errorf("删除后的草稿时长不正确: %d", sf.Duration)
+	}
+
+	if err := sf.RippleDelete(0, 1*types.SEC, WithRippleTracks("不存在")); err == nil {
+		t.Error("轨道不存在时应返回错误")
+	}
+	if err := sf.RippleDelete(1*types.SEC, 3*types.SEC, WithRipplePolicy(track.RippleError)); err == nil {
+		t.Error("存在跨越编辑点的片段时应返回错误")
+	}
+
+	output, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("导出失败: %v", err)
+	}
+	if !strings.Contains(output, `"segment_2"`) {
+		t.Error("导出内容应包含导入的片段")
+	}
+}
+
+// TestScriptFileRippleImportedSegments 测试波纹编辑时分割导入的片段，以及与导入轨道同名的新建轨道
+func TestScriptFileRippleImportedSegments(t *testing.T) {
+	sf := loadReplaceTestTemplate(t)
+	video := sf.ImportedTracks[0]
+	subtitle := sf.ImportedTracks[1]
+
+	if err := sf.RippleInsert(1*types.SEC, 1*types.SEC); err != nil {
+		t.Fatalf("波纹插入失败: %v", err)
+	}
+	if len(video.Segments) != 3 || len(subtitle.Segments) != 2 {
+		t.Fatalf("跨越编辑点的导入片段应被分割，得到 %d, %d 个片段", len(video.Segments), len(subtitle.Segments))
+	}
+	left, right := video.Segments[0].(*template.ImportedMediaSegment), video.Segments[1].(*template.ImportedMediaSegment)
+	if *left.TargetTimerange != *types.NewTimerange(0, 1*types.SEC) || *left.SourceTimerange != *types.NewTimerange(0, 1*types.SEC) {
+		t.Errorf("前一部分的时间范围不正确: %+v %+v", left.TargetTimerange, left.SourceTimerange)
+	}
+	if *right.TargetTimerange != *types.NewTimerange(2*types.SEC, 1*types.SEC) || *right.SourceTimerange != *types.NewTimerange(1*types.SEC, 1*types.SEC) {
+		t.Errorf("后一部分的时间范围不正确: %+v %+v", right.TargetTimerange, right.SourceTimerange)
+	}
+	if video.Segments[2].Start() != 3*types.SEC || subtitle.Segments[1].Start() != 2*types.SEC || sf.Duration != 5*types.SEC {
+		t.Errorf("编辑点之后的片段未正确推移，草稿时长 %d", sf.Duration)
+	}
+
+	// 删除范围跨越的导入片段被裁剪
+	if err := sf.RippleDelete(500000, 2500000); err != nil {
+		t.Fatalf("波纹删除失败: %v", err)
+	}
+	if *left.TargetTimerange != *types.NewTimerange(0, 500000) || *right.TargetTimerange != *types.NewTimerange(500000, 500000) ||
+		*right.SourceTimerange != *types.NewTimerange(1500000, 500000) {
+		t.Errorf("裁剪后的时间范围不正确: %+v %+v %+v", left.TargetTimerange, right.TargetTimerange, right.SourceTimerange)
+	}
+
+	output, err := sf.Dumps()
+	if err != nil {
+		t.Fatalf("导出失败: %v", err)
+	}
+	if !strings.Contains(output, `"segment_1"`) || !strings.Contains(output, right.SegmentID) {
+		t.Error("导出内容应包含分割产生的两部分")
+	}
+
+	// 名称同时对应新建轨道及导入轨道时返回错误
+	name := "主轨道"
+	sf.AddTrack(track.TrackTypeVideo, &name)
+	if err := sf.RippleInsert(0, 1*types.SEC, WithRippleTracks(name)); err == nil {
+		t.Error("名称同时对应新建轨道及导入轨道时应返回错误")
+	}
+	if video.Segments[0].Start() != 0 {
+		t.Error("出错时不应修改任何轨道")
+	}
+}
+
+// TestScriptFileAppendSequence 测试首尾相接地追加片段
+func TestScriptFileAppendSequence(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	sf.AddTrack(track.TrackTypeVideo, nil)
+	sf.AddMaterial(&material.VideoMaterial{MaterialID: "clip1", MaterialName: "clip1.mp4", MaterialType: material.MaterialTypeVideo,
+		Duration: 4 * types.SEC, CropSettings: material.NewCropSettings()})
+	sf.AddMaterial(&material.VideoMaterial{MaterialID: "clip2", MaterialName: "clip2.mp4", MaterialType: material.MaterialTypeVideo,
+		Duration: 6 * types.SEC, CropSettings: material.NewCropSettings()})
+
+	first := segment.NewVideoSegment("clip1", nil, nil, 1, 1, nil)
+	second := segment.NewVideoSegment("clip2", nil, nil, 2, 1, nil)
+	third := segment.NewVideoSegment("clip1", types.NewTimerange(1*types.SEC, 1*types.SEC), nil, 1, 1, nil)
+	if err := sf.AppendSequence("video", first, second, third); err != nil {
+		t.Fatalf("追加片段失败: %v", err)
+	}
+
+	expected := []*types.Timerange{
+		types.NewTimerange(0, 4*types.SEC),
+		types.NewTimerange(4*types.SEC, 3*types.SEC),
+		types.NewTimerange(7*types.SEC, 1*types.SEC),
+	}
+	for i, seg := range []*segment.VideoSegment{first, second, third} {
+		if *seg.TargetTimerange != *expected[i] {
+			t.Errorf("第%d个片段的时间范围不正确: %+v", i+1, seg.TargetTimerange)
+		}
+	}
+	if sf.Duration != 8*types.SEC {
+		t.Errorf("期望草稿时长为8s，得到 %d", sf.Duration)
+	}
+	if !sf.Materials.Contains(second.Speed) {
+		t.Error("片段的变速应加入素材列表")
+	}
+	if len(sf.Materials.Videos) != 2 {
+		t.Errorf("不应重复添加视频素材，得到 %d 个", len(sf.Materials.Videos))
+	}
+
+	if err := sf.AppendSequence("video", segment.NewVideoSegment("missing", nil, nil, 1, 1, nil)); err == nil {
+		t.Error("无法确定片段时长时应返回错误")
+	}
+	if err := sf.AppendSequence("不存在", first); err == nil {
+		t.Error("轨道不存在时应返回错误")
+	}
+	if problems := sf.Validate(); len(problems) != 0 {
+		t.Errorf("草稿不应存在问题，得到 %v", problems)
+	}
+}
+
+// TestScriptFileAddSegmentToFreeTrack 测试将重叠的片段放到空闲的同类型轨道上
+func TestScriptFileAddSegmentToFreeTrack(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+
+	names := make([]string, 0, 3)
+	for _, tr := range []*types.Timerange{
+		types.NewTimerange(0, 2*types.SEC),
+		types.NewTimerange(1*types.SEC, 2*types.SEC),
+		types.NewTimerange(2*types.SEC, 2*types.SEC),
+		types.NewTimerange(1500000, 1*types.SEC),
+	} {
+		name, err := sf.AddSegmentToFreeTrack(segment.NewTextSegment("字幕", tr, "", nil, nil))
+		if err != nil {
+			t.Fatalf("添加片段失败: %v", err)
+		}
+		names = append(names, name)
+	}
+
+	expected := []string{"text", "text_2", "text", "text_3"}
+	for i, name := range names {
+		if name != expected[i] {
+			t.Errorf("第%d个片段应加入轨道 %s，得到 %s", i+1, expected[i], name)
+		}
+	}
+	if sf.Tracks["text_2"].RenderIndex <= sf.Tracks["text"].RenderIndex || sf.Tracks["text_3"].RenderIndex <= sf.Tracks["text_2"].RenderIndex {
+		t.Error("新建的轨道应位于已有的同类型轨道之上")
+	}
+	if sf.Duration != 4*types.SEC {
+		t.Errorf("期望草稿时长为4s，得到 %d", sf.Duration)
+	}
+
+	if _, err := sf.AddSegmentToFreeTrack(segment.NewTextSegment("字幕", nil, "", nil, nil)); err == nil {
+		t.Error("片段未指定时间范围时应返回错误")
+	}
+}
+
+// TestScriptFileAddSegmentOverlapPolicy 测试添加片段时的重叠处理方式
+func TestScriptFileAddSegmentOverlapPolicy(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	sf.AddTrack(track.TrackTypeVideo, nil)
+	sf.AddMaterial(&material.VideoMaterial{MaterialID: "clip", MaterialName: "clip.mp4", MaterialType: material.MaterialTypeVideo,
+		Duration: 10 * types.SEC, CropSettings: material.NewCropSettings()})
+	existing := segment.NewVideoSegment("clip", types.NewTimerange(0, 6*types.SEC), types.NewTimerange(0, 6*types.SEC), 1, 1, nil)
+	if err := sf.AddSegment(existing, nil); err != nil {
+		t.Fatalf("添加片段失败: %v", err)
+	}
+
+	// 默认拒绝
+	newSegment := func() *segment.VideoSegment {
+		return segment.NewVideoSegment("clip", types.NewTimerange(0, 2*types.SEC), types.NewTimerange(2*types.SEC, 2*types.SEC), 1, 1, nil)
+	}
+	var overlap *util.SegmentOverlapError
+	if err := sf.AddSegment(newSegment(), nil); !errors.As(err, &overlap) {
+		t.Fatalf("期望返回SegmentOverlapError，得到 %v", err)
+	}
+
+	// 放到其他轨道上
+	spilled := newSegment()
+	if err := sf.AddSegment(spilled, nil, WithOverlapPolicy(track.OverlapSpill)); err != nil {
+		t.Fatalf("添加片段失败: %v", err)
+	}
+	if len(sf.Tracks) != 2 || len(sf.Tracks["video_2"].Segments) != 1 || sf.Tracks["video_2"].Segments[0] != spilled {
+		t.Error("片段应被放到新建的视频轨道上")
+	}
+
+	// 插入到主轨道中，原片段被分割并推移
+	name := "video"
+	if err := sf.AddSegment(newSegment(), &name, WithOverlapPolicy(track.OverlapInsert)); err != nil {
+		t.Fatalf("插入片段失败: %v", err)
+	}
+	main := sf.Tracks["video"]
+	if len(main.Segments) != 3 || main.EndTime() != 8*types.SEC || sf.Duration != 8*types.SEC {
+		t.Errorf("插入后的轨道不正确: %d 个片段，结束于 %d", len(main.Segments), main.EndTime())
+	}
+	if right := main.Segments[2].(*segment.VideoSegment); right.Start() != 4*types.SEC || !sf.Materials.Contains(right.Speed) {
+		t.Error("分割产生的变速应加入素材列表")
+	}
+
+	// 覆盖插入的片段及前后各1s
+	overwrite := segment.NewVideoSegment("clip", types.NewTimerange(0, 4*types.SEC), types.NewTimerange(1*types.SEC, 4*types.SEC), 1, 1, nil)
+	if err := sf.AddSegment(overwrite, &name, WithOverlapPolicy(track.OverlapOverwrite)); err != nil {
+		t.Fatalf("覆盖片段失败: %v", err)
+	}
+	if len(main.Segments) != 3 || existing.Duration() != 1*types.SEC || main.Segments[1] != overwrite || main.Segments[2].Start() != 5*types.SEC {
+		t.Errorf("覆盖后的轨道不正确: %d 个片段", len(main.Segments))
+	}
+
+	if problems := sf.Validate(); len(problems) != 0 {
+		t.Errorf("草稿不应存在问题，得到 %v", problems)
+	}
+}
+
+// TestScriptFileGaps 测试检测及填补轨道上的空隙
+func TestScriptFileGaps(t *testing.T) {
+	newScript := func() *ScriptFile {
+		sf, err := NewScriptFile(1920, 1080)
+		if err != nil {
+			t.Fatalf("创建ScriptFile失败: %v", err)
+		}
+		sf.AddTrack(track.TrackTypeVideo, nil).AddTrack(track.TrackTypeText, nil)
+		for _, tr := range []*types.Timerange{types.NewTimerange(1*types.SEC, 2*types.SEC), types.NewTimerange(4*types.SEC, 2*types.SEC)} {
+			if err := sf.AddSegment(segment.NewVideoSegment("clip", nil, tr, 1, 1, nil), nil); err != nil {
+				t.Fatalf("添加片段失败: %v", err)
+			}
+		}
+		// 字幕轨道决定草稿时长
+		if err := sf.AddSegment(segment.NewTextSegment("字幕", types.NewTimerange(0, 8*types.SEC), "", nil, nil), nil); err != nil {
+			t.Fatalf("添加片段失败: %v", err)
+		}
+		return sf
+	}
+
+	sf := newScript()
+	gaps, err := sf.Gaps()
+	if err != nil {
+		t.Fatalf("检测空隙失败: %v", err)
+	}
+	expected := []*types.Timerange{
+		types.NewTimerange(0, 1*types.SEC),
+		types.NewTimerange(3*types.SEC, 1*types.SEC),
+		types.NewTimerange(6*types.SEC, 2*types.SEC),
+	}
+	if len(gaps) != len(expected) {
+		t.Fatalf("期望 %d 个空隙，得到 %d 个", len(expected), len(gaps))
+	}
+	for i, gap := range gaps {
+		if gap.TrackName != "video" || *gap.Timerange != *expected[i] {
+			t.Errorf("第%d个空隙不正确: %s %+v", i+1, gap.TrackName, gap.Timerange)
+		}
+	}
+	if gaps, _ := sf.Gaps(WithMinGap(2 * types.SEC)); len(gaps) != 1 {
+		t.Errorf("期望1个不短于2s的空隙，得到 %d 个", len(gaps))
+	}
+	if gaps, _ := sf.Gaps(WithGapTracks("text")); len(gaps) != 0 {
+		t.Errorf("字幕轨道不应有空隙，得到 %d 个", len(gaps))
+	}
+	if _, err := sf.Gaps(WithGapTracks("不存在")); err == nil {
+		t.Error("轨道不存在时应返回错误")
+	}
+
+	// 插入纯色背景的占位片段
+	if err := sf.FillGaps(track.GapPlaceholder); err == nil {
+		t.Error("未指定占位素材时应返回错误")
+	}
+	photo := &material.VideoMaterial{MaterialID: "placeholder", MaterialName: "blank.png", MaterialType: material.MaterialTypePhoto,
+		Duration: 10800 * types.SEC, CropSettings: material.NewCropSettings()}
+	if err := sf.FillGaps(track.GapPlaceholder, WithPlaceholderColor(photo, "#ffffff")); err != nil {
+		t.Fatalf("插入占位片段失败: %v", err)
+	}
+	if gaps, _ := sf.Gaps(); len(gaps) != 0 {
+		t.Errorf("填补后不应有空隙，得到 %d 个", len(gaps))
+	}
+	if len(sf.Materials.Canvases) != 3 || sf.Materials.Canvases[0].Color != "#ffffff" {
+		t.Errorf("期望3个纯色背景，得到 %d 个", len(sf.Materials.Canvases))
+	}
+	if len(sf.Materials.Videos) != 1 || sf.Materials.Videos[0] != photo {
+		t.Error("占位素材应加入素材列表")
+	}
+
+	// 延长相邻片段，末尾的空隙由最后一个片段覆盖
+	sf = newScript()
+	if err := sf.FillGaps(track.GapExtend, WithMinGap(1)); err != nil {
+		t.Fatalf("延长片段失败: %v", err)
+	}
+	video := sf.Tracks["video"]
+	if video.Segments[0].Start() != 0 || video.Segments[0].Duration() != 4*types.SEC || video.Segments[1].Duration() != 4*types.SEC {
+		t.Errorf("延长后的片段不正确: [%d, +%d) [%d, +%d)", video.Segments[0].Start(), video.Segments[0].Duration(),
+			video.Segments[1].Start(), video.Segments[1].Duration())
+	}
+
+	// 删除空隙，草稿时长随之更新
+	sf = newScript()
+	if err := sf.FillGaps(track.GapClose, WithGapTracks("video", "text")); err != nil {
+		t.Fatalf("删除空隙失败: %v", err)
+	}
+	if video := sf.Tracks["video"]; video.EndTime() != 4*types.SEC || sf.Duration != 8*types.SEC {
+		t.Errorf("删除空隙后的时长不正确: 轨道 %d，草稿 %d", video.EndTime(), sf.Duration)
+	}
+
+	// 删除空隙时字幕轨道一同推移，跨越空隙的字幕被裁掉空隙部分
+	sf = newScript()
+	if err := sf.FillGaps(track.GapClose, WithGapRipple(WithRippleTracks("text"))); err != nil {
+		t.Fatalf("删除空隙失败: %v", err)
+	}
+	if text := sf.Tracks["text"]; len(text.Segments) != 2 || text.EndTime() != 6*types.SEC || sf.Duration != 6*types.SEC {
+		t.Errorf("字幕轨道未随视频轨道推移: %d 个片段，结束于 %d，草稿 %d", len(text.Segments), text.EndTime(), sf.Duration)
+	}
+	if video := sf.Tracks["video"]; video.Segments[0].Start() != 0 || video.Segments[1].Start() != 2*types.SEC {
+		t.Errorf("删除空隙后的片段不正确: %d, %d", video.Segments[0].Start(), video.Segments[1].Start())
+	}
+}
+
+// TestScriptFileGapsImportedTracks 测试导入的轨道上的空隙
+func TestScriptFileGapsImportedTracks(t *testing.T) {
+	sf := loadReplaceTestTemplate(t)
+	if gaps, err := sf.Gaps(); err != nil || len(gaps) != 0 {
+		t.Errorf("默认不应检测导入的轨道，得到 %v %v", gaps, err)
+	}
+	if err := sf.FillGaps(track.GapExtend, WithGapTracks("主轨道")); err == nil {
+		t.Error("延长导入的轨道上的片段时应返回错误")
+	}
+
+	// 导入的轨道上的空隙可以删除
+	video := sf.ImportedTracks[0]
+	second := video.Segments[1].(*template.ImportedMediaSegment)
+	start := second.Start()
+	if err := video.MoveSegment(second.GetID(), start+1*types.SEC); err != nil {
+		t.Fatalf("移动片段失败: %v", err)
+	}
+	if err := sf.FillGaps(track.GapClose, WithGapTracks("主轨道")); err != nil {
+		t.Fatalf("删除导入的轨道上的空隙失败: %v", err)
+	}
+	if second.Start() != start {
+		t.Errorf("期望片段移回 %d，得到 %d", start, second.Start())
+	}
+}
@@ -0,0 +1,291 @@
+package script
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/track"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+// SRTCue SRT文件中的一条字幕
+type SRTCue struct {
+	Index     int              // 字幕序号，缺省时为0
+	Line      int              // 字幕块在文件中的起始行号（从1开始）
+	Timerange *types.Timerange // 字幕的时间范围
+	Text      string           // 字幕内容，多行字幕以换行符连接
+}
+
+// SRTCueError SRT文件中格式错误的字幕块
+type SRTCueError struct {
+	Line   int    // 字幕块的起始行号（从1开始）
+	Reason string // 错误原因
+}
+
+// Error 实现error接口
+func (e *SRTCueError) Error() string {
+	return fmt.Sprintf("第%d行: %s", e.Line, e.Reason)
+}
+
+// SRTParseError 解析SRT文件时发现的格式错误
+type SRTParseError struct {
+	Errors []*SRTCueError // 所有格式错误的字幕块
+}
+
+// Error 实现error接口
+func (e *SRTParseError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, cueErr := range e.Errors {
+		messages[i] = cueErr.Error()
+	}
+	return fmt.Sprintf("SRT文件中存在%d个格式错误的字幕: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+// ParseSRT 解析SRT格式的字幕
+//
+// 支持UTF-8 BOM、CRLF换行及多行字幕；格式错误的字幕块会被跳过，
+// 并通过*SRTParseError一并报告，此时返回的字幕列表仍包含所有格式正确的字幕
+func ParseSRT(r io.Reader) ([]*SRTCue, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取SRT内容失败: %v", err)
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	var cues []*SRTCue
+	var cueErrors []*SRTCueError
+
+	// 以空行分隔字幕块
+	var block []string
+	blockLine := 0
+	flush := func() {
+		if len(block) == 0 {
+			return
+		}
+		cue, reason := parseSRTBlock(block)
+		if reason != "" {
+			cueErrors = append(cueErrors, &SRTCueError{Line: blockLine, Reason: reason})
+		} else {
+			cue.Line = blockLine
+			cues = append(cues, cue)
+		}
+		block = nil
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		if len(block) == 0 {
+			blockLine = i + 1
+		}
+		block = append(block, line)
+	}
+	flush()
+
+	if len(cueErrors) > 0 {
+		return cues, &SRTParseError{Errors: cueErrors}
+	}
+	return cues, nil
+}
+
+// parseSRTBlock 解析单个字幕块，格式错误时返回错误原因
+func parseSRTBlock(lines []string) (*SRTCue, string) {
+	cue := &SRTCue{}
+
+	// 序号行可以省略
+	if !strings.Contains(lines[0], "-->") {
+		index, err := strconv.Atoi(strings.TrimSpace(lines[0]))
+		if err != nil {
+			return nil, fmt.Sprintf("无效的字幕序号 '%s'", lines[0])
+		}
+		cue.Index = index
+		lines = lines[1:]
+	}
+	if len(lines) == 0 {
+		return nil, "缺少时间轴"
+	}
+
+	start, end, reason := parseSRTTiming(lines[0])
+	if reason != "" {
+		return nil, reason
+	}
+	if len(lines) == 1 {
+		return nil, "字幕内容为空"
+	}
+
+	cue.Timerange = types.NewTimerange(start, end-start)
+	cue.Text = strings.Join(lines[1:], "\n")
+	return cue, ""
+}
+
+// parseSRTTiming 解析形如 "00:00:01,000 --> 00:00:02,500" 的时间轴
+func parseSRTTiming(line string) (int64, int64, string) {
+	parts := strings.SplitN(line, "-->", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Sprintf("无效的时间轴 '%s'", line)
+	}
+
+	// 结束时间之后可能跟有坐标等附加信息
+	endFields := strings.Fields(parts[1])
+	if len(endFields) == 0 {
+		return 0, 0, fmt.Sprintf("无效的时间轴 '%s'", line)
+	}
+
+	start, err := parseSRTTimestamp(parts[0])
+	if err != nil {
+		return 0, 0, err.Error()
+	}
+	end, err := parseSRTTimestamp(endFields[0])
+	if err != nil {
+		return 0, 0, err.Error()
+	}
+	if end <= start {
+		return 0, 0, fmt.Sprintf("结束时间不晚于开始时间 '%s'", strings.TrimSpace(line))
+	}
+	return start, end, ""
+}
+
+// parseSRTTimestamp 解析时间戳，兼容以'.'分隔毫秒的写法
+func parseSRTTimestamp(timestamp string) (int64, error) {
+	return types.SrtTimestamp(strings.Replace(strings.TrimSpace(timestamp), ".", ",", 1))
+}
+
+// SRTConfig 导入SRT字幕的配置
+type SRTConfig struct {
+	TimeOffset     int64                 // 时间偏移量，单位为微秒
+	StyleReference *segment.TextSegment  // 作为样式参考的文本片段
+	TextStyle      *segment.TextStyle    // 字幕样式，仅在未指定样式参考时生效
+	ClipSettings   *segment.ClipSettings // 图像调节设置，默认将字幕放置在画面下方
+	SkipMalformed  bool                  // 是否跳过格式错误的字幕，而非返回错误
+}
+
+// SRTOption 导入SRT字幕的选项函数类型
+type SRTOption func(*SRTConfig)
+
+// WithSRTTimeOffset 设置字幕的时间偏移量，单位为微秒
+func WithSRTTimeOffset(offset int64) SRTOption {
+	return func(c *SRTConfig) {
+		c.TimeOffset = offset
+	}
+}
+
+// WithSRTStyleReference 以指定的文本片段为样式参考创建字幕
+func WithSRTStyleReference(reference *segment.TextSegment) SRTOption {
+	return func(c *SRTConfig) {
+		c.StyleReference = reference
+	}
+}
+
+// WithSRTTextStyle 设置字幕的文本样式
+func WithSRTTextStyle(style *segment.TextStyle) SRTOption {
+	return func(c *SRTConfig) {
+		c.TextStyle = style
+	}
+}
+
+// WithSRTClipSettings 设置字幕的图像调节设置，指定样式参考时会覆盖参考片段的设置
+func WithSRTClipSettings(clipSettings *segment.ClipSettings) SRTOption {
+	return func(c *SRTConfig) {
+		c.ClipSettings = clipSettings
+	}
+}
+
+// WithSRTSkipMalformed 设置是否跳过格式错误的字幕
+func WithSRTSkipMalformed(skip bool) SRTOption {
+	return func(c *SRTConfig) {
+		c.SkipMalformed = skip
+	}
+}
+
+// ImportSRT 从SRT文件导入字幕到指定的文本轨道，每条字幕对应一个文本片段
+// 对应Python的import_srt方法
+//
+// 轨道不存在时会自动创建一个位于其他文本轨道之上的文本轨道。
+// 未指定样式参考时，默认使用居中、字号为5的样式，并放置在画面下方
+func (sf *ScriptFile) ImportSRT(srtPath string, trackName string, options ...SRTOption) error {
+	config := &SRTConfig{}
+	for _, option := range options {
+		option(config)
+	}
+
+	file, err := os.Open(srtPath)
+	if err != nil {
+		return fmt.Errorf("无法打开SRT文件: %v", err)
+	}
+	defer file.Close()
+
+	cues, err := ParseSRT(file)
+	if err != nil {
+		if _, ok := err.(*SRTParseError); !ok || !config.SkipMalformed {
+			return err
+		}
+	}
+
+	// 先创建所有片段，确保时间范围有效后再加入轨道
+	segments := make([]*segment.TextSegment, 0, len(cues))
+	for _, cue := range cues {
+		start := cue.Timerange.Start + config.TimeOffset
+		if start < 0 {
+			return fmt.Errorf("第%d行的字幕在时间偏移后开始时间为负", cue.Line)
+		}
+		segments = append(segments, newSRTSegment(cue.Text, types.NewTimerange(start, cue.Timerange.Duration), config))
+	}
+
+	if existing, ok := sf.Tracks[trackName]; ok {
+		if existing.TrackType != track.TrackTypeText {
+			return fmt.Errorf("指定的轨道 '%s' (类型为 %s) 不是文本轨道", trackName, existing.TrackType)
+		}
+	} else {
+		sf.AddTrack(track.TrackTypeText, &trackName, WithRelativeIndex(999))
+	}
+
+	for i, seg := range segments {
+		if err := sf.AddSegment(seg, &trackName); err != nil {
+			return fmt.Errorf("添加第%d行的字幕失败: %v", cues[i].Line, err)
+		}
+	}
+	return nil
+}
+
+// newSRTSegment 按导入配置创建字幕片段
+func newSRTSegment(text string, timerange *types.Timerange, config *SRTConfig) *segment.TextSegment {
+	if config.StyleReference != nil {
+		// CreateFromTemplate会共享参考片段的样式与图像调节设置，这里为每条字幕复制一份
+		seg := segment.CreateFromTemplate(text, timerange, config.StyleReference)
+		if seg.Style != nil {
+			style := *seg.Style
+			seg.Style = &style
+		}
+		clipSettings := config.ClipSettings
+		if clipSettings == nil {
+			clipSettings = seg.ClipSettings
+		}
+		if clipSettings != nil {
+			clipCopy := *clipSettings
+			seg.ClipSettings = &clipCopy
+		}
+		return seg
+	}
+
+	style := segment.NewTextStyle()
+	style.Size = 5.0
+	style.Align = 1
+	if config.TextStyle != nil {
+		*style = *config.TextStyle
+	}
+	clipSettings := segment.NewClipSettings()
+	clipSettings.TransformY = -0.8
+	if config.ClipSettings != nil {
+		*clipSettings = *config.ClipSettings
+	}
+	return segment.NewTextSegment(text, timerange, "思源黑体", style, clipSettings)
+}
@@ -0,0 +1,197 @@
+package script
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/track"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+// writeSRT 将SRT内容写入临时文件
+func writeSRT(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "subtitle.srt")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("写入SRT文件失败: %v", err)
+	}
+	return path
+}
+
+// TestParseSRT 测试SRT解析
+func TestParseSRT(t *testing.T) {
+	content := "\ufeff1\r\n00:00:01,000 --> 00:00:02,500\r\n第一行\r\n第二行\r\n\r\n" +
+		"2\r\n00:00:03.000 --> 00:00:04,000 X1:10 X2:20\r\n第二条\r\n\r\n\r\n" +
+		"00:00:05,000 --> 00:00:06,000\r\n无序号\r\n"
+
+	cues, err := ParseSRT(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("解析SRT失败: %v", err)
+	}
+	if len(cues) != 3 {
+		t.Fatalf("期望3条字幕，得到%d条", len(cues))
+	}
+
+	if cues[0].Index != 1 || cues[0].Line != 1 || cues[0].Text != "第一行\n第二行" {
+		t.Errorf("第一条字幕解析错误: %+v", cues[0])
+	}
+	if cues[0].Timerange.Start != 1000000 || cues[0].Timerange.Duration != 1500000 {
+		t.Errorf("第一条字幕时间范围错误: %v", cues[0].Timerange)
+	}
+	if cues[1].Index != 2 || cues[1].Timerange.Start != 3000000 || cues[1].Text != "第二条" {
+		t.Errorf("第二条字幕解析错误: %+v", cues[1])
+	}
+	if cues[2].Index != 0 || cues[2].Line != 11 || cues[2].Text != "无序号" {
+		t.Errorf("第三条字幕解析错误: %+v", cues[2])
+	}
+}
+
+// TestParseSRTMalformed 测试格式错误的字幕报告
+func TestParseSRTMalformed(t *testing.T) {
+	content := "1\n00:00:01,000 --> 00:00:02,000\n正常\n\n" +
+		"abc\n00:00:02,000 --> 00:00:03,000\n序号错误\n\n" +
+		"3\n00:00:04,000 --> 00:00:03,000\n时间倒置\n\n" +
+		"4\n00:00:05,000 --> 00:00:06,000\n\n" +
+		"5\n00:00:07,000 -> 00:00:08,000\n时间轴错误\n"
+
+	cues, err := ParseSRT(strings.NewReader(content))
+	parseErr, ok := err.(*SRTParseError)
+	if !ok {
+		t.Fatalf("期望返回SRTParseError，得到 %v", err)
+	}
+	if len(cues) != 1 || cues[0].Text != "正常" {
+		t.Errorf("格式正确的字幕应被保留: %+v", cues)
+	}
+
+	expectedLines := []int{5, 9, 13, 16}
+	if len(parseErr.Errors) != len(expectedLines) {
+		t.Fatalf("期望%d个错误，得到%d个: %v", len(expectedLines), len(parseErr.Errors), parseErr)
+	}
+	for i, line := range expectedLines {
+		if parseErr.Errors[i].Line != line {
+			t.Errorf("第%d个错误的行号期望为%d，得到%d", i, line, parseErr.Errors[i].Line)
+		}
+	}
+}
+
+// TestScriptFileImportSRT 测试导入SRT字幕
+func TestScriptFileImportSRT(t *testing.T) {
+	path := writeSRT(t, "1\n00:00:01,000 --> 00:00:02,000\n你好\n\n2\n00:00:02,000 --> 00:00:03,500\n世界\n")
+
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	if err := sf.ImportSRT(path, "字幕", WithSRTTimeOffset(types.SEC)); err != nil {
+		t.Fatalf("导入SRT失败: %v", err)
+	}
+
+	subtitleTrack, ok := sf.Tracks["字幕"]
+	if !ok {
+		t.Fatal("导入SRT时应自动创建文本轨道")
+	}
+	if subtitleTrack.TrackType != track.TrackTypeText {
+		t.Errorf("期望轨道类型为text，得到%s", subtitleTrack.TrackType)
+	}
+	if len(subtitleTrack.Segments) != 2 {
+		t.Fatalf("期望2个片段，得到%d个", len(subtitleTrack.Segments))
+	}
+
+	first := subtitleTrack.Segments[0].(*segment.TextSegment)
+	if first.Text != "你好" || first.TargetTimerange.Start != 2000000 || first.TargetTimerange.Duration != 1000000 {
+		t.Errorf("第一个字幕片段错误: text=%s timerange=%v", first.Text, first.TargetTimerange)
+	}
+	if first.Style.Size != 5.0 || first.Style.Align != 1 || first.ClipSettings.TransformY != -0.8 {
+		t.Error("未指定样式时应使用默认的字幕样式")
+	}
+	if sf.Duration != 4500000 {
+		t.Errorf("期望草稿时长为4500000，得到%d", sf.Duration)
+	}
+	if len(sf.Materials.Texts) != 2 {
+		t.Errorf("期望注册2个文本素材，得到%d个", len(sf.Materials.Texts))
+	}
+
+	// 非文本轨道
+	videoTrack := "视频"
+	sf.AddTrack(track.TrackTypeVideo, &videoTrack)
+	if err := sf.ImportSRT(path, videoTrack); err == nil {
+		t.Error("期望导入到非文本轨道时返回错误")
+	}
+}
+
+// TestScriptFileImportSRTStyleReference 测试以样式参考导入SRT字幕
+func TestScriptFileImportSRTStyleReference(t *testing.T) {
+	path := writeSRT(t, "1\n00:00:00,000 --> 00:00:01,000\n参考\n\n2\n00:00:01,000 --> 00:00:02,000\n样式\n")
+
+	reference := segment.NewTextSegment("参考", types.NewTimerange(0, types.SEC), "字体", nil, nil)
+	reference.Style.Size = 12
+	reference.SetBorder(1.0, [3]float64{0, 0, 0}, 40)
+
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	clip := segment.NewClipSettings()
+	clip.TransformY = -0.5
+	if err := sf.ImportSRT(path, "字幕", WithSRTStyleReference(reference), WithSRTClipSettings(clip)); err != nil {
+		t.Fatalf("导入SRT失败: %v", err)
+	}
+
+	segments := sf.Tracks["字幕"].Segments
+	first := segments[0].(*segment.TextSegment)
+	second := segments[1].(*segment.TextSegment)
+	if first.Font != "字体" || first.Style.Size != 12 || first.Border == nil {
+		t.Error("字幕片段应复制参考片段的样式")
+	}
+	if first.ClipSettings.TransformY != -0.5 {
+		t.Errorf("指定的图像调节设置应覆盖参考片段，得到%f", first.ClipSettings.TransformY)
+	}
+	if first.Style == second.Style || first.Style == reference.Style || first.ClipSettings == second.ClipSettings {
+		t.Error("各字幕片段不应共享样式对象")
+	}
+}
+
+// TestScriptFileImportSRTMalformed 测试导入包含格式错误字幕的SRT文件
+func TestScriptFileImportSRTMalformed(t *testing.T) {
+	path := writeSRT(t, "1\n00:00:01,000 --> 00:00:02,000\n正常\n\n2\n无效时间轴\n错误\n")
+
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	if err := sf.ImportSRT(path, "字幕"); err == nil {
+		t.Fatal("期望格式错误的字幕导致导入失败")
+	} else if _, ok := err.(*SRTParseError); !ok {
+		t.Errorf("期望返回SRTParseError，得到 %v", err)
+	}
+	if _, ok := sf.Tracks["字幕"]; ok {
+		t.Error("导入失败时不应创建轨道")
+	}
+
+	if err := sf.ImportSRT(path, "字幕", WithSRTSkipMalformed(true)); err != nil {
+		t.Fatalf("跳过格式错误的字幕后导入失败: %v", err)
+	}
+	if len(sf.Tracks["字幕"].Segments) != 1 {
+		t.Errorf("期望导入1个片段，得到%d个", len(sf.Tracks["字幕"].Segments))
+	}
+
+	// 时间偏移后开始时间为负
+	if err := sf.ImportSRT(path, "字幕2", WithSRTSkipMalformed(true), WithSRTTimeOffset(-2*types.SEC)); err == nil {
+		t.Error("期望偏移后开始时间为负时返回错误")
+	}
+}
+
+// TestScriptFileImportSRTMissingFile 测试导入不存在的SRT文件
+func TestScriptFileImportSRTMissingFile(t *testing.T) {
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	if err := sf.ImportSRT(filepath.Join(t.TempDir(), "missing.srt"), "字幕"); err == nil {
+		t.Error("期望文件不存在时返回错误")
+	}
+}
@@ -0,0 +1,339 @@
+package script
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zhangshican/go-capcut/internal/content"
+	"github.com/zhangshican/go-capcut/internal/keyframe"
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/template"
+	"github.com/zhangshican/go-capcut/internal/track"
+	"github.com/zhangshican/go-capcut/internal/util"
+)
+
+// ValidationSeverity 校验问题的严重程度
+type ValidationSeverity int
+
+const (
+	SeverityWarning ValidationSeverity = iota // 警告，草稿能够打开，但效果可能与预期不符
+	SeverityError                             // 错误，草稿可能无法被剪映正确打开
+)
+
+// String 返回严重程度的名称
+func (s ValidationSeverity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// ValidationProblem 草稿校验发现的一个问题
+//
+// Err为*util.ValidationError、*util.MaterialNotFoundError或*util.SegmentOverlapError
+type ValidationProblem struct {
+	Severity  ValidationSeverity // 严重程度
+	TrackName string             // 问题所在的轨道名称，与轨道无关时为空
+	SegmentID string             // 问题所在的片段id，与片段无关时为空
+	Err       error              // 具体的问题
+}
+
+// Error 实现error接口
+func (p *ValidationProblem) Error() string {
+	location := "草稿"
+	if p.SegmentID != "" {
+		location = fmt.Sprintf("轨道 '%s' 的片段 %s", p.TrackName, p.SegmentID)
+	} else if p.TrackName != "" {
+		location = fmt.Sprintf("轨道 '%s'", p.TrackName)
+	}
+	return fmt.Sprintf("[%s] %s: %v", p.Severity, location, p.Err)
+}
+
+// Unwrap 返回具体的问题，以便使用errors.As判断问题类型
+func (p *ValidationProblem) Unwrap() error {
+	return p.Err
+}
+
+// InvalidDraftError 草稿未通过校验，导出被拒绝
+type InvalidDraftError struct {
+	Problems []*ValidationProblem // 导致导出被拒绝的问题
+}
+
+// Error 实现error接口
+func (e *InvalidDraftError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		messages[i] = problem.Error()
+	}
+	return fmt.Sprintf("草稿未通过校验，存在%d个问题: %s", len(e.Problems), strings.Join(messages, "; "))
+}
+
+// DumpConfig 导出草稿的配置
+type DumpConfig struct {
+	Validate    bool               // 是否在导出前校验草稿
+	MinSeverity ValidationSeverity // 拒绝导出的最低严重程度
+	PathRules   []util.PathRule    // 导出时应用于素材路径的映射规则
+}
+
+// DumpOption 导出草稿的选项函数类型
+type DumpOption func(*DumpConfig)
+
+// WithValidation 导出前校验草稿，存在严重程度不低于minSeverity的问题时拒绝导出并返回*InvalidDraftError
+func WithValidation(minSeverity ValidationSeverity) DumpOption {
+	return func(c *DumpConfig) {
+		c.Validate = true
+		c.MinSeverity = minSeverity
+	}
+}
+
+// newDumpConfig 根据选项创建导出配置
+func newDumpConfig(options []DumpOption) *DumpConfig {
+	config := &DumpConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// checkDump 按导出配置校验草稿
+func (sf *ScriptFile) checkDump(options []DumpOption) error {
+	config := newDumpConfig(options)
+	if !config.Validate {
+		return nil
+	}
+
+	var rejected []*ValidationProblem
+	for _, problem := range sf.Validate() {
+		if problem.Severity >= config.MinSeverity {
+			rejected = append(rejected, problem)
+		}
+	}
+	if len(rejected) > 0 {
+		return &InvalidDraftError{Problems: rejected}
+	}
+	return nil
+}
+
+// Validate 检查草稿结构的一致性，返回发现的所有问题，草稿没有问题时返回nil
+//
+// 检查的内容包括：片段引用的素材是否存在、同一轨道上的片段是否重叠、关键帧和动画是否超出片段范围、
+// 转场是否位于轨道的最后一个片段上，以及草稿时长是否与各轨道的结束时间一致
+func (sf *ScriptFile) Validate() []*ValidationProblem {
+	v := &validator{materialIDs: sf.materialIDs()}
+
+	var maxEnd int64
+	for _, t := range sf.sortedTracks() {
+		v.checkTrack(t)
+		if end := trackEnd(t); end > maxEnd {
+			maxEnd = end
+		}
+	}
+
+	if sf.Duration < maxEnd {
+		v.add(SeverityError, "", "", util.NewValidationError("duration", sf.Duration,
+			fmt.Sprintf("草稿时长短于轨道的结束时间 %d", maxEnd)))
+	} else if sf.Duration > maxEnd {
+		v.add(SeverityWarning, "", "", util.NewValidationError("duration", sf.Duration,
+			fmt.Sprintf("草稿时长超出轨道的结束时间 %d，末尾将为空白", maxEnd)))
+	}
+
+	return v.problems
+}
+
+// materialIDs 返回草稿中所有素材的id，包括导入的素材和新增的素材
+func (sf *ScriptFile) materialIDs() map[string]bool {
+	ids := make(map[string]bool)
+	collect := func(materials interface{}) {
+		generic, err := genericJSON(materials)
+		if err != nil {
+			return
+		}
+		kinds, _ := generic.(map[string]interface{})
+		for _, list := range kinds {
+			items, _ := list.([]interface{})
+			for _, item := range items {
+				if mat, ok := item.(map[string]interface{}); ok {
+					if id, ok := mat["id"].(string); ok && id != "" {
+						ids[id] = true
+					}
+				}
+			}
+		}
+	}
+
+	if imported, err := content.ToMap(sf.importedMaterials()); err == nil {
+		collect(imported)
+	}
+	collect(sf.Materials.ExportJSON())
+	return ids
+}
+
+// trackEnd 返回轨道的结束时间，忽略没有时间范围的片段
+func trackEnd(t *track.Track) int64 {
+	var end int64
+	for _, seg := range t.Segments {
+		if base := segmentBase(seg); base != nil && base.TargetTimerange != nil && base.End() > end {
+			end = base.End()
+		}
+	}
+	return end
+}
+
+// validator 校验过程中的状态
+type validator struct {
+	materialIDs map[string]bool
+	problems    []*ValidationProblem
+}
+
+// add 记录一个问题
+func (v *validator) add(severity ValidationSeverity, trackName, segmentID string, err error) {
+	v.problems = append(v.problems, &ValidationProblem{
+		Severity:  severity,
+		TrackName: trackName,
+		SegmentID: segmentID,
+		Err:       err,
+	})
+}
+
+// checkTrack 校验轨道及其中的片段
+func (v *validator) checkTrack(t *track.Track) {
+	type entry struct {
+		seg  segment.SegmentInterface
+		base *segment.BaseSegment
+	}
+	entries := make([]entry, 0, len(t.Segments))
+	for _, seg := range t.Segments {
+		base := segmentBase(seg)
+		if base == nil {
+			continue
+		}
+		if base.TargetTimerange == nil {
+			v.add(SeverityError, t.Name, base.SegmentID, util.NewValidationError("target_timerange", nil, "片段缺少时间范围"))
+			continue
+		}
+		v.checkSegment(t.Name, seg, base)
+		entries = append(entries, entry{seg, base})
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	// 片段按开始时间排序后，检查相邻片段是否重叠
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].base.Start() < entries[j].base.Start()
+	})
+	for i := 1; i < len(entries); i++ {
+		prev, cur := entries[i-1].base, entries[i].base
+		if prev.End() > cur.Start() {
+			v.add(SeverityError, t.Name, cur.SegmentID,
+				util.NewSegmentOverlapError(cur.Start(), cur.End(), prev.Start(), prev.End()))
+		}
+	}
+
+	// 转场作用于当前片段与下一个片段之间，最后一个片段上的转场无效
+	if video, ok := entries[len(entries)-1].seg.(*segment.VideoSegment); ok && video.Transition != nil {
+		v.add(SeverityWarning, t.Name, video.SegmentID,
+			util.NewValidationError("transition", video.Transition.Name, "转场位于轨道的最后一个片段上，不会生效"))
+	}
+}
+
+// checkSegment 校验片段的素材引用、关键帧及动画
+func (v *validator) checkSegment(trackName string, seg segment.SegmentInterface, base *segment.BaseSegment) {
+	duration := base.Duration()
+	if duration <= 0 {
+		v.add(SeverityError, trackName, base.SegmentID, util.NewValidationError("target_timerange.duration", duration, "片段时长必须为正数"))
+	}
+
+	if base.MaterialID != "" && !v.materialIDs[base.MaterialID] {
+		v.add(SeverityError, trackName, base.SegmentID,
+			util.NewMaterialNotFoundError(fmt.Sprintf("片段引用的id为 '%s' 的素材", base.MaterialID)))
+	}
+	for _, ref := range segmentRefs(seg) {
+		if !v.materialIDs[ref] {
+			v.add(SeverityError, trackName, base.SegmentID,
+				util.NewMaterialNotFoundError(fmt.Sprintf("片段附加的id为 '%s' 的素材", ref)))
+		}
+	}
+
+	for _, list := range segmentKeyframeLists(seg, base) {
+		for _, kf := range list.Keyframes {
+			if kf.TimeOffset < 0 || kf.TimeOffset > duration {
+				v.add(SeverityWarning, trackName, base.SegmentID, util.NewValidationError(
+					"keyframe."+string(list.KeyframeProperty), kf.TimeOffset, fmt.Sprintf("关键帧超出片段时长 %d", duration)))
+			}
+		}
+	}
+
+	if base.Animations != nil {
+		for _, anim := range base.Animations.Animations {
+			if anim.Start < 0 || anim.Start+anim.Duration > duration {
+				v.add(SeverityWarning, trackName, base.SegmentID, util.NewValidationError(
+					"animation."+string(anim.AnimationType), anim.Name, fmt.Sprintf("动画超出片段时长 %d", duration)))
+			}
+		}
+	}
+}
+
+// segmentBase 返回片段的基础部分，无法识别的片段类型返回nil
+func segmentBase(seg segment.SegmentInterface) *segment.BaseSegment {
+	switch v := seg.(type) {
+	case *segment.VideoSegment:
+		return v.BaseSegment
+	case *segment.AudioSegment:
+		return v.BaseSegment
+	case *segment.TextSegment:
+		return v.BaseSegment
+	case *segment.StickerSegment:
+		return v.BaseSegment
+	case *segment.EffectSegment:
+		return v.BaseSegment
+	case *segment.FilterSegment:
+		return v.BaseSegment
+	case *template.ImportedSegment:
+		return v.BaseSegment
+	case *template.ImportedMediaSegment:
+		return v.BaseSegment
+	}
+	return nil
+}
+
+// segmentRefs 返回片段附加的素材id列表
+func segmentRefs(seg segment.SegmentInterface) []string {
+	switch v := seg.(type) {
+	case *segment.VideoSegment:
+		return v.ExtraMaterialRefs
+	case *segment.AudioSegment:
+		return v.ExtraMaterialRefs
+	case *segment.TextSegment:
+		return v.ExtraMaterialRefs
+	case *segment.StickerSegment:
+		return v.ExtraMaterialRefs
+	case *template.ImportedSegment:
+		return v.ExtraMaterialRefs
+	case *template.ImportedMediaSegment:
+		return v.ExtraMaterialRefs
+	}
+	return nil
+}
+
+// segmentKeyframeLists 返回片段的所有关键帧列表，按属性名排序
+func segmentKeyframeLists(seg segment.SegmentInterface, base *segment.BaseSegment) []*keyframe.KeyframeList {
+	var lists []*keyframe.KeyframeList
+	switch v := seg.(type) {
+	case *template.ImportedSegment:
+		lists = append(lists, v.KeyframeLists...)
+	case *template.ImportedMediaSegment:
+		lists = append(lists, v.KeyframeLists...)
+	}
+	if base.KeyframeManager != nil {
+		lists = append(lists, base.KeyframeManager.GetAllKeyframeLists()...)
+	}
+	sort.SliceStable(lists, func(i, j int) bool {
+		return lists[i].KeyframeProperty < lists[j].KeyframeProperty
+	})
+	return lists
+}
@@ -0,0 +1,166 @@
+package script
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/animation"
+	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/metadata"
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/track"
+	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut/internal/util"
+)
+
+// newValidateTestDraft 创建包含一个视频片段的合法草稿
+func newValidateTestDraft(t *testing.T) (*ScriptFile, *segment.VideoSegment) {
+	t.Helper()
+
+	sf, err := NewScriptFile(1920, 1080)
+	if err != nil {
+		t.Fatalf("创建ScriptFile失败: %v", err)
+	}
+	sf.AddTrack(track.TrackTypeVideo, nil)
+
+	videoMat := &material.VideoMaterial{MaterialID: "video_1", Path: "/path/to/video.mp4", CropSettings: material.NewCropSettings()}
+	sf.AddMaterial(videoMat)
+
+	seg := segment.NewVideoSegment(videoMat.MaterialID, types.NewTimerange(0, 5*types.SEC), types.NewTimerange(0, 5*types.SEC), 1.0, 1.0, nil)
+	if err := seg.AddKeyframe("alpha", 2*types.SEC, 0.5); err != nil {
+		t.Fatalf("添加关键帧失败: %v", err)
+	}
+	if err := sf.AddSegment(seg, nil); err != nil {
+		t.Fatalf("添加片段失败: %v", err)
+	}
+	return sf, seg
+}
+
+// findProblem 返回第一个Err能够赋值给target的问题，target的用法与errors.As相同
+func findProblem(problems []*ValidationProblem, target interface{}) *ValidationProblem {
+	for _, problem := range problems {
+		if errors.As(problem, target) {
+			return problem
+		}
+	}
+	return nil
+}
+
+// TestScriptFileValidateValidDraft 测试合法草稿及测试草稿不会报告问题
+func TestScriptFileValidateValidDraft(t *testing.T) {
+	sf, _ := newValidateTestDraft(t)
+	if problems := sf.Validate(); len(problems) != 0 {
+		t.Errorf("合法草稿不应有问题，得到: %v", problems)
+	}
+
+	for _, path := range goldenDrafts(t) {
+		sf, _ := loadGoldenDraft(t, path)
+		if problems := sf.Validate(); len(problems) != 0 {
+			t.Errorf("%s 不应有问题，得到: %v", filepath.Base(path), problems)
+		}
+	}
+}
+
+// TestScriptFileValidate 测试校验发现的各类问题
+func TestScriptFileValidate(t *testing.T) {
+	sf, first := newValidateTestDraft(t)
+
+	// 关键帧及动画超出片段时长
+	if err := first.AddKeyframe("alpha", 6*types.SEC, 1.0); err != nil {
+		t.Fatalf("添加关键帧失败: %v", err)
+	}
+	animMeta := metadata.NewAnimationMeta("入场", false, 0.5, "anim_resource", "anim_id", "")
+	if err := first.Animations.AddAnimation(animation.NewAnimation(animMeta, 4*types.SEC, 2*types.SEC, animation.AnimationTypeIn, true)); err != nil {
+		t.Fatalf("添加动画失败: %v", err)
+	}
+
+	// 引用不存在的素材，添加后改动时间范围使其与前一片段重叠，并在其上放置转场
+	second := segment.NewVideoSegment("missing_video", types.NewTimerange(0, 2*types.SEC), types.NewTimerange(5*types.SEC, 2*types.SEC), 1.0, 1.0, nil)
+	second.AddTransition("转场", "transition_id", "resource_id", 500000)
+	if err := sf.AddSegment(second, nil); err != nil {
+		t.Fatalf("添加片段失败: %v", err)
+	}
+	second.TargetTimerange.Start = 4 * types.SEC
+	sf.Duration = 3 * types.SEC
+
+	problems := sf.Validate()
+
+	var notFound *util.MaterialNotFoundError
+	if problem := findProblem(problems, &notFound); problem == nil {
+		t.Error("应报告缺失的素材")
+	} else if problem.Severity != SeverityError || problem.SegmentID != second.SegmentID || notFound.Condition == "" {
+		t.Errorf("缺失素材的问题不正确: %v", problem)
+	}
+
+	var overlap *util.SegmentOverlapError
+	if problem := findProblem(problems, &overlap); problem == nil {
+		t.Error("应报告重叠的片段")
+	} else if overlap.NewSegmentStart != 4*types.SEC || overlap.ExistingEnd != 5*types.SEC {
+		t.Errorf("重叠片段的范围不正确: %v", overlap)
+	}
+
+	fields := make(map[string]ValidationSeverity)
+	for _, problem := range problems {
+		var validationErr *util.ValidationError
+		if errors.As(problem, &validationErr) {
+			fields[validationErr.Field] = problem.Severity
+		}
+	}
+	expected := map[string]ValidationSeverity{
+		"keyframe.KFTypeAlpha": SeverityWarning,
+		"animation.in":         SeverityWarning,
+		"transition":           SeverityWarning,
+		"duration":             SeverityError,
+	}
+	for field, severity := range expected {
+		if got, ok := fields[field]; !ok {
+			t.Errorf("应报告字段 %s 的问题", field)
+		} else if got != severity {
+			t.Errorf("字段 %s 的严重程度应为%s，得到%s", field, severity, got)
+		}
+	}
+}
+
+// TestScriptFileDumpWithValidation 测试导出前校验草稿
+func TestScriptFileDumpWithValidation(t *testing.T) {
+	dir := t.TempDir()
+
+	sf, seg := newValidateTestDraft(t)
+	path := filepath.Join(dir, "valid.json")
+	if err := sf.Dump(path, WithValidation(SeverityWarning)); err != nil {
+		t.Fatalf("合法草稿应能导出: %v", err)
+	}
+
+	// 只有警告时，按错误级别校验仍可导出
+	sf.Duration = 6 * types.SEC
+	if err := sf.Dump(path, WithValidation(SeverityError)); err != nil {
+		t.Errorf("只有警告的草稿应能导出: %v", err)
+	}
+	if err := sf.Dump(path, WithValidation(SeverityWarning)); err == nil {
+		t.Error("按警告级别校验时应拒绝导出")
+	}
+
+	// 存在错误时拒绝导出，且不写入文件
+	seg.MaterialID = "missing_video"
+	invalidPath := filepath.Join(dir, "invalid.json")
+	err := sf.Dump(invalidPath, WithValidation(SeverityError))
+	var invalid *InvalidDraftError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("期望得到InvalidDraftError，得到: %v", err)
+	}
+	for _, problem := range invalid.Problems {
+		if problem.Severity < SeverityError {
+			t.Errorf("拒绝导出的问题中不应包含警告: %v", problem)
+		}
+	}
+	if _, err := os.Stat(invalidPath); !os.IsNotExist(err) {
+		t.Error("未通过校验的草稿不应写入文件")
+	}
+
+	// 不指定校验选项时照常导出
+	if err := sf.Dump(invalidPath); err != nil {
+		t.Errorf("未启用校验时应能导出: %v", err)
+	}
+}
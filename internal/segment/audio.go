@@ -6,8 +6,7 @@ import (
 	"fmt"
 
 	"github.com/zhangshican/go-capcut/internal/types"
-
-	"github.com/google/uuid"
+	"github.com/zhangshican/go-capcut/internal/util"
 )
 
 // AudioFade 音频淡入淡出效果
@@ -21,7 +20,7 @@ type AudioFade struct {
 // NewAudioFade 创建新的音频淡入淡出效果
 func NewAudioFade(inDuration, outDuration int64) *AudioFade {
 	return &AudioFade{
-		FadeID:      uuid.New().String(),
+		FadeID:      util.NewID(),
 		InDuration:  inDuration,
 		OutDuration: outDuration,
 	}
@@ -68,7 +67,7 @@ type AudioEffect struct {
 func NewAudioEffect(name, resourceID, categoryID, categoryName string) *AudioEffect {
 	return &AudioEffect{
 		Name:              name,
-		EffectID:          uuid.New().String(),
+		EffectID:          util.NewID(),
 		ResourceID:        resourceID,
 		CategoryID:        categoryID,
 		CategoryName:      categoryName,
@@ -136,7 +135,14 @@ func NewAudioSegment(materialID string, targetTimerange *types.Timerange, source
 	var finalSpeed float64
 	var finalTargetTimerange *types.Timerange
 
-	if sourceTimerange != nil && speed != 0 {
+	if targetTimerange == nil {
+		// 未指定目标时间范围，放置到轨道上时再按素材计算，见PlaceAt
+		finalSourceTimerange = sourceTimerange
+		finalSpeed = speed
+		if speed == 0 {
+			finalSpeed = 1.0
+		}
+	} else if sourceTimerange != nil && speed != 0 {
 		// 如果同时指定了源时间范围和速度，重新计算目标时间范围
 		newDuration := int64(float64(sourceTimerange.Duration)/speed + 0.5)
 		finalTargetTimerange = types.NewTimerange(targetTimerange.Start, newDuration)
@@ -8,8 +8,7 @@ import (
 	"github.com/zhangshican/go-capcut/internal/animation"
 	"github.com/zhangshican/go-capcut/internal/keyframe"
 	"github.com/zhangshican/go-capcut/internal/types"
-
-	"github.com/google/uuid"
+	"github.com/zhangshican/go-capcut/internal/util"
 )
 
 // SegmentInterface 片段接口，所有片段类型都需要实现此接口
@@ -32,7 +31,7 @@ type BaseSegment struct {
 // NewBaseSegment 创建基础片段
 func NewBaseSegment(materialID string, targetTimerange *types.Timerange) *BaseSegment {
 	return &BaseSegment{
-		SegmentID:       uuid.New().String(),
+		SegmentID:       util.NewID(),
 		MaterialID:      materialID,
 		TargetTimerange: targetTimerange,
 		KeyframeManager: keyframe.NewKeyframeManager(),
@@ -190,7 +189,7 @@ type Speed struct {
 // NewSpeed 创建新的播放速度对象
 func NewSpeed(speed float64) *Speed {
 	return &Speed{
-		GlobalID: uuid.New().String(),
+		GlobalID: util.NewID(),
 		Value:    speed,
 	}
 }
@@ -206,6 +205,32 @@ func (s *Speed) ExportJSON() map[string]interface{} {
 	}
 }
 
+// LoudnessAdjust 片段的响度统一设置，音量已根据素材的响度调整为使片段达到目标响度
+type LoudnessAdjust struct {
+	GlobalID       string  `json:"id"`              // 全局id，由程序自动生成
+	TargetLoudness float64 `json:"target_loudness"` // 目标响度，单位为LUFS
+}
+
+// NewLoudnessAdjust 创建新的响度统一设置
+func NewLoudnessAdjust(targetLoudness float64) *LoudnessAdjust {
+	return &LoudnessAdjust{
+		GlobalID:       util.NewID(),
+		TargetLoudness: targetLoudness,
+	}
+}
+
+// ExportJSON 导出为JSON格式
+func (la *LoudnessAdjust) ExportJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"enable":          true,
+		"file_id":         "",
+		"id":              la.GlobalID,
+		"loudness_param":  nil,
+		"target_loudness": la.TargetLoudness,
+		"time_range":      nil,
+	}
+}
+
 // ClipSettings 素材片段的图像调节设置
 // 对应Python的Clip_settings类
 type ClipSettings struct {
@@ -275,6 +300,7 @@ type MediaSegment struct {
 	Speed             *Speed           `json:"-"`                   // 播放速度设置，在JSON中体现为speed字段
 	Volume            float64          `json:"volume"`              // 音量
 	ExtraMaterialRefs []string         `json:"extra_material_refs"` // 附加的素材id列表，用于链接动画/特效等
+	Loudness          *LoudnessAdjust  `json:"-"`                   // 响度统一设置，未设置时为nil
 }
 
 // NewMediaSegment 创建媒体片段
@@ -307,6 +333,19 @@ func (ms *MediaSegment) ExportJSON() map[string]interface{} {
 	return result
 }
 
+// SetLoudness 将片段的音量设为volume，并记录使片段达到的目标响度targetLoudness(LUFS)
+//
+// 已有响度统一设置时更新其目标响度，否则创建新的设置并加入附加素材引用列表
+func (ms *MediaSegment) SetLoudness(volume, targetLoudness float64) {
+	ms.Volume = volume
+	if ms.Loudness != nil {
+		ms.Loudness.TargetLoudness = targetLoudness
+		return
+	}
+	ms.Loudness = NewLoudnessAdjust(targetLoudness)
+	ms.ExtraMaterialRefs = append(ms.ExtraMaterialRefs, ms.Loudness.GlobalID)
+}
+
 // VisualSegment 视觉片段基类，用于处理所有可见片段（视频、贴纸、文本）的共同属性和行为
 // 对应Python的Visual_segment类
 type VisualSegment struct {
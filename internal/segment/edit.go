@@ -0,0 +1,368 @@
+// Package segment/edit 实现片段的分割与裁剪
+package segment
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/zhangshican/go-capcut/internal/animation"
+	"github.com/zhangshican/go-capcut/internal/keyframe"
+	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut/internal/util"
+)
+
+// cut 返回片段中[start, end]部分的关键帧及动画，start、end为相对片段开头的偏移，可超出片段范围以表示延长
+//
+// keepStart、keepEnd表示结果是否保留片段原来的开头及结尾：入场动画只保留在含有开头的部分，
+// 出场动画只保留在含有结尾的部分；组合动画及循环动画截取到结果的范围内，原本紧贴开头或结尾的一端仍紧贴新的开头或结尾
+func (bs *BaseSegment) cut(start, end int64, keepStart, keepEnd bool) (*keyframe.KeyframeManager, []*animation.Animation) {
+	duration, newDuration := bs.Duration(), end-start
+
+	var inEnd int64
+	if keepStart {
+		for _, ani := range bs.Animations.Animations {
+			if ani.AnimationType == animation.AnimationTypeIn {
+				inEnd = min(ani.Duration, newDuration)
+			}
+		}
+	}
+
+	animations := make([]*animation.Animation, 0, len(bs.Animations.Animations))
+	for _, ani := range bs.Animations.Animations {
+		aniStart, aniEnd := ani.Start-start, ani.Start+ani.Duration-start
+		switch ani.AnimationType {
+		case animation.AnimationTypeIn:
+			if !keepStart {
+				continue
+			}
+			aniStart, aniEnd = 0, inEnd
+		case animation.AnimationTypeOut:
+			if !keepEnd {
+				continue
+			}
+			// 出场动画紧贴结尾，且不与入场动画重叠
+			aniStart, aniEnd = max(newDuration-ani.Duration, inEnd), newDuration
+		default:
+			if keepStart && ani.Start <= 0 {
+				aniStart = 0
+			}
+			if keepEnd && ani.Start+ani.Duration >= duration {
+				aniEnd = newDuration
+			}
+			aniStart, aniEnd = max(aniStart, 0), min(aniEnd, newDuration)
+		}
+		if aniEnd <= aniStart {
+			continue
+		}
+
+		cutAni := *ani
+		cutAni.Start, cutAni.Duration = aniStart, aniEnd-aniStart
+		animations = append(animations, &cutAni)
+	}
+	return bs.KeyframeManager.Slice(start, end), animations
+}
+
+// split 将片段从offset处分为两部分，片段本身成为前一部分，返回后一部分，后一部分使用新的片段id及动画id
+func (bs *BaseSegment) split(offset int64) *BaseSegment {
+	duration := bs.Duration()
+	right := NewBaseSegment(bs.MaterialID, types.NewTimerange(bs.Start()+offset, duration-offset))
+	right.KeyframeManager, right.Animations.Animations = bs.cut(offset, duration, false, true)
+
+	bs.KeyframeManager, bs.Animations.Animations = bs.cut(0, offset, true, false)
+	bs.TargetTimerange = types.NewTimerange(bs.Start(), offset)
+	return right
+}
+
+// trim 将片段的范围改为[start, end]，start、end为相对片段开头的偏移
+func (bs *BaseSegment) trim(start, end int64) {
+	bs.KeyframeManager, bs.Animations.Animations = bs.cut(start, end, true, true)
+	bs.TargetTimerange = types.NewTimerange(bs.Start()+start, end-start)
+}
+
+// sourceAt 返回相对片段开头偏移offset处对应的素材时间，按播放速度换算
+func (ms *MediaSegment) sourceAt(offset int64) int64 {
+	source, duration := ms.SourceTimerange, ms.Duration()
+	if offset >= duration {
+		// 从结尾换算，避免舍入误差使结尾发生偏移
+		return source.End() + int64(math.Round(float64(offset-duration)*ms.Speed.Value))
+	}
+	return source.Start + int64(math.Round(float64(offset)*ms.Speed.Value))
+}
+
+// split 将片段从offset处分为两部分，片段本身成为前一部分，返回后一部分
+//
+// 后一部分使用新的变速及响度统一设置，素材时间范围按播放速度划分
+func (ms *MediaSegment) split(offset int64) *MediaSegment {
+	oldAnimationID := ms.Animations.AnimationID
+	var sourceOffset int64
+	if ms.SourceTimerange != nil {
+		sourceOffset = ms.sourceAt(offset)
+	}
+
+	right := &MediaSegment{
+		BaseSegment:       ms.BaseSegment.split(offset),
+		Speed:             NewSpeed(ms.Speed.Value),
+		Volume:            ms.Volume,
+		ExtraMaterialRefs: append([]string(nil), ms.ExtraMaterialRefs...),
+	}
+	right.replaceRef(ms.Speed.GlobalID, right.Speed.GlobalID)
+	if len(right.Animations.Animations) > 0 {
+		right.replaceRef(oldAnimationID, right.Animations.AnimationID)
+	} else {
+		right.replaceRef(oldAnimationID, "")
+	}
+	if ms.Loudness != nil {
+		right.Loudness = NewLoudnessAdjust(ms.Loudness.TargetLoudness)
+		right.replaceRef(ms.Loudness.GlobalID, right.Loudness.GlobalID)
+	}
+
+	if source := ms.SourceTimerange; source != nil {
+		right.SourceTimerange = types.NewTimerange(sourceOffset, source.End()-sourceOffset)
+		ms.SourceTimerange = types.NewTimerange(source.Start, sourceOffset-source.Start)
+	}
+	return right
+}
+
+// trim 将片段的范围改为[start, end]，素材时间范围按播放速度同步调整
+//
+// materialDuration为素材的时长，大于0时检查调整后的素材时间范围是否超出素材
+func (ms *MediaSegment) trim(start, end, materialDuration int64) error {
+	if end <= start {
+		return fmt.Errorf("裁剪后的片段时长必须大于0")
+	}
+
+	var source *types.Timerange
+	if ms.SourceTimerange != nil {
+		sourceStart, sourceEnd := ms.sourceAt(start), ms.sourceAt(end)
+		if sourceStart < 0 || (materialDuration > 0 && sourceEnd > materialDuration) {
+			return fmt.Errorf("裁剪后的素材范围 [%d, %d) 超出素材时长", sourceStart, sourceEnd)
+		}
+		source = types.NewTimerange(sourceStart, sourceEnd-sourceStart)
+	}
+
+	ms.BaseSegment.trim(start, end)
+	if source != nil {
+		ms.SourceTimerange = source
+	}
+	return nil
+}
+
+// replaceRef 将附加素材引用列表中的oldID替换为newID，newID为空时移除
+func (ms *MediaSegment) replaceRef(oldID, newID string) {
+	for i, ref := range ms.ExtraMaterialRefs {
+		if ref != oldID {
+			continue
+		}
+		if newID == "" {
+			ms.ExtraMaterialRefs = append(ms.ExtraMaterialRefs[:i], ms.ExtraMaterialRefs[i+1:]...)
+		} else {
+			ms.ExtraMaterialRefs[i] = newID
+		}
+		return
+	}
+}
+
+// split 将片段从offset处分为两部分，片段本身成为前一部分，返回后一部分
+//
+// 动画实例不与前一部分共享：指向片段动画时改为指向后一部分的动画，否则复制并使用新的动画id
+func (vs *VisualSegment) split(offset int64) *VisualSegment {
+	clipSettings := *vs.ClipSettings
+	right := &VisualSegment{
+		MediaSegment: vs.MediaSegment.split(offset),
+		ClipSettings: &clipSettings,
+		UniformScale: vs.UniformScale,
+	}
+	if instance, ok := vs.AnimationsInstance.(*animation.SegmentAnimations); ok && instance != nil {
+		if instance == vs.Animations {
+			right.AnimationsInstance = right.Animations
+		} else {
+			animations := make([]*animation.Animation, 0, len(instance.Animations))
+			for _, ani := range instance.Animations {
+				copied := *ani
+				animations = append(animations, &copied)
+			}
+			right.AnimationsInstance = &animation.SegmentAnimations{
+				AnimationID: strings.ReplaceAll(util.NewID(), "-", ""),
+				Animations:  animations,
+			}
+		}
+	}
+	return right
+}
+
+// checkSplitOffset 检查分割位置是否位于片段内部
+func checkSplitOffset(bs *BaseSegment, offset int64) error {
+	if offset <= 0 || offset >= bs.Duration() {
+		return fmt.Errorf("分割位置 %d 不在片段范围 (0, %d) 内", offset, bs.Duration())
+	}
+	return nil
+}
+
+// Split 将视频片段从相对片段开头偏移offset处分为两部分，片段本身成为前一部分，返回新建的后一部分
+//
+// 关键帧在分割处以插值补齐，入场动画保留在前一部分，出场动画及转场移至后一部分；
+// 蒙版、特效、滤镜及背景填充被复制到后一部分并使用新的id
+func (vs *VideoSegment) Split(offset int64) (*VideoSegment, error) {
+	if err := checkSplitOffset(vs.BaseSegment, offset); err != nil {
+		return nil, err
+	}
+
+	right := &VideoSegment{
+		VisualSegment:    vs.VisualSegment.split(offset),
+		MaterialInstance: vs.MaterialInstance,
+		Transition:       vs.Transition,
+	}
+	if vs.Mask != nil {
+		mask := *vs.Mask
+		mask.GlobalID = util.NewID()
+		right.Mask = &mask
+		right.replaceRef(vs.Mask.GlobalID, mask.GlobalID)
+	}
+	for _, effect := range vs.Effects {
+		copied := *effect
+		copied.GlobalID = util.NewID()
+		copied.AdjustParams = append([]interface{}(nil), effect.AdjustParams...)
+		right.Effects = append(right.Effects, &copied)
+		right.replaceRef(effect.GlobalID, copied.GlobalID)
+	}
+	for _, filter := range vs.Filters {
+		copied := *filter
+		copied.GlobalID = util.NewID()
+		right.Filters = append(right.Filters, &copied)
+		right.replaceRef(filter.GlobalID, copied.GlobalID)
+	}
+	if vs.BackgroundFilling != nil {
+		filling := *vs.BackgroundFilling
+		filling.GlobalID = util.NewID()
+		right.BackgroundFilling = &filling
+		right.replaceRef(vs.BackgroundFilling.GlobalID, filling.GlobalID)
+	}
+	if vs.Transition != nil {
+		vs.replaceRef(vs.Transition.GlobalID, "")
+		vs.Transition = nil
+	}
+	return right, nil
+}
+
+// Trim 将视频片段的范围改为相对原开头偏移[start, end]的部分，start可为负、end可超出片段以延长片段
+//
+// 素材时间范围按播放速度同步调整，不能超出素材；关键帧在新的边界处以插值补齐，入场及出场动画保持在新的开头及结尾
+func (vs *VideoSegment) Trim(start, end int64) error {
+	var materialDuration int64
+	if mat, ok := vs.MaterialInstance.(*material.VideoMaterial); ok && mat != nil && mat.MaterialType != material.MaterialTypePhoto {
+		materialDuration = mat.Duration
+	}
+	return vs.trim(start, end, materialDuration)
+}
+
+// Split 将音频片段从相对片段开头偏移offset处分为两部分，片段本身成为前一部分，返回新建的后一部分
+//
+// 淡入保留在前一部分，淡出移至后一部分，只有淡出时沿用原淡入淡出效果；音频特效被复制到后一部分并使用新的id
+func (as *AudioSegment) Split(offset int64) (*AudioSegment, error) {
+	if err := checkSplitOffset(as.BaseSegment, offset); err != nil {
+		return nil, err
+	}
+
+	right := &AudioSegment{
+		MediaSegment:     as.MediaSegment.split(offset),
+		MaterialInstance: as.MaterialInstance,
+	}
+	for _, effect := range as.Effects {
+		copied := *effect
+		copied.EffectID = util.NewID()
+		copied.AudioAdjustParams = append([]interface{}(nil), effect.AudioAdjustParams...)
+		right.Effects = append(right.Effects, &copied)
+		right.replaceRef(effect.EffectID, copied.EffectID)
+	}
+	if fade := as.Fade; fade != nil {
+		switch {
+		case fade.InDuration <= 0:
+			// 只有淡出时直接将原淡入淡出效果移至后一部分，避免其成为无片段引用的素材
+			fade.OutDuration = min(fade.OutDuration, right.Duration())
+			right.Fade = fade
+			as.RemoveFade()
+		case fade.OutDuration > 0:
+			right.Fade = NewAudioFade(0, min(fade.OutDuration, right.Duration()))
+			right.replaceRef(fade.FadeID, right.Fade.FadeID)
+			fade.InDuration, fade.OutDuration = min(fade.InDuration, offset), 0
+		default:
+			right.replaceRef(fade.FadeID, "")
+			fade.InDuration = min(fade.InDuration, offset)
+		}
+	}
+	return right, nil
+}
+
+// Trim 将音频片段的范围改为相对原开头偏移[start, end]的部分，start可为负、end可超出片段以延长片段
+//
+// 素材时间范围按播放速度同步调整，不能超出素材；淡入淡出的时长不超过新的片段时长
+func (as *AudioSegment) Trim(start, end int64) error {
+	var materialDuration int64
+	if mat, ok := as.MaterialInstance.(*material.AudioMaterial); ok && mat != nil {
+		materialDuration = mat.Duration
+	}
+	if err := as.trim(start, end, materialDuration); err != nil {
+		return err
+	}
+	if as.Fade != nil {
+		as.Fade.InDuration = min(as.Fade.InDuration, as.Duration())
+		as.Fade.OutDuration = min(as.Fade.OutDuration, as.Duration())
+	}
+	return nil
+}
+
+// Split 将文本片段从相对片段开头偏移offset处分为两部分，片段本身成为前一部分，返回新建的后一部分
+//
+// 后一部分使用新的文本素材id，样式等属性被复制，气泡及花字被复制并使用新的id
+func (ts *TextSegment) Split(offset int64) (*TextSegment, error) {
+	if err := checkSplitOffset(ts.BaseSegment, offset); err != nil {
+		return nil, err
+	}
+
+	right := *ts
+	right.VisualSegment = ts.VisualSegment.split(offset)
+	right.MaterialID = util.NewID()
+	if ts.Style != nil {
+		style := *ts.Style
+		right.Style = &style
+	}
+	if ts.Border != nil {
+		border := *ts.Border
+		right.Border = &border
+	}
+	if ts.Background != nil {
+		background := *ts.Background
+		right.Background = &background
+	}
+	if ts.Shadow != nil {
+		shadow := *ts.Shadow
+		right.Shadow = &shadow
+	}
+	if ts.TextStyles != nil {
+		right.TextStyles = make([]*TextStyleRange, len(ts.TextStyles))
+		for i, styleRange := range ts.TextStyles {
+			copied := *styleRange
+			right.TextStyles[i] = &copied
+		}
+	}
+	if ts.Bubble != nil {
+		bubble := *ts.Bubble
+		bubble.GlobalID = util.NewID()
+		right.Bubble = &bubble
+		right.replaceRef(ts.Bubble.GlobalID, bubble.GlobalID)
+	}
+	if ts.Effect != nil {
+		effect := *ts.Effect
+		effect.GlobalID = util.NewID()
+		right.Effect = &effect
+		right.replaceRef(ts.Effect.GlobalID, effect.GlobalID)
+	}
+	return &right, nil
+}
+
+// Trim 将文本片段的范围改为相对原开头偏移[start, end]的部分，start可为负、end可超出片段以延长片段
+func (ts *TextSegment) Trim(start, end int64) error {
+	return ts.trim(start, end, 0)
+}
@@ -0,0 +1,327 @@
+package segment
+
+import (
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/animation"
+	"github.com/zhangshican/go-capcut/internal/keyframe"
+	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/metadata"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+func hasRef(refs []string, id string) bool {
+	for _, ref := range refs {
+		if ref == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestVideoSegmentSplit(t *testing.T) {
+	// 2倍速：4s的片段取用素材中[1s, 9s)的部分
+	video := NewVideoSegment("video_1", types.NewTimerange(1*types.SEC, 8*types.SEC),
+		types.NewTimerange(10*types.SEC, 4*types.SEC), 2.0, 1.0, nil)
+	video.AddKeyframe("alpha", int64(0), 0.0)
+	video.AddKeyframe("alpha", 4*types.SEC, 1.0)
+	video.AddTransition("叠化", "transition_1", "resource_1", 500000)
+	intro, err := metadata.FindIntroByName("渐显")
+	if err != nil {
+		t.Fatalf("Failed to find intro: %v", err)
+	}
+	if err := video.Animations.AddVideoAnimation(intro, 0, 500000); err != nil {
+		t.Fatalf("Failed to add intro: %v", err)
+	}
+	if err := video.Animations.AddVideoAnimation(metadata.OutroType缩小, 3500000, 500000); err != nil {
+		t.Fatalf("Failed to add outro: %v", err)
+	}
+	video.ExtraMaterialRefs = append(video.ExtraMaterialRefs, video.Animations.AnimationID)
+	video.SetLoudness(0.5, -14)
+	video.AddMask("circle", "circle_mask", "image", "mask_resource", 0.0, 0.0, 1.0, 0.0, 0.0, false, nil, nil)
+	video.AddEffect("cool_effect", "effect_1", "effect_resource", "video_effect", 0)
+	video.AddFilter("warm_filter", "filter_1", "filter_resource", 0.8, 0)
+	video.SetBackgroundFilling("canvas_blur", 0.5, "")
+	video.ExtraMaterialRefs = append(video.ExtraMaterialRefs, video.Mask.GlobalID, video.BackgroundFilling.GlobalID)
+
+	right, err := video.Split(1 * types.SEC)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+
+	// 时间范围
+	if video.Start() != 10*types.SEC || video.Duration() != 1*types.SEC {
+		t.Errorf("Unexpected left timerange: %+v", video.TargetTimerange)
+	}
+	if right.Start() != 11*types.SEC || right.Duration() != 3*types.SEC {
+		t.Errorf("Unexpected right timerange: %+v", right.TargetTimerange)
+	}
+	if *video.SourceTimerange != *types.NewTimerange(1*types.SEC, 2*types.SEC) {
+		t.Errorf("Unexpected left source timerange: %+v", video.SourceTimerange)
+	}
+	if *right.SourceTimerange != *types.NewTimerange(3*types.SEC, 6*types.SEC) {
+		t.Errorf("Unexpected right source timerange: %+v", right.SourceTimerange)
+	}
+	if right.SegmentID == video.SegmentID || right.Speed == video.Speed || right.Speed.Value != 2.0 {
+		t.Error("Expected right half to have its own id and speed")
+	}
+
+	// 关键帧在分割处以插值补齐
+	left := video.GetKeyframeList(keyframe.KeyframePropertyAlpha)
+	if len(left.Keyframes) != 2 || left.Keyframes[1].TimeOffset != 1*types.SEC || left.Keyframes[1].Values[0] != 0.25 {
+		t.Errorf("Unexpected left keyframes: %+v", left.Keyframes)
+	}
+	rightKeyframes := right.GetKeyframeList(keyframe.KeyframePropertyAlpha)
+	if len(rightKeyframes.Keyframes) != 2 || rightKeyframes.Keyframes[0].Values[0] != 0.25 || rightKeyframes.Keyframes[1].TimeOffset != 3*types.SEC {
+		t.Errorf("Unexpected right keyframes: %+v", rightKeyframes.Keyframes)
+	}
+
+	// 入场动画保留在前一部分，出场动画移至后一部分
+	if len(video.Animations.Animations) != 1 || video.Animations.Animations[0].AnimationType != animation.AnimationTypeIn {
+		t.Errorf("Expected only the intro on the left half, got %+v", video.Animations.Animations)
+	}
+	if len(right.Animations.Animations) != 1 || right.Animations.Animations[0].AnimationType != animation.AnimationTypeOut {
+		t.Fatalf("Expected only the outro on the right half, got %+v", right.Animations.Animations)
+	}
+	if outro := right.Animations.Animations[0]; outro.Start != 2500000 || outro.Duration != 500000 {
+		t.Errorf("Expected outro at the end of the right half, got %+v", outro)
+	}
+
+	// 转场移至后一部分，附加素材引用随之更新
+	if video.Transition != nil || right.Transition == nil {
+		t.Error("Expected transition to move to the right half")
+	}
+	if hasRef(video.ExtraMaterialRefs, right.Transition.GlobalID) || !hasRef(right.ExtraMaterialRefs, right.Transition.GlobalID) {
+		t.Errorf("Unexpected transition refs: %v %v", video.ExtraMaterialRefs, right.ExtraMaterialRefs)
+	}
+	if !hasRef(right.ExtraMaterialRefs, right.Speed.GlobalID) || hasRef(right.ExtraMaterialRefs, video.Speed.GlobalID) {
+		t.Errorf("Expected right half to reference its own speed: %v", right.ExtraMaterialRefs)
+	}
+	if !hasRef(right.ExtraMaterialRefs, right.Animations.AnimationID) || right.Animations.AnimationID == video.Animations.AnimationID {
+		t.Errorf("Expected right half to reference its own animations: %v", right.ExtraMaterialRefs)
+	}
+	if right.Loudness == nil || right.Loudness == video.Loudness || !hasRef(right.ExtraMaterialRefs, right.Loudness.GlobalID) {
+		t.Fatal("Expected right half to have its own loudness adjustment")
+	}
+	if right.Volume != 0.5 || right.Loudness.TargetLoudness != -14 || hasRef(right.ExtraMaterialRefs, video.Loudness.GlobalID) {
+		t.Errorf("Expected right half to keep the volume and target loudness, got %g %g", right.Volume, right.Loudness.TargetLoudness)
+	}
+
+	// 蒙版、特效、滤镜及背景填充被复制并使用新的id
+	copies := []struct {
+		name        string
+		left, right string
+		distinct    bool
+	}{
+		{"mask", video.Mask.GlobalID, right.Mask.GlobalID, right.Mask != video.Mask && right.Mask.Name == video.Mask.Name},
+		{"effect", video.Effects[0].GlobalID, right.Effects[0].GlobalID, right.Effects[0] != video.Effects[0] && right.Effects[0].EffectID == "effect_1"},
+		{"filter", video.Filters[0].GlobalID, right.Filters[0].GlobalID, right.Filters[0] != video.Filters[0] && right.Filters[0].Intensity == 0.8},
+		{"background filling", video.BackgroundFilling.GlobalID, right.BackgroundFilling.GlobalID,
+			right.BackgroundFilling != video.BackgroundFilling && right.BackgroundFilling.Blur == 0.5},
+	}
+	for _, c := range copies {
+		if !c.distinct || c.left == c.right {
+			t.Errorf("Expected the %s to be copied with a new id", c.name)
+		}
+		if !hasRef(video.ExtraMaterialRefs, c.left) || hasRef(video.ExtraMaterialRefs, c.right) {
+			t.Errorf("Expected left half to keep referencing its own %s: %v", c.name, video.ExtraMaterialRefs)
+		}
+		if hasRef(right.ExtraMaterialRefs, c.left) || !hasRef(right.ExtraMaterialRefs, c.right) {
+			t.Errorf("Expected right half to reference its own %s: %v", c.name, right.ExtraMaterialRefs)
+		}
+	}
+
+	for _, offset := range []int64{0, 1 * types.SEC, -1} {
+		if _, err := video.Split(offset); err == nil {
+			t.Errorf("Expected error for split offset %d", offset)
+		}
+	}
+}
+
+func TestVideoSegmentTrim(t *testing.T) {
+	video := NewVideoSegment("video_1", types.NewTimerange(2*types.SEC, 4*types.SEC),
+		types.NewTimerange(0, 4*types.SEC), 1.0, 1.0, nil)
+	video.MaterialInstance = &material.VideoMaterial{Duration: 7 * types.SEC, MaterialType: material.MaterialTypeVideo}
+	video.AddKeyframe("alpha", 2*types.SEC, 0.5)
+	if err := video.Animations.AddVideoAnimation(metadata.OutroType缩小, 3*types.SEC, 1*types.SEC); err != nil {
+		t.Fatalf("Failed to add outro: %v", err)
+	}
+
+	// 裁掉开头1s、延长结尾1s
+	if err := video.Trim(1*types.SEC, 5*types.SEC); err != nil {
+		t.Fatalf("Trim failed: %v", err)
+	}
+	if video.Start() != 1*types.SEC || video.Duration() != 4*types.SEC {
+		t.Errorf("Unexpected timerange: %+v", video.TargetTimerange)
+	}
+	if *video.SourceTimerange != *types.NewTimerange(3*types.SEC, 4*types.SEC) {
+		t.Errorf("Unexpected source timerange: %+v", video.SourceTimerange)
+	}
+	if kf := video.GetKeyframeList(keyframe.KeyframePropertyAlpha).Keyframes; len(kf) != 1 || kf[0].TimeOffset != 1*types.SEC {
+		t.Errorf("Expected keyframe to shift with the trimmed start, got %+v", kf)
+	}
+	if outro := video.Animations.Animations[0]; outro.Start != 3*types.SEC {
+		t.Errorf("Expected outro to stay at the end, got %+v", outro)
+	}
+
+	// 超出素材范围
+	if err := video.Trim(-4*types.SEC, 4*types.SEC); err == nil {
+		t.Error("Expected error when extending before the material start")
+	}
+	if err := video.Trim(0, 5*types.SEC); err == nil {
+		t.Error("Expected error when extending past the material end")
+	}
+	if err := video.Trim(2*types.SEC, 2*types.SEC); err == nil {
+		t.Error("Expected error for empty range")
+	}
+}
+
+func TestAudioSegmentSplit(t *testing.T) {
+	audio := NewAudioSegment("audio_1", types.NewTimerange(0, 6*types.SEC), nil, 1.0, 1.0)
+	if err := audio.AddFade("1s", "2s"); err != nil {
+		t.Fatalf("Failed to add fade: %v", err)
+	}
+	fadeID := audio.Fade.FadeID
+	if err := audio.AddEffect("reverb", "reverb_resource", AudioEffectCategorySoundEffect); err != nil {
+		t.Fatalf("Failed to add effect: %v", err)
+	}
+	effectID := audio.Effects[0].EffectID
+
+	right, err := audio.Split(3 * types.SEC)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	if audio.Fade == nil || audio.Fade.InDuration != 1*types.SEC || audio.Fade.OutDuration != 0 {
+		t.Errorf("Expected only fade in on the left half, got %+v", audio.Fade)
+	}
+	if right.Fade == nil || right.Fade.InDuration != 0 || right.Fade.OutDuration != 2*types.SEC || right.Fade.FadeID == fadeID {
+		t.Errorf("Expected a new fade out on the right half, got %+v", right.Fade)
+	}
+	if hasRef(right.ExtraMaterialRefs, fadeID) || !hasRef(right.ExtraMaterialRefs, right.Fade.FadeID) {
+		t.Errorf("Unexpected right refs: %v", right.ExtraMaterialRefs)
+	}
+	if *right.SourceTimerange != *types.NewTimerange(3*types.SEC, 3*types.SEC) {
+		t.Errorf("Unexpected right source timerange: %+v", right.SourceTimerange)
+	}
+	if len(right.Effects) != 1 || right.Effects[0] == audio.Effects[0] || right.Effects[0].EffectID == effectID {
+		t.Fatalf("Expected the effect to be copied with a new id, got %+v", right.Effects)
+	}
+	if hasRef(right.ExtraMaterialRefs, effectID) || !hasRef(right.ExtraMaterialRefs, right.Effects[0].EffectID) || !hasRef(audio.ExtraMaterialRefs, effectID) {
+		t.Errorf("Unexpected effect refs: %v %v", audio.ExtraMaterialRefs, right.ExtraMaterialRefs)
+	}
+
+	// 淡入淡出不超过裁剪后的时长
+	if err := right.Trim(0, 1*types.SEC); err != nil {
+		t.Fatalf("Trim failed: %v", err)
+	}
+	if right.Fade.OutDuration != 1*types.SEC {
+		t.Errorf("Expected fade out clamped to 1s, got %d", right.Fade.OutDuration)
+	}
+}
+
+func TestAudioSegmentSplitFadeOutOnly(t *testing.T) {
+	audio := NewAudioSegment("audio_1", types.NewTimerange(0, 6*types.SEC), nil, 1.0, 1.0)
+	if err := audio.AddFade("0s", "4s"); err != nil {
+		t.Fatalf("Failed to add fade: %v", err)
+	}
+	fade := audio.Fade
+
+	right, err := audio.Split(3 * types.SEC)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	if audio.Fade != nil || hasRef(audio.ExtraMaterialRefs, fade.FadeID) {
+		t.Errorf("Expected no fade on the left half, got %+v %v", audio.Fade, audio.ExtraMaterialRefs)
+	}
+	// 原淡入淡出效果移至后一部分，不会成为无片段引用的素材
+	if right.Fade != fade || right.Fade.OutDuration != 3*types.SEC || !hasRef(right.ExtraMaterialRefs, fade.FadeID) {
+		t.Errorf("Expected the original fade moved to the right half, got %+v %v", right.Fade, right.ExtraMaterialRefs)
+	}
+}
+
+func TestVisualSegmentSplitAnimationsInstance(t *testing.T) {
+	video := NewVideoSegment("video_1", types.NewTimerange(0, 4*types.SEC), types.NewTimerange(0, 4*types.SEC), 1.0, 1.0, nil)
+	instance := &animation.SegmentAnimations{AnimationID: "instance_1",
+		Animations: []*animation.Animation{{Name: "loop", Start: 0, Duration: 4 * types.SEC}}}
+	video.AnimationsInstance = instance
+
+	right, err := video.Split(2 * types.SEC)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	copied, ok := right.AnimationsInstance.(*animation.SegmentAnimations)
+	if !ok || copied == instance || copied.AnimationID == instance.AnimationID {
+		t.Fatalf("Expected the animations instance to be copied with a new id, got %+v", right.AnimationsInstance)
+	}
+	if len(copied.Animations) != 1 || copied.Animations[0] == instance.Animations[0] {
+		t.Errorf("Expected the animations to be copied, got %+v", copied.Animations)
+	}
+
+	// 指向片段动画时改为指向后一部分的动画
+	other := NewVideoSegment("video_2", types.NewTimerange(0, 4*types.SEC), types.NewTimerange(0, 4*types.SEC), 1.0, 1.0, nil)
+	other.AnimationsInstance = other.Animations
+	right, err = other.Split(2 * types.SEC)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	if right.AnimationsInstance != right.Animations {
+		t.Errorf("Expected the right half to use its own animations, got %+v", right.AnimationsInstance)
+	}
+
+	// 为空时保持为空
+	empty := NewVideoSegment("video_3", types.NewTimerange(0, 4*types.SEC), types.NewTimerange(0, 4*types.SEC), 1.0, 1.0, nil)
+	right, err = empty.Split(2 * types.SEC)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	if right.AnimationsInstance != nil {
+		t.Errorf("Expected nil animations instance, got %+v", right.AnimationsInstance)
+	}
+}
+
+func TestTextSegmentSplit(t *testing.T) {
+	text := NewTextSegment("hello", types.NewTimerange(0, 4*types.SEC), "", nil, nil)
+	if err := text.Animations.AddTextAnimation(metadata.TextLoopAnim闪烁, 0, 4*types.SEC); err != nil {
+		t.Fatalf("Failed to add loop animation: %v", err)
+	}
+	text.SetBubble("bubble_1", "bubble_resource", "bubble")
+	text.SetEffect("effect_1", "effect_resource", "effect")
+	text.ExtraMaterialRefs = append(text.ExtraMaterialRefs, text.Bubble.GlobalID, text.Effect.GlobalID)
+
+	right, err := text.Split(1 * types.SEC)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	if right.MaterialID == text.MaterialID || right.Text != "hello" {
+		t.Errorf("Expected a copy of the text with a new material id, got %s", right.MaterialID)
+	}
+	if right.Style == text.Style {
+		t.Error("Expected the text style to be copied")
+	}
+
+	// 气泡及花字被复制并使用新的id
+	if right.Bubble == text.Bubble || right.Bubble.GlobalID == text.Bubble.GlobalID || right.Bubble.EffectID != "bubble_1" {
+		t.Errorf("Expected the bubble to be copied with a new id, got %+v", right.Bubble)
+	}
+	if right.Effect == text.Effect || right.Effect.GlobalID == text.Effect.GlobalID || right.Effect.EffectID != "effect_1" {
+		t.Errorf("Expected the text effect to be copied with a new id, got %+v", right.Effect)
+	}
+	for _, id := range []string{right.Bubble.GlobalID, right.Effect.GlobalID} {
+		if !hasRef(right.ExtraMaterialRefs, id) || hasRef(text.ExtraMaterialRefs, id) {
+			t.Errorf("Expected only the right half to reference %s: %v %v", id, text.ExtraMaterialRefs, right.ExtraMaterialRefs)
+		}
+	}
+	for _, id := range []string{text.Bubble.GlobalID, text.Effect.GlobalID} {
+		if hasRef(right.ExtraMaterialRefs, id) {
+			t.Errorf("Expected the right half to drop the reference to %s: %v", id, right.ExtraMaterialRefs)
+		}
+	}
+
+	// 循环动画截取到两部分各自的范围内
+	if loop := text.Animations.Animations[0]; loop.Start != 0 || loop.Duration != 1*types.SEC {
+		t.Errorf("Unexpected left loop animation: %+v", loop)
+	}
+	if loop := right.Animations.Animations[0]; loop.Start != 0 || loop.Duration != 3*types.SEC {
+		t.Errorf("Unexpected right loop animation: %+v", loop)
+	}
+}
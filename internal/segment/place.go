@@ -0,0 +1,57 @@
+package segment
+
+import (
+	"fmt"
+
+	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+// place 将片段放置到start处开始
+//
+// 已有目标时间范围时只修改开始时间；否则按素材时间范围及播放速度计算时长，
+// 未设置素材时间范围时截取整个素材，此时materialDuration必须大于0
+func (ms *MediaSegment) place(start, materialDuration int64) error {
+	if ms.TargetTimerange != nil {
+		ms.TargetTimerange.Start = start
+		return nil
+	}
+
+	source := ms.SourceTimerange
+	if source == nil {
+		if materialDuration <= 0 {
+			return fmt.Errorf("片段 %s 未指定时间范围，且无法获取素材 %s 的时长", ms.SegmentID, ms.MaterialID)
+		}
+		source = types.NewTimerange(0, materialDuration)
+	}
+	duration := int64(float64(source.Duration)/ms.Speed.Value + 0.5)
+	if duration <= 0 {
+		return fmt.Errorf("片段 %s 的时长必须大于0", ms.SegmentID)
+	}
+	ms.SourceTimerange = source
+	ms.TargetTimerange = types.NewTimerange(start, duration)
+	return nil
+}
+
+// PlaceAt 将视频片段放置到轨道上start处开始
+//
+// 未设置目标时间范围时按素材时间范围及播放速度计算时长，二者都未设置时截取MaterialInstance中的整个视频素材；
+// 图片素材没有固有时长，必须指定其中一个时间范围
+func (vs *VideoSegment) PlaceAt(start int64) error {
+	var materialDuration int64
+	if mat, ok := vs.MaterialInstance.(*material.VideoMaterial); ok && mat != nil && mat.MaterialType != material.MaterialTypePhoto {
+		materialDuration = mat.Duration
+	}
+	return vs.place(start, materialDuration)
+}
+
+// PlaceAt 将音频片段放置到轨道上start处开始
+//
+// 未设置目标时间范围时按素材时间范围及播放速度计算时长，二者都未设置时截取MaterialInstance中的整个音频素材
+func (as *AudioSegment) PlaceAt(start int64) error {
+	var materialDuration int64
+	if mat, ok := as.MaterialInstance.(*material.AudioMaterial); ok && mat != nil {
+		materialDuration = mat.Duration
+	}
+	return as.place(start, materialDuration)
+}
@@ -0,0 +1,63 @@
+package segment
+
+import (
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+func TestVideoSegmentPlaceAt(t *testing.T) {
+	// 截取整个素材，2倍速时时长减半
+	video := NewVideoSegment("video_1", nil, nil, 2.0, 1.0, nil)
+	video.MaterialInstance = &material.VideoMaterial{Duration: 5 * types.SEC, MaterialType: material.MaterialTypeVideo}
+	if err := video.PlaceAt(1 * types.SEC); err != nil {
+		t.Fatalf("PlaceAt failed: %v", err)
+	}
+	if *video.TargetTimerange != *types.NewTimerange(1*types.SEC, 2500000) {
+		t.Errorf("Unexpected target timerange: %+v", video.TargetTimerange)
+	}
+	if *video.SourceTimerange != *types.NewTimerange(0, 5*types.SEC) {
+		t.Errorf("Unexpected source timerange: %+v", video.SourceTimerange)
+	}
+
+	// 已有目标时间范围时只修改开始时间
+	if err := video.PlaceAt(3 * types.SEC); err != nil {
+		t.Fatalf("PlaceAt failed: %v", err)
+	}
+	if video.Start() != 3*types.SEC || video.Duration() != 2500000 {
+		t.Errorf("Unexpected target timerange after moving: %+v", video.TargetTimerange)
+	}
+
+	// 按素材时间范围计算时长
+	partial := NewVideoSegment("video_1", types.NewTimerange(1*types.SEC, 3*types.SEC), nil, 1.5, 1.0, nil)
+	if err := partial.PlaceAt(0); err != nil {
+		t.Fatalf("PlaceAt failed: %v", err)
+	}
+	if partial.Duration() != 2*types.SEC {
+		t.Errorf("Expected duration 2s, got %d", partial.Duration())
+	}
+
+	photo := NewVideoSegment("photo_1", nil, nil, 1.0, 1.0, nil)
+	photo.MaterialInstance = &material.VideoMaterial{Duration: 10800 * types.SEC, MaterialType: material.MaterialTypePhoto}
+	if err := photo.PlaceAt(0); err == nil {
+		t.Error("Expected error for a photo without any timerange")
+	}
+	if photo.TargetTimerange != nil {
+		t.Error("Expected the photo segment to stay unplaced")
+	}
+}
+
+func TestAudioSegmentPlaceAt(t *testing.T) {
+	audio := NewAudioSegment("audio_1", nil, nil, 1.0, 1.0)
+	if err := audio.PlaceAt(0); err == nil {
+		t.Error("Expected error without material duration")
+	}
+	audio.MaterialInstance = &material.AudioMaterial{Duration: 4 * types.SEC}
+	if err := audio.PlaceAt(2 * types.SEC); err != nil {
+		t.Fatalf("PlaceAt failed: %v", err)
+	}
+	if *audio.TargetTimerange != *types.NewTimerange(2*types.SEC, 4*types.SEC) {
+		t.Errorf("Unexpected target timerange: %+v", audio.TargetTimerange)
+	}
+}
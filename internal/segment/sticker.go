@@ -0,0 +1,74 @@
+// Package segment/sticker 定义贴纸片段及贴纸素材类
+// 对应Python的 video_segment.py 中的Sticker_segment类
+package segment
+
+import (
+	"fmt"
+
+	"github.com/zhangshican/go-capcut/internal/metadata"
+	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut/internal/util"
+)
+
+// StickerMaterial 贴纸素材
+type StickerMaterial struct {
+	MaterialID string `json:"id"`          // 素材全局id，由程序自动生成
+	ResourceID string `json:"resource_id"` // 贴纸资源id，由剪映本身提供
+}
+
+// NewStickerMaterial 创建新的贴纸素材
+func NewStickerMaterial(resourceID string) *StickerMaterial {
+	return &StickerMaterial{
+		MaterialID: util.NewID(),
+		ResourceID: resourceID,
+	}
+}
+
+// ExportJSON 导出为JSON格式
+// 对应Python的Sticker_segment.export_material方法
+func (sm *StickerMaterial) ExportJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"id":              sm.MaterialID,
+		"resource_id":     sm.ResourceID,
+		"sticker_id":      sm.ResourceID,
+		"source_platform": 1,
+		"type":            "sticker",
+	}
+}
+
+// StickerSegment 贴纸片段，放置在贴纸轨道上
+// 对应Python的Sticker_segment类
+//
+// 贴纸的动画与文本动画通用，可通过Animations.AddTextAnimation添加
+type StickerSegment struct {
+	*VisualSegment
+	Material *StickerMaterial `json:"material"` // 相应的贴纸素材，在放入轨道时自动添加到素材列表中
+}
+
+// NewStickerSegment 创建新的贴纸片段，clipSettings为nil时使用默认的图像调节设置
+func NewStickerSegment(resourceID string, targetTimerange *types.Timerange, clipSettings *ClipSettings) *StickerSegment {
+	stickerMaterial := NewStickerMaterial(resourceID)
+	return &StickerSegment{
+		VisualSegment: NewVisualSegment(stickerMaterial.MaterialID, nil, targetTimerange, 1.0, 1.0, clipSettings),
+		Material:      stickerMaterial,
+	}
+}
+
+// NewStickerSegmentFromType 根据已注册的贴纸创建贴纸片段
+func NewStickerSegmentFromType(stickerType metadata.EffectEnumerable, targetTimerange *types.Timerange, clipSettings *ClipSettings) (*StickerSegment, error) {
+	meta, ok := stickerType.GetMeta().(metadata.StickerMeta)
+	if !ok {
+		return nil, fmt.Errorf("invalid sticker meta type: %T", stickerType.GetMeta())
+	}
+	return NewStickerSegment(meta.ResourceID, targetTimerange, clipSettings), nil
+}
+
+// ExportMaterial 导出贴纸素材
+func (ss *StickerSegment) ExportMaterial() map[string]interface{} {
+	return ss.Material.ExportJSON()
+}
+
+// String 返回贴纸片段的字符串表示
+func (ss *StickerSegment) String() string {
+	return fmt.Sprintf("StickerSegment{%s, Resource: %s}", ss.BaseSegment.String(), ss.Material.ResourceID)
+}
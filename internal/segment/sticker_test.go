@@ -0,0 +1,65 @@
+package segment
+
+import (
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/metadata"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+func TestStickerSegment(t *testing.T) {
+	timerange, _ := types.Trange("1s", "3s")
+	clip := NewClipSettingsWithParams(0.8, 15, 0.5, 0.5, 0.2, -0.3, false, false)
+	sticker := NewStickerSegment("7226264929129287177", timerange, clip)
+
+	if sticker.MaterialID != sticker.Material.MaterialID {
+		t.Errorf("Expected material id %s, got %s", sticker.Material.MaterialID, sticker.MaterialID)
+	}
+	if sticker.SourceTimerange != nil {
+		t.Error("Expected sticker to have no source timerange")
+	}
+	if sticker.ClipSettings != clip {
+		t.Error("Expected clip settings to be kept")
+	}
+
+	// 贴纸素材
+	material := sticker.ExportMaterial()
+	if material["resource_id"] != "7226264929129287177" || material["sticker_id"] != "7226264929129287177" {
+		t.Errorf("Unexpected sticker material: %v", material)
+	}
+	if material["type"] != "sticker" || material["id"] != sticker.MaterialID {
+		t.Errorf("Unexpected sticker material: %v", material)
+	}
+
+	// 关键帧
+	if err := sticker.AddKeyframe("alpha", "1s", 0.5); err != nil {
+		t.Fatalf("Failed to add keyframe: %v", err)
+	}
+	result := sticker.ExportJSON()
+	if result["material_id"] != sticker.MaterialID {
+		t.Errorf("Expected material_id %s, got %v", sticker.MaterialID, result["material_id"])
+	}
+	if keyframes, ok := result["common_keyframes"].([]map[string]interface{}); !ok || len(keyframes) != 1 {
+		t.Errorf("Expected 1 keyframe list, got %v", result["common_keyframes"])
+	}
+	if clipJSON, ok := result["clip"].(map[string]interface{}); !ok || clipJSON["rotation"] != 15.0 {
+		t.Errorf("Unexpected clip settings: %v", result["clip"])
+	}
+}
+
+func TestStickerSegmentFromType(t *testing.T) {
+	timerange, _ := types.Trange("0s", "2s")
+	stickerType := metadata.RegisterSticker("segment_test_sticker", "sticker_resource_1")
+
+	sticker, err := NewStickerSegmentFromType(stickerType, timerange, nil)
+	if err != nil {
+		t.Fatalf("Failed to create sticker segment: %v", err)
+	}
+	if sticker.Material.ResourceID != "sticker_resource_1" {
+		t.Errorf("Expected resource id sticker_resource_1, got %s", sticker.Material.ResourceID)
+	}
+
+	if _, err := NewStickerSegmentFromType(metadata.FilterType自然, timerange, nil); err == nil {
+		t.Error("Expected error for non-sticker meta")
+	}
+}
@@ -7,8 +7,7 @@ import (
 	"strings"
 
 	"github.com/zhangshican/go-capcut/internal/types"
-
-	"github.com/google/uuid"
+	"github.com/zhangshican/go-capcut/internal/util"
 )
 
 // TextStyle 字体样式类
@@ -185,7 +184,7 @@ type TextBubble struct {
 // NewTextBubble 创建新的文本气泡效果
 func NewTextBubble(effectID, resourceID, name string) *TextBubble {
 	return &TextBubble{
-		GlobalID:   uuid.New().String(),
+		GlobalID:   util.NewID(),
 		EffectID:   effectID,
 		ResourceID: resourceID,
 		Name:       name,
@@ -217,7 +216,7 @@ type TextEffect struct {
 // NewTextEffect 创建新的花字效果
 func NewTextEffect(effectID, resourceID, name string) *TextEffect {
 	return &TextEffect{
-		GlobalID:   uuid.New().String(),
+		GlobalID:   util.NewID(),
 		EffectID:   effectID,
 		ResourceID: resourceID,
 		Name:       name,
@@ -282,7 +281,7 @@ func NewTextSegment(text string, targetTimerange *types.Timerange, font string,
 	}
 
 	// 生成虚拟的material_id用于文本
-	textMaterialID := uuid.New().String()
+	textMaterialID := util.NewID()
 
 	return &TextSegment{
 		VisualSegment: NewVisualSegment(textMaterialID, nil, targetTimerange, 1.0, 1.0, clipSettings),
@@ -6,8 +6,7 @@ import (
 	"fmt"
 
 	"github.com/zhangshican/go-capcut/internal/types"
-
-	"github.com/google/uuid"
+	"github.com/zhangshican/go-capcut/internal/util"
 )
 
 // Mask 蒙版对象
@@ -33,7 +32,7 @@ type Mask struct {
 // NewMask 创建新的蒙版对象
 func NewMask(name, resourceType, resourceID string, cx, cy, w, h, ratio, rot, feather, roundCorner float64, inv bool) *Mask {
 	return &Mask{
-		GlobalID:     uuid.New().String(),
+		GlobalID:     util.NewID(),
 		Name:         name,
 		ResourceType: resourceType,
 		ResourceID:   resourceID,
@@ -93,7 +92,7 @@ type VideoEffect struct {
 func NewVideoEffect(name, effectID, resourceID, effectType string, applyTargetType int) *VideoEffect {
 	return &VideoEffect{
 		Name:            name,
-		GlobalID:        uuid.New().String(),
+		GlobalID:        util.NewID(),
 		EffectID:        effectID,
 		ResourceID:      resourceID,
 		EffectType:      effectType,
@@ -143,7 +142,7 @@ type Filter struct {
 // NewFilter 创建新的滤镜
 func NewFilter(name, effectID, resourceID string, intensity float64, applyTargetType int) *Filter {
 	return &Filter{
-		GlobalID:        uuid.New().String(),
+		GlobalID:        util.NewID(),
 		Name:            name,
 		EffectID:        effectID,
 		ResourceID:      resourceID,
@@ -199,7 +198,7 @@ type Transition struct {
 // NewTransition 创建新的转场效果
 func NewTransition(name, effectID, resourceID string, duration int64) *Transition {
 	return &Transition{
-		GlobalID:   uuid.New().String(),
+		GlobalID:   util.NewID(),
 		Name:       name,
 		EffectID:   effectID,
 		ResourceID: resourceID,
@@ -238,7 +237,7 @@ type BackgroundFilling struct {
 // NewBackgroundFilling 创建新的背景填充
 func NewBackgroundFilling(fillType string, blur float64, color string) *BackgroundFilling {
 	return &BackgroundFilling{
-		GlobalID: uuid.New().String(),
+		GlobalID: util.NewID(),
 		FillType: fillType,
 		Blur:     blur,
 		Color:    color,
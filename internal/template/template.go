@@ -3,15 +3,18 @@
 package template
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 
+	"github.com/zhangshican/go-capcut/internal/content"
+	"github.com/zhangshican/go-capcut/internal/keyframe"
 	"github.com/zhangshican/go-capcut/internal/material"
 	"github.com/zhangshican/go-capcut/internal/segment"
 	"github.com/zhangshican/go-capcut/internal/track"
 	"github.com/zhangshican/go-capcut/internal/types"
-
-	"github.com/google/uuid"
+	"github.com/zhangshican/go-capcut/internal/util"
 )
 
 // ShrinkMode 处理替换素材时素材变短情况的方法
@@ -38,68 +41,104 @@ const (
 
 // ImportedSegment 导入的片段
 // 对应Python的ImportedSegment类
+//
+// 片段以原始json数据为基础导出，其中时间范围、附加素材引用、图像调节设置和关键帧会被解析为对应的类型，
+// 导出时只有被修改过的字段才会写回原始数据
 type ImportedSegment struct {
 	*segment.BaseSegment
-	RawData map[string]interface{} `json:"-"` // 原始json数据
+	ExtraMaterialRefs []string                 `json:"extra_material_refs"` // 附加的素材id列表，用于链接动画/特效等
+	ClipSettings      *segment.ClipSettings    `json:"clip"`                // 图像调节设置，音频片段为nil
+	KeyframeLists     []*keyframe.KeyframeList `json:"common_keyframes"`    // 按原始顺序排列的关键帧列表
+	RawData           *content.Segment         `json:"-"`                   // 原始json数据，导出时不会被修改
 }
 
-// NewImportedSegment 创建导入的片段
+// NewImportedSegment 从map形式的json数据创建导入的片段
 func NewImportedSegment(jsonData map[string]interface{}) (*ImportedSegment, error) {
-	// 提取基本属性
-	materialID, ok := jsonData["material_id"].(string)
-	if !ok {
+	if _, ok := jsonData["material_id"].(string); !ok {
 		return nil, fmt.Errorf("missing or invalid material_id")
 	}
 
-	targetTimerangeData, ok := jsonData["target_timerange"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("missing or invalid target_timerange")
+	data := &content.Segment{}
+	if err := content.FromMap(jsonData, data); err != nil {
+		return nil, fmt.Errorf("invalid segment data: %v", err)
 	}
+	return NewImportedSegmentFromContent(data)
+}
 
-	start, ok := targetTimerangeData["start"].(float64)
-	if !ok {
-		return nil, fmt.Errorf("missing or invalid target_timerange.start")
+// NewImportedSegmentFromContent 从草稿内容模型创建导入的片段，data作为片段的原始数据被保留
+func NewImportedSegmentFromContent(data *content.Segment) (*ImportedSegment, error) {
+	if data.TargetTimerange == nil {
+		return nil, fmt.Errorf("missing or invalid target_timerange")
 	}
 
-	duration, ok := targetTimerangeData["duration"].(float64)
-	if !ok {
-		return nil, fmt.Errorf("missing or invalid target_timerange.duration")
+	// 创建基础片段，沿用原始片段id
+	targetTimerange := types.NewTimerange(data.TargetTimerange.Start, data.TargetTimerange.Duration)
+	baseSegment := segment.NewBaseSegment(data.MaterialID, targetTimerange)
+	if data.ID != "" {
+		baseSegment.SegmentID = data.ID
 	}
 
-	targetTimerange := types.NewTimerange(int64(start), int64(duration))
+	importedSegment := &ImportedSegment{
+		BaseSegment:       baseSegment,
+		ExtraMaterialRefs: append(make([]string, 0, len(data.ExtraMaterialRefs)), data.ExtraMaterialRefs...),
+		KeyframeLists:     make([]*keyframe.KeyframeList, 0, len(data.CommonKeyframes)),
+		RawData:           data,
+	}
 
-	// 创建基础片段
-	baseSegment := segment.NewBaseSegment(materialID, targetTimerange)
+	if data.Clip != nil {
+		importedSegment.ClipSettings = parseClipSettings(data.Clip)
+	}
 
-	// 复制原始数据
-	rawData := make(map[string]interface{})
-	for k, v := range jsonData {
-		rawData[k] = v
+	for _, listData := range data.CommonKeyframes {
+		// 无法解析的关键帧列表保持原样导出
+		if list, ok := parseKeyframeList(listData); ok {
+			importedSegment.KeyframeLists = append(importedSegment.KeyframeLists, list)
+		}
 	}
 
-	return &ImportedSegment{
-		BaseSegment: baseSegment,
-		RawData:     rawData,
-	}, nil
+	return importedSegment, nil
 }
 
-// ExportJSON 导出为JSON格式
+// ExportJSON 导出为JSON格式，时间范围为int64类型，关键帧的值为[]float64类型，其余数值为float64类型
 // 对应Python的export_json方法
 func (is *ImportedSegment) ExportJSON() map[string]interface{} {
-	// 从原始数据开始
-	jsonData := make(map[string]interface{})
-	for k, v := range is.RawData {
-		jsonData[k] = v
+	data, err := is.ExportContent()
+	if err != nil {
+		return nil
 	}
+	return exportSegmentMap(data)
+}
 
-	// 更新基本属性
-	jsonData["material_id"] = is.MaterialID
-	jsonData["target_timerange"] = map[string]interface{}{
-		"start":    is.TargetTimerange.Start,
-		"duration": is.TargetTimerange.Duration,
+// ExportContent 导出为草稿内容模型，以原始数据为基础，只改写发生变化的字段
+func (is *ImportedSegment) ExportContent() (*content.Segment, error) {
+	raw := is.RawData
+	if raw == nil {
+		raw = &content.Segment{}
 	}
+	data := *raw
 
-	return jsonData
+	data.MaterialID = is.MaterialID
+	data.TargetTimerange = exportTimerange(raw.TargetTimerange, is.TargetTimerange)
+	data.ExtraMaterialRefs = append(make([]string, 0, len(is.ExtraMaterialRefs)), is.ExtraMaterialRefs...)
+
+	if is.ClipSettings != nil {
+		data.Clip = exportClip(raw.Clip, is.ClipSettings)
+	}
+
+	// 通过BaseSegment.AddKeyframe新增的关键帧追加在导入的关键帧之后
+	keyframeLists := is.KeyframeLists
+	if is.KeyframeManager.HasKeyframes() {
+		keyframeLists = append(append([]*keyframe.KeyframeList{}, keyframeLists...), is.KeyframeManager.GetAllKeyframeLists()...)
+	}
+	if raw.CommonKeyframes != nil || len(keyframeLists) > 0 {
+		lists, err := exportKeyframeLists(raw.CommonKeyframes, keyframeLists)
+		if err != nil {
+			return nil, err
+		}
+		data.CommonKeyframes = lists
+	}
+
+	return &data, nil
 }
 
 // ImportedMediaSegment 导入的视频/音频片段
@@ -107,61 +146,444 @@ func (is *ImportedSegment) ExportJSON() map[string]interface{} {
 type ImportedMediaSegment struct {
 	*ImportedSegment
 	SourceTimerange *types.Timerange `json:"source_timerange"` // 片段取用的素材时间范围
+	Speed           float64          `json:"speed"`            // 播放速度，只读，由原始数据或变速素材得到
+	Volume          float64          `json:"volume"`           // 音量
 }
 
-// NewImportedMediaSegment 创建导入的媒体片段
+// NewImportedMediaSegment 从map形式的json数据创建导入的媒体片段
 func NewImportedMediaSegment(jsonData map[string]interface{}) (*ImportedMediaSegment, error) {
+	if _, ok := jsonData["material_id"].(string); !ok {
+		return nil, fmt.Errorf("missing or invalid material_id")
+	}
+
+	data := &content.Segment{}
+	if err := content.FromMap(jsonData, data); err != nil {
+		return nil, fmt.Errorf("invalid segment data: %v", err)
+	}
+	return NewImportedMediaSegmentFromContent(data)
+}
+
+// NewImportedMediaSegmentFromContent 从草稿内容模型创建导入的媒体片段，data作为片段的原始数据被保留
+func NewImportedMediaSegmentFromContent(data *content.Segment) (*ImportedMediaSegment, error) {
 	// 先创建基础导入片段
-	importedSegment, err := NewImportedSegment(jsonData)
+	importedSegment, err := NewImportedSegmentFromContent(data)
 	if err != nil {
 		return nil, err
 	}
 
-	// 提取源时间范围
-	sourceTimerangeData, ok := jsonData["source_timerange"].(map[string]interface{})
-	if !ok {
+	if data.SourceTimerange == nil {
 		return nil, fmt.Errorf("missing or invalid source_timerange")
 	}
 
-	start, ok := sourceTimerangeData["start"].(float64)
-	if !ok {
-		return nil, fmt.Errorf("missing or invalid source_timerange.start")
+	speed := 1.0
+	if data.Speed > 0 {
+		speed = data.Speed
 	}
 
-	duration, ok := sourceTimerangeData["duration"].(float64)
-	if !ok {
-		return nil, fmt.Errorf("missing or invalid source_timerange.duration")
+	volume := 1.0
+	if data.Volume != 0 || data.Has("volume") {
+		volume = data.Volume
 	}
 
-	sourceTimerange := types.NewTimerange(int64(start), int64(duration))
-
 	return &ImportedMediaSegment{
 		ImportedSegment: importedSegment,
-		SourceTimerange: sourceTimerange,
+		SourceTimerange: types.NewTimerange(data.SourceTimerange.Start, data.SourceTimerange.Duration),
+		Speed:           speed,
+		Volume:          volume,
 	}, nil
 }
 
-// ExportJSON 导出为JSON格式
+// ExportJSON 导出为JSON格式，数值类型与ImportedSegment.ExportJSON相同
 func (ims *ImportedMediaSegment) ExportJSON() map[string]interface{} {
-	jsonData := ims.ImportedSegment.ExportJSON()
+	data, err := ims.ExportContent()
+	if err != nil {
+		return nil
+	}
+	return exportSegmentMap(data)
+}
+
+// ExportContent 导出为草稿内容模型，以原始数据为基础，只改写发生变化的字段
+func (ims *ImportedMediaSegment) ExportContent() (*content.Segment, error) {
+	data, err := ims.ImportedSegment.ExportContent()
+	if err != nil {
+		return nil, err
+	}
 
 	// 添加源时间范围
-	jsonData["source_timerange"] = map[string]interface{}{
-		"start":    ims.SourceTimerange.Start,
-		"duration": ims.SourceTimerange.Duration,
+	if ims.SourceTimerange != nil {
+		data.SourceTimerange = exportTimerange(data.SourceTimerange, ims.SourceTimerange)
 	}
+	data.Volume = ims.Volume
 
-	return jsonData
+	return data, nil
+}
+
+// Split 将导入的片段从相对片段开头偏移offset处分为两部分，片段本身成为前一部分，返回新建的后一部分
+//
+// 后一部分复制片段当前的原始json数据并使用新的片段id，附加素材引用与前一部分相同；
+// 关键帧在分割处以插值补齐，前一部分沿用原有的关键帧id，后一部分使用新的id
+func (is *ImportedSegment) Split(offset int64) (*ImportedSegment, error) {
+	duration := is.Duration()
+	if offset <= 0 || offset >= duration {
+		return nil, fmt.Errorf("split offset %d out of range (0, %d)", offset, duration)
+	}
+
+	data, err := is.ExportContent()
+	if err != nil {
+		return nil, err
+	}
+	cloned, err := content.ToMap(data)
+	if err != nil {
+		return nil, err
+	}
+	rightData := &content.Segment{}
+	if err := content.FromMap(cloned, rightData); err != nil {
+		return nil, err
+	}
+	rightData.ID = util.NewID()
+	rightData.CommonKeyframes = nil
+
+	right, err := NewImportedSegmentFromContent(rightData)
+	if err != nil {
+		return nil, err
+	}
+	right.TargetTimerange = types.NewTimerange(is.Start()+offset, duration-offset)
+	right.KeyframeLists = sliceKeyframeLists(is.KeyframeLists, offset, duration, false)
+	right.KeyframeManager = is.KeyframeManager.Slice(offset, duration)
+
+	is.KeyframeLists = sliceKeyframeLists(is.KeyframeLists, 0, offset, true)
+	is.KeyframeManager = is.KeyframeManager.Slice(0, offset)
+	is.TargetTimerange = types.NewTimerange(is.Start(), offset)
+	return right, nil
+}
+
+// SplitSegment 与Split相同，返回值为segment.SegmentInterface，供track.Track分割及波纹编辑导入的片段
+func (is *ImportedSegment) SplitSegment(offset int64) (segment.SegmentInterface, error) {
+	return is.Split(offset)
+}
+
+// Trim 将导入的片段的范围改为相对原开头偏移[start, end]的部分，start可为负、end可超出片段以延长片段
+//
+// 关键帧的处理与Split相同，原有的关键帧id保持不变
+func (is *ImportedSegment) Trim(start, end int64) error {
+	if end <= start {
+		return fmt.Errorf("trimmed duration must be positive: [%d, %d)", start, end)
+	}
+	is.KeyframeLists = sliceKeyframeLists(is.KeyframeLists, start, end, true)
+	is.KeyframeManager = is.KeyframeManager.Slice(start, end)
+	is.TargetTimerange = types.NewTimerange(is.Start()+start, end-start)
+	return nil
+}
+
+// Split 将导入的媒体片段从相对片段开头偏移offset处分为两部分，片段本身成为前一部分，返回新建的后一部分
+//
+// 素材时间范围按播放速度划分，与ProcessTimerange相同，片段时长乘以播放速度即为素材截取时长；其余处理见ImportedSegment.Split
+func (ims *ImportedMediaSegment) Split(offset int64) (*ImportedMediaSegment, error) {
+	source := ims.SourceTimerange
+	if source == nil {
+		return nil, fmt.Errorf("missing or invalid source_timerange")
+	}
+	sourceOffset := ims.sourceAt(offset)
+
+	rightSegment, err := ims.ImportedSegment.Split(offset)
+	if err != nil {
+		return nil, err
+	}
+	right := &ImportedMediaSegment{
+		ImportedSegment: rightSegment,
+		SourceTimerange: types.NewTimerange(sourceOffset, source.End()-sourceOffset),
+		Speed:           ims.Speed,
+		Volume:          ims.Volume,
+	}
+	ims.SourceTimerange = types.NewTimerange(source.Start, sourceOffset-source.Start)
+	return right, nil
+}
+
+// SplitSegment 与Split相同，返回值为segment.SegmentInterface，供track.Track分割及波纹编辑导入的片段
+func (ims *ImportedMediaSegment) SplitSegment(offset int64) (segment.SegmentInterface, error) {
+	return ims.Split(offset)
+}
+
+// Trim 将导入的媒体片段的范围改为相对原开头偏移[start, end]的部分，素材时间范围按播放速度同步调整
+//
+// 素材时长未知，只检查调整后的素材时间范围不早于素材开头
+func (ims *ImportedMediaSegment) Trim(start, end int64) error {
+	if end <= start {
+		return fmt.Errorf("trimmed duration must be positive: [%d, %d)", start, end)
+	}
+	if ims.SourceTimerange == nil {
+		return fmt.Errorf("missing or invalid source_timerange")
+	}
+	sourceStart, sourceEnd := ims.sourceAt(start), ims.sourceAt(end)
+	if sourceStart < 0 {
+		return fmt.Errorf("trimmed source range [%d, %d) starts before the material", sourceStart, sourceEnd)
+	}
+
+	if err := ims.ImportedSegment.Trim(start, end); err != nil {
+		return err
+	}
+	ims.SourceTimerange = types.NewTimerange(sourceStart, sourceEnd-sourceStart)
+	return nil
+}
+
+// sourceAt 返回相对片段开头偏移offset处对应的素材时间，按播放速度换算
+func (ims *ImportedMediaSegment) sourceAt(offset int64) int64 {
+	speed := ims.Speed
+	if speed <= 0 {
+		speed = 1.0
+	}
+	source, duration := ims.SourceTimerange, ims.Duration()
+	if offset >= duration {
+		// 从结尾换算，避免舍入误差使结尾发生偏移
+		return source.End() + int64(math.Round(float64(offset-duration)*speed))
+	}
+	return source.Start + int64(math.Round(float64(offset)*speed))
+}
+
+// sliceKeyframeLists 返回各关键帧列表在[start, end]范围内的部分，keepIDs为true时沿用原有的列表id及关键帧id
+func sliceKeyframeLists(lists []*keyframe.KeyframeList, start, end int64, keepIDs bool) []*keyframe.KeyframeList {
+	result := make([]*keyframe.KeyframeList, 0, len(lists))
+	for _, list := range lists {
+		sliced := list.Slice(start, end)
+		if keepIDs {
+			sliced.ListID = list.ListID
+			ids := make(map[int64]string, len(list.Keyframes))
+			for _, kf := range list.Keyframes {
+				ids[kf.TimeOffset-start] = kf.KfID
+			}
+			for _, kf := range sliced.Keyframes {
+				if id, ok := ids[kf.TimeOffset]; ok {
+					kf.KfID = id
+				}
+			}
+		}
+		result = append(result, sliced)
+	}
+	return result
+}
+
+// exportMap 将草稿内容模型转换为map，数值为float64类型，无法编码时（如数值为NaN）返回nil
+func exportMap(data json.Marshaler) map[string]interface{} {
+	result, err := content.ToPlainMap(data)
+	if err != nil {
+		return nil
+	}
+	return result
+}
+
+// exportSegmentMap 将片段的草稿内容模型转换为map，时间范围写为int64类型，关键帧的值写为[]float64类型
+func exportSegmentMap(data *content.Segment) map[string]interface{} {
+	result := exportMap(data)
+	if result == nil {
+		return nil
+	}
+
+	for key, timerange := range map[string]*content.Timerange{
+		"target_timerange": data.TargetTimerange,
+		"source_timerange": data.SourceTimerange,
+	} {
+		if item, ok := result[key].(map[string]interface{}); ok && timerange != nil {
+			item["start"] = timerange.Start
+			item["duration"] = timerange.Duration
+		}
+	}
+
+	lists, _ := result["common_keyframes"].([]interface{})
+	for i, listData := range data.CommonKeyframes {
+		if i >= len(lists) || listData == nil {
+			break
+		}
+		list, _ := lists[i].(map[string]interface{})
+		keyframes, _ := list["keyframe_list"].([]interface{})
+		for j, kf := range listData.Keyframes {
+			if j >= len(keyframes) {
+				break
+			}
+			if item, ok := keyframes[j].(map[string]interface{}); ok && kf != nil && kf.Values != nil {
+				item["values"] = kf.Values
+			}
+		}
+	}
+	return result
+}
+
+// exportTimerange 导出时间范围，保留原始数据中的其他字段
+func exportTimerange(raw *content.Timerange, timerange *types.Timerange) *content.Timerange {
+	result := &content.Timerange{}
+	if raw != nil {
+		*result = *raw
+	}
+	result.Start = timerange.Start
+	result.Duration = timerange.Duration
+	return result
+}
+
+// parseClipSettings 解析片段的clip字段，缺失的值取默认值
+func parseClipSettings(clip *content.Clip) *segment.ClipSettings {
+	settings := segment.NewClipSettings()
+	if clip.Has("alpha") {
+		settings.Alpha = clip.Alpha
+	}
+	if clip.Has("rotation") {
+		settings.Rotation = clip.Rotation
+	}
+	if clip.Flip != nil {
+		settings.FlipHorizontal = clip.Flip.Horizontal
+		settings.FlipVertical = clip.Flip.Vertical
+	}
+	if clip.Scale != nil {
+		if clip.Scale.Has("x") {
+			settings.ScaleX = clip.Scale.X
+		}
+		if clip.Scale.Has("y") {
+			settings.ScaleY = clip.Scale.Y
+		}
+	}
+	if clip.Transform != nil {
+		settings.TransformX = clip.Transform.X
+		settings.TransformY = clip.Transform.Y
+	}
+	return settings
+}
+
+// exportClip 将图像调节设置写入原始clip数据的副本
+func exportClip(raw *content.Clip, settings *segment.ClipSettings) *content.Clip {
+	clip := &content.Clip{}
+	if raw != nil {
+		*clip = *raw
+	}
+	clip.Alpha = settings.Alpha
+	clip.Rotation = settings.Rotation
+
+	flip := &content.Flip{}
+	if clip.Flip != nil {
+		*flip = *clip.Flip
+	}
+	flip.Horizontal = settings.FlipHorizontal
+	flip.Vertical = settings.FlipVertical
+	clip.Flip = flip
+
+	scale := &content.Vector{}
+	if clip.Scale != nil {
+		*scale = *clip.Scale
+	}
+	scale.X, scale.Y = settings.ScaleX, settings.ScaleY
+	clip.Scale = scale
+
+	transform := &content.Vector{}
+	if clip.Transform != nil {
+		*transform = *clip.Transform
+	}
+	transform.X, transform.Y = settings.TransformX, settings.TransformY
+	clip.Transform = transform
+
+	return clip
+}
+
+// parseKeyframeList 解析一个关键帧列表，沿用原始的列表id和关键帧id
+func parseKeyframeList(listData *content.KeyframeList) (*keyframe.KeyframeList, bool) {
+	if listData == nil || listData.ID == "" || listData.PropertyType == "" || listData.Keyframes == nil {
+		return nil, false
+	}
+
+	list := &keyframe.KeyframeList{
+		ListID:           listData.ID,
+		KeyframeProperty: keyframe.KeyframeProperty(listData.PropertyType),
+		MaterialID:       listData.MaterialID,
+		Keyframes:        make([]*keyframe.Keyframe, 0, len(listData.Keyframes)),
+	}
+
+	for _, kfData := range listData.Keyframes {
+		if kfData == nil || kfData.ID == "" || !kfData.Has("time_offset") {
+			return nil, false
+		}
+		list.Keyframes = append(list.Keyframes, &keyframe.Keyframe{
+			KfID:       kfData.ID,
+			TimeOffset: kfData.TimeOffset,
+			Values:     append(make([]float64, 0, len(kfData.Values)), kfData.Values...),
+		})
+	}
+
+	return list, true
+}
+
+// exportKeyframeLists 将关键帧列表合并进原始common_keyframes数据
+//
+// 原始数据中无法解析的列表保持原样；已解析但被删除的列表不再导出；
+// 列表和关键帧均按id匹配，只改写时间偏移和值发生变化的关键帧，新增的列表和关键帧追加在末尾
+func exportKeyframeLists(raw []*content.KeyframeList, lists []*keyframe.KeyframeList) ([]*content.KeyframeList, error) {
+	byID := make(map[string]*keyframe.KeyframeList, len(lists))
+	for _, list := range lists {
+		byID[list.ListID] = list
+	}
+
+	result := make([]*content.KeyframeList, 0, len(lists))
+	exported := make(map[string]bool, len(lists))
+	for _, listData := range raw {
+		if _, parsed := parseKeyframeList(listData); !parsed {
+			result = append(result, listData)
+			continue
+		}
+		list, ok := byID[listData.ID]
+		if !ok {
+			continue
+		}
+		merged, err := exportKeyframeList(listData, list)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, merged)
+		exported[listData.ID] = true
+	}
+
+	for _, list := range lists {
+		if exported[list.ListID] {
+			continue
+		}
+		listData := &content.KeyframeList{}
+		if err := content.FromMap(list.ExportJSON(), listData); err != nil {
+			return nil, err
+		}
+		result = append(result, listData)
+	}
+
+	return result, nil
+}
+
+// exportKeyframeList 将单个关键帧列表合并进原始数据的副本
+func exportKeyframeList(listData *content.KeyframeList, list *keyframe.KeyframeList) (*content.KeyframeList, error) {
+	rawKeyframes := make(map[string]*content.Keyframe, len(listData.Keyframes))
+	for _, kfData := range listData.Keyframes {
+		rawKeyframes[kfData.ID] = kfData
+	}
+
+	keyframes := make([]*content.Keyframe, 0, len(list.Keyframes))
+	for _, kf := range list.Keyframes {
+		kfData := &content.Keyframe{}
+		if rawKeyframe, ok := rawKeyframes[kf.KfID]; ok {
+			*kfData = *rawKeyframe
+			kfData.TimeOffset = kf.TimeOffset
+			kfData.Values = append(make([]float64, 0, len(kf.Values)), kf.Values...)
+		} else if err := content.FromMap(kf.ExportJSON(), kfData); err != nil {
+			return nil, err
+		}
+		keyframes = append(keyframes, kfData)
+	}
+
+	result := *listData
+	result.Keyframes = keyframes
+	result.PropertyType = string(list.KeyframeProperty)
+	return &result, nil
 }
 
 // ImportedTrack 模板模式下导入的轨道
 // 对应Python的ImportedTrack类
 type ImportedTrack struct {
-	TrackType   track.TrackType        `json:"type"`         // 轨道类型
-	Name        string                 `json:"name"`         // 轨道名称
-	TrackID     string                 `json:"id"`           // 轨道ID
-	RenderIndex int                    `json:"render_index"` // 渲染层级
-	RawData     map[string]interface{} `json:"-"`            // 原始轨道数据
+	TrackType   track.TrackType `json:"type"`         // 轨道类型
+	Name        string          `json:"name"`         // 轨道名称
+	TrackID     string          `json:"id"`           // 轨道ID
+	RenderIndex int             `json:"render_index"` // 渲染层级
+	RawData     *content.Track  `json:"-"`            // 原始轨道数据
 }
 
 // NewImportedTrack 创建导入的轨道
@@ -189,49 +611,42 @@ func NewImportedTrack(jsonData map[string]interface{}) (*ImportedTrack, error) {
 		return nil, fmt.Errorf("missing or invalid track id")
 	}
 
-	// 计算渲染层级（从片段中获取最大值）
-	renderIndex := 0
-	if segments, ok := jsonData["segments"].([]interface{}); ok {
-		for _, segData := range segments {
-			if segMap, ok := segData.(map[string]interface{}); ok {
-				if ri, ok := segMap["render_index"].(float64); ok {
-					if int(ri) > renderIndex {
-						renderIndex = int(ri)
-					}
-				}
-			}
-		}
-	}
-
-	// 复制原始数据
-	rawData := make(map[string]interface{})
-	for k, v := range jsonData {
-		rawData[k] = v
+	rawData := &content.Track{}
+	if err := content.FromMap(jsonData, rawData); err != nil {
+		return nil, fmt.Errorf("invalid track data: %v", err)
 	}
 
 	return &ImportedTrack{
 		TrackType:   trackType,
 		Name:        name,
 		TrackID:     trackID,
-		RenderIndex: renderIndex,
+		RenderIndex: maxRenderIndex(rawData),
 		RawData:     rawData,
 	}, nil
 }
 
-// ExportJSON 导出为JSON格式
+// ExportJSON 导出为JSON格式，数值为float64类型
 // 对应Python的export_json方法
 func (it *ImportedTrack) ExportJSON() map[string]interface{} {
 	// 从原始数据开始
-	jsonData := make(map[string]interface{})
-	for k, v := range it.RawData {
-		jsonData[k] = v
-	}
+	data := *it.RawData
 
 	// 更新基本属性
-	jsonData["name"] = it.Name
-	jsonData["id"] = it.TrackID
+	data.Name = it.Name
+	data.ID = it.TrackID
 
-	return jsonData
+	return exportMap(&data)
+}
+
+// maxRenderIndex 返回轨道中所有片段的最大渲染层级
+func maxRenderIndex(data *content.Track) int {
+	renderIndex := 0
+	for _, seg := range data.Segments {
+		if seg != nil && seg.RenderIndex > renderIndex {
+			renderIndex = seg.RenderIndex
+		}
+	}
+	return renderIndex
 }
 
 // EditableTrack 模板模式下导入且可修改的轨道(音视频及文本轨道)
@@ -364,13 +779,18 @@ func NewImportedMediaTrack(jsonData map[string]interface{}) (*ImportedMediaTrack
 // CheckMaterialType 检查素材类型是否与轨道类型匹配
 // 对应Python的check_material_type方法
 func (imt *ImportedMediaTrack) CheckMaterialType(mat interface{}) bool {
-	switch imt.TrackType {
+	return CheckMaterialType(imt.TrackType, mat)
+}
+
+// CheckMaterialType 检查素材类型是否与给定的轨道类型匹配，视频轨道接受视频素材，音频轨道接受音频素材
+func CheckMaterialType(trackType track.TrackType, mat interface{}) bool {
+	switch trackType {
 	case track.TrackTypeVideo:
-		_, ok := mat.(*material.VideoMaterial)
-		return ok
+		videoMaterial, ok := mat.(*material.VideoMaterial)
+		return ok && videoMaterial != nil
 	case track.TrackTypeAudio:
-		_, ok := mat.(*material.AudioMaterial)
-		return ok
+		audioMaterial, ok := mat.(*material.AudioMaterial)
+		return ok && audioMaterial != nil
 	default:
 		return false
 	}
@@ -379,12 +799,29 @@ func (imt *ImportedMediaTrack) CheckMaterialType(mat interface{}) bool {
 // ProcessTimerange 处理素材替换的时间范围变更
 // 对应Python的process_timerange方法
 func (imt *ImportedMediaTrack) ProcessTimerange(segIndex int, srcTimerange *types.Timerange, shrinkMode ShrinkMode, extendModes []ExtendMode) error {
-	if segIndex < 0 || segIndex >= len(imt.MediaSegments) {
+	return ProcessTimerange(imt.MediaSegments, segIndex, srcTimerange, shrinkMode, extendModes)
+}
+
+// ProcessTimerange 在按时间顺序排列的媒体片段列表上处理素材替换的时间范围变更
+//
+// 片段的新时长为素材截取时长除以片段的播放速度；时长变短时按shrinkMode处理，
+// 变长时依次尝试extendModes中的方法，全部失败时返回ExtensionFailedError。
+// 片段保存srcTimerange的副本，不会修改调用方的对象
+func ProcessTimerange(segments []*ImportedMediaSegment, segIndex int, srcTimerange *types.Timerange, shrinkMode ShrinkMode, extendModes []ExtendMode) error {
+	if segIndex < 0 || segIndex >= len(segments) {
 		return fmt.Errorf("segment index %d out of range", segIndex)
 	}
+	if srcTimerange == nil {
+		return fmt.Errorf("source timerange is nil")
+	}
+	srcTimerange = types.NewTimerange(srcTimerange.Start, srcTimerange.Duration)
 
-	seg := imt.MediaSegments[segIndex]
-	newDuration := srcTimerange.Duration
+	seg := segments[segIndex]
+	speed := seg.Speed
+	if speed <= 0 {
+		speed = 1.0
+	}
+	newDuration := int64(math.Round(float64(srcTimerange.Duration) / speed))
 	oldDuration := seg.TargetTimerange.Duration
 
 	// 计算时长差
@@ -398,13 +835,14 @@ func (imt *ImportedMediaTrack) ProcessTimerange(segIndex int, srcTimerange *type
 		switch shrinkMode {
 		case ShrinkModeCutHead:
 			seg.TargetTimerange.Start += deltaDuration
+			seg.TargetTimerange.Duration -= deltaDuration
 		case ShrinkModeCutTail:
 			seg.TargetTimerange.Duration -= deltaDuration
 		case ShrinkModeCutTailAlign:
 			seg.TargetTimerange.Duration -= deltaDuration
 			// 后续片段也依次前移相应值（保持间隙）
-			for i := segIndex + 1; i < len(imt.MediaSegments); i++ {
-				imt.MediaSegments[i].TargetTimerange.Start -= deltaDuration
+			for i := segIndex + 1; i < len(segments); i++ {
+				segments[i].TargetTimerange.Start -= deltaDuration
 			}
 		case ShrinkModeShrink:
 			seg.TargetTimerange.Duration -= deltaDuration
@@ -417,13 +855,13 @@ func (imt *ImportedMediaTrack) ProcessTimerange(segIndex int, srcTimerange *type
 		successFlag := false
 		prevSegEnd := int64(0)
 		if segIndex > 0 {
-			prevSeg := imt.MediaSegments[segIndex-1]
+			prevSeg := segments[segIndex-1]
 			prevSegEnd = prevSeg.TargetTimerange.Start + prevSeg.TargetTimerange.Duration
 		}
 
 		nextSegStart := int64(1e15)
-		if segIndex < len(imt.MediaSegments)-1 {
-			nextSegStart = imt.MediaSegments[segIndex+1].TargetTimerange.Start
+		if segIndex < len(segments)-1 {
+			nextSegStart = segments[segIndex+1].TargetTimerange.Start
 		}
 
 		for _, mode := range extendModes {
@@ -431,6 +869,7 @@ func (imt *ImportedMediaTrack) ProcessTimerange(segIndex int, srcTimerange *type
 			case ExtendModeExtendHead:
 				if seg.TargetTimerange.Start-deltaDuration >= prevSegEnd {
 					seg.TargetTimerange.Start -= deltaDuration
+					seg.TargetTimerange.Duration += deltaDuration
 					successFlag = true
 				}
 			case ExtendModeExtendTail:
@@ -447,13 +886,13 @@ func (imt *ImportedMediaTrack) ProcessTimerange(segIndex int, srcTimerange *type
 				seg.TargetTimerange.Duration += deltaDuration
 				if shiftDuration > 0 {
 					// 有必要时后移后续片段
-					for i := segIndex + 1; i < len(imt.MediaSegments); i++ {
-						imt.MediaSegments[i].TargetTimerange.Start += shiftDuration
+					for i := segIndex + 1; i < len(segments); i++ {
+						segments[i].TargetTimerange.Start += shiftDuration
 					}
 				}
 				successFlag = true
 			case ExtendModeCutMaterialTail:
-				srcTimerange.Duration = seg.TargetTimerange.Duration
+				srcTimerange.Duration = int64(math.Round(float64(seg.TargetTimerange.Duration) * speed))
 				successFlag = true
 			default:
 				return fmt.Errorf("unsupported extend mode: %s", mode)
@@ -465,7 +904,9 @@ func (imt *ImportedMediaTrack) ProcessTimerange(segIndex int, srcTimerange *type
 		}
 
 		if !successFlag {
-			return fmt.Errorf("failed to extend segment to %d μs, tried methods: %v", newDuration, extendModes)
+			return util.NewExtensionFailedError(
+				fmt.Sprintf("failed to extend segment to %d μs, tried methods: %v", newDuration, extendModes),
+				seg.SegmentID, seg.MaterialID)
 		}
 	}
 
@@ -475,60 +916,94 @@ func (imt *ImportedMediaTrack) ProcessTimerange(segIndex int, srcTimerange *type
 	return nil
 }
 
-// ImportTrack 导入轨道
+// lookupSpeed 在导入的变速素材中查找片段引用的播放速度，找不到时返回1.0
+func lookupSpeed(refs []string, materials *content.Materials) float64 {
+	if materials == nil {
+		return 1.0
+	}
+	for _, speed := range materials.Speeds {
+		for _, ref := range refs {
+			if ref == speed.ID && speed.Speed > 0 {
+				return speed.Speed
+			}
+		}
+	}
+	return 1.0
+}
+
+// ImportTrack 从map形式的json数据导入轨道
 // 对应Python的import_track函数
+//
+// importedMaterials为草稿的materials字段，用于查找片段的变速素材
 func ImportTrack(jsonData map[string]interface{}, importedMaterials map[string]interface{}) (*track.Track, error) {
-	trackTypeName, ok := jsonData["type"].(string)
-	if !ok {
+	if _, ok := jsonData["type"].(string); !ok {
 		return nil, fmt.Errorf("missing or invalid track type")
 	}
-
-	trackType, err := track.TrackTypeFromName(trackTypeName)
-	if err != nil {
-		return nil, fmt.Errorf("invalid track type: %s", trackTypeName)
+	if _, ok := jsonData["name"].(string); !ok {
+		return nil, fmt.Errorf("missing or invalid track name")
 	}
 
-	name, ok := jsonData["name"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing or invalid track name")
+	data := &content.Track{}
+	if err := content.FromMap(jsonData, data); err != nil {
+		return nil, fmt.Errorf("invalid track data: %v", err)
 	}
 
-	// 计算渲染层级
-	renderIndex := 0
-	if segments, ok := jsonData["segments"].([]interface{}); ok {
-		for _, segData := range segments {
-			if segMap, ok := segData.(map[string]interface{}); ok {
-				if ri, ok := segMap["render_index"].(float64); ok {
-					if int(ri) > renderIndex {
-						renderIndex = int(ri)
-					}
-				}
-			}
+	materials := &content.Materials{}
+	if importedMaterials != nil {
+		if err := content.FromMap(importedMaterials, materials); err != nil {
+			return nil, fmt.Errorf("invalid materials data: %v", err)
 		}
 	}
 
-	// 获取静音状态
-	mute := false
-	if attribute, ok := jsonData["attribute"].(float64); ok {
-		mute = attribute != 0
+	return ImportTrackFromContent(data, materials)
+}
+
+// ImportTrackFromContent 从草稿内容模型导入轨道，data作为轨道的原始数据被保留
+//
+// 轨道中的所有片段均被导入：音视频片段导入为ImportedMediaSegment，其余片段导入为ImportedSegment；
+// 片段没有速度字段时，播放速度从materials中其引用的变速素材得到
+func ImportTrackFromContent(data *content.Track, materials *content.Materials) (*track.Track, error) {
+	trackType, err := track.TrackTypeFromName(data.Type)
+	if err != nil {
+		return nil, fmt.Errorf("invalid track type: %s", data.Type)
 	}
 
-	// 创建新的Track实例
-	newTrack := track.NewTrack(trackType, name, renderIndex, mute)
+	// 创建新的Track实例，保留原始数据以便无损导出
+	newTrack := track.NewTrack(trackType, data.Name, maxRenderIndex(data), data.Attribute != 0)
+	newTrack.RawData = data
 
 	// 设置track_id，使用原始ID
-	if trackID, ok := jsonData["id"].(string); ok {
-		newTrack.TrackID = trackID
+	if data.ID != "" {
+		newTrack.TrackID = data.ID
 	} else {
-		newTrack.TrackID = strings.ReplaceAll(uuid.New().String(), "-", "")
+		newTrack.TrackID = strings.ReplaceAll(util.NewID(), "-", "")
 	}
 
-	// 如果轨道类型允许修改且有导入的素材，导入所有片段
-	trackMeta := track.GetTrackMeta(trackType)
-	if trackMeta.AllowModify && importedMaterials != nil {
-		// 这里可以根据需要实现具体的片段导入逻辑
-		// 由于涉及复杂的素材匹配和片段创建，暂时返回基本轨道
-		// 在实际使用中，可以根据具体需求扩展这部分功能
+	// 导入所有片段，音视频片段同时解析素材截取范围
+	for i, segData := range data.Segments {
+		if segData == nil {
+			return nil, fmt.Errorf("invalid segment #%d in track %s", i, data.Name)
+		}
+
+		var seg segment.SegmentInterface
+		switch trackType {
+		case track.TrackTypeVideo, track.TrackTypeAudio:
+			mediaSegment, err := NewImportedMediaSegmentFromContent(segData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to import segment #%d in track %s: %v", i, data.Name, err)
+			}
+			if segData.Speed <= 0 {
+				mediaSegment.Speed = lookupSpeed(mediaSegment.ExtraMaterialRefs, materials)
+			}
+			seg = mediaSegment
+		default:
+			importedSegment, err := NewImportedSegmentFromContent(segData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to import segment #%d in track %s: %v", i, data.Name, err)
+			}
+			seg = importedSegment
+		}
+		newTrack.Segments = append(newTrack.Segments, seg)
 	}
 
 	return newTrack, nil
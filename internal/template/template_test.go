@@ -84,7 +84,7 @@ func TestNewImportedSegment(t *testing.T) {
 	}
 
 	// 验证原始数据被保存
-	if segment.RawData["extra_field"] != "extra_value" {
+	if string(segment.RawData.Field("extra_field")) != `"extra_value"` {
 		t.Errorf("期望原始数据包含 'extra_field': 'extra_value'")
 	}
 }
@@ -563,6 +563,151 @@ func TestProcessTimerangeShrinkModes(t *testing.T) {
 	}
 }
 
+// TestProcessTimerangeHeadModes 测试裁剪/延伸头部时片段终止点保持不变
+func TestProcessTimerangeHeadModes(t *testing.T) {
+	newSegment := func(start, duration int64) *ImportedMediaSegment {
+		seg, err := NewImportedMediaSegment(map[string]interface{}{
+			"material_id":      "material",
+			"target_timerange": map[string]interface{}{"start": float64(start), "duration": float64(duration)},
+			"source_timerange": map[string]interface{}{"start": float64(0), "duration": float64(duration)},
+		})
+		if err != nil {
+			t.Fatalf("创建导入媒体片段失败: %v", err)
+		}
+		return seg
+	}
+
+	// cut_head: 起始点后移，终止点不变
+	segments := []*ImportedMediaSegment{newSegment(1000000, 2000000)}
+	if err := ProcessTimerange(segments, 0, types.NewTimerange(0, 1500000), ShrinkModeCutHead, nil); err != nil {
+		t.Fatalf("cut_head模式失败: %v", err)
+	}
+	if got := segments[0].TargetTimerange; got.Start != 1500000 || got.End() != 3000000 {
+		t.Errorf("cut_head: 期望时间范围为 [1500000, 3000000), 得到 %v", got)
+	}
+
+	// extend_head: 起始点前移，终止点不变
+	segments = []*ImportedMediaSegment{newSegment(0, 500000), newSegment(1000000, 2000000)}
+	if err := ProcessTimerange(segments, 1, types.NewTimerange(0, 2500000), ShrinkModeCutTail, []ExtendMode{ExtendModeExtendHead}); err != nil {
+		t.Fatalf("extend_head模式失败: %v", err)
+	}
+	if got := segments[1].TargetTimerange; got.Start != 500000 || got.End() != 3000000 {
+		t.Errorf("extend_head: 期望时间范围为 [500000, 3000000), 得到 %v", got)
+	}
+}
+
+// TestProcessTimerangeWithSpeed 测试变速片段的时长按播放速度换算
+func TestProcessTimerangeWithSpeed(t *testing.T) {
+	seg, err := NewImportedMediaSegment(map[string]interface{}{
+		"material_id":      "material",
+		"target_timerange": map[string]interface{}{"start": float64(0), "duration": float64(1000000)},
+		"source_timerange": map[string]interface{}{"start": float64(0), "duration": float64(2000000)},
+		"speed":            float64(2),
+	})
+	if err != nil {
+		t.Fatalf("创建导入媒体片段失败: %v", err)
+	}
+	segments := []*ImportedMediaSegment{seg}
+
+	// 截取4秒素材，2倍速下片段时长为2秒
+	if err := ProcessTimerange(segments, 0, types.NewTimerange(0, 4000000), ShrinkModeCutTail, []ExtendMode{ExtendModeExtendTail}); err != nil {
+		t.Fatalf("处理时间范围失败: %v", err)
+	}
+	if seg.TargetTimerange.Duration != 2000000 {
+		t.Errorf("期望片段时长为 2000000, 得到 %d", seg.TargetTimerange.Duration)
+	}
+
+	// cut_material_tail: 片段时长不变，素材截取长度为片段时长乘以速度
+	src := types.NewTimerange(0, 8000000)
+	if err := ProcessTimerange(segments, 0, src, ShrinkModeCutTail, []ExtendMode{ExtendModeCutMaterialTail}); err != nil {
+		t.Fatalf("处理时间范围失败: %v", err)
+	}
+	if seg.TargetTimerange.Duration != 2000000 || seg.SourceTimerange.Duration != 4000000 {
+		t.Errorf("期望片段时长 2000000, 素材截取时长 4000000, 得到 %d, %d", seg.TargetTimerange.Duration, seg.SourceTimerange.Duration)
+	}
+	if src.Duration != 8000000 || seg.SourceTimerange == src {
+		t.Errorf("调用方的时间范围不应被修改, 得到 %d", src.Duration)
+	}
+}
+
+// TestImportedMediaSegmentSplit 测试按播放速度分割及裁剪导入的媒体片段
+func TestImportedMediaSegmentSplit(t *testing.T) {
+	seg, err := NewImportedMediaSegment(map[string]interface{}{
+		"id":                  "segment_1",
+		"material_id":         "material",
+		"target_timerange":    map[string]interface{}{"start": float64(1000000), "duration": float64(2000000)},
+		"source_timerange":    map[string]interface{}{"start": float64(500000), "duration": float64(4000000)},
+		"speed":               float64(2),
+		"extra_material_refs": []interface{}{"speed_1"},
+		"custom_field":        "保留",
+		"common_keyframes": []interface{}{
+			map[string]interface{}{
+				"id":            "list_1",
+				"property_type": "KFTypeAlpha",
+				"keyframe_list": []interface{}{
+					map[string]interface{}{"id": "kf_1", "time_offset": float64(0), "values": []interface{}{float64(0)}},
+					map[string]interface{}{"id": "kf_2", "time_offset": float64(2000000), "values": []interface{}{float64(1)}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("创建导入媒体片段失败: %v", err)
+	}
+
+	right, err := seg.Split(500000)
+	if err != nil {
+		t.Fatalf("分割失败: %v", err)
+	}
+	// 2倍速下0.5秒的片段对应1秒素材
+	if *seg.TargetTimerange != *types.NewTimerange(1000000, 500000) || *seg.SourceTimerange != *types.NewTimerange(500000, 1000000) {
+		t.Errorf("前一部分的时间范围不正确: %+v %+v", seg.TargetTimerange, seg.SourceTimerange)
+	}
+	if *right.TargetTimerange != *types.NewTimerange(1500000, 1500000) || *right.SourceTimerange != *types.NewTimerange(1500000, 3000000) {
+		t.Errorf("后一部分的时间范围不正确: %+v %+v", right.TargetTimerange, right.SourceTimerange)
+	}
+	if right.Speed != 2 || right.SegmentID == seg.SegmentID {
+		t.Errorf("后一部分应沿用播放速度并使用新的片段id, 得到 %v %s", right.Speed, right.SegmentID)
+	}
+
+	exported := right.ExportJSON()
+	if exported["id"] != right.SegmentID || exported["custom_field"] != "保留" {
+		t.Errorf("后一部分应复制原始数据并使用新的id, 得到 %v %v", exported["id"], exported["custom_field"])
+	}
+	if refs := exported["extra_material_refs"].([]interface{}); len(refs) != 1 || refs[0] != "speed_1" {
+		t.Errorf("后一部分应保留附加素材引用, 得到 %v", refs)
+	}
+	if seg.RawData.ID != "segment_1" || seg.ExportJSON()["id"] != "segment_1" {
+		t.Error("前一部分应保留原始片段id")
+	}
+
+	// 关键帧在分割处以插值补齐，前一部分沿用原有id
+	left := seg.KeyframeLists[0]
+	if left.ListID != "list_1" || len(left.Keyframes) != 2 || left.Keyframes[0].KfID != "kf_1" ||
+		left.Keyframes[1].TimeOffset != 500000 || left.Keyframes[1].Values[0] != 0.25 {
+		t.Errorf("前一部分的关键帧不正确: %+v", left.Keyframes)
+	}
+	rightList := right.KeyframeLists[0]
+	if rightList.ListID == "list_1" || len(rightList.Keyframes) != 2 || rightList.Keyframes[0].Values[0] != 0.25 ||
+		rightList.Keyframes[1].TimeOffset != 1500000 || rightList.Keyframes[1].KfID == "kf_2" {
+		t.Errorf("后一部分的关键帧不正确: %+v", rightList.Keyframes)
+	}
+
+	// 裁掉后一部分的开头，素材时间范围按播放速度同步调整
+	if err := right.Trim(500000, 1500000); err != nil {
+		t.Fatalf("裁剪失败: %v", err)
+	}
+	if *right.TargetTimerange != *types.NewTimerange(2000000, 1000000) || *right.SourceTimerange != *types.NewTimerange(2500000, 2000000) {
+		t.Errorf("裁剪后的时间范围不正确: %+v %+v", right.TargetTimerange, right.SourceTimerange)
+	}
+	if err := seg.Trim(-1000000, 500000); err == nil {
+		t.Error("延长后素材时间范围早于素材开头时应返回错误")
+	}
+	if _, err := seg.Split(500000); err == nil {
+		t.Error("在片段边界处分割时应返回错误")
+	}
+}
+
 // TestImportTrack 测试导入轨道函数
 func TestImportTrack(t *testing.T) {
 	jsonData := map[string]interface{}{
@@ -571,6 +716,16 @@ func TestImportTrack(t *testing.T) {
 		"id":   "imported_track_123",
 		"segments": []interface{}{
 			map[string]interface{}{
+				"id":          "segment_1",
+				"material_id": "video_material_1",
+				"target_timerange": map[string]interface{}{
+					"start":    float64(0),
+					"duration": float64(3000000),
+				},
+				"source_timerange": map[string]interface{}{
+					"start":    float64(1000000),
+					"duration": float64(3000000),
+				},
 				"render_index": float64(150),
 			},
 		},
@@ -606,6 +761,188 @@ func TestImportTrack(t *testing.T) {
 	if newTrack.Mute != false {
 		t.Errorf("期望静音状态为 false, 得到 %v", newTrack.Mute)
 	}
+
+	if len(newTrack.Segments) != 1 {
+		t.Fatalf("期望导入1个片段, 得到 %d", len(newTrack.Segments))
+	}
+
+	mediaSegment, ok := newTrack.Segments[0].(*ImportedMediaSegment)
+	if !ok {
+		t.Fatalf("期望视频片段导入为ImportedMediaSegment, 得到 %T", newTrack.Segments[0])
+	}
+
+	if mediaSegment.SegmentID != "segment_1" {
+		t.Errorf("期望沿用原始片段ID 'segment_1', 得到 '%s'", mediaSegment.SegmentID)
+	}
+
+	if mediaSegment.SourceTimerange.Start != 1000000 {
+		t.Errorf("期望源开始时间为 1000000, 得到 %d", mediaSegment.SourceTimerange.Start)
+	}
+}
+
+// TestImportTrackSegments 测试导入各类轨道的片段及其速度、关键帧等属性
+func TestImportTrackSegments(t *testing.T) {
+	trackData := map[string]interface{}{
+		"type": "video",
+		"name": "video",
+		"id":   "track_1",
+		"segments": []interface{}{
+			map[string]interface{}{
+				"id":                  "segment_1",
+				"material_id":         "video_material_1",
+				"target_timerange":    map[string]interface{}{"start": float64(0), "duration": float64(2000000)},
+				"source_timerange":    map[string]interface{}{"start": float64(0), "duration": float64(4000000)},
+				"extra_material_refs": []interface{}{"speed_1", "animation_1"},
+				"volume":              float64(0.5),
+				"clip": map[string]interface{}{
+					"alpha":     float64(0.8),
+					"flip":      map[string]interface{}{"horizontal": true, "vertical": false},
+					"rotation":  float64(90),
+					"scale":     map[string]interface{}{"x": float64(1.5), "y": float64(1.5)},
+					"transform": map[string]interface{}{"x": float64(0.1), "y": float64(-0.2)},
+				},
+				"common_keyframes": []interface{}{
+					map[string]interface{}{
+						"id":            "list_1",
+						"property_type": "KFTypeAlpha",
+						"material_id":   "",
+						"keyframe_list": []interface{}{
+							map[string]interface{}{
+								"id":          "kf_1",
+								"curveType":   "Line",
+								"time_offset": float64(0),
+								"values":      []interface{}{float64(0)},
+							},
+							map[string]interface{}{
+								"id":          "kf_2",
+								"curveType":   "Line",
+								"time_offset": float64(1000000),
+								"values":      []interface{}{float64(1)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	importedMaterials := map[string]interface{}{
+		"speeds": []interface{}{
+			map[string]interface{}{"id": "speed_1", "speed": float64(2)},
+		},
+	}
+
+	newTrack, err := ImportTrack(trackData, importedMaterials)
+	if err != nil {
+		t.Fatalf("导入轨道失败: %v", err)
+	}
+
+	seg := newTrack.Segments[0].(*ImportedMediaSegment)
+	if seg.Speed != 2 {
+		t.Errorf("期望从变速素材中得到速度 2, 得到 %v", seg.Speed)
+	}
+	if seg.Volume != 0.5 {
+		t.Errorf("期望音量为 0.5, 得到 %v", seg.Volume)
+	}
+	if len(seg.ExtraMaterialRefs) != 2 || seg.ExtraMaterialRefs[1] != "animation_1" {
+		t.Errorf("附加素材引用解析错误: %v", seg.ExtraMaterialRefs)
+	}
+	if seg.ClipSettings == nil || seg.ClipSettings.Rotation != 90 || !seg.ClipSettings.FlipHorizontal || seg.ClipSettings.TransformY != -0.2 {
+		t.Errorf("图像调节设置解析错误: %+v", seg.ClipSettings)
+	}
+	if len(seg.KeyframeLists) != 1 || len(seg.KeyframeLists[0].Keyframes) != 2 {
+		t.Fatalf("关键帧解析错误: %+v", seg.KeyframeLists)
+	}
+
+	// 未修改时导出与原始数据一致
+	original := trackData["segments"].([]interface{})[0]
+	exported := seg.ExportJSON()
+	if !jsonEqual(t, exported, original) {
+		t.Errorf("未修改的片段导出结果与原始数据不一致\n导出: %v\n原始: %v", exported, original)
+	}
+
+	// 修改关键帧和图像调节设置后，只有被修改的值发生变化，其余原始字段保留
+	seg.KeyframeLists[0].Keyframes[1].Values[0] = 0.5
+	seg.ClipSettings.Rotation = 180
+	exported = seg.ExportJSON()
+
+	keyframes := exported["common_keyframes"].([]interface{})[0].(map[string]interface{})["keyframe_list"].([]interface{})
+	kf := keyframes[1].(map[string]interface{})
+	if kf["curveType"] != "Line" {
+		t.Error("期望保留关键帧的原始字段")
+	}
+	if values := kf["values"].([]float64); values[0] != 0.5 {
+		t.Errorf("期望关键帧值为 0.5, 得到 %v", values)
+	}
+	if clip := exported["clip"].(map[string]interface{}); clip["rotation"] != float64(180) {
+		t.Errorf("期望旋转角度为 180, 得到 %v", clip["rotation"])
+	}
+
+	// 原始数据不应被导出过程修改
+	if seg.RawData.Clip.Rotation != 90 {
+		t.Error("导出不应修改原始数据")
+	}
+}
+
+// TestImportTrackNonMediaSegments 测试导入文本及特效轨道的片段
+func TestImportTrackNonMediaSegments(t *testing.T) {
+	for _, trackType := range []string{"text", "effect"} {
+		trackData := map[string]interface{}{
+			"type": trackType,
+			"name": trackType,
+			"id":   "track_" + trackType,
+			"segments": []interface{}{
+				map[string]interface{}{
+					"id":               "segment_" + trackType,
+					"material_id":      "material_" + trackType,
+					"target_timerange": map[string]interface{}{"start": json.Number("1000000"), "duration": json.Number("2000000")},
+					"source_timerange": nil,
+				},
+			},
+		}
+
+		newTrack, err := ImportTrack(trackData, nil)
+		if err != nil {
+			t.Fatalf("导入%s轨道失败: %v", trackType, err)
+		}
+		if len(newTrack.Segments) != 1 {
+			t.Fatalf("期望%s轨道导入1个片段, 得到 %d", trackType, len(newTrack.Segments))
+		}
+		seg, ok := newTrack.Segments[0].(*ImportedSegment)
+		if !ok {
+			t.Fatalf("期望%s片段导入为ImportedSegment, 得到 %T", trackType, newTrack.Segments[0])
+		}
+		if seg.Start() != 1000000 || seg.Duration() != 2000000 {
+			t.Errorf("期望时间范围为 [1000000, 2000000], 得到 [%d, %d]", seg.Start(), seg.Duration())
+		}
+		if newTrack.EndTime() != 3000000 {
+			t.Errorf("期望轨道结束时间为 3000000, 得到 %d", newTrack.EndTime())
+		}
+	}
+
+	// 缺少时间范围的片段应导致导入失败
+	invalidTrack := map[string]interface{}{
+		"type":     "text",
+		"name":     "text",
+		"id":       "track_invalid",
+		"segments": []interface{}{map[string]interface{}{"material_id": "material"}},
+	}
+	if _, err := ImportTrack(invalidTrack, nil); err == nil {
+		t.Error("期望导入无效片段时返回错误")
+	}
+}
+
+// jsonEqual 比较两个对象序列化为JSON后是否相同
+func jsonEqual(t *testing.T, a, b interface{}) bool {
+	t.Helper()
+	aBytes, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("JSON序列化失败: %v", err)
+	}
+	bBytes, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("JSON序列化失败: %v", err)
+	}
+	return string(aBytes) == string(bBytes)
 }
 
 // TestJSONSerialization 测试JSON序列化兼容性
@@ -0,0 +1,95 @@
+package track
+
+import (
+	"fmt"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+// Append 将片段放在轨道末尾之后gap处开始，gap为0时紧接在轨道的最后一个片段之后
+//
+// 片段已有目标时间范围时只修改其开始时间；尚未设置目标时间范围的视频、音频片段按素材时间范围及播放速度计算时长，
+// 未设置素材时间范围时截取整个素材，详见segment.VideoSegment.PlaceAt。其他类型的片段必须设置目标时间范围。
+// 出错时片段的时间范围保持不变
+func (t *Track) Append(seg segment.SegmentInterface, gap int64) error {
+	if gap < 0 {
+		return fmt.Errorf("片段间隔不能为负: %d", gap)
+	}
+	if err := t.checkSegmentType(seg); err != nil {
+		return err
+	}
+
+	restore := savePlacement(seg)
+	if err := placeSegment(seg, t.EndTime()+gap); err != nil {
+		restore()
+		return err
+	}
+	if err := t.AddSegment(seg); err != nil {
+		restore()
+		return err
+	}
+	return nil
+}
+
+// IsFree 检查轨道在[start, end)范围内是否没有任何片段
+func (t *Track) IsFree(start, end int64) bool {
+	for _, seg := range t.Segments {
+		if segStart := seg.Start(); start < segStart+seg.Duration() && segStart < end {
+			return false
+		}
+	}
+	return true
+}
+
+// placeSegment 将片段放置到start处开始
+func placeSegment(seg segment.SegmentInterface, start int64) error {
+	switch v := seg.(type) {
+	case *segment.VideoSegment:
+		return v.PlaceAt(start)
+	case *segment.AudioSegment:
+		return v.PlaceAt(start)
+	}
+
+	timed, ok := seg.(timedSegment)
+	if !ok || timed.GetTargetTimerange() == nil {
+		return fmt.Errorf("片段未指定时间范围: %T", seg)
+	}
+	timed.GetTargetTimerange().Start = start
+	return nil
+}
+
+// savePlacement 记录片段当前的时间范围，返回将片段恢复到记录时状态的函数
+func savePlacement(seg segment.SegmentInterface) func() {
+	var media *segment.MediaSegment
+	switch v := seg.(type) {
+	case *segment.VideoSegment:
+		media = v.MediaSegment
+	case *segment.AudioSegment:
+		media = v.MediaSegment
+	}
+
+	var target *types.Timerange
+	if media != nil {
+		target = media.TargetTimerange
+	} else if timed, ok := seg.(timedSegment); ok {
+		target = timed.GetTargetTimerange()
+	}
+	var saved types.Timerange
+	if target != nil {
+		saved = *target
+	}
+
+	var source *types.Timerange
+	if media != nil {
+		source = media.SourceTimerange
+	}
+	return func() {
+		if target != nil {
+			*target = saved
+		}
+		if media != nil {
+			media.TargetTimerange, media.SourceTimerange = target, source
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package track
+
+import (
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+func TestTrackAppend(t *testing.T) {
+	track := NewTrack(TrackTypeVideo, "video_track", 0, false)
+
+	first := segment.NewVideoSegment("video1", nil, nil, 1.0, 1.0, nil)
+	first.MaterialInstance = &material.VideoMaterial{Duration: 3 * types.SEC, MaterialType: material.MaterialTypeVideo}
+	if err := track.Append(first, 0); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	second := segment.NewVideoSegment("video2", nil, types.NewTimerange(10*types.SEC, 2*types.SEC), 1.0, 1.0, nil)
+	if err := track.Append(second, 1*types.SEC); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if first.Start() != 0 || first.Duration() != 3*types.SEC {
+		t.Errorf("Unexpected first timerange: %+v", first.TargetTimerange)
+	}
+	if second.Start() != 4*types.SEC || second.Duration() != 2*types.SEC {
+		t.Errorf("Unexpected second timerange: %+v", second.TargetTimerange)
+	}
+
+	if !track.IsFree(3*types.SEC, 4*types.SEC) || track.IsFree(5*types.SEC, 7*types.SEC) {
+		t.Error("Unexpected IsFree result")
+	}
+
+	if err := track.Append(segment.NewVideoSegment("video3", nil, nil, 1.0, 1.0, nil), 0); err == nil {
+		t.Error("Expected error for a segment without duration")
+	}
+	if err := track.Append(segment.NewTextSegment("text", types.NewTimerange(0, types.SEC), "", nil, nil), 0); err == nil {
+		t.Error("Expected error for a mismatched segment type")
+	}
+	if err := track.Append(segment.NewVideoSegment("video4", nil, types.NewTimerange(0, types.SEC), 1.0, 1.0, nil), -1); err == nil {
+		t.Error("Expected error for negative gap")
+	}
+	if len(track.Segments) != 2 {
+		t.Errorf("Expected 2 segments, got %d", len(track.Segments))
+	}
+}
+
+func TestTrackAppendRestoresOnError(t *testing.T) {
+	track := NewTrack(TrackTypeVideo, "video_track", 0, false)
+	first := segment.NewVideoSegment("video1", types.NewTimerange(0, 2*types.SEC), nil, 1.0, 1.0, nil)
+	if err := track.Append(first, 0); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	// 再次追加已在轨道上的片段时与其自身重叠，片段的位置保持不变
+	if err := track.Append(first, 1*types.SEC); err == nil {
+		t.Error("Expected error when appending a segment already on the track")
+	}
+	if first.Start() != 0 || first.Duration() != 2*types.SEC {
+		t.Errorf("Expected the timerange to be restored, got %+v", first.TargetTimerange)
+	}
+
+	unplaced := segment.NewVideoSegment("video3", nil, nil, 1.0, 1.0, nil)
+	if err := track.Append(unplaced, 0); err == nil {
+		t.Error("Expected error for a segment without duration")
+	}
+	if unplaced.TargetTimerange != nil {
+		t.Errorf("Expected no target timerange, got %+v", unplaced.TargetTimerange)
+	}
+}
@@ -0,0 +1,170 @@
+package track
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+// timedSegment 具有id及时间范围的片段，包括导入的片段
+type timedSegment interface {
+	segment.SegmentInterface
+	GetID() string
+	GetTargetTimerange() *types.Timerange
+}
+
+// editableSegment 能自行分割及裁剪的片段，如模板模式下导入的片段
+type editableSegment interface {
+	segment.SegmentInterface
+	SplitSegment(offset int64) (segment.SegmentInterface, error)
+	Trim(start, end int64) error
+}
+
+// FindSegment 返回轨道中id为segmentID的片段及其下标
+func (t *Track) FindSegment(segmentID string) (segment.SegmentInterface, int, error) {
+	for i, seg := range t.Segments {
+		if timed, ok := seg.(timedSegment); ok && timed.GetID() == segmentID {
+			return seg, i, nil
+		}
+	}
+	return nil, -1, fmt.Errorf("轨道 %s 中不存在id为 %s 的片段", t.Name, segmentID)
+}
+
+// RemoveSegment 从轨道中移除id为segmentID的片段并返回该片段，其余片段的位置保持不变
+func (t *Track) RemoveSegment(segmentID string) (segment.SegmentInterface, error) {
+	seg, index, err := t.FindSegment(segmentID)
+	if err != nil {
+		return nil, err
+	}
+	t.Segments = append(t.Segments[:index], t.Segments[index+1:]...)
+	return seg, nil
+}
+
+// MoveSegment 将id为segmentID的片段移动到newStart处开始，片段的时长不变，移动后不能与其他片段重叠
+func (t *Track) MoveSegment(segmentID string, newStart int64) error {
+	seg, _, err := t.FindSegment(segmentID)
+	if err != nil {
+		return err
+	}
+	timerange := seg.(timedSegment).GetTargetTimerange()
+	if err := t.checkRange(seg, newStart, newStart+timerange.Duration); err != nil {
+		return err
+	}
+	timerange.Start = newStart
+	t.sortSegments()
+	return nil
+}
+
+// TrimStart 将id为segmentID的片段的开头调整到newStart，结尾不变
+//
+// newStart晚于原开头时裁掉开头，早于原开头时向前延长片段，延长的部分不能超出素材或与其他片段重叠。
+// 素材时间范围按播放速度同步调整，关键帧及动画的处理见segment.VideoSegment.Trim
+func (t *Track) TrimStart(segmentID string, newStart int64) error {
+	seg, _, err := t.FindSegment(segmentID)
+	if err != nil {
+		return err
+	}
+	start, end := seg.Start(), seg.Start()+seg.Duration()
+	return t.trim(seg, newStart-start, end-start)
+}
+
+// TrimEnd 将id为segmentID的片段的结尾调整到newEnd，开头不变
+//
+// newEnd早于原结尾时裁掉结尾，晚于原结尾时向后延长片段，延长的部分不能超出素材或与其他片段重叠
+func (t *Track) TrimEnd(segmentID string, newEnd int64) error {
+	seg, _, err := t.FindSegment(segmentID)
+	if err != nil {
+		return err
+	}
+	return t.trim(seg, 0, newEnd-seg.Start())
+}
+
+// trim 将片段的范围改为相对原开头偏移[start, end]的部分
+func (t *Track) trim(seg segment.SegmentInterface, start, end int64) error {
+	if end <= start {
+		return fmt.Errorf("裁剪后的片段时长必须大于0")
+	}
+	if err := t.checkRange(seg, seg.Start()+start, seg.Start()+end); err != nil {
+		return err
+	}
+
+	switch v := seg.(type) {
+	case *segment.VideoSegment:
+		return v.Trim(start, end)
+	case *segment.AudioSegment:
+		return v.Trim(start, end)
+	case *segment.TextSegment:
+		return v.Trim(start, end)
+	case editableSegment:
+		return v.Trim(start, end)
+	default:
+		return fmt.Errorf("不支持裁剪的片段类型: %T", seg)
+	}
+}
+
+// SplitAt 将轨道上覆盖时间点at的片段从at处分为两部分，返回新建的后一部分
+//
+// 原片段成为前一部分，后一部分紧随其后插入轨道。at恰好位于片段边界上或不在任何片段内时返回错误。
+// 仅支持视频、音频、文本及导入的片段，关键帧、动画等的处理见segment.VideoSegment.Split
+func (t *Track) SplitAt(at int64) (segment.SegmentInterface, error) {
+	for i, seg := range t.Segments {
+		if at <= seg.Start() || at >= seg.Start()+seg.Duration() {
+			continue
+		}
+
+		right, err := splitSegment(seg, at-seg.Start())
+		if err != nil {
+			return nil, err
+		}
+		t.Segments = append(t.Segments[:i+1], append([]segment.SegmentInterface{right}, t.Segments[i+1:]...)...)
+		return right, nil
+	}
+	return nil, fmt.Errorf("轨道 %s 在时间点 %d 处没有可分割的片段", t.Name, at)
+}
+
+// splitSegment 将片段从相对其开头offset处分为两部分，返回新建的后一部分
+func splitSegment(seg segment.SegmentInterface, offset int64) (segment.SegmentInterface, error) {
+	switch v := seg.(type) {
+	case *segment.VideoSegment:
+		return v.Split(offset)
+	case *segment.AudioSegment:
+		return v.Split(offset)
+	case *segment.TextSegment:
+		return v.Split(offset)
+	case editableSegment:
+		return v.SplitSegment(offset)
+	default:
+		return nil, fmt.Errorf("不支持分割的片段类型: %T", seg)
+	}
+}
+
+// checkRange 检查将片段seg调整为[start, end)后是否与轨道上的其他片段重叠
+func (t *Track) checkRange(seg segment.SegmentInterface, start, end int64) error {
+	if start < 0 {
+		return fmt.Errorf("片段的开始时间不能为负: %d", start)
+	}
+	for _, other := range t.Segments {
+		if other == seg {
+			continue
+		}
+		if otherStart, otherEnd := other.Start(), other.Start()+other.Duration(); start < otherEnd && otherStart < end {
+			return fmt.Errorf("片段与现有片段重叠 [start: %d, end: %d]", otherStart, otherEnd)
+		}
+	}
+	return nil
+}
+
+// insertSegment 将片段插入轨道，保持片段按开始时间排序
+func (t *Track) insertSegment(seg segment.SegmentInterface) {
+	i := sort.Search(len(t.Segments), func(i int) bool { return t.Segments[i].Start() > seg.Start() })
+	t.Segments = append(t.Segments, nil)
+	copy(t.Segments[i+1:], t.Segments[i:])
+	t.Segments[i] = seg
+}
+
+// sortSegments 将轨道中的片段按开始时间重新排序
+func (t *Track) sortSegments() {
+	sort.SliceStable(t.Segments, func(i, j int) bool { return t.Segments[i].Start() < t.Segments[j].Start() })
+}
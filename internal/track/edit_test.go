@@ -0,0 +1,112 @@
+package track
+
+import (
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+func TestTrackSegmentsSorted(t *testing.T) {
+	sec := int64(types.SEC)
+	track := NewTrack(TrackTypeText, "text_track", 0, false)
+	for _, start := range []int64{4 * sec, 0, 2 * sec} {
+		if err := track.AddSegment(segment.NewTextSegment("text", types.NewTimerange(start, 1*sec), "", nil, nil)); err != nil {
+			t.Fatalf("Failed to add segment: %v", err)
+		}
+	}
+	checkRanges(t, track, [][2]int64{{0, 1 * sec}, {2 * sec, 1 * sec}, {4 * sec, 1 * sec}})
+
+	// 移动后重新排序
+	first := track.Segments[0].(*segment.TextSegment)
+	if err := track.MoveSegment(first.SegmentID, 6*sec); err != nil {
+		t.Fatalf("MoveSegment failed: %v", err)
+	}
+	checkRanges(t, track, [][2]int64{{2 * sec, 1 * sec}, {4 * sec, 1 * sec}, {6 * sec, 1 * sec}})
+	if track.Segments[2] != first {
+		t.Error("Expected the moved segment to be last")
+	}
+}
+
+func TestTrackEditing(t *testing.T) {
+	track := NewTrack(TrackTypeVideo, "video_track", 0, false)
+	first := segment.NewVideoSegment("video1", types.NewTimerange(0, 10*types.SEC), types.NewTimerange(0, 4*types.SEC), 1.0, 1.0, nil)
+	second := segment.NewVideoSegment("video2", nil, types.NewTimerange(6*types.SEC, 2*types.SEC), 1.0, 1.0, nil)
+	for _, seg := range []*segment.VideoSegment{first, second} {
+		if err := track.AddSegment(seg); err != nil {
+			t.Fatalf("Failed to add segment: %v", err)
+		}
+	}
+
+	// 移动
+	if err := track.MoveSegment(second.SegmentID, 3*types.SEC); err == nil {
+		t.Error("Expected error when moving onto another segment")
+	}
+	if err := track.MoveSegment(second.SegmentID, 5*types.SEC); err != nil {
+		t.Fatalf("MoveSegment failed: %v", err)
+	}
+	if second.Start() != 5*types.SEC || second.Duration() != 2*types.SEC {
+		t.Errorf("Unexpected timerange after move: %+v", second.TargetTimerange)
+	}
+
+	// 裁剪及延长
+	if err := track.TrimEnd(first.SegmentID, 6*types.SEC); err == nil {
+		t.Error("Expected error when extending onto another segment")
+	}
+	if err := track.TrimEnd(first.SegmentID, 5*types.SEC); err != nil {
+		t.Fatalf("TrimEnd failed: %v", err)
+	}
+	if err := track.TrimStart(first.SegmentID, 1*types.SEC); err != nil {
+		t.Fatalf("TrimStart failed: %v", err)
+	}
+	if first.Start() != 1*types.SEC || first.Duration() != 4*types.SEC || first.SourceTimerange.Start != 1*types.SEC {
+		t.Errorf("Unexpected ranges after trim: %+v %+v", first.TargetTimerange, first.SourceTimerange)
+	}
+	if err := track.TrimStart(first.SegmentID, 5*types.SEC); err == nil {
+		t.Error("Expected error when trimming to an empty segment")
+	}
+
+	// 分割
+	right, err := track.SplitAt(3 * types.SEC)
+	if err != nil {
+		t.Fatalf("SplitAt failed: %v", err)
+	}
+	if len(track.Segments) != 3 || track.Segments[1] != right {
+		t.Fatalf("Expected the new half right after the original segment")
+	}
+	if right.Start() != 3*types.SEC || right.Duration() != 2*types.SEC || first.Duration() != 2*types.SEC {
+		t.Errorf("Unexpected split: %+v %+v", first.TargetTimerange, right.(*segment.VideoSegment).TargetTimerange)
+	}
+	if _, err := track.SplitAt(5 * types.SEC); err == nil {
+		t.Error("Expected error when splitting at a segment boundary")
+	}
+	if _, err := track.SplitAt(20 * types.SEC); err == nil {
+		t.Error("Expected error when splitting outside of any segment")
+	}
+
+	// 移除
+	removed, err := track.RemoveSegment(first.SegmentID)
+	if err != nil || removed != first {
+		t.Fatalf("RemoveSegment failed: %v", err)
+	}
+	if len(track.Segments) != 2 || track.Segments[0] != right {
+		t.Errorf("Unexpected segments after removal: %v", track.Segments)
+	}
+	if _, err := track.RemoveSegment(first.SegmentID); err == nil {
+		t.Error("Expected error when removing a missing segment")
+	}
+}
+
+func TestTrackSplitUnsupportedSegment(t *testing.T) {
+	track := NewTrack(TrackTypeSticker, "sticker_track", 0, false)
+	sticker := segment.NewStickerSegment("resource", types.NewTimerange(0, 2*types.SEC), nil)
+	if err := track.AddSegment(sticker); err != nil {
+		t.Fatalf("Failed to add segment: %v", err)
+	}
+	if _, err := track.SplitAt(1 * types.SEC); err == nil {
+		t.Error("Expected error when splitting a sticker segment")
+	}
+	if err := track.TrimEnd(sticker.SegmentID, 1*types.SEC); err == nil {
+		t.Error("Expected error when trimming a sticker segment")
+	}
+}
@@ -0,0 +1,106 @@
+package track
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+// GapStrategy 填补轨道上空隙的方式
+type GapStrategy int
+
+const (
+	// GapClose 删除空隙，之后的片段整体向前推移，单独的轨道上只推移该轨道，草稿中可使其他轨道一同推移
+	GapClose GapStrategy = iota
+	// GapExtend 延长空隙前的片段以覆盖空隙，无法延长时改为向前延长空隙后的片段，仅支持视频、音频及文本片段
+	GapExtend
+	// GapPlaceholder 在空隙处插入带有背景填充的占位片段，需要由草稿提供素材，单独的轨道上不支持
+	GapPlaceholder
+)
+
+// String 返回填补方式的名称
+func (s GapStrategy) String() string {
+	switch s {
+	case GapClose:
+		return "close"
+	case GapExtend:
+		return "extend"
+	case GapPlaceholder:
+		return "placeholder"
+	default:
+		return fmt.Sprintf("GapStrategy(%d)", int(s))
+	}
+}
+
+// Gaps 返回轨道上时长不小于minDuration的空隙，包括第一个片段之前的空隙，按时间顺序排列
+//
+// 只检查轨道结束时间之前的范围，空轨道没有空隙
+func (t *Track) Gaps(minDuration int64) []*types.Timerange {
+	segments := append([]segment.SegmentInterface(nil), t.Segments...)
+	sort.Slice(segments, func(i, j int) bool { return segments[i].Start() < segments[j].Start() })
+
+	var gaps []*types.Timerange
+	var cursor int64
+	for _, seg := range segments {
+		if duration := seg.Start() - cursor; duration > 0 && duration >= minDuration {
+			gaps = append(gaps, types.NewTimerange(cursor, duration))
+		}
+		cursor = max(cursor, seg.Start()+seg.Duration())
+	}
+	return gaps
+}
+
+// FillGaps 按strategy填补轨道上时长不小于minDuration的空隙
+//
+// 各空隙依次填补，某个空隙无法填补时返回错误，此前已填补的空隙保持不变
+func (t *Track) FillGaps(minDuration int64, strategy GapStrategy) error {
+	gaps := t.Gaps(minDuration)
+	switch strategy {
+	case GapClose:
+		// 从后往前删除，避免推移影响尚未处理的空隙的位置
+		for i := len(gaps) - 1; i >= 0; i-- {
+			if _, err := t.RippleDelete(gaps[i].Start, gaps[i].End(), RippleError); err != nil {
+				return err
+			}
+		}
+	case GapExtend:
+		for _, gap := range gaps {
+			if err := t.extendOver(gap); err != nil {
+				return err
+			}
+		}
+	case GapPlaceholder:
+		return fmt.Errorf("轨道 %s 无法单独插入占位片段，请通过草稿填补空隙", t.Name)
+	default:
+		return fmt.Errorf("未知的空隙填补方式: %s", strategy)
+	}
+	return nil
+}
+
+// extendOver 延长空隙前或空隙后的片段以覆盖空隙gap
+func (t *Track) extendOver(gap *types.Timerange) error {
+	var before, after segment.SegmentInterface
+	for _, seg := range t.Segments {
+		if seg.Start()+seg.Duration() == gap.Start {
+			before = seg
+		}
+		if seg.Start() == gap.End() {
+			after = seg
+		}
+	}
+
+	var err error
+	if before != nil {
+		if err = t.trim(before, 0, before.Duration()+gap.Duration); err == nil {
+			return nil
+		}
+	}
+	if after != nil {
+		if err = t.trim(after, -gap.Duration, after.Duration()); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("无法延长片段以填补轨道 %s 上的空隙 [%d, %d): %w", t.Name, gap.Start, gap.End(), err)
+}
@@ -0,0 +1,94 @@
+package track
+
+import (
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/material"
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+func TestTrackGaps(t *testing.T) {
+	sec := int64(types.SEC)
+
+	track := NewTrack(TrackTypeText, "text_track", 0, false)
+	if gaps := track.Gaps(0); len(gaps) != 0 {
+		t.Errorf("Expected no gaps on an empty track, got %v", gaps)
+	}
+
+	// 片段顺序与时间顺序无关
+	for _, tr := range []*types.Timerange{
+		types.NewTimerange(7*sec, 1*sec),
+		types.NewTimerange(1*sec, 2*sec),
+		types.NewTimerange(3500000, 3*sec),
+	} {
+		if err := track.AddSegment(segment.NewTextSegment("text", tr, "", nil, nil)); err != nil {
+			t.Fatalf("Failed to add segment: %v", err)
+		}
+	}
+
+	gaps := track.Gaps(0)
+	expected := []*types.Timerange{
+		types.NewTimerange(0, 1*sec),
+		types.NewTimerange(3*sec, 500000),
+		types.NewTimerange(6500000, 500000),
+	}
+	if len(gaps) != len(expected) {
+		t.Fatalf("Expected %d gaps, got %v", len(expected), gaps)
+	}
+	for i, gap := range gaps {
+		if *gap != *expected[i] {
+			t.Errorf("Gap %d: expected %+v, got %+v", i, expected[i], gap)
+		}
+	}
+	if gaps := track.Gaps(1 * sec); len(gaps) != 1 || gaps[0].Start != 0 {
+		t.Errorf("Expected only the leading gap, got %v", gaps)
+	}
+}
+
+func TestTrackFillGaps(t *testing.T) {
+	sec := int64(types.SEC)
+
+	track, _ := newRippleTrack(t)
+	if err := track.FillGaps(0, GapClose); err != nil {
+		t.Fatalf("FillGaps failed: %v", err)
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {2 * sec, 3 * sec}, {5 * sec, 1 * sec}})
+
+	track, _ = newRippleTrack(t)
+	if err := track.FillGaps(0, GapExtend); err != nil {
+		t.Fatalf("FillGaps failed: %v", err)
+	}
+	checkRanges(t, track, [][2]int64{{0, 3 * sec}, {3 * sec, 4 * sec}, {7 * sec, 1 * sec}})
+
+	if err := track.FillGaps(0, GapPlaceholder); err == nil {
+		t.Error("Expected error for placeholder strategy on a single track")
+	}
+
+	// 前一个片段的素材不足以延长时改为向前延长后一个片段
+	video := NewTrack(TrackTypeVideo, "video_track", 0, false)
+	first := segment.NewVideoSegment("video1", types.NewTimerange(0, 2*sec), types.NewTimerange(0, 2*sec), 1.0, 1.0, nil)
+	first.MaterialInstance = &material.VideoMaterial{Duration: 2 * sec, MaterialType: material.MaterialTypeVideo}
+	second := segment.NewVideoSegment("video2", types.NewTimerange(2*sec, 2*sec), types.NewTimerange(3*sec, 2*sec), 1.0, 1.0, nil)
+	for _, seg := range []*segment.VideoSegment{first, second} {
+		if err := video.AddSegment(seg); err != nil {
+			t.Fatalf("Failed to add segment: %v", err)
+		}
+	}
+	if err := video.FillGaps(0, GapExtend); err != nil {
+		t.Fatalf("FillGaps failed: %v", err)
+	}
+	if first.Duration() != 2*sec || second.Start() != 2*sec || second.SourceTimerange.Start != 1*sec {
+		t.Errorf("Expected the second segment to be extended backwards, got %+v %+v", second.TargetTimerange, second.SourceTimerange)
+	}
+
+	// 两侧都无法延长
+	second.MaterialInstance = &material.VideoMaterial{Duration: 4 * sec, MaterialType: material.MaterialTypeVideo}
+	if err := video.MoveSegment(second.SegmentID, 3*sec); err != nil {
+		t.Fatalf("MoveSegment failed: %v", err)
+	}
+	second.SourceTimerange = types.NewTimerange(0, 2*sec)
+	if err := video.FillGaps(0, GapExtend); err == nil {
+		t.Error("Expected error when neither neighbour can be extended")
+	}
+}
@@ -0,0 +1,84 @@
+package track
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/util"
+)
+
+// OverlapPolicy 新片段与轨道上已有片段重叠时的处理方式
+type OverlapPolicy int
+
+const (
+	// OverlapReject 拒绝添加，返回*util.SegmentOverlapError
+	OverlapReject OverlapPolicy = iota
+	// OverlapOverwrite 覆盖新片段范围内的内容：裁剪部分重叠的片段，移除被完全覆盖的片段，新片段位于已有片段中间时将其分为两部分
+	OverlapOverwrite
+	// OverlapInsert 在新片段开始处插入新片段的时长，之后的片段整体向后推移，跨越该处的片段被分为两部分
+	OverlapInsert
+	// OverlapSpill 将新片段放到另一条同类型的轨道上，需要由草稿选择轨道，单独的轨道上等同于OverlapReject
+	OverlapSpill
+)
+
+// String 返回处理方式的名称
+func (p OverlapPolicy) String() string {
+	switch p {
+	case OverlapReject:
+		return "reject"
+	case OverlapOverwrite:
+		return "overwrite"
+	case OverlapInsert:
+		return "insert"
+	case OverlapSpill:
+		return "spill"
+	default:
+		return fmt.Sprintf("OverlapPolicy(%d)", int(p))
+	}
+}
+
+// AddSegmentWithPolicy 向轨道中添加一个片段，与已有片段重叠时按policy处理
+//
+// 不重叠时与AddSegment相同。覆盖及插入需要分割已有片段时仅支持视频、音频、文本及导入的片段，
+// 返回分割产生的新片段；出错时轨道保持不变
+func (t *Track) AddSegmentWithPolicy(seg segment.SegmentInterface, policy OverlapPolicy) ([]segment.SegmentInterface, error) {
+	err := t.AddSegment(seg)
+	var overlap *util.SegmentOverlapError
+	if err == nil || !errors.As(err, &overlap) {
+		return nil, err
+	}
+
+	start, end := seg.Start(), seg.Start()+seg.Duration()
+	var created []segment.SegmentInterface
+	switch policy {
+	case OverlapReject, OverlapSpill:
+		return nil, err
+
+	case OverlapOverwrite:
+		if err := t.CheckRipple(start, end, RippleSplit); err != nil {
+			return nil, err
+		}
+		if created, err = t.cutRange(start, end); err != nil {
+			return created, err
+		}
+		remaining := t.Segments[:0]
+		for _, other := range t.Segments {
+			if otherStart := other.Start(); otherStart < start || otherStart+other.Duration() > end {
+				remaining = append(remaining, other)
+			}
+		}
+		t.Segments = remaining
+
+	case OverlapInsert:
+		if created, err = t.RippleInsert(start, end-start, RippleSplit); err != nil {
+			return created, err
+		}
+
+	default:
+		return nil, fmt.Errorf("未知的重叠处理方式: %s", policy)
+	}
+
+	t.insertSegment(seg)
+	return created, nil
+}
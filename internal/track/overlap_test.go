@@ -0,0 +1,82 @@
+package track
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/types"
+	"github.com/zhangshican/go-capcut/internal/util"
+)
+
+func TestTrackAddSegmentWithPolicy(t *testing.T) {
+	sec := int64(types.SEC)
+	newText := func(start, duration int64) *segment.TextSegment {
+		return segment.NewTextSegment("new", types.NewTimerange(start, duration), "", nil, nil)
+	}
+
+	// 拒绝时返回带有重叠范围的错误
+	track, _ := newRippleTrack(t)
+	_, err := track.AddSegmentWithPolicy(newText(5*sec, 3*sec), OverlapReject)
+	var overlap *util.SegmentOverlapError
+	if !errors.As(err, &overlap) {
+		t.Fatalf("Expected SegmentOverlapError, got %v", err)
+	}
+	if overlap.NewSegmentStart != 5*sec || overlap.ExistingStart != 3*sec || overlap.ExistingEnd != 6*sec {
+		t.Errorf("Unexpected overlap error: %+v", overlap)
+	}
+	if _, err := track.AddSegmentWithPolicy(newText(5*sec, 3*sec), OverlapSpill); !errors.As(err, &overlap) {
+		t.Errorf("Expected SegmentOverlapError for spill, got %v", err)
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {3 * sec, 3 * sec}, {7 * sec, 1 * sec}})
+
+	// 覆盖：裁剪部分重叠的片段，移除被完全覆盖的片段
+	if _, err := track.AddSegmentWithPolicy(newText(5*sec, 3*sec), OverlapOverwrite); err != nil {
+		t.Fatalf("Overwrite failed: %v", err)
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {3 * sec, 2 * sec}, {5 * sec, 3 * sec}})
+
+	// 覆盖已有片段的中间部分时将其分为两部分
+	track, _ = newRippleTrack(t)
+	created, err := track.AddSegmentWithPolicy(newText(4*sec, 1*sec), OverlapOverwrite)
+	if err != nil {
+		t.Fatalf("Overwrite failed: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected one new segment, got %d", len(created))
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {3 * sec, 1 * sec}, {4 * sec, 1 * sec}, {5 * sec, 1 * sec}, {7 * sec, 1 * sec}})
+
+	// 插入：之后的片段向后推移
+	track, _ = newRippleTrack(t)
+	created, err = track.AddSegmentWithPolicy(newText(4*sec, 1*sec), OverlapInsert)
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected one new segment, got %d", len(created))
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {3 * sec, 1 * sec}, {4 * sec, 1 * sec}, {5 * sec, 2 * sec}, {8 * sec, 1 * sec}})
+
+	// 不重叠时直接添加
+	if _, err := track.AddSegmentWithPolicy(newText(10*sec, 1*sec), OverlapInsert); err != nil {
+		t.Fatalf("AddSegmentWithPolicy failed: %v", err)
+	}
+	if len(track.Segments) != 6 || track.EndTime() != 11*sec {
+		t.Error("Expected the segment to be appended without changes")
+	}
+}
+
+func TestTrackOverwriteUnsupportedSegment(t *testing.T) {
+	track := NewTrack(TrackTypeSticker, "sticker_track", 0, false)
+	if err := track.AddSegment(segment.NewStickerSegment("resource", types.NewTimerange(0, 4*types.SEC), nil)); err != nil {
+		t.Fatalf("Failed to add segment: %v", err)
+	}
+	sticker := segment.NewStickerSegment("resource", types.NewTimerange(1*types.SEC, 1*types.SEC), nil)
+	if _, err := track.AddSegmentWithPolicy(sticker, OverlapOverwrite); err == nil {
+		t.Error("Expected error when splitting a sticker segment")
+	}
+	if len(track.Segments) != 1 || track.Segments[0].Duration() != 4*types.SEC {
+		t.Error("Expected the track to stay unchanged")
+	}
+}
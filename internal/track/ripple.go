@@ -0,0 +1,215 @@
+package track
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+)
+
+// RipplePolicy 波纹编辑时对跨越编辑点的片段的处理方式
+type RipplePolicy int
+
+const (
+	// RippleSplit 在编辑点处分割片段，仅支持视频、音频、文本及导入的片段
+	RippleSplit RipplePolicy = iota
+	// RipplePush 保持片段完整，将其与编辑点之后的片段一起推移
+	RipplePush
+	// RippleError 存在跨越编辑点的片段时返回错误
+	RippleError
+)
+
+// String 返回处理方式的名称
+func (p RipplePolicy) String() string {
+	switch p {
+	case RippleSplit:
+		return "split"
+	case RipplePush:
+		return "push"
+	case RippleError:
+		return "error"
+	default:
+		return fmt.Sprintf("RipplePolicy(%d)", int(p))
+	}
+}
+
+// RippleInsert 在时间点at处插入时长为duration的空白，at之后的片段整体向后推移duration
+//
+// 跨越at的片段按policy处理：RippleSplit在at处分割并推移后一部分，RipplePush推移整个片段，
+// RippleError返回错误。返回分割产生的新片段，出错时轨道保持不变
+func (t *Track) RippleInsert(at, duration int64, policy RipplePolicy) ([]segment.SegmentInterface, error) {
+	if at < 0 || duration <= 0 {
+		return nil, fmt.Errorf("无效的插入范围: at=%d, duration=%d", at, duration)
+	}
+	if err := t.CheckRipple(at, at, policy); err != nil {
+		return nil, err
+	}
+
+	var created []segment.SegmentInterface
+	if policy == RippleSplit {
+		for _, seg := range t.straddling(at) {
+			right, err := t.splitAfter(seg, at)
+			if err != nil {
+				return created, err
+			}
+			created = append(created, right)
+		}
+	}
+
+	// 分割后跨越at的片段只剩下需要整体推移的片段
+	for _, seg := range t.Segments {
+		if seg.Start()+seg.Duration() > at {
+			seg.(timedSegment).GetTargetTimerange().Start += duration
+		}
+	}
+	return created, nil
+}
+
+// RippleDelete 删除[start, end)范围内的内容，end之后的片段整体向前推移end-start
+//
+// 完全位于范围内的片段被移除。跨越start或end的片段按policy处理：RippleSplit裁掉片段位于范围内的部分，
+// 同时跨越两端的片段被分为两部分；RipplePush保留整个片段，跨越start的片段位置不变，
+// 跨越end的片段移至start处开始，推移后与其他片段重叠时返回错误；RippleError返回错误。
+// 返回分割产生的新片段，出错时轨道保持不变
+func (t *Track) RippleDelete(start, end int64, policy RipplePolicy) ([]segment.SegmentInterface, error) {
+	if start < 0 || end <= start {
+		return nil, fmt.Errorf("无效的删除范围: [%d, %d)", start, end)
+	}
+	if err := t.CheckRipple(start, end, policy); err != nil {
+		return nil, err
+	}
+
+	var created []segment.SegmentInterface
+	if policy == RippleSplit {
+		var err error
+		if created, err = t.cutRange(start, end); err != nil {
+			return created, err
+		}
+	}
+
+	remaining := t.Segments[:0]
+	for _, seg := range t.Segments {
+		segStart, segEnd := seg.Start(), seg.Start()+seg.Duration()
+		if segStart >= start && segEnd <= end {
+			continue
+		}
+		seg.(timedSegment).GetTargetTimerange().Start = rippleDeleteStart(segStart, start, end)
+		remaining = append(remaining, seg)
+	}
+	t.Segments = remaining
+	return created, nil
+}
+
+// CheckRipple 检查能否对[start, end)进行波纹编辑而不修改轨道，start等于end时检查在该时间点的插入
+//
+// RippleInsert及RippleDelete会先进行同样的检查，可用于在修改多条轨道之前确认每条轨道都能完成编辑
+func (t *Track) CheckRipple(start, end int64, policy RipplePolicy) error {
+	for _, seg := range t.Segments {
+		if _, ok := seg.(timedSegment); !ok {
+			return fmt.Errorf("轨道 %s 中存在无法移动的片段类型: %T", t.Name, seg)
+		}
+	}
+
+	for _, seg := range t.straddling(start, end) {
+		switch policy {
+		case RippleSplit:
+			switch seg.(type) {
+			case *segment.VideoSegment, *segment.AudioSegment, *segment.TextSegment, editableSegment:
+			default:
+				return fmt.Errorf("轨道 %s 中跨越编辑点的片段 %s 不支持分割: %T", t.Name, seg.(timedSegment).GetID(), seg)
+			}
+		case RipplePush:
+		case RippleError:
+			return fmt.Errorf("轨道 %s 中的片段 %s [start: %d, end: %d] 跨越编辑点",
+				t.Name, seg.(timedSegment).GetID(), seg.Start(), seg.Start()+seg.Duration())
+		default:
+			return fmt.Errorf("未知的波纹编辑处理方式: %s", policy)
+		}
+	}
+
+	// 删除时整体推移跨越end的片段可能与保留在原位的片段重叠
+	if policy != RipplePush || start == end {
+		return nil
+	}
+	type span struct{ start, end int64 }
+	var spans []span
+	for _, seg := range t.Segments {
+		segStart, segEnd := seg.Start(), seg.Start()+seg.Duration()
+		if segStart >= start && segEnd <= end {
+			continue
+		}
+		newStart := rippleDeleteStart(segStart, start, end)
+		spans = append(spans, span{newStart, newStart + segEnd - segStart})
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	for i := 1; i < len(spans); i++ {
+		if spans[i].start < spans[i-1].end {
+			return fmt.Errorf("轨道 %s 推移后片段重叠 [start: %d, end: %d]", t.Name, spans[i].start, spans[i].end)
+		}
+	}
+	return nil
+}
+
+// cutRange 裁掉跨越start或end的片段位于[start, end)内的部分，同时跨越两端的片段被分为两部分，返回分割产生的新片段
+func (t *Track) cutRange(start, end int64) ([]segment.SegmentInterface, error) {
+	var created []segment.SegmentInterface
+	for _, seg := range t.straddling(start, end) {
+		segStart, segEnd := seg.Start(), seg.Start()+seg.Duration()
+		if segStart < start && segEnd > end {
+			right, err := t.splitAfter(seg, end)
+			if err != nil {
+				return created, err
+			}
+			created = append(created, right)
+			segEnd = end
+		}
+		keepStart, keepEnd := int64(0), segEnd-segStart
+		if segStart < start {
+			keepEnd = start - segStart
+		} else {
+			keepStart = end - segStart
+		}
+		if err := t.trim(seg, keepStart, keepEnd); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// straddling 返回严格跨越任一时间点的片段
+func (t *Track) straddling(points ...int64) []segment.SegmentInterface {
+	var result []segment.SegmentInterface
+	for _, seg := range t.Segments {
+		segStart, segEnd := seg.Start(), seg.Start()+seg.Duration()
+		for _, point := range points {
+			if segStart < point && point < segEnd {
+				result = append(result, seg)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// splitAfter 将片段seg在时间点at处分割，后一部分紧随其后插入轨道
+func (t *Track) splitAfter(seg segment.SegmentInterface, at int64) (segment.SegmentInterface, error) {
+	right, err := splitSegment(seg, at-seg.Start())
+	if err != nil {
+		return nil, err
+	}
+	for i, other := range t.Segments {
+		if other == seg {
+			t.Segments = append(t.Segments[:i+1], append([]segment.SegmentInterface{right}, t.Segments[i+1:]...)...)
+			break
+		}
+	}
+	return right, nil
+}
+
+// rippleDeleteStart 返回删除[start, end)后原本在segStart开始的片段的新开始时间
+func rippleDeleteStart(segStart, start, end int64) int64 {
+	if segStart < start {
+		return segStart
+	}
+	return segStart - min(end-start, segStart-start)
+}
@@ -0,0 +1,144 @@
+package track
+
+import (
+	"testing"
+
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+// newRippleTrack 创建依次位于[0s, 2s)、[3s, 6s)、[7s, 8s)的三个文本片段组成的轨道
+func newRippleTrack(t *testing.T) (*Track, []*segment.TextSegment) {
+	track := NewTrack(TrackTypeText, "text_track", 0, false)
+	var segs []*segment.TextSegment
+	for _, tr := range []*types.Timerange{
+		types.NewTimerange(0, 2*types.SEC),
+		types.NewTimerange(3*types.SEC, 3*types.SEC),
+		types.NewTimerange(7*types.SEC, 1*types.SEC),
+	} {
+		seg := segment.NewTextSegment("text", tr, "", nil, nil)
+		if err := track.AddSegment(seg); err != nil {
+			t.Fatalf("Failed to add segment: %v", err)
+		}
+		segs = append(segs, seg)
+	}
+	return track, segs
+}
+
+func checkRanges(t *testing.T, track *Track, expected [][2]int64) {
+	t.Helper()
+	if len(track.Segments) != len(expected) {
+		t.Fatalf("Expected %d segments, got %d", len(expected), len(track.Segments))
+	}
+	for i, seg := range track.Segments {
+		if seg.Start() != expected[i][0] || seg.Duration() != expected[i][1] {
+			t.Errorf("Segment %d: expected [%d, +%d), got [%d, +%d)", i, expected[i][0], expected[i][1], seg.Start(), seg.Duration())
+		}
+	}
+}
+
+func TestTrackRippleInsert(t *testing.T) {
+	sec := int64(types.SEC)
+
+	track, _ := newRippleTrack(t)
+	created, err := track.RippleInsert(4*sec, 1*sec, RippleSplit)
+	if err != nil {
+		t.Fatalf("RippleInsert failed: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected one new segment, got %d", len(created))
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {3 * sec, 1 * sec}, {5 * sec, 2 * sec}, {8 * sec, 1 * sec}})
+
+	track, _ = newRippleTrack(t)
+	if _, err := track.RippleInsert(4*sec, 1*sec, RipplePush); err != nil {
+		t.Fatalf("RippleInsert failed: %v", err)
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {4 * sec, 3 * sec}, {8 * sec, 1 * sec}})
+
+	track, _ = newRippleTrack(t)
+	if _, err := track.RippleInsert(4*sec, 1*sec, RippleError); err == nil {
+		t.Error("Expected error for a straddling segment")
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {3 * sec, 3 * sec}, {7 * sec, 1 * sec}})
+
+	// 编辑点位于片段边界上时没有跨越的片段
+	if _, err := track.RippleInsert(3*sec, 1*sec, RippleError); err != nil {
+		t.Fatalf("RippleInsert failed: %v", err)
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {4 * sec, 3 * sec}, {8 * sec, 1 * sec}})
+
+	if _, err := track.RippleInsert(1*sec, 0, RippleSplit); err == nil {
+		t.Error("Expected error for zero duration")
+	}
+}
+
+func TestTrackRippleDelete(t *testing.T) {
+	sec := int64(types.SEC)
+
+	// 裁掉跨越两端的部分
+	track, _ := newRippleTrack(t)
+	if _, err := track.RippleDelete(1*sec, 4*sec, RippleSplit); err != nil {
+		t.Fatalf("RippleDelete failed: %v", err)
+	}
+	checkRanges(t, track, [][2]int64{{0, 1 * sec}, {1 * sec, 2 * sec}, {4 * sec, 1 * sec}})
+
+	// 完全位于范围内的片段被移除
+	track, segs := newRippleTrack(t)
+	if _, err := track.RippleDelete(2*sec, 7*sec, RippleError); err != nil {
+		t.Fatalf("RippleDelete failed: %v", err)
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {2 * sec, 1 * sec}})
+	if track.Segments[1] != segs[2] {
+		t.Error("Expected the last segment to be kept")
+	}
+
+	// 同时跨越两端的片段被分为两部分
+	track, _ = newRippleTrack(t)
+	created, err := track.RippleDelete(4*sec, 5*sec, RippleSplit)
+	if err != nil {
+		t.Fatalf("RippleDelete failed: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected one new segment, got %d", len(created))
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {3 * sec, 1 * sec}, {4 * sec, 1 * sec}, {6 * sec, 1 * sec}})
+
+	// 推移跨越end的片段
+	track, _ = newRippleTrack(t)
+	if _, err := track.RippleDelete(2*sec, 4*sec, RipplePush); err != nil {
+		t.Fatalf("RippleDelete failed: %v", err)
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {2 * sec, 3 * sec}, {5 * sec, 1 * sec}})
+
+	// 跨越start的片段保持原位，推移后与后面的片段重叠
+	track, _ = newRippleTrack(t)
+	if _, err := track.RippleDelete(5*sec, 7*sec, RipplePush); err == nil {
+		t.Error("Expected error when pushed segments overlap")
+	}
+	checkRanges(t, track, [][2]int64{{0, 2 * sec}, {3 * sec, 3 * sec}, {7 * sec, 1 * sec}})
+
+	if _, err := track.RippleDelete(1*sec, 4*sec, RippleError); err == nil {
+		t.Error("Expected error for straddling segments")
+	}
+	if _, err := track.RippleDelete(4*sec, 4*sec, RippleSplit); err == nil {
+		t.Error("Expected error for empty range")
+	}
+}
+
+func TestTrackRippleUnsupportedSegment(t *testing.T) {
+	track := NewTrack(TrackTypeSticker, "sticker_track", 0, false)
+	sticker := segment.NewStickerSegment("resource", types.NewTimerange(0, 2*types.SEC), nil)
+	if err := track.AddSegment(sticker); err != nil {
+		t.Fatalf("Failed to add segment: %v", err)
+	}
+	if _, err := track.RippleInsert(1*types.SEC, 1*types.SEC, RippleSplit); err == nil {
+		t.Error("Expected error when splitting a sticker segment")
+	}
+	if _, err := track.RippleInsert(1*types.SEC, 1*types.SEC, RipplePush); err != nil {
+		t.Fatalf("RippleInsert failed: %v", err)
+	}
+	if sticker.Start() != 1*types.SEC {
+		t.Errorf("Expected sticker to be pushed, got start %d", sticker.Start())
+	}
+}
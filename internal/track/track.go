@@ -6,10 +6,10 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/zhangshican/go-capcut/internal/content"
 	"github.com/zhangshican/go-capcut/internal/keyframe"
 	"github.com/zhangshican/go-capcut/internal/segment"
-
-	"github.com/google/uuid"
+	"github.com/zhangshican/go-capcut/internal/util"
 )
 
 // TrackMeta 与轨道类型关联的轨道元数据
@@ -38,10 +38,10 @@ const (
 var trackTypeMetas = map[TrackType]TrackMeta{
 	TrackTypeVideo:   {reflect.TypeOf(&segment.VideoSegment{}), 0, true},
 	TrackTypeAudio:   {reflect.TypeOf(&segment.AudioSegment{}), 0, true},
-	TrackTypeEffect:  {reflect.TypeOf(&segment.EffectSegment{}), 10000, false}, // 特效轨道
-	TrackTypeFilter:  {reflect.TypeOf(&segment.FilterSegment{}), 11000, false}, // 滤镜轨道
-	TrackTypeSticker: {nil, 14000, false},                                      // TODO: 待实现Sticker_segment后设置
-	TrackTypeText:    {reflect.TypeOf(&segment.TextSegment{}), 15000, true},    // 原本是14000，避免与sticker冲突改为15000
+	TrackTypeEffect:  {reflect.TypeOf(&segment.EffectSegment{}), 10000, false},  // 特效轨道
+	TrackTypeFilter:  {reflect.TypeOf(&segment.FilterSegment{}), 11000, false},  // 滤镜轨道
+	TrackTypeSticker: {reflect.TypeOf(&segment.StickerSegment{}), 14000, false}, // 贴纸轨道
+	TrackTypeText:    {reflect.TypeOf(&segment.TextSegment{}), 15000, true},     // 原本是14000，避免与sticker冲突改为15000
 	TrackTypeAdjust:  {nil, 0, false},
 }
 
@@ -128,8 +128,9 @@ type Track struct {
 	TrackID          string                     `json:"id"`                // 轨道全局ID
 	RenderIndex      int                        `json:"render_index"`      // 渲染顺序，值越大越接近前景
 	Mute             bool                       `json:"mute"`              // 是否静音
-	Segments         []segment.SegmentInterface `json:"segments"`          // 该轨道包含的片段列表
+	Segments         []segment.SegmentInterface `json:"segments"`          // 该轨道包含的片段列表，按开始时间排序
 	PendingKeyframes []PendingKeyframe          `json:"pending_keyframes"` // 待处理的关键帧列表
+	RawData          *content.Track             `json:"-"`                 // 导入轨道的原始json数据，新建的轨道为nil
 }
 
 // NewTrack 创建新的轨道
@@ -142,7 +143,7 @@ func NewTrack(trackType TrackType, name string, renderIndex int, mute bool) *Tra
 	return &Track{
 		TrackType:        trackType,
 		Name:             name,
-		TrackID:          uuid.New().String(),
+		TrackID:          util.NewID(),
 		RenderIndex:      renderIndex,
 		Mute:             mute,
 		Segments:         make([]segment.SegmentInterface, 0),
@@ -221,46 +222,34 @@ func (t *Track) ProcessPendingKeyframes() error {
 			}
 		} else {
 			// 尝试其他片段类型
+			var vs *segment.VisualSegment
 			switch seg := targetSegment.(type) {
 			case *segment.VideoSegment:
-				if vs := seg.VisualSegment; vs != nil {
-					// 解析值
-					keyframeProp, err := keyframe.KeyframePropertyFromString(propertyType)
-					if err != nil {
-						fmt.Printf("不支持的属性类型: %v\n", err)
-						continue
-					}
-					floatValue, err := keyframe.ParseValue(keyframeProp, value)
-					if err != nil {
-						fmt.Printf("解析值失败: %v\n", err)
-						continue
-					}
-					err = vs.AddKeyframe(propertyType, offsetTime, floatValue)
-					if err != nil {
-						fmt.Printf("添加视频片段关键帧失败: %v\n", err)
-					}
-				}
+				vs = seg.VisualSegment
+			case *segment.StickerSegment:
+				vs = seg.VisualSegment
 			case *segment.TextSegment:
-				if vs := seg.VisualSegment; vs != nil {
-					// 解析值
-					keyframeProp, err := keyframe.KeyframePropertyFromString(propertyType)
-					if err != nil {
-						fmt.Printf("不支持的属性类型: %v\n", err)
-						continue
-					}
-					floatValue, err := keyframe.ParseValue(keyframeProp, value)
-					if err != nil {
-						fmt.Printf("解析值失败: %v\n", err)
-						continue
-					}
-					err = vs.AddKeyframe(propertyType, offsetTime, floatValue)
-					if err != nil {
-						fmt.Printf("添加文本片段关键帧失败: %v\n", err)
-					}
-				}
+				vs = seg.VisualSegment
 			default:
 				fmt.Printf("不支持的片段类型进行关键帧添加: %T\n", targetSegment)
 			}
+			if vs != nil {
+				// 解析值
+				keyframeProp, err := keyframe.KeyframePropertyFromString(propertyType)
+				if err != nil {
+					fmt.Printf("不支持的属性类型: %v\n", err)
+					continue
+				}
+				floatValue, err := keyframe.ParseValue(keyframeProp, value)
+				if err != nil {
+					fmt.Printf("解析值失败: %v\n", err)
+					continue
+				}
+				err = vs.AddKeyframe(propertyType, offsetTime, floatValue)
+				if err != nil {
+					fmt.Printf("添加片段关键帧失败: %v\n", err)
+				}
+			}
 		}
 
 		fmt.Printf("成功添加关键帧: %s 在 %.2fs\n", propertyType, time)
@@ -294,26 +283,46 @@ func (t *Track) AcceptSegmentType() reflect.Type {
 	return t.TrackType.Meta().SegmentType
 }
 
+// TrackTypeOfSegment 返回接受给定片段的轨道类型
+func TrackTypeOfSegment(seg segment.SegmentInterface) (TrackType, error) {
+	segmentType := reflect.TypeOf(seg)
+	for _, trackType := range []TrackType{TrackTypeVideo, TrackTypeAudio, TrackTypeEffect, TrackTypeFilter, TrackTypeSticker, TrackTypeText} {
+		if meta := trackType.Meta(); meta.SegmentType != nil && meta.SegmentType == segmentType {
+			return trackType, nil
+		}
+	}
+	return TrackTypeVideo, fmt.Errorf("不存在接受片段类型 (%s) 的轨道类型", segmentType)
+}
+
 // AddSegment 向轨道中添加一个片段，添加的片段必须匹配轨道类型且不与现有片段重叠
+//
+// 与现有片段重叠时返回*util.SegmentOverlapError，其他处理方式见AddSegmentWithPolicy
 func (t *Track) AddSegment(seg segment.SegmentInterface) error {
-	// 检查片段类型是否匹配轨道类型
-	acceptedType := t.AcceptSegmentType()
-	if acceptedType != nil {
-		segmentType := reflect.TypeOf(seg)
-		if segmentType != acceptedType {
-			return fmt.Errorf("新片段类型 (%s) 与轨道类型 (%s) 不匹配", segmentType, acceptedType)
-		}
+	if err := t.checkSegmentType(seg); err != nil {
+		return err
 	}
 
 	// 检查片段是否重叠
 	for _, existingSeg := range t.Segments {
 		if t.segmentsOverlap(existingSeg, seg) {
-			return fmt.Errorf("新片段与现有片段重叠 [start: %d, end: %d]",
-				seg.Start(), seg.Start()+seg.Duration())
+			return util.NewSegmentOverlapError(seg.Start(), seg.Start()+seg.Duration(),
+				existingSeg.Start(), existingSeg.Start()+existingSeg.Duration())
 		}
 	}
 
-	t.Segments = append(t.Segments, seg)
+	t.insertSegment(seg)
+	return nil
+}
+
+// checkSegmentType 检查片段类型是否匹配轨道类型
+func (t *Track) checkSegmentType(seg segment.SegmentInterface) error {
+	acceptedType := t.AcceptSegmentType()
+	if acceptedType != nil {
+		segmentType := reflect.TypeOf(seg)
+		if segmentType != acceptedType {
+			return fmt.Errorf("新片段类型 (%s) 与轨道类型 (%s) 不匹配", segmentType, acceptedType)
+		}
+	}
 	return nil
 }
 
@@ -328,6 +337,10 @@ func (t *Track) segmentsOverlap(seg1, seg2 segment.SegmentInterface) bool {
 
 // ExportJSON 导出轨道为JSON格式
 func (t *Track) ExportJSON() map[string]interface{} {
+	if t.RawData != nil {
+		return t.exportRawJSON()
+	}
+
 	// 导出所有片段的JSON，并为每个片段设置render_index
 	segmentExports := make([]interface{}, len(t.Segments))
 	for i, seg := range t.Segments {
@@ -348,6 +361,86 @@ func (t *Track) ExportJSON() map[string]interface{} {
 	}
 }
 
+// contentSegment 能够直接导出为草稿内容模型的片段，如模板模式下导入的片段
+type contentSegment interface {
+	ExportContent() (*content.Segment, error)
+}
+
+// exportRawJSON 以原始json数据为基础导出导入的轨道，无法编码时（如数值为NaN）返回nil
+func (t *Track) exportRawJSON() map[string]interface{} {
+	data, err := t.ExportContent()
+	if err != nil {
+		return nil
+	}
+	jsonData, err := content.ToPlainMap(data)
+	if err != nil {
+		return nil
+	}
+
+	// 片段沿用各自ExportJSON的数值类型
+	segmentExports := make([]interface{}, len(t.Segments))
+	for i, seg := range t.Segments {
+		segmentJSON := seg.ExportJSON()
+		if _, ok := segmentJSON["render_index"]; !ok {
+			segmentJSON["render_index"] = t.RenderIndex
+		}
+		segmentExports[i] = segmentJSON
+	}
+	jsonData["segments"] = segmentExports
+	return jsonData
+}
+
+// ExportContent 导出为草稿内容模型
+//
+// 导入的轨道以原始数据为基础，只有被修改过的字段才会写回，原始数据本身不会被修改
+func (t *Track) ExportContent() (*content.Track, error) {
+	if t.RawData == nil {
+		data := &content.Track{}
+		if err := content.FromMap(t.ExportJSON(), data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	data := *t.RawData
+
+	// 导入的片段保留各自的render_index，新加入的片段使用轨道的渲染层级
+	data.Segments = make([]*content.Segment, len(t.Segments))
+	for i, seg := range t.Segments {
+		if exporter, ok := seg.(contentSegment); ok {
+			segData, err := exporter.ExportContent()
+			if err != nil {
+				return nil, err
+			}
+			if !segData.Has("render_index") {
+				segData.RenderIndex = t.RenderIndex
+			}
+			data.Segments[i] = segData
+			continue
+		}
+
+		segmentJSON := seg.ExportJSON()
+		if _, ok := segmentJSON["render_index"]; !ok {
+			segmentJSON["render_index"] = t.RenderIndex
+		}
+		data.Segments[i] = &content.Segment{}
+		if err := content.FromMap(segmentJSON, data.Segments[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if data.Name != t.Name {
+		data.Name = t.Name
+		data.IsDefaultName = len(t.Name) == 0
+	}
+	data.ID = t.TrackID
+	data.Type = t.TrackType.String()
+	if (data.Attribute != 0) != t.Mute {
+		data.Attribute = t.getMuteAttribute()
+	}
+
+	return &data, nil
+}
+
 // getMuteAttribute 获取静音属性值
 func (t *Track) getMuteAttribute() int {
 	if t.Mute {
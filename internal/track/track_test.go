@@ -197,6 +197,32 @@ func TestEndTime(t *testing.T) {
 	}
 }
 
+func TestTrackTypeOfSegment(t *testing.T) {
+	// 测试根据片段类型推断轨道类型
+	timerange, _ := types.Trange("0s", "1s")
+
+	tests := []struct {
+		seg      segment.SegmentInterface
+		expected TrackType
+	}{
+		{segment.NewVideoSegment("video_material", nil, timerange, 1.0, 1.0, nil), TrackTypeVideo},
+		{segment.NewAudioSegment("audio_material", timerange, nil, 1.0, 1.0), TrackTypeAudio},
+		{segment.NewTextSegmentSimple("text", timerange), TrackTypeText},
+		{segment.NewStickerSegment("sticker_resource", timerange, nil), TrackTypeSticker},
+	}
+
+	for _, test := range tests {
+		trackType, err := TrackTypeOfSegment(test.seg)
+		if err != nil {
+			t.Errorf("Unexpected error for %T: %v", test.seg, err)
+			continue
+		}
+		if trackType != test.expected {
+			t.Errorf("Expected %s for %T, got %s", test.expected, test.seg, trackType)
+		}
+	}
+}
+
 func TestAddSegment(t *testing.T) {
 	// 测试添加片段
 	track := NewTrack(TrackTypeVideo, "video_track", 0, false)
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator id生成器，决定草稿中新建对象的id
+type IDGenerator interface {
+	NewID() string
+}
+
+// RandomIDGenerator 随机id生成器，生成UUID v4格式的id，为默认的生成器
+type RandomIDGenerator struct{}
+
+// NewID 生成一个随机id
+func (RandomIDGenerator) NewID() string {
+	return uuid.New().String()
+}
+
+// SeededIDGenerator 基于种子的确定性id生成器
+//
+// 第n个id由种子与序号的哈希得到（UUID v5格式），相同的种子总是生成相同的id序列
+type SeededIDGenerator struct {
+	seed    string
+	counter uint64
+	mu      sync.Mutex
+}
+
+// NewSeededIDGenerator 创建基于种子的确定性id生成器
+func NewSeededIDGenerator(seed string) *SeededIDGenerator {
+	return &SeededIDGenerator{seed: seed}
+}
+
+// NewID 生成序列中的下一个id
+func (g *SeededIDGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.counter++
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("%s:%d", g.seed, g.counter))).String()
+}
+
+// NewID 生成一个随机id，供各类对象的构造函数使用
+//
+// 需要确定性的id时，应通过ScriptFile.SetIDGenerator为草稿设置生成器，导出时新建对象的id会被替换
+func NewID() string {
+	return RandomIDGenerator{}.NewID()
+}
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"regexp"
+	"testing"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// TestSeededIDGenerator 测试基于种子的id生成器
+func TestSeededIDGenerator(t *testing.T) {
+	first := NewSeededIDGenerator("draft")
+	second := NewSeededIDGenerator("draft")
+	other := NewSeededIDGenerator("other")
+
+	seen := make(map[string]bool)
+	for i := 0; i < 10; i++ {
+		id := first.NewID()
+		if !uuidPattern.MatchString(id) {
+			t.Errorf("Expected UUID format, got %s", id)
+		}
+		if seen[id] {
+			t.Errorf("Duplicate id %s", id)
+		}
+		seen[id] = true
+
+		if second.NewID() != id {
+			t.Error("Generators with the same seed should produce the same sequence")
+		}
+		if other.NewID() == id {
+			t.Error("Generators with different seeds should produce different ids")
+		}
+	}
+}
+
+// TestNewID 测试默认生成随机id
+func TestNewID(t *testing.T) {
+	if a, b := NewID(), NewID(); a == b || !uuidPattern.MatchString(a) {
+		t.Errorf("Expected distinct random UUIDs, got %s and %s", a, b)
+	}
+}
@@ -0,0 +1,110 @@
+package util
+
+import (
+	"sort"
+	"strings"
+)
+
+// PathRule 路径前缀映射规则，将以From开头的路径改写为以To开头
+//
+// From和To均可使用Windows或类Unix风格的路径。匹配时不区分两种分隔符，且只在完整的路径组成部分上匹配，
+// 如"/srv/media"匹配"/srv/media/a.mp4"而不匹配"/srv/media2/a.mp4"；含盘符的From不区分大小写。
+// 改写后的路径统一使用To的分隔符：To含反斜杠时使用反斜杠，否则使用正斜杠
+type PathRule struct {
+	From string // 原路径前缀，如"/srv/media"
+	To   string // 目标路径前缀，如`D:\media`或DraftPathPlaceholder
+}
+
+// DraftRelativeRule 返回将草稿文件夹draftDir中的路径改写为以DraftPathPlaceholder开头的草稿相对路径的规则
+func DraftRelativeRule(draftDir string) PathRule {
+	return PathRule{From: draftDir, To: DraftPathPlaceholder}
+}
+
+// PathMapper 按前缀规则改写素材路径，多条规则匹配时使用From最长的规则
+type PathMapper struct {
+	rules []PathRule
+}
+
+// NewPathMapper 创建路径映射器，忽略From为空的规则
+func NewPathMapper(rules ...PathRule) *PathMapper {
+	mapper := &PathMapper{}
+	for _, rule := range rules {
+		if trimSeparators(normalizeSeparators(rule.From)) == "" && !isRootPath(rule.From) {
+			continue
+		}
+		mapper.rules = append(mapper.rules, rule)
+	}
+	// 按From的长度降序排列，长度相同时保持原有顺序
+	sort.SliceStable(mapper.rules, func(i, j int) bool {
+		return len(trimSeparators(normalizeSeparators(mapper.rules[i].From))) >
+			len(trimSeparators(normalizeSeparators(mapper.rules[j].From)))
+	})
+	return mapper
+}
+
+// Map 返回改写后的路径，没有匹配的规则时原样返回
+func (m *PathMapper) Map(path string) string {
+	if m == nil || path == "" {
+		return path
+	}
+	normalized := normalizeSeparators(path)
+	for _, rule := range m.rules {
+		rest, ok := cutPathPrefix(normalized, normalizeSeparators(rule.From))
+		if !ok {
+			continue
+		}
+		to := rule.To
+		if strings.Contains(to, `\`) {
+			return trimSeparators(to) + strings.ReplaceAll(rest, "/", `\`)
+		}
+		return trimSeparators(to) + rest
+	}
+	return path
+}
+
+// cutPathPrefix 在完整的路径组成部分上匹配前缀，返回剩余部分，剩余部分为空或以"/"开头
+//
+// path和prefix均应使用正斜杠分隔
+func cutPathPrefix(path, prefix string) (string, bool) {
+	prefix = trimSeparators(prefix)
+	if len(path) < len(prefix) {
+		return "", false
+	}
+	head := path[:len(prefix)]
+	if hasDriveLetter(prefix) {
+		if !strings.EqualFold(head, prefix) {
+			return "", false
+		}
+	} else if head != prefix {
+		return "", false
+	}
+	rest := path[len(prefix):]
+	if rest != "" && rest[0] != '/' {
+		return "", false
+	}
+	return rest, true
+}
+
+// normalizeSeparators 将路径中的反斜杠统一为正斜杠
+func normalizeSeparators(path string) string {
+	return strings.ReplaceAll(path, `\`, "/")
+}
+
+// trimSeparators 去除路径末尾的分隔符
+func trimSeparators(path string) string {
+	return strings.TrimRight(path, `/\`)
+}
+
+// isRootPath 检测路径是否为根目录"/"
+func isRootPath(path string) bool {
+	return path != "" && trimSeparators(path) == ""
+}
+
+// hasDriveLetter 检测路径是否以Windows盘符(如"C:")开头
+func hasDriveLetter(path string) bool {
+	if len(path) < 2 || path[1] != ':' {
+		return false
+	}
+	c := path[0]
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -263,6 +263,15 @@ func IsWindowsPath(path string) bool {
 	return matched
 }
 
+// DraftPathPlaceholder 剪映草稿中代表草稿文件夹的占位符，以其开头的素材路径相对于草稿文件夹，
+// 如"##_draftpath_placeholder_0E685133-18CE-45ED-8CB8-2904A212EC80_##/media/a.mp4"
+const DraftPathPlaceholder = "##_draftpath_placeholder_0E685133-18CE-45ED-8CB8-2904A212EC80_##"
+
+// IsDraftRelativePath 检测路径是否为以DraftPathPlaceholder开头的草稿相对路径
+func IsDraftRelativePath(path string) bool {
+	return strings.HasPrefix(path, DraftPathPlaceholder)
+}
+
 // URLToHash 将URL转换为固定长度的哈希字符串
 // 对应根目录util.py的url_to_hash函数
 func URLToHash(url string, length int) string {
@@ -281,6 +290,16 @@ func URLToHash(url string, length int) string {
 	return hashStr[:length]
 }
 
+// ToInt64 将JSON中解析出的数值（float64、json.Number、整数类型等）转换为int64
+func ToInt64(value interface{}) (int64, bool) {
+	return convertToInt(value)
+}
+
+// ToFloat64 将JSON中解析出的数值（float64、json.Number、整数类型等）转换为float64
+func ToFloat64(value interface{}) (float64, bool) {
+	return convertToFloat(value)
+}
+
 // 类型转换辅助函数
 
 func convertToInt(value interface{}) (int64, bool) {
@@ -317,6 +336,9 @@ func convertToInt(value interface{}) (int64, bool) {
 		if i, err := v.Int64(); err == nil {
 			return i, true
 		}
+		if f, err := v.Float64(); err == nil {
+			return int64(f), true
+		}
 	}
 	return 0, false
 }
@@ -266,6 +266,18 @@ func TestIsWindowsPath(t *testing.T) {
 	}
 }
 
+// TestIsDraftRelativePath 测试草稿相对路径检测
+func TestIsDraftRelativePath(t *testing.T) {
+	if !IsDraftRelativePath(DraftPathPlaceholder + "/packed_media/a.mp4") {
+		t.Error("以占位符开头的路径应为草稿相对路径")
+	}
+	for _, path := range []string{"/srv/media/a.mp4", "C:\\media\\a.mp4", ""} {
+		if IsDraftRelativePath(path) {
+			t.Errorf("路径'%s'不应为草稿相对路径", path)
+		}
+	}
+}
+
 // TestURLToHash 测试URL哈希转换
 func TestURLToHash(t *testing.T) {
 	testCases := []struct {
@@ -483,3 +495,44 @@ func TestJSONExportableInterface(t *testing.T) {
 		t.Errorf("Exportable.value期望42，得到%v", exportableData["value"])
 	}
 }
+
+// TestPathMapper 测试素材路径的前缀映射
+func TestPathMapper(t *testing.T) {
+	mapper := NewPathMapper(
+		PathRule{From: "/srv/media", To: `D:\media`},
+		PathRule{From: "/srv/media/music/", To: "/Volumes/Music"},
+		PathRule{From: `C:\Users\editor`, To: "/Users/editor"},
+		DraftRelativeRule("/srv/drafts/demo"),
+	)
+
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{"/srv/media/clips/a.mp4", `D:\media\clips\a.mp4`},
+		{"/srv/media", `D:\media`},
+		{"/srv/media2/a.mp4", "/srv/media2/a.mp4"},
+		{"/srv/media/music/b.mp3", "/Volumes/Music/b.mp3"},
+		{`c:\users\editor\Videos\c.mov`, "/Users/editor/Videos/c.mov"},
+		{"C:/Users/editor/d.mov", "/Users/editor/d.mov"},
+		{"/srv/drafts/demo/media/e.png", DraftPathPlaceholder + "/media/e.png"},
+		{"/other/f.mp4", "/other/f.mp4"},
+		{"", ""},
+	}
+	for _, tc := range testCases {
+		if result := mapper.Map(tc.path); result != tc.expected {
+			t.Errorf("Map(%q)期望%q，得到%q", tc.path, tc.expected, result)
+		}
+	}
+
+	// 盘符形式的目标路径使用正斜杠
+	mapper = NewPathMapper(PathRule{From: "/mnt/d", To: "D:"})
+	if result := mapper.Map("/mnt/d/media/a.mp4"); result != "D:/media/a.mp4" {
+		t.Errorf("期望D:/media/a.mp4，得到%q", result)
+	}
+
+	var nilMapper *PathMapper
+	if result := nilMapper.Map("/a.mp4"); result != "/a.mp4" {
+		t.Errorf("nil映射器应原样返回路径，得到%q", result)
+	}
+}
@@ -0,0 +1,2 @@
+// Package keyframe 提供关键帧及其可控制的属性，时间偏移均以微秒为单位
+package keyframe
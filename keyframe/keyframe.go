@@ -0,0 +1,66 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package keyframe
+
+import (
+	"github.com/zhangshican/go-capcut/internal/keyframe"
+)
+
+// Keyframe 一个关键帧（关键点），目前只支持线性插值
+// 对应Python的Keyframe类
+type Keyframe = keyframe.Keyframe
+
+// NewKeyframe 创建新的关键帧
+func NewKeyframe(timeOffset int64, value float64) *Keyframe {
+	return keyframe.NewKeyframe(timeOffset, value)
+}
+
+// KeyframeProperty 关键帧所控制的属性类型
+// 对应Python的Keyframe_property枚举
+type KeyframeProperty = keyframe.KeyframeProperty
+
+const (
+	// 位置相关
+	KeyframePropertyPositionX = keyframe.KeyframePropertyPositionX // 右移为正，此处的数值应该为`剪映中显示的值` / `草稿宽度`，也即单位是半个画布宽
+	KeyframePropertyPositionY = keyframe.KeyframePropertyPositionY // 上移为正，此处的数值应该为`剪映中显示的值` / `草稿高度`，也即单位是半个画布高
+	KeyframePropertyRotation  = keyframe.KeyframePropertyRotation  // 顺时针旋转的**角度**
+	// 缩放相关
+	KeyframePropertyScaleX       = keyframe.KeyframePropertyScaleX       // 单独控制X轴缩放比例(1.0为不缩放)，与`uniform_scale`互斥
+	KeyframePropertyScaleY       = keyframe.KeyframePropertyScaleY       // 单独控制Y轴缩放比例(1.0为不缩放)，与`uniform_scale`互斥
+	KeyframePropertyUniformScale = keyframe.KeyframePropertyUniformScale // 同时控制X轴及Y轴缩放比例(1.0为不缩放)，与`scale_x`和`scale_y`互斥
+	// 视觉效果相关
+	KeyframePropertyAlpha      = keyframe.KeyframePropertyAlpha      // 不透明度，1.0为完全不透明，仅对`Video_segment`有效
+	KeyframePropertySaturation = keyframe.KeyframePropertySaturation // 饱和度，0.0为原始饱和度，范围为-1.0到1.0，仅对`Video_segment`有效
+	KeyframePropertyContrast   = keyframe.KeyframePropertyContrast   // 对比度，0.0为原始对比度，范围为-1.0到1.0，仅对`Video_segment`有效
+	KeyframePropertyBrightness = keyframe.KeyframePropertyBrightness // 亮度，0.0为原始亮度，范围为-1.0到1.0，仅对`Video_segment`有效
+	// 音频相关
+	KeyframePropertyVolume = keyframe.KeyframePropertyVolume // 音量，1.0为原始音量，仅对`Audio_segment`和`Video_segment`有效
+)
+
+// KeyframePropertyFromString 从字符串创建关键帧属性
+func KeyframePropertyFromString(s string) (KeyframeProperty, error) {
+	return keyframe.KeyframePropertyFromString(s)
+}
+
+// KeyframeList 关键帧列表，记录与某个特定属性相关的一系列关键帧
+// 对应Python的Keyframe_list类
+type KeyframeList = keyframe.KeyframeList
+
+// NewKeyframeList 为给定的关键帧属性初始化关键帧列表
+func NewKeyframeList(keyframeProperty KeyframeProperty) *KeyframeList {
+	return keyframe.NewKeyframeList(keyframeProperty)
+}
+
+// ParseValue 解析字符串值为float64
+// 支持各种格式：百分比、角度、位置等
+func ParseValue(propertyType KeyframeProperty, value string) (float64, error) {
+	return keyframe.ParseValue(propertyType, value)
+}
+
+// KeyframeManager 关键帧管理器，管理所有属性的关键帧列表
+type KeyframeManager = keyframe.KeyframeManager
+
+// NewKeyframeManager 创建新的关键帧管理器
+func NewKeyframeManager() *KeyframeManager {
+	return keyframe.NewKeyframeManager()
+}
@@ -0,0 +1,156 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package capcut
+
+import (
+	"github.com/zhangshican/go-capcut/internal/material"
+)
+
+// CropSettings 素材的裁剪设置，各属性均在0-1之间，注意素材的坐标原点在左上角
+// 对应Python的Crop_settings类
+type CropSettings = material.CropSettings
+
+// NewCropSettings 创建新的裁剪设置，默认参数表示不裁剪
+func NewCropSettings() *CropSettings {
+	return material.NewCropSettings()
+}
+
+// NewCropSettingsWithParams 创建带参数的裁剪设置
+func NewCropSettingsWithParams(upperLeftX, upperLeftY, upperRightX, upperRightY, lowerLeftX, lowerLeftY, lowerRightX, lowerRightY float64) *CropSettings {
+	return material.NewCropSettingsWithParams(upperLeftX, upperLeftY, upperRightX, upperRightY, lowerLeftX, lowerLeftY, lowerRightX, lowerRightY)
+}
+
+// MaterialType 素材类型
+type MaterialType = material.MaterialType
+
+const (
+	MaterialTypeVideo = material.MaterialTypeVideo
+	MaterialTypePhoto = material.MaterialTypePhoto
+	MaterialTypeAudio = material.MaterialTypeAudio
+)
+
+// VideoMaterial 本地视频素材（视频或图片），一份素材可以在多个片段中使用
+// 对应Python的Video_material类
+type VideoMaterial = material.VideoMaterial
+
+// NewVideoMaterial 创建新的视频素材
+//
+// 本地文件未提供全部媒体信息时通过探测获取，探测器无法识别文件格式时使用默认值（30秒，1920x1080），
+// 读取文件失败时返回错误
+func NewVideoMaterial(materialType MaterialType, path, replacePath, materialName, remoteURL *string, cropSettings *CropSettings, duration *float64, width, height *int) (*VideoMaterial, error) {
+	return material.NewVideoMaterial(materialType, path, replacePath, materialName, remoteURL, cropSettings, duration, width, height)
+}
+
+// NewVideoMaterialFromDict 从字典创建视频素材对象
+func NewVideoMaterialFromDict(data map[string]interface{}) (*VideoMaterial, error) {
+	return material.NewVideoMaterialFromDict(data)
+}
+
+// AudioMaterial 本地音频素材
+// 对应Python的Audio_material类
+type AudioMaterial = material.AudioMaterial
+
+// NewAudioMaterial 创建新的音频素材
+//
+// 本地文件未提供时长时通过探测获取，探测器无法识别文件格式时使用默认值（3分钟），读取文件失败时返回错误
+func NewAudioMaterial(path, replacePath, materialName, remoteURL *string, duration *float64) (*AudioMaterial, error) {
+	return material.NewAudioMaterial(path, replacePath, materialName, remoteURL, duration)
+}
+
+// NewAudioMaterialFromDict 从字典创建音频素材对象
+func NewAudioMaterialFromDict(data map[string]interface{}) (*AudioMaterial, error) {
+	return material.NewAudioMaterialFromDict(data)
+}
+
+// MaterialInterface 素材接口，所有素材类型都应该实现
+type MaterialInterface = material.MaterialInterface
+
+// ErrAudioOnlyMaterial 文件只包含音频，不能作为视频或图片素材
+var (
+	ErrAudioOnlyMaterial = material.ErrAudioOnlyMaterial
+)
+
+// DetectMaterialType 根据文件内容判断视觉素材的类型
+//
+// 静态图片为MaterialTypePhoto，视频及GIF动图为MaterialTypeVideo；只包含音频的文件返回ErrAudioOnlyMaterial。
+// 全局探测器无法识别文件格式时，根据扩展名判断
+func DetectMaterialType(path string) (MaterialType, error) {
+	return material.DetectMaterialType(path)
+}
+
+// NewVisualMaterial 创建本地视觉素材，素材类型由DetectMaterialType根据文件内容自动判断，
+// 时长及宽高通过探测文件获取
+//
+// 仅凭扩展名识别的文件（如BMP、HEIC、AVI、MPEG-TS）无法探测媒体信息，使用与NewVideoMaterial相同的默认值，
+// 需要准确信息时请使用NewVideoMaterial并显式提供时长及宽高
+func NewVisualMaterial(path string, replacePath, materialName *string, cropSettings *CropSettings) (*VideoMaterial, error) {
+	return material.NewVisualMaterial(path, replacePath, materialName, cropSettings)
+}
+
+// Fingerprint 计算文件的内容指纹，用于判断两个路径是否指向相同的素材
+//
+// 指纹由文件大小及文件开头、中间、结尾各64KB内容的SHA-256哈希组成，不超过192KB的文件对全部内容计算哈希。
+// 内容相同的文件指纹相同，与文件名及路径无关
+func Fingerprint(path string) (string, error) {
+	return material.Fingerprint(path)
+}
+
+// CropAnchor 按宽高比裁剪时保留区域的对齐位置，X、Y均在0-1之间，(0, 0)为左上角，(1, 1)为右下角
+type CropAnchor = material.CropAnchor
+
+// 常用的裁剪对齐位置
+var (
+	CropAnchorCenter      = material.CropAnchorCenter
+	CropAnchorTop         = material.CropAnchorTop
+	CropAnchorBottom      = material.CropAnchorBottom
+	CropAnchorLeft        = material.CropAnchorLeft
+	CropAnchorRight       = material.CropAnchorRight
+	CropAnchorTopLeft     = material.CropAnchorTopLeft
+	CropAnchorTopRight    = material.CropAnchorTopRight
+	CropAnchorBottomLeft  = material.CropAnchorBottomLeft
+	CropAnchorBottomRight = material.CropAnchorBottomRight
+)
+
+// NewCropSettingsFromRect 创建保留矩形区域的裁剪设置，x、y为区域左上角，w、h为区域的宽和高，均以素材宽高为单位
+func NewCropSettingsFromRect(x, y, w, h float64) (*CropSettings, error) {
+	return material.NewCropSettingsFromRect(x, y, w, h)
+}
+
+// Fetcher 远程素材的获取器，可替换为自定义的实现以支持其他协议或鉴权方式
+type Fetcher = material.Fetcher
+
+// FetchResponse 获取器打开的资源
+type FetchResponse = material.FetchResponse
+
+// FetcherFunc 将函数适配为Fetcher
+type FetcherFunc = material.FetcherFunc
+
+// HTTPFetcher 通过HTTP(S)获取远程素材，使用Range请求续传
+//
+// 续传时以If-Range携带资源的ETag或Last-Modified，资源已被修改时服务器返回完整的内容；
+// 服务器返回的Content-Range与请求的位置不一致时从头下载
+type HTTPFetcher = material.HTTPFetcher
+
+// CacheConfig 远程素材缓存的配置
+type CacheConfig = material.CacheConfig
+
+// CacheOption 远程素材缓存的选项函数类型
+type CacheOption = material.CacheOption
+
+// WithFetcher 使用自定义的获取器下载远程素材，默认使用HTTPFetcher
+func WithFetcher(fetcher Fetcher) CacheOption {
+	return material.WithFetcher(fetcher)
+}
+
+// MaterialCache 远程素材的本地缓存
+//
+// 每个URL对应缓存目录中以其哈希命名的文件，已下载的URL不会重复下载。
+// 下载中断时已下载的部分保留在同名的.part文件中，资源的版本标识保存在.part.validator文件中，
+// 下次获取时确认资源未被修改后从中断处继续，否则从头下载。
+// 同一缓存目录不应被多个进程同时写入
+type MaterialCache = material.MaterialCache
+
+// NewMaterialCache 创建以dir为缓存目录的远程素材缓存，目录不存在时在首次下载时创建
+func NewMaterialCache(dir string, options ...CacheOption) *MaterialCache {
+	return material.NewMaterialCache(dir, options...)
+}
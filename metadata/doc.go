@@ -0,0 +1,3 @@
+// Package metadata 提供剪映/CapCut内置的特效、滤镜、转场、蒙版、动画、
+// 音频特效和字体元数据枚举，以及按名称查找的函数
+package metadata
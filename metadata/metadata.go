@@ -0,0 +1,751 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package metadata
+
+import (
+	"github.com/zhangshican/go-capcut/internal/metadata"
+)
+
+// EffectParam 特效参数信息
+// 对应Python的Effect_param类
+type EffectParam = metadata.EffectParam
+
+// NewEffectParam 创建新的特效参数
+func NewEffectParam(name string, defaultValue, minValue, maxValue float64) EffectParam {
+	return metadata.NewEffectParam(name, defaultValue, minValue, maxValue)
+}
+
+// EffectParamInstance 特效参数实例
+// 对应Python的Effect_param_instance类
+type EffectParamInstance = metadata.EffectParamInstance
+
+// NewEffectParamInstance 创建特效参数实例
+func NewEffectParamInstance(param EffectParam, index int, value float64) EffectParamInstance {
+	return metadata.NewEffectParamInstance(param, index, value)
+}
+
+// EffectMeta 特效元数据
+// 对应Python的Effect_meta类
+type EffectMeta = metadata.EffectMeta
+
+// NewEffectMeta 创建新的特效元数据
+func NewEffectMeta(name string, isVIP bool, resourceID, effectID, md5 string, params []EffectParam) EffectMeta {
+	return metadata.NewEffectMeta(name, isVIP, resourceID, effectID, md5, params)
+}
+
+// EffectEnumerable 特效枚举接口
+// 对应Python的Effect_enum基类功能
+type EffectEnumerable = metadata.EffectEnumerable
+
+// AnimationMetaProvider 动画元数据提供者接口
+// 用于 animation 包中的类型匹配
+type AnimationMetaProvider = metadata.AnimationMetaProvider
+
+// EffectEnum 特效枚举基础结构
+type EffectEnum = metadata.EffectEnum
+
+// NewEffectEnum 创建特效枚举项
+func NewEffectEnum(name string, meta interface{}) EffectEnum {
+	return metadata.NewEffectEnum(name, meta)
+}
+
+// FindEffectByName 根据名称查找特效，忽略大小写、空格和下划线
+// 对应Python Effect_enum.from_name方法
+func FindEffectByName(effects []EffectEnumerable, name string) (EffectEnumerable, error) {
+	return metadata.FindEffectByName(effects, name)
+}
+
+// EffectRegistry 特效注册表
+// 用于统一管理所有特效类型的注册和获取
+type EffectRegistry = metadata.EffectRegistry
+
+// NewEffectRegistry 创建新的特效注册表
+func NewEffectRegistry() *EffectRegistry {
+	return metadata.NewEffectRegistry()
+}
+
+// RegisterEffect 全局注册特效函数
+func RegisterEffect(category string, effect EffectEnumerable) EffectEnumerable {
+	return metadata.RegisterEffect(category, effect)
+}
+
+// GetAllEffects 获取指定分类的所有特效
+func GetAllEffects(category string) []EffectEnumerable {
+	return metadata.GetAllEffects(category)
+}
+
+// FindEffect 在指定分类中根据名称查找特效
+func FindEffect(category, name string) (EffectEnumerable, error) {
+	return metadata.FindEffect(category, name)
+}
+
+// AnimationMeta 动画元数据
+// 对应Python的Animation_meta类
+type AnimationMeta = metadata.AnimationMeta
+
+// NewAnimationMeta 创建新的动画元数据
+// duration参数单位为秒，会自动转换为微秒
+func NewAnimationMeta(title string, isVIP bool, duration float64, resourceID, effectID, md5 string) AnimationMeta {
+	return metadata.NewAnimationMeta(title, isVIP, duration, resourceID, effectID, md5)
+}
+
+// IntroType入场动画类型
+// 对应Python的Intro_type枚举
+type IntroType = metadata.IntroType
+
+// 剪映自带的视频/图片入场动画类型
+var (
+	//免费入场动画
+	IntroType缩小 = metadata.IntroType缩小
+	IntroType渐显 = metadata.IntroType渐显
+	IntroType放大 = metadata.IntroType放大
+)
+
+// OutroType 出场动画类型
+// 对应Python的Outro_type枚举
+type OutroType = metadata.OutroType
+
+// 剪映自带的视频/图片出场动画类型
+var (
+	OutroType缩小 = metadata.OutroType缩小
+)
+
+// GroupAnimationType 组合动画类型
+// 对应Python的Group_animation_type枚举
+type GroupAnimationType = metadata.GroupAnimationType
+
+// 剪映自带的组合动画类型
+var (
+	GroupAnimationType呼吸  = metadata.GroupAnimationType呼吸
+	GroupAnimationType三分割 = metadata.GroupAnimationType三分割
+)
+
+// TextIntro 文字入场动画类型
+// 对应Python的Text_intro枚举
+type TextIntro = metadata.TextIntro
+
+// 剪映自带的文字入场动画
+var (
+	TextIntro打字机     = metadata.TextIntro打字机
+	TextIntroType打字机 = metadata.TextIntroType打字机
+)
+
+// TextOutro 文字出场动画类型
+// 对应Python的Text_outro枚举
+type TextOutro = metadata.TextOutro
+
+// 剪映自带的文字出场动画
+var (
+	TextOutro逐字消失   = metadata.TextOutro逐字消失
+	TextOutroType渐隐 = metadata.TextOutroType渐隐
+)
+
+// TextLoopAnim 文字循环动画类型
+// 对应Python的Text_loop_anim枚举
+type TextLoopAnim = metadata.TextLoopAnim
+
+// 剪映自带的文字循环动画
+var (
+	TextLoopAnim闪烁     = metadata.TextLoopAnim闪烁
+	TextLoopAnimType跳动 = metadata.TextLoopAnimType跳动
+)
+
+// GetAllIntroTypes 获取所有入场动画类型
+func GetAllIntroTypes() []EffectEnumerable {
+	return metadata.GetAllIntroTypes()
+}
+
+// GetAllOutroTypes 获取所有出场动画类型
+func GetAllOutroTypes() []EffectEnumerable {
+	return metadata.GetAllOutroTypes()
+}
+
+// GetAllGroupAnimationTypes 获取所有组合动画类型
+func GetAllGroupAnimationTypes() []EffectEnumerable {
+	return metadata.GetAllGroupAnimationTypes()
+}
+
+// GetAllTextIntroTypes 获取所有文字入场动画类型
+func GetAllTextIntroTypes() []EffectEnumerable {
+	return metadata.GetAllTextIntroTypes()
+}
+
+// GetAllTextOutroTypes 获取所有文字出场动画类型
+func GetAllTextOutroTypes() []EffectEnumerable {
+	return metadata.GetAllTextOutroTypes()
+}
+
+// GetAllTextLoopAnimTypes 获取所有文字循环动画类型
+func GetAllTextLoopAnimTypes() []EffectEnumerable {
+	return metadata.GetAllTextLoopAnimTypes()
+}
+
+// FindIntroByName 根据名称查找入场动画
+func FindIntroByName(name string) (EffectEnumerable, error) {
+	return metadata.FindIntroByName(name)
+}
+
+// FindOutroByName 根据名称查找出场动画
+func FindOutroByName(name string) (EffectEnumerable, error) {
+	return metadata.FindOutroByName(name)
+}
+
+// FindGroupAnimationByName 根据名称查找组合动画
+func FindGroupAnimationByName(name string) (EffectEnumerable, error) {
+	return metadata.FindGroupAnimationByName(name)
+}
+
+// FindTextIntroByName 根据名称查找文字入场动画
+func FindTextIntroByName(name string) (EffectEnumerable, error) {
+	return metadata.FindTextIntroByName(name)
+}
+
+// FindTextOutroByName 根据名称查找文字出场动画
+func FindTextOutroByName(name string) (EffectEnumerable, error) {
+	return metadata.FindTextOutroByName(name)
+}
+
+// FindTextLoopAnimByName 根据名称查找文字循环动画
+func FindTextLoopAnimByName(name string) (EffectEnumerable, error) {
+	return metadata.FindTextLoopAnimByName(name)
+}
+
+// FindIntroTypeByName 根据名称查找入场动画类型（别名函数）
+func FindIntroTypeByName(name string) (EffectEnumerable, error) {
+	return metadata.FindIntroTypeByName(name)
+}
+
+// FindTextIntroTypeByName 根据名称查找文字入场动画类型（别名函数）
+func FindTextIntroTypeByName(name string) (EffectEnumerable, error) {
+	return metadata.FindTextIntroTypeByName(name)
+}
+
+// FindTextOutroTypeByName 根据名称查找文字出场动画类型（别名函数）
+func FindTextOutroTypeByName(name string) (EffectEnumerable, error) {
+	return metadata.FindTextOutroTypeByName(name)
+}
+
+// FindTextLoopAnimTypeByName 根据名称查找文字循环动画类型（别名函数）
+func FindTextLoopAnimTypeByName(name string) (EffectEnumerable, error) {
+	return metadata.FindTextLoopAnimTypeByName(name)
+}
+
+// AudioEffectMeta 音频特效元数据
+// 对应Python的Audio_effect_meta类（虽然Python中没有明确定义，但隐含存在）
+type AudioEffectMeta = metadata.AudioEffectMeta
+
+// NewAudioEffectMeta 创建新的音频特效元数据
+func NewAudioEffectMeta(name string, isVIP bool, resourceID, effectID, md5, category, description string, params []EffectParam) AudioEffectMeta {
+	return metadata.NewAudioEffectMeta(name, isVIP, resourceID, effectID, md5, category, description, params)
+}
+
+// AudioSceneEffectType 音频场景特效类型
+// 对应Python的Audio_scene_effect_type枚举
+type AudioSceneEffectType = metadata.AudioSceneEffectType
+
+// 剪映自带的音频场景特效类型
+var (
+	// === 环境音效 ===
+	AudioSceneEffectType雨声 = metadata.AudioSceneEffectType雨声
+)
+
+// ToneEffectType 音调特效类型
+// 对应Python的Tone_effect_type枚举
+type ToneEffectType = metadata.ToneEffectType
+
+// 音调调节特效类型
+var (
+	// === 基础音调调节 ===
+	ToneEffectType升调 = metadata.ToneEffectType升调
+)
+
+// SpeechToSongType 语音转歌声特效类型
+// 对应Python的Speech_to_song_type枚举
+type SpeechToSongType = metadata.SpeechToSongType
+
+// 语音转歌声特效类型
+var (
+	SpeechToSongType流行 = metadata.SpeechToSongType流行
+)
+
+// GetAllAudioSceneEffectTypes 获取所有音频场景特效类型
+func GetAllAudioSceneEffectTypes() []EffectEnumerable {
+	return metadata.GetAllAudioSceneEffectTypes()
+}
+
+// GetAllToneEffectTypes 获取所有音调特效类型
+func GetAllToneEffectTypes() []EffectEnumerable {
+	return metadata.GetAllToneEffectTypes()
+}
+
+// GetAllSpeechToSongTypes 获取所有语音转歌声类型
+func GetAllSpeechToSongTypes() []EffectEnumerable {
+	return metadata.GetAllSpeechToSongTypes()
+}
+
+// GetAudioEffectsByCategory 根据分类获取音频特效
+func GetAudioEffectsByCategory(category string) []EffectEnumerable {
+	return metadata.GetAudioEffectsByCategory(category)
+}
+
+// GetAllAudioEffectCategories 获取所有音频特效分类
+func GetAllAudioEffectCategories() []string {
+	return metadata.GetAllAudioEffectCategories()
+}
+
+// FindAudioSceneEffectByName 根据名称查找音频场景特效
+func FindAudioSceneEffectByName(name string) (EffectEnumerable, error) {
+	return metadata.FindAudioSceneEffectByName(name)
+}
+
+// FindToneEffectByName 根据名称查找音调特效
+func FindToneEffectByName(name string) (EffectEnumerable, error) {
+	return metadata.FindToneEffectByName(name)
+}
+
+// FindSpeechToSongByName 根据名称查找语音转歌声特效
+func FindSpeechToSongByName(name string) (EffectEnumerable, error) {
+	return metadata.FindSpeechToSongByName(name)
+}
+
+// CapCutIntroType CapCut特有入场动画类型
+// 对应Python的CapCut_Intro_type枚举
+type CapCutIntroType = metadata.CapCutIntroType
+
+// CapCut特有的高级入场动画类型
+var (
+	// 剪映自带的画面特效类型
+	CapCutIntroType_1998  = metadata.CapCutIntroType_1998
+	CapCutIntroTypeFadeIn = metadata.CapCutIntroTypeFadeIn
+)
+
+// CapCutOutroType CapCut特有出场动画类型
+// 对应Python的CapCut_Outro_type枚举
+type CapCutOutroType = metadata.CapCutOutroType
+
+// CapCut特有的高级出场动画类型
+var (
+	// AI智能动画
+	CapCutOutroTypeAI人物消散 = metadata.CapCutOutroTypeAI人物消散
+)
+
+// CapCutGroupAnimationType CapCut特有组合动画类型
+// 对应Python的CapCut_Group_animation_type枚举
+type CapCutGroupAnimationType = metadata.CapCutGroupAnimationType
+
+// CapCut特有的高级组合动画类型
+var (
+	// AI驱动动画
+	CapCutGroupAnimationTypeAI节拍同步   = metadata.CapCutGroupAnimationTypeAI节拍同步
+	CapCutGroupAnimationTypeRotation = metadata.CapCutGroupAnimationTypeRotation
+)
+
+// CapCutTextIntro CapCut特有文字入场动画类型
+// 对应Python的CapCut_Text_intro枚举
+type CapCutTextIntro = metadata.CapCutTextIntro
+
+// CapCut特有的高级文字入场动画
+var (
+	// AI文字动画
+	CapCutTextIntroAI智能排版         = metadata.CapCutTextIntroAI智能排版
+	CapCutTextIntroTypeTypewriter = metadata.CapCutTextIntroTypeTypewriter
+)
+
+// CapCutTextOutro CapCut特有文字出场动画类型
+// 对应Python的CapCut_Text_outro枚举
+type CapCutTextOutro = metadata.CapCutTextOutro
+
+// CapCut特有的高级文字出场动画
+var (
+	// AI文字动画
+	CapCutTextOutroAI智能消散 = metadata.CapCutTextOutroAI智能消散
+)
+
+// CapCutTextLoopAnim CapCut特有文字循环动画类型
+// 对应Python的CapCut_Text_loop_anim枚举
+type CapCutTextLoopAnim = metadata.CapCutTextLoopAnim
+
+// CapCut特有的高级文字循环动画
+var (
+	// AI文字动画
+	CapCutTextLoopAnimAI节拍跟随 = metadata.CapCutTextLoopAnimAI节拍跟随
+)
+
+// GetAllCapCutIntroTypes 获取所有CapCut入场动画类型
+func GetAllCapCutIntroTypes() []EffectEnumerable {
+	return metadata.GetAllCapCutIntroTypes()
+}
+
+// GetAllCapCutOutroTypes 获取所有CapCut出场动画类型
+func GetAllCapCutOutroTypes() []EffectEnumerable {
+	return metadata.GetAllCapCutOutroTypes()
+}
+
+// GetAllCapCutGroupAnimationTypes 获取所有CapCut组合动画类型
+func GetAllCapCutGroupAnimationTypes() []EffectEnumerable {
+	return metadata.GetAllCapCutGroupAnimationTypes()
+}
+
+// GetAllCapCutTextIntroTypes 获取所有CapCut文字入场动画类型
+func GetAllCapCutTextIntroTypes() []EffectEnumerable {
+	return metadata.GetAllCapCutTextIntroTypes()
+}
+
+// GetAllCapCutTextOutroTypes 获取所有CapCut文字出场动画类型
+func GetAllCapCutTextOutroTypes() []EffectEnumerable {
+	return metadata.GetAllCapCutTextOutroTypes()
+}
+
+// GetAllCapCutTextLoopAnimTypes 获取所有CapCut文字循环动画类型
+func GetAllCapCutTextLoopAnimTypes() []EffectEnumerable {
+	return metadata.GetAllCapCutTextLoopAnimTypes()
+}
+
+// FindCapCutIntroByName 根据名称查找CapCut入场动画
+func FindCapCutIntroByName(name string) (EffectEnumerable, error) {
+	return metadata.FindCapCutIntroByName(name)
+}
+
+// FindCapCutOutroByName 根据名称查找CapCut出场动画
+func FindCapCutOutroByName(name string) (EffectEnumerable, error) {
+	return metadata.FindCapCutOutroByName(name)
+}
+
+// FindCapCutGroupAnimationByName 根据名称查找CapCut组合动画
+func FindCapCutGroupAnimationByName(name string) (EffectEnumerable, error) {
+	return metadata.FindCapCutGroupAnimationByName(name)
+}
+
+// FindCapCutTextIntroByName 根据名称查找CapCut文字入场动画
+func FindCapCutTextIntroByName(name string) (EffectEnumerable, error) {
+	return metadata.FindCapCutTextIntroByName(name)
+}
+
+// FindCapCutTextOutroByName 根据名称查找CapCut文字出场动画
+func FindCapCutTextOutroByName(name string) (EffectEnumerable, error) {
+	return metadata.FindCapCutTextOutroByName(name)
+}
+
+// FindCapCutTextLoopAnimByName 根据名称查找CapCut文字循环动画
+func FindCapCutTextLoopAnimByName(name string) (EffectEnumerable, error) {
+	return metadata.FindCapCutTextLoopAnimByName(name)
+}
+
+// FindCapCutIntroTypeByName 根据名称查找CapCut入场动画类型（别名函数）
+func FindCapCutIntroTypeByName(name string) (EffectEnumerable, error) {
+	return metadata.FindCapCutIntroTypeByName(name)
+}
+
+// CapCutVoiceFiltersEffectType CapCut语音滤镜特效类型
+// 对应Python的CapCut_Voice_filters_effect_type枚举
+type CapCutVoiceFiltersEffectType = metadata.CapCutVoiceFiltersEffectType
+
+// CapCut特有的语音滤镜特效类型
+var (
+	// AI智能语音处理
+	CapCutVoiceFiltersEffectTypeAI降噪 = metadata.CapCutVoiceFiltersEffectTypeAI降噪
+)
+
+// CapCutVoiceCharactersEffectType CapCut语音角色特效类型
+// 对应Python的CapCut_Voice_characters_effect_type枚举
+type CapCutVoiceCharactersEffectType = metadata.CapCutVoiceCharactersEffectType
+
+// CapCut特有的语音角色特效类型
+var (
+	// AI智能角色声音
+	CapCutVoiceCharactersEffectTypeAI小萝莉 = metadata.CapCutVoiceCharactersEffectTypeAI小萝莉
+)
+
+// CapCutSpeechToSongEffectType CapCut语音转歌声特效类型
+// 对应Python的CapCut_Speech_to_song_effect_type枚举
+type CapCutSpeechToSongEffectType = metadata.CapCutSpeechToSongEffectType
+
+// CapCut特有的语音转歌声特效类型
+var (
+	// AI智能转换
+	CapCutSpeechToSongEffectTypeAI流行风 = metadata.CapCutSpeechToSongEffectTypeAI流行风
+)
+
+// GetAllCapCutVoiceFiltersEffectTypes 获取所有CapCut语音滤镜特效类型
+func GetAllCapCutVoiceFiltersEffectTypes() []EffectEnumerable {
+	return metadata.GetAllCapCutVoiceFiltersEffectTypes()
+}
+
+// GetAllCapCutVoiceCharactersEffectTypes 获取所有CapCut语音角色特效类型
+func GetAllCapCutVoiceCharactersEffectTypes() []EffectEnumerable {
+	return metadata.GetAllCapCutVoiceCharactersEffectTypes()
+}
+
+// GetAllCapCutSpeechToSongEffectTypes 获取所有CapCut语音转歌声特效类型
+func GetAllCapCutSpeechToSongEffectTypes() []EffectEnumerable {
+	return metadata.GetAllCapCutSpeechToSongEffectTypes()
+}
+
+// GetCapCutAudioEffectsByCategory 根据分类获取CapCut音频特效
+func GetCapCutAudioEffectsByCategory(category string) []EffectEnumerable {
+	return metadata.GetCapCutAudioEffectsByCategory(category)
+}
+
+// GetAllCapCutAudioEffectCategories 获取所有CapCut音频特效分类
+func GetAllCapCutAudioEffectCategories() []string {
+	return metadata.GetAllCapCutAudioEffectCategories()
+}
+
+// FindCapCutVoiceFilterByName 根据名称查找CapCut语音滤镜特效
+func FindCapCutVoiceFilterByName(name string) (EffectEnumerable, error) {
+	return metadata.FindCapCutVoiceFilterByName(name)
+}
+
+// FindCapCutVoiceCharacterByName 根据名称查找CapCut语音角色特效
+func FindCapCutVoiceCharacterByName(name string) (EffectEnumerable, error) {
+	return metadata.FindCapCutVoiceCharacterByName(name)
+}
+
+// FindCapCutSpeechToSongByName 根据名称查找CapCut语音转歌声特效
+func FindCapCutSpeechToSongByName(name string) (EffectEnumerable, error) {
+	return metadata.FindCapCutSpeechToSongByName(name)
+}
+
+// FilterMeta 滤镜元数据
+// 对应Python的Filter_meta类（虽然Python中没有明确定义，但隐含存在）
+type FilterMeta = metadata.FilterMeta
+
+// NewFilterMeta 创建新的滤镜元数据
+func NewFilterMeta(name string, isVIP bool, resourceID, effectID, md5, category, description string, intensity float64) FilterMeta {
+	return metadata.NewFilterMeta(name, isVIP, resourceID, effectID, md5, category, description, intensity)
+}
+
+// FilterType 滤镜类型枚举
+// 对应Python的Filter_type枚举
+type FilterType = metadata.FilterType
+
+// 剪映自带的滤镜类型 - 按分类组织
+var (
+	// === 人像滤镜 ===
+	FilterType自然 = metadata.FilterType自然
+)
+
+// GetAllFilterTypes 获取所有滤镜类型
+func GetAllFilterTypes() []EffectEnumerable {
+	return metadata.GetAllFilterTypes()
+}
+
+// GetFiltersByCategory 根据分类获取滤镜类型
+func GetFiltersByCategory(category string) []EffectEnumerable {
+	return metadata.GetFiltersByCategory(category)
+}
+
+// GetAllFilterCategories 获取所有滤镜分类
+func GetAllFilterCategories() []string {
+	return metadata.GetAllFilterCategories()
+}
+
+// FindFilterByName 根据名称查找滤镜类型
+func FindFilterByName(name string) (EffectEnumerable, error) {
+	return metadata.FindFilterByName(name)
+}
+
+// FontMeta 字体元数据
+// 对应Python的Font_meta类（虽然Python中没有明确定义，但隐含存在）
+type FontMeta = metadata.FontMeta
+
+// NewFontMeta 创建新的字体元数据
+func NewFontMeta(name string, isVIP bool, resourceID, fontFamily, fontWeight, fontStyle, category, description, previewText string, language []string) FontMeta {
+	return metadata.NewFontMeta(name, isVIP, resourceID, fontFamily, fontWeight, fontStyle, category, description, previewText, language)
+}
+
+// FontType 字体类型枚举
+// 对应Python的Font_type枚举
+type FontType = metadata.FontType
+
+// 剪映自带的字体类型 - 按分类组织
+var (
+	// === 系统字体 ===
+	FontType默认 = metadata.FontType默认
+)
+
+// GetAllFontTypes 获取所有字体类型
+func GetAllFontTypes() []EffectEnumerable {
+	return metadata.GetAllFontTypes()
+}
+
+// GetFontsByCategory 根据分类获取字体类型
+func GetFontsByCategory(category string) []EffectEnumerable {
+	return metadata.GetFontsByCategory(category)
+}
+
+// GetFontsByLanguage 根据语言获取字体类型
+func GetFontsByLanguage(language string) []EffectEnumerable {
+	return metadata.GetFontsByLanguage(language)
+}
+
+// GetAllFontCategories 获取所有字体分类
+func GetAllFontCategories() []string {
+	return metadata.GetAllFontCategories()
+}
+
+// GetSupportedLanguages 获取所有支持的语言
+func GetSupportedLanguages() []string {
+	return metadata.GetSupportedLanguages()
+}
+
+// FindFontByName 根据名称查找字体类型
+func FindFontByName(name string) (EffectEnumerable, error) {
+	return metadata.FindFontByName(name)
+}
+
+// MaskMeta 蒙版元数据
+// 对应Python的Mask_meta类
+type MaskMeta = metadata.MaskMeta
+
+// NewMaskMeta 创建新的蒙版元数据
+func NewMaskMeta(name, resourceType, resourceID, effectID, md5 string, defaultAspectRatio float64) MaskMeta {
+	return metadata.NewMaskMeta(name, resourceType, resourceID, effectID, md5, defaultAspectRatio)
+}
+
+// MaskType 蒙版类型枚举
+// 对应Python的Mask_type枚举
+type MaskType = metadata.MaskType
+
+// 剪映自带的蒙版类型
+var (
+	// 基础几何形状蒙版
+	MaskType圆形 = metadata.MaskType圆形
+)
+
+// GetAllMaskTypes 获取所有蒙版类型
+func GetAllMaskTypes() []EffectEnumerable {
+	return metadata.GetAllMaskTypes()
+}
+
+// CapCutMaskType CapCut特有蒙版类型
+// 对应Python的CapCut_Mask_type枚举
+type CapCutMaskType = metadata.CapCutMaskType
+
+// CapCut特有的高级蒙版类型
+var (
+	// AI智能蒙版
+	CapCutMaskTypeAI人物 = metadata.CapCutMaskTypeAI人物
+)
+
+// GetAllCapCutMaskTypes 获取所有CapCut蒙版类型
+func GetAllCapCutMaskTypes() []EffectEnumerable {
+	return metadata.GetAllCapCutMaskTypes()
+}
+
+// FindMaskByName 根据名称查找蒙版类型
+func FindMaskByName(name string) (EffectEnumerable, error) {
+	return metadata.FindMaskByName(name)
+}
+
+// StickerMeta 贴纸元数据
+type StickerMeta = metadata.StickerMeta
+
+// NewStickerMeta 创建新的贴纸元数据
+func NewStickerMeta(name, resourceID string) StickerMeta {
+	return metadata.NewStickerMeta(name, resourceID)
+}
+
+// StickerType 贴纸类型枚举
+type StickerType = metadata.StickerType
+
+// RegisterSticker 注册贴纸，注册后可通过FindStickerByName按名称查找
+//
+// 贴纸的资源ID可在剪映中使用该贴纸后，通过ScriptFile的InspectMaterial方法获取
+func RegisterSticker(name, resourceID string) StickerType {
+	return metadata.RegisterSticker(name, resourceID)
+}
+
+// GetAllStickerTypes 获取所有已注册的贴纸
+func GetAllStickerTypes() []EffectEnumerable {
+	return metadata.GetAllStickerTypes()
+}
+
+// FindStickerByName 根据名称查找已注册的贴纸，忽略大小写、空格和下划线
+func FindStickerByName(name string) (EffectEnumerable, error) {
+	return metadata.FindStickerByName(name)
+}
+
+// TransitionMeta 转场元数据
+// 对应Python的Transition_meta类
+type TransitionMeta = metadata.TransitionMeta
+
+// NewTransitionMeta 创建新的转场元数据
+// duration参数单位为秒，会自动转换为微秒
+func NewTransitionMeta(name string, isVIP bool, resourceID, effectID, md5 string, duration float64, isOverlap bool) TransitionMeta {
+	return metadata.NewTransitionMeta(name, isVIP, resourceID, effectID, md5, duration, isOverlap)
+}
+
+// TransitionType 转场类型枚举
+// 对应Python的Transition_type枚举
+type TransitionType = metadata.TransitionType
+
+// 剪映自带的转场类型 - 免费转场
+var (
+	// 基础转场
+	TransitionType淡入淡出 = metadata.TransitionType淡入淡出
+)
+
+// GetAllTransitionTypes 获取所有转场类型
+func GetAllTransitionTypes() []EffectEnumerable {
+	return metadata.GetAllTransitionTypes()
+}
+
+// CapCutTransitionType CapCut特有转场类型
+// 对应Python的CapCut_Transition_type枚举
+type CapCutTransitionType = metadata.CapCutTransitionType
+
+// CapCut特有的高级转场类型
+var (
+	// AI智能转场
+	CapCutTransitionTypeAI场景识别 = metadata.CapCutTransitionTypeAI场景识别
+)
+
+// GetAllCapCutTransitionTypes 获取所有CapCut转场类型
+func GetAllCapCutTransitionTypes() []EffectEnumerable {
+	return metadata.GetAllCapCutTransitionTypes()
+}
+
+// FindTransitionByName 根据名称查找转场类型
+func FindTransitionByName(name string) (EffectEnumerable, error) {
+	return metadata.FindTransitionByName(name)
+}
+
+// VideoSceneEffectType 视频场景效果类型
+// 对应Python的CapCut_Intro_type枚举
+type VideoSceneEffectType = metadata.VideoSceneEffectType
+
+// 记录剪映自带的视频特效
+var (
+	// 剪映自带的画面特效类型
+	CapCutIntroTypeAI人物识别 = metadata.CapCutIntroTypeAI人物识别
+)
+
+// VideoCharacterEffectType 视频角色效果类型
+// 对应Python的CapCut_Outro_type枚举
+type VideoCharacterEffectType = metadata.VideoCharacterEffectType
+
+var (
+	// 剪映自带的画面特效类型
+	CapCutIntroTypeAI人物识别1 = metadata.CapCutIntroTypeAI人物识别1
+)
+
+// GetAllVideoSceneEffectType 视频场景效果类型
+func GetAllVideoSceneEffectType() []EffectEnumerable {
+	return metadata.GetAllVideoSceneEffectType()
+}
+
+// GetAllVideoCharacterEffectType 视频角色效果类型
+func GetAllVideoCharacterEffectType() []EffectEnumerable {
+	return metadata.GetAllVideoCharacterEffectType()
+}
+
+// FindVideoSceneEffectByName 根据名称查找频场景效果
+func FindVideoSceneEffectByName(name string) (EffectEnumerable, error) {
+	return metadata.FindVideoSceneEffectByName(name)
+}
+
+// FindVideoCharacterEffectByName 根据名称查找视频角色效果类型
+func FindVideoCharacterEffectByName(name string) (EffectEnumerable, error) {
+	return metadata.FindVideoCharacterEffectByName(name)
+}
@@ -0,0 +1,259 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package capcut
+
+import (
+	"github.com/zhangshican/go-capcut/internal/script"
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"io"
+)
+
+// ScriptMaterial 草稿文件中的素材信息部分
+// 对应Python的Script_material类
+type ScriptMaterial = script.ScriptMaterial
+
+// NewScriptMaterial 创建新的草稿素材管理器
+func NewScriptMaterial() *ScriptMaterial {
+	return script.NewScriptMaterial()
+}
+
+// ScriptFile 剪映草稿文件，大部分接口定义在此
+// 对应Python的Script_file类
+type ScriptFile = script.ScriptFile
+
+const (
+	TemplateFile = script.TemplateFile
+)
+
+// NewScriptFile 创建一个剪映草稿
+func NewScriptFile(width, height int, fps ...int) (*ScriptFile, error) {
+	return script.NewScriptFile(width, height, fps...)
+}
+
+// LoadTemplate 从JSON文件加载草稿模板
+// 对应Python的load_template静态方法
+func LoadTemplate(jsonPath string) (*ScriptFile, error) {
+	return script.LoadTemplate(jsonPath)
+}
+
+// TrackConfig 轨道配置
+type TrackConfig = script.TrackConfig
+
+// TrackOption 轨道选项函数类型
+type TrackOption = script.TrackOption
+
+// WithMute 设置轨道静音
+func WithMute(mute bool) TrackOption {
+	return script.WithMute(mute)
+}
+
+// WithRelativeIndex 设置相对图层位置
+func WithRelativeIndex(index int) TrackOption {
+	return script.WithRelativeIndex(index)
+}
+
+// WithAbsoluteIndex 设置绝对图层位置
+func WithAbsoluteIndex(index int) TrackOption {
+	return script.WithAbsoluteIndex(index)
+}
+
+// SegmentConfig 添加片段的配置
+type SegmentConfig = script.SegmentConfig
+
+// SegmentOption 添加片段的选项函数类型
+type SegmentOption = script.SegmentOption
+
+// WithOverlapPolicy 设置片段与轨道上已有片段重叠时的处理方式
+//
+// track.OverlapSpill将片段放到第一条在其时间范围内空闲的同类型轨道上，均被占用时新建一条，见AddSegmentToFreeTrack
+func WithOverlapPolicy(policy OverlapPolicy) SegmentOption {
+	return script.WithOverlapPolicy(policy)
+}
+
+// SRTCue SRT文件中的一条字幕
+type SRTCue = script.SRTCue
+
+// SRTCueError SRT文件中格式错误的字幕块
+type SRTCueError = script.SRTCueError
+
+// SRTParseError 解析SRT文件时发现的格式错误
+type SRTParseError = script.SRTParseError
+
+// ParseSRT 解析SRT格式的字幕
+//
+// 支持UTF-8 BOM、CRLF换行及多行字幕；格式错误的字幕块会被跳过，
+// 并通过*SRTParseError一并报告，此时返回的字幕列表仍包含所有格式正确的字幕
+func ParseSRT(r io.Reader) ([]*SRTCue, error) {
+	return script.ParseSRT(r)
+}
+
+// SRTConfig 导入SRT字幕的配置
+type SRTConfig = script.SRTConfig
+
+// SRTOption 导入SRT字幕的选项函数类型
+type SRTOption = script.SRTOption
+
+// WithSRTTimeOffset 设置字幕的时间偏移量，单位为微秒
+func WithSRTTimeOffset(offset int64) SRTOption {
+	return script.WithSRTTimeOffset(offset)
+}
+
+// WithSRTStyleReference 以指定的文本片段为样式参考创建字幕
+func WithSRTStyleReference(reference *segment.TextSegment) SRTOption {
+	return script.WithSRTStyleReference(reference)
+}
+
+// WithSRTTextStyle 设置字幕的文本样式
+func WithSRTTextStyle(style *segment.TextStyle) SRTOption {
+	return script.WithSRTTextStyle(style)
+}
+
+// WithSRTClipSettings 设置字幕的图像调节设置，指定样式参考时会覆盖参考片段的设置
+func WithSRTClipSettings(clipSettings *segment.ClipSettings) SRTOption {
+	return script.WithSRTClipSettings(clipSettings)
+}
+
+// WithSRTSkipMalformed 设置是否跳过格式错误的字幕
+func WithSRTSkipMalformed(skip bool) SRTOption {
+	return script.WithSRTSkipMalformed(skip)
+}
+
+// ValidationSeverity 校验问题的严重程度
+type ValidationSeverity = script.ValidationSeverity
+
+const (
+	SeverityWarning = script.SeverityWarning // 警告，草稿能够打开，但效果可能与预期不符
+	SeverityError   = script.SeverityError   // 错误，草稿可能无法被剪映正确打开
+)
+
+// ValidationProblem 草稿校验发现的一个问题
+//
+// Err为*util.ValidationError、*util.MaterialNotFoundError或*util.SegmentOverlapError
+type ValidationProblem = script.ValidationProblem
+
+// InvalidDraftError 草稿未通过校验，导出被拒绝
+type InvalidDraftError = script.InvalidDraftError
+
+// DumpConfig 导出草稿的配置
+type DumpConfig = script.DumpConfig
+
+// DumpOption 导出草稿的选项函数类型
+type DumpOption = script.DumpOption
+
+// WithValidation 导出前校验草稿，存在严重程度不低于minSeverity的问题时拒绝导出并返回*InvalidDraftError
+func WithValidation(minSeverity ValidationSeverity) DumpOption {
+	return script.WithValidation(minSeverity)
+}
+
+// RelinkConfig 重新链接素材的配置
+type RelinkConfig = script.RelinkConfig
+
+// RelinkOption 重新链接素材的选项函数类型
+type RelinkOption = script.RelinkOption
+
+// WithHashCheck 比较同名候选文件的内容指纹，而不只是文件大小，素材记录了内容指纹时只接受指纹相同的候选文件
+func WithHashCheck() RelinkOption {
+	return script.WithHashCheck()
+}
+
+// WithDryRun 只生成报告，不修改素材路径
+func WithDryRun() RelinkOption {
+	return script.WithDryRun()
+}
+
+// RelinkEntry 一个素材的重新链接结果
+type RelinkEntry = script.RelinkEntry
+
+// RelinkReport 重新链接素材的报告，各列表按素材在草稿中的顺序排列
+type RelinkReport = script.RelinkReport
+
+// WithPathMapping 导出时按规则改写音视频素材的路径，草稿中的素材路径本身保持不变
+//
+// 用于在一个系统上生成供另一个系统上的剪映打开的草稿，如将"/srv/media"映射为`D:\media`，
+// 或使用DraftRelativeRule的规则将草稿文件夹中的素材改写为草稿相对路径。可多次使用，规则会累加
+func WithPathMapping(rules ...PathRule) DumpOption {
+	return script.WithPathMapping(rules...)
+}
+
+// FitMode 素材放入画布的方式
+type FitMode = script.FitMode
+
+const (
+	FitModeFit     = script.FitModeFit     // 等比缩放至完整显示在画布内，画布可能留有空白
+	FitModeFill    = script.FitModeFill    // 将素材裁剪为画布的宽高比后铺满画布
+	FitModeStretch = script.FitModeStretch // 不保持宽高比，将素材拉伸至铺满画布
+)
+
+// LoudnessConfig 统一响度的配置
+type LoudnessConfig = script.LoudnessConfig
+
+// LoudnessOption 统一响度的选项函数类型
+type LoudnessOption = script.LoudnessOption
+
+// WithPeakCeiling 限制调整后的采样峰值不超过ceiling(dBFS)，必要时片段达不到目标响度
+func WithPeakCeiling(ceiling float64) LoudnessOption {
+	return script.WithPeakCeiling(ceiling)
+}
+
+// WithMaxGain 限制最大增益为gain(dB)，默认为20dB，即剪映允许的最大音量
+func WithMaxGain(gain float64) LoudnessOption {
+	return script.WithMaxGain(gain)
+}
+
+// LoudnessEntry 一个片段的响度统一结果
+type LoudnessEntry = script.LoudnessEntry
+
+// LoudnessReport 统一响度的报告，各列表按轨道的导出顺序及片段在轨道中的顺序排列
+type LoudnessReport = script.LoudnessReport
+
+// RippleConfig 波纹编辑的配置
+type RippleConfig = script.RippleConfig
+
+// RippleOption 波纹编辑的选项函数类型
+type RippleOption = script.RippleOption
+
+// WithRippleTracks 只对指定名称的轨道进行波纹编辑，其余轨道保持不变
+func WithRippleTracks(names ...string) RippleOption {
+	return script.WithRippleTracks(names...)
+}
+
+// WithRipplePolicy 设置跨越编辑点的片段的处理方式
+func WithRipplePolicy(policy RipplePolicy) RippleOption {
+	return script.WithRipplePolicy(policy)
+}
+
+// TrackGap 轨道上的一个空隙
+type TrackGap = script.TrackGap
+
+// GapConfig 检测及填补空隙的配置
+type GapConfig = script.GapConfig
+
+// GapOption 检测及填补空隙的选项函数类型
+type GapOption = script.GapOption
+
+// WithMinGap 忽略时长小于minDuration(微秒)的空隙
+func WithMinGap(minDuration int64) GapOption {
+	return script.WithMinGap(minDuration)
+}
+
+// WithGapTracks 只处理指定名称的轨道
+func WithGapTracks(names ...string) GapOption {
+	return script.WithGapTracks(names...)
+}
+
+// WithGapRipple 删除空隙时按options对空隙所在轨道及其他轨道一同进行波纹编辑，
+// 例如WithGapRipple(WithRippleTracks("audio"))使音频轨道与视频轨道保持同步
+func WithGapRipple(options ...RippleOption) GapOption {
+	return script.WithGapRipple(options...)
+}
+
+// WithPlaceholderColor 使用mat作为占位片段的素材，并以纯色color(如"#000000")填充背景
+func WithPlaceholderColor(mat *VideoMaterial, color string) GapOption {
+	return script.WithPlaceholderColor(mat, color)
+}
+
+// WithPlaceholderBlur 使用mat作为占位片段的素材，并以模糊程度为blur的模糊画面填充背景，
+// blur取0.0625、0.375、0.75或1.0，分别对应剪映中的四档模糊
+func WithPlaceholderBlur(mat *VideoMaterial, blur float64) GapOption {
+	return script.WithPlaceholderBlur(mat, blur)
+}
@@ -0,0 +1,5 @@
+// Package segment 提供视频、音频、文本、特效和滤镜片段，以及蒙版、转场、
+// 背景填充、文本样式等附属效果的构造函数
+//
+// 片段通过capcut.ScriptFile.AddSegment添加到草稿中，相关素材会被自动加入草稿
+package segment
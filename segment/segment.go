@@ -0,0 +1,347 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package segment
+
+import (
+	"github.com/zhangshican/go-capcut"
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/metadata"
+)
+
+// SegmentInterface 片段接口，所有片段类型都需要实现此接口
+type SegmentInterface = segment.SegmentInterface
+
+// BaseSegment 片段基类
+// 对应Python的Base_segment类
+type BaseSegment = segment.BaseSegment
+
+// NewBaseSegment 创建基础片段
+func NewBaseSegment(materialID string, targetTimerange *capcut.Timerange) *BaseSegment {
+	return segment.NewBaseSegment(materialID, targetTimerange)
+}
+
+// Speed 播放速度对象，目前只支持固定速度
+// 对应Python的Speed类
+type Speed = segment.Speed
+
+// NewSpeed 创建新的播放速度对象
+func NewSpeed(speed float64) *Speed {
+	return segment.NewSpeed(speed)
+}
+
+// LoudnessAdjust 片段的响度统一设置，音量已根据素材的响度调整为使片段达到目标响度
+type LoudnessAdjust = segment.LoudnessAdjust
+
+// NewLoudnessAdjust 创建新的响度统一设置
+func NewLoudnessAdjust(targetLoudness float64) *LoudnessAdjust {
+	return segment.NewLoudnessAdjust(targetLoudness)
+}
+
+// ClipSettings 素材片段的图像调节设置
+// 对应Python的Clip_settings类
+type ClipSettings = segment.ClipSettings
+
+// NewClipSettings 创建新的图像调节设置，默认不作任何图像变换
+func NewClipSettings() *ClipSettings {
+	return segment.NewClipSettings()
+}
+
+// NewClipSettingsWithParams 创建带参数的图像调节设置
+func NewClipSettingsWithParams(alpha, rotation, scaleX, scaleY, transformX, transformY float64, flipH, flipV bool) *ClipSettings {
+	return segment.NewClipSettingsWithParams(alpha, rotation, scaleX, scaleY, transformX, transformY, flipH, flipV)
+}
+
+// MediaSegment 媒体片段基类
+// 对应Python的Media_segment类
+type MediaSegment = segment.MediaSegment
+
+// NewMediaSegment 创建媒体片段
+func NewMediaSegment(materialID string, sourceTimerange, targetTimerange *capcut.Timerange, speed, volume float64) *MediaSegment {
+	return segment.NewMediaSegment(materialID, sourceTimerange, targetTimerange, speed, volume)
+}
+
+// VisualSegment 视觉片段基类，用于处理所有可见片段（视频、贴纸、文本）的共同属性和行为
+// 对应Python的Visual_segment类
+type VisualSegment = segment.VisualSegment
+
+// NewVisualSegment 创建视觉片段
+func NewVisualSegment(materialID string, sourceTimerange, targetTimerange *capcut.Timerange, speed, volume float64, clipSettings *ClipSettings) *VisualSegment {
+	return segment.NewVisualSegment(materialID, sourceTimerange, targetTimerange, speed, volume, clipSettings)
+}
+
+// SegmentType 片段类型枚举
+type SegmentType = segment.SegmentType
+
+const (
+	SegmentTypeVideo   = segment.SegmentTypeVideo
+	SegmentTypeAudio   = segment.SegmentTypeAudio
+	SegmentTypeText    = segment.SegmentTypeText
+	SegmentTypeSticker = segment.SegmentTypeSticker
+	SegmentTypeEffect  = segment.SegmentTypeEffect
+)
+
+// Mask 蒙版对象
+// 对应Python的Mask类
+type Mask = segment.Mask
+
+// NewMask 创建新的蒙版对象
+func NewMask(name, resourceType, resourceID string, cx, cy, w, h, ratio, rot, feather, roundCorner float64, inv bool) *Mask {
+	return segment.NewMask(name, resourceType, resourceID, cx, cy, w, h, ratio, rot, feather, roundCorner, inv)
+}
+
+// VideoEffect 视频特效素材
+// 对应Python的Video_effect类
+type VideoEffect = segment.VideoEffect
+
+// NewVideoEffect 创建新的视频特效
+func NewVideoEffect(name, effectID, resourceID, effectType string, applyTargetType int) *VideoEffect {
+	return segment.NewVideoEffect(name, effectID, resourceID, effectType, applyTargetType)
+}
+
+// Filter 滤镜素材
+// 对应Python的Filter类
+type Filter = segment.Filter
+
+// NewFilter 创建新的滤镜
+func NewFilter(name, effectID, resourceID string, intensity float64, applyTargetType int) *Filter {
+	return segment.NewFilter(name, effectID, resourceID, intensity, applyTargetType)
+}
+
+// Transition 转场效果
+// 对应Python的Transition类
+type Transition = segment.Transition
+
+// NewTransition 创建新的转场效果
+func NewTransition(name, effectID, resourceID string, duration int64) *Transition {
+	return segment.NewTransition(name, effectID, resourceID, duration)
+}
+
+// BackgroundFilling 背景填充
+// 对应Python的BackgroundFilling类
+type BackgroundFilling = segment.BackgroundFilling
+
+// NewBackgroundFilling 创建新的背景填充
+func NewBackgroundFilling(fillType string, blur float64, color string) *BackgroundFilling {
+	return segment.NewBackgroundFilling(fillType, blur, color)
+}
+
+// VideoSegment 视频片段
+// 对应Python的Video_segment类
+type VideoSegment = segment.VideoSegment
+
+// NewVideoSegment 创建新的视频片段
+func NewVideoSegment(materialID string, sourceTimerange, targetTimerange *capcut.Timerange, speed, volume float64, clipSettings *ClipSettings) *VideoSegment {
+	return segment.NewVideoSegment(materialID, sourceTimerange, targetTimerange, speed, volume, clipSettings)
+}
+
+// AudioFade 音频淡入淡出效果
+// 对应Python的Audio_fade类
+type AudioFade = segment.AudioFade
+
+// NewAudioFade 创建新的音频淡入淡出效果
+func NewAudioFade(inDuration, outDuration int64) *AudioFade {
+	return segment.NewAudioFade(inDuration, outDuration)
+}
+
+// NewAudioFadeFromString 从字符串时间创建音频淡入淡出效果
+func NewAudioFadeFromString(inDuration, outDuration interface{}) (*AudioFade, error) {
+	return segment.NewAudioFadeFromString(inDuration, outDuration)
+}
+
+// AudioEffect 音频特效对象
+// 对应Python的Audio_effect类
+type AudioEffect = segment.AudioEffect
+
+// NewAudioEffect 创建新的音频特效
+func NewAudioEffect(name, resourceID, categoryID, categoryName string) *AudioEffect {
+	return segment.NewAudioEffect(name, resourceID, categoryID, categoryName)
+}
+
+// AudioEffectCategory 音频特效分类
+type AudioEffectCategory = segment.AudioEffectCategory
+
+// 预定义的音频特效分类
+var (
+	AudioEffectCategorySoundEffect  = segment.AudioEffectCategorySoundEffect
+	AudioEffectCategoryTone         = segment.AudioEffectCategoryTone
+	AudioEffectCategorySpeechToSong = segment.AudioEffectCategorySpeechToSong
+	// CapCut版本的分类
+	AudioEffectCategoryVoiceFilters       = segment.AudioEffectCategoryVoiceFilters
+	AudioEffectCategoryVoiceCharacters    = segment.AudioEffectCategoryVoiceCharacters
+	AudioEffectCategoryCapCutSpeechToSong = segment.AudioEffectCategoryCapCutSpeechToSong
+)
+
+// NewAudioEffectWithCategory 使用预定义分类创建音频特效
+func NewAudioEffectWithCategory(name, resourceID string, category AudioEffectCategory) *AudioEffect {
+	return segment.NewAudioEffectWithCategory(name, resourceID, category)
+}
+
+// AudioSegment 安放在轨道上的一个音频片段
+// 对应Python的Audio_segment类
+type AudioSegment = segment.AudioSegment
+
+// NewAudioSegment 创建新的音频片段
+func NewAudioSegment(materialID string, targetTimerange *capcut.Timerange, sourceTimerange *capcut.Timerange, speed, volume float64) *AudioSegment {
+	return segment.NewAudioSegment(materialID, targetTimerange, sourceTimerange, speed, volume)
+}
+
+// NewAudioSegmentSimple 创建简单的音频片段
+func NewAudioSegmentSimple(materialID string, targetTimerange *capcut.Timerange, volume float64) *AudioSegment {
+	return segment.NewAudioSegmentSimple(materialID, targetTimerange, volume)
+}
+
+// TextStyle 字体样式类
+// 对应Python的Text_style类
+type TextStyle = segment.TextStyle
+
+// NewTextStyle 创建新的文本样式
+func NewTextStyle() *TextStyle {
+	return segment.NewTextStyle()
+}
+
+// NewTextStyleWithParams 创建带参数的文本样式
+func NewTextStyleWithParams(size float64, bold, italic, underline bool, color [3]float64, alpha float64, align int, vertical bool, letterSpacing, lineSpacing int) *TextStyle {
+	return segment.NewTextStyleWithParams(size, bold, italic, underline, color, alpha, align, vertical, letterSpacing, lineSpacing)
+}
+
+// TextBorder 文本描边的参数
+// 对应Python的Text_border类
+type TextBorder = segment.TextBorder
+
+// NewTextBorder 创建新的文本描边
+func NewTextBorder(alpha float64, color [3]float64, width float64) *TextBorder {
+	return segment.NewTextBorder(alpha, color, width)
+}
+
+// NewTextBorderDefault 创建默认的文本描边
+func NewTextBorderDefault() *TextBorder {
+	return segment.NewTextBorderDefault()
+}
+
+// TextBackground 文本背景参数
+// 对应Python的Text_background类
+type TextBackground = segment.TextBackground
+
+// NewTextBackground 创建新的文本背景
+func NewTextBackground(color string, style int, alpha, roundRadius, height, width, horizontalOffset, verticalOffset float64) *TextBackground {
+	return segment.NewTextBackground(color, style, alpha, roundRadius, height, width, horizontalOffset, verticalOffset)
+}
+
+// NewTextBackgroundDefault 创建默认的文本背景
+func NewTextBackgroundDefault(color string) *TextBackground {
+	return segment.NewTextBackgroundDefault(color)
+}
+
+// TextShadow 文本阴影参数
+// 对应Python的Text_shadow类
+type TextShadow = segment.TextShadow
+
+// NewTextShadow 创建新的文本阴影
+func NewTextShadow(hasShadow bool, alpha, angle float64, color string, distance, smoothing float64) *TextShadow {
+	return segment.NewTextShadow(hasShadow, alpha, angle, color, distance, smoothing)
+}
+
+// NewTextShadowDefault 创建默认的文本阴影
+func NewTextShadowDefault() *TextShadow {
+	return segment.NewTextShadowDefault()
+}
+
+// TextBubble 文本气泡效果
+// 对应Python的TextBubble类（简化版本）
+type TextBubble = segment.TextBubble
+
+// NewTextBubble 创建新的文本气泡效果
+func NewTextBubble(effectID, resourceID, name string) *TextBubble {
+	return segment.NewTextBubble(effectID, resourceID, name)
+}
+
+// TextEffect 花字效果
+// 对应Python的TextEffect类（简化版本）
+type TextEffect = segment.TextEffect
+
+// NewTextEffect 创建新的花字效果
+func NewTextEffect(effectID, resourceID, name string) *TextEffect {
+	return segment.NewTextEffect(effectID, resourceID, name)
+}
+
+// TextStyleRange 多样式文本的样式范围
+// 对应Python的TextStyleRange类
+type TextStyleRange = segment.TextStyleRange
+
+// NewTextStyleRange 创建新的文本样式范围
+func NewTextStyleRange(start, end int, style *TextStyle, border *TextBorder, font string) *TextStyleRange {
+	return segment.NewTextStyleRange(start, end, style, border, font)
+}
+
+// TextSegment 文本片段
+// 对应Python的Text_segment类
+type TextSegment = segment.TextSegment
+
+// NewTextSegment 创建新的文本片段
+func NewTextSegment(text string, targetTimerange *capcut.Timerange, font string, style *TextStyle, clipSettings *ClipSettings) *TextSegment {
+	return segment.NewTextSegment(text, targetTimerange, font, style, clipSettings)
+}
+
+// NewTextSegmentSimple 创建简单的文本片段
+func NewTextSegmentSimple(text string, targetTimerange *capcut.Timerange) *TextSegment {
+	return segment.NewTextSegmentSimple(text, targetTimerange)
+}
+
+// CreateFromTemplate 从模板文本片段创建新的文本片段（简化版本）
+func CreateFromTemplate(text string, targetTimerange *capcut.Timerange, template *TextSegment) *TextSegment {
+	return segment.CreateFromTemplate(text, targetTimerange, template)
+}
+
+// EffectSegment 放置在独立特效轨道上的特效片段
+// 对应Python的Effect_segment类
+type EffectSegment = segment.EffectSegment
+
+// NewEffectSegment 创建新的特效片段
+// 对应Python的Effect_segment.__init__方法
+func NewEffectSegment(effectMeta metadata.EffectMeta, targetTimerange *capcut.Timerange, params []float64) (*EffectSegment, error) {
+	return segment.NewEffectSegment(effectMeta, targetTimerange, params)
+}
+
+// NewVideoEffectFromMeta 从元数据创建VideoEffect
+func NewVideoEffectFromMeta(effectMeta metadata.EffectMeta, params []metadata.EffectParamInstance, applyTargetType int) *VideoEffect {
+	return segment.NewVideoEffectFromMeta(effectMeta, params, applyTargetType)
+}
+
+// FilterSegment 放置在独立滤镜轨道上的滤镜片段
+// 对应Python的Filter_segment类
+type FilterSegment = segment.FilterSegment
+
+// NewFilterSegment 创建新的滤镜片段
+// 对应Python的Filter_segment.__init__方法
+func NewFilterSegment(filterMeta metadata.EffectMeta, targetTimerange *capcut.Timerange, intensity float64) *FilterSegment {
+	return segment.NewFilterSegment(filterMeta, targetTimerange, intensity)
+}
+
+// NewFilterFromMeta 从元数据创建Filter
+func NewFilterFromMeta(filterMeta metadata.EffectMeta, intensity float64, applyTargetType int) *Filter {
+	return segment.NewFilterFromMeta(filterMeta, intensity, applyTargetType)
+}
+
+// StickerMaterial 贴纸素材
+type StickerMaterial = segment.StickerMaterial
+
+// NewStickerMaterial 创建新的贴纸素材
+func NewStickerMaterial(resourceID string) *StickerMaterial {
+	return segment.NewStickerMaterial(resourceID)
+}
+
+// StickerSegment 贴纸片段，放置在贴纸轨道上
+// 对应Python的Sticker_segment类
+//
+// 贴纸的动画与文本动画通用，可通过Animations.AddTextAnimation添加
+type StickerSegment = segment.StickerSegment
+
+// NewStickerSegment 创建新的贴纸片段，clipSettings为nil时使用默认的图像调节设置
+func NewStickerSegment(resourceID string, targetTimerange *capcut.Timerange, clipSettings *ClipSettings) *StickerSegment {
+	return segment.NewStickerSegment(resourceID, targetTimerange, clipSettings)
+}
+
+// NewStickerSegmentFromType 根据已注册的贴纸创建贴纸片段
+func NewStickerSegmentFromType(stickerType metadata.EffectEnumerable, targetTimerange *capcut.Timerange, clipSettings *ClipSettings) (*StickerSegment, error) {
+	return segment.NewStickerSegmentFromType(stickerType, targetTimerange, clipSettings)
+}
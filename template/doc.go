@@ -0,0 +1,3 @@
+// Package template 提供从已有草稿导入轨道与片段的功能，
+// 以及替换素材时处理时长变化的ShrinkMode和ExtendMode
+package template
@@ -0,0 +1,128 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package template
+
+import (
+	"github.com/zhangshican/go-capcut"
+	"github.com/zhangshican/go-capcut/content"
+	"github.com/zhangshican/go-capcut/internal/template"
+)
+
+// ShrinkMode 处理替换素材时素材变短情况的方法
+// 对应Python的Shrink_mode枚举
+type ShrinkMode = template.ShrinkMode
+
+const (
+	ShrinkModeCutHead      = template.ShrinkModeCutHead      // 裁剪头部，即后移片段起始点
+	ShrinkModeCutTail      = template.ShrinkModeCutTail      // 裁剪尾部，即前移片段终止点
+	ShrinkModeCutTailAlign = template.ShrinkModeCutTailAlign // 裁剪尾部并消除间隙，即前移片段终止点，后续片段也依次前移
+	ShrinkModeShrink       = template.ShrinkModeShrink       // 保持中间点不变，两端点向中间靠拢
+)
+
+// ExtendMode 处理替换素材时素材变长情况的方法
+// 对应Python的Extend_mode枚举
+type ExtendMode = template.ExtendMode
+
+const (
+	ExtendModeCutMaterialTail = template.ExtendModeCutMaterialTail // 裁剪素材尾部，使得片段维持原长不变，此方法总是成功
+	ExtendModeExtendHead      = template.ExtendModeExtendHead      // 延伸头部，即尝试前移片段起始点，与前续片段重合时失败
+	ExtendModeExtendTail      = template.ExtendModeExtendTail      // 延伸尾部，即尝试后移片段终止点，与后续片段重合时失败
+	ExtendModePushTail        = template.ExtendModePushTail        // 延伸尾部，若有必要则依次后移后续片段，此方法总是成功
+)
+
+// ImportedSegment 导入的片段
+// 对应Python的ImportedSegment类
+//
+// 片段以原始json数据为基础导出，其中时间范围、附加素材引用、图像调节设置和关键帧会被解析为对应的类型，
+// 导出时只有被修改过的字段才会写回原始数据
+type ImportedSegment = template.ImportedSegment
+
+// NewImportedSegment 从map形式的json数据创建导入的片段
+func NewImportedSegment(jsonData map[string]interface{}) (*ImportedSegment, error) {
+	return template.NewImportedSegment(jsonData)
+}
+
+// NewImportedSegmentFromContent 从草稿内容模型创建导入的片段，data作为片段的原始数据被保留
+func NewImportedSegmentFromContent(data *content.Segment) (*ImportedSegment, error) {
+	return template.NewImportedSegmentFromContent(data)
+}
+
+// ImportedMediaSegment 导入的视频/音频片段
+// 对应Python的ImportedMediaSegment类
+type ImportedMediaSegment = template.ImportedMediaSegment
+
+// NewImportedMediaSegment 从map形式的json数据创建导入的媒体片段
+func NewImportedMediaSegment(jsonData map[string]interface{}) (*ImportedMediaSegment, error) {
+	return template.NewImportedMediaSegment(jsonData)
+}
+
+// NewImportedMediaSegmentFromContent 从草稿内容模型创建导入的媒体片段，data作为片段的原始数据被保留
+func NewImportedMediaSegmentFromContent(data *content.Segment) (*ImportedMediaSegment, error) {
+	return template.NewImportedMediaSegmentFromContent(data)
+}
+
+// ImportedTrack 模板模式下导入的轨道
+// 对应Python的ImportedTrack类
+type ImportedTrack = template.ImportedTrack
+
+// NewImportedTrack 创建导入的轨道
+func NewImportedTrack(jsonData map[string]interface{}) (*ImportedTrack, error) {
+	return template.NewImportedTrack(jsonData)
+}
+
+// EditableTrack 模板模式下导入且可修改的轨道(音视频及文本轨道)
+// 对应Python的EditableTrack类
+type EditableTrack = template.EditableTrack
+
+// NewEditableTrack 创建可编辑轨道
+func NewEditableTrack(jsonData map[string]interface{}) (*EditableTrack, error) {
+	return template.NewEditableTrack(jsonData)
+}
+
+// ImportedTextTrack 模板模式下导入的文本轨道
+// 对应Python的ImportedTextTrack类
+type ImportedTextTrack = template.ImportedTextTrack
+
+// NewImportedTextTrack 创建导入的文本轨道
+func NewImportedTextTrack(jsonData map[string]interface{}) (*ImportedTextTrack, error) {
+	return template.NewImportedTextTrack(jsonData)
+}
+
+// ImportedMediaTrack 模板模式下导入的音频/视频轨道
+// 对应Python的ImportedMediaTrack类
+type ImportedMediaTrack = template.ImportedMediaTrack
+
+// NewImportedMediaTrack 创建导入的媒体轨道
+func NewImportedMediaTrack(jsonData map[string]interface{}) (*ImportedMediaTrack, error) {
+	return template.NewImportedMediaTrack(jsonData)
+}
+
+// CheckMaterialType 检查素材类型是否与给定的轨道类型匹配，视频轨道接受视频素材，音频轨道接受音频素材
+func CheckMaterialType(trackType capcut.TrackType, mat interface{}) bool {
+	return template.CheckMaterialType(trackType, mat)
+}
+
+// ProcessTimerange 在按时间顺序排列的媒体片段列表上处理素材替换的时间范围变更
+//
+// 片段的新时长为素材截取时长除以片段的播放速度；时长变短时按shrinkMode处理，
+// 变长时依次尝试extendModes中的方法，全部失败时返回ExtensionFailedError。
+// 片段保存srcTimerange的副本，不会修改调用方的对象
+func ProcessTimerange(segments []*ImportedMediaSegment, segIndex int, srcTimerange *capcut.Timerange, shrinkMode ShrinkMode, extendModes []ExtendMode) error {
+	return template.ProcessTimerange(segments, segIndex, srcTimerange, shrinkMode, extendModes)
+}
+
+// ImportTrack 从map形式的json数据导入轨道
+// 对应Python的import_track函数
+//
+// importedMaterials为草稿的materials字段，用于查找片段的变速素材
+func ImportTrack(jsonData map[string]interface{}, importedMaterials map[string]interface{}) (*capcut.Track, error) {
+	return template.ImportTrack(jsonData, importedMaterials)
+}
+
+// ImportTrackFromContent 从草稿内容模型导入轨道，data作为轨道的原始数据被保留
+//
+// 轨道中的所有片段均被导入：音视频片段导入为ImportedMediaSegment，其余片段导入为ImportedSegment；
+// 片段没有速度字段时，播放速度从materials中其引用的变速素材得到
+func ImportTrackFromContent(data *content.Track, materials *content.Materials) (*capcut.Track, error) {
+	return template.ImportTrackFromContent(data, materials)
+}
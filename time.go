@@ -0,0 +1,64 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package capcut
+
+import (
+	"github.com/zhangshican/go-capcut/internal/types"
+)
+
+// SEC 一秒=1e6微秒
+const (
+	SEC = types.SEC
+)
+
+// Tim 将输入的字符串转换为微秒，也可直接输入微秒数
+// 支持类似 "1h52m3s" 或 "0.15s" 这样的格式，可包含负号以表示负偏移
+// 对应Python的tim函数
+func Tim(inp interface{}) (int64, error) {
+	return types.Tim(inp)
+}
+
+// Timerange 记录了起始时间及持续长度的时间范围
+// 对应Python的Timerange类
+type Timerange = types.Timerange
+
+// NewTimerange 构造一个时间范围
+func NewTimerange(start, duration int64) *Timerange {
+	return types.NewTimerange(start, duration)
+}
+
+// Trange Timerange的简便构造函数，接受字符串或微秒数作为参数
+// 支持类似 "1h52m3s" 或 "0.15s" 这样的格式
+// 对应Python的trange函数
+func Trange(start, duration interface{}) (*Timerange, error) {
+	return types.Trange(start, duration)
+}
+
+// MustTrange Trange的不返回错误版本，遇到错误会panic
+// 主要用于测试和已知正确的场景
+func MustTrange(start, duration interface{}) *Timerange {
+	return types.MustTrange(start, duration)
+}
+
+// SrtTimestamp 解析SRT中的时间戳字符串，返回微秒数
+// 格式: "01:23:45,678"
+// 对应Python的srt_tstamp函数
+func SrtTimestamp(srtTimestamp string) (int64, error) {
+	return types.SrtTimestamp(srtTimestamp)
+}
+
+// FormatDuration 将微秒格式化为可读的时间字符串
+// 例如: 3661000000 -> "1h1m1s"
+func FormatDuration(micros int64) string {
+	return types.FormatDuration(micros)
+}
+
+// MicrosecondsToSeconds 将微秒转换为秒（浮点数）
+func MicrosecondsToSeconds(micros int64) float64 {
+	return types.MicrosecondsToSeconds(micros)
+}
+
+// SecondsToMicroseconds 将秒（浮点数）转换为微秒
+func SecondsToMicroseconds(seconds float64) int64 {
+	return types.SecondsToMicroseconds(seconds)
+}
@@ -0,0 +1,96 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package capcut
+
+import (
+	"github.com/zhangshican/go-capcut/internal/segment"
+	"github.com/zhangshican/go-capcut/internal/track"
+)
+
+// TrackMeta 与轨道类型关联的轨道元数据
+// 对应Python的Track_meta类
+type TrackMeta = track.TrackMeta
+
+// TrackType 轨道类型枚举
+// 对应Python的Track_type枚举
+type TrackType = track.TrackType
+
+const (
+	TrackTypeVideo   = track.TrackTypeVideo
+	TrackTypeAudio   = track.TrackTypeAudio
+	TrackTypeEffect  = track.TrackTypeEffect
+	TrackTypeFilter  = track.TrackTypeFilter
+	TrackTypeSticker = track.TrackTypeSticker
+	TrackTypeText    = track.TrackTypeText
+	TrackTypeAdjust  = track.TrackTypeAdjust // 仅供导入时使用，不要尝试新建此类型的轨道
+)
+
+// TrackTypeFromName 根据名称获取轨道类型
+// 对应Python的Track_type.from_name方法
+func TrackTypeFromName(name string) (TrackType, error) {
+	return track.TrackTypeFromName(name)
+}
+
+// GetTrackMeta 获取轨道类型的元数据
+func GetTrackMeta(trackType TrackType) TrackMeta {
+	return track.GetTrackMeta(trackType)
+}
+
+// BaseTrack 轨道基类接口
+// 对应Python的Base_track抽象基类
+type BaseTrack = track.BaseTrack
+
+// PendingKeyframe 待处理的关键帧
+type PendingKeyframe = track.PendingKeyframe
+
+// Track 非模板模式下的轨道
+// 对应Python的Track[Seg_type]泛型类
+type Track = track.Track
+
+// NewTrack 创建新的轨道
+func NewTrack(trackType TrackType, name string, renderIndex int, mute bool) *Track {
+	return track.NewTrack(trackType, name, renderIndex, mute)
+}
+
+// TrackTypeOfSegment 返回接受给定片段的轨道类型
+func TrackTypeOfSegment(seg segment.SegmentInterface) (TrackType, error) {
+	return track.TrackTypeOfSegment(seg)
+}
+
+// RipplePolicy 波纹编辑时对跨越编辑点的片段的处理方式
+type RipplePolicy = track.RipplePolicy
+
+const (
+	// RippleSplit 在编辑点处分割片段，仅支持视频、音频、文本及导入的片段
+	RippleSplit = track.RippleSplit
+	// RipplePush 保持片段完整，将其与编辑点之后的片段一起推移
+	RipplePush = track.RipplePush
+	// RippleError 存在跨越编辑点的片段时返回错误
+	RippleError = track.RippleError
+)
+
+// OverlapPolicy 新片段与轨道上已有片段重叠时的处理方式
+type OverlapPolicy = track.OverlapPolicy
+
+const (
+	// OverlapReject 拒绝添加，返回*util.SegmentOverlapError
+	OverlapReject = track.OverlapReject
+	// OverlapOverwrite 覆盖新片段范围内的内容：裁剪部分重叠的片段，移除被完全覆盖的片段，新片段位于已有片段中间时将其分为两部分
+	OverlapOverwrite = track.OverlapOverwrite
+	// OverlapInsert 在新片段开始处插入新片段的时长，之后的片段整体向后推移，跨越该处的片段被分为两部分
+	OverlapInsert = track.OverlapInsert
+	// OverlapSpill 将新片段放到另一条同类型的轨道上，需要由草稿选择轨道，单独的轨道上等同于OverlapReject
+	OverlapSpill = track.OverlapSpill
+)
+
+// GapStrategy 填补轨道上空隙的方式
+type GapStrategy = track.GapStrategy
+
+const (
+	// GapClose 删除空隙，之后的片段整体向前推移，单独的轨道上只推移该轨道，草稿中可使其他轨道一同推移
+	GapClose = track.GapClose
+	// GapExtend 延长空隙前的片段以覆盖空隙，无法延长时改为向前延长空隙后的片段，仅支持视频、音频及文本片段
+	GapExtend = track.GapExtend
+	// GapPlaceholder 在空隙处插入带有背景填充的占位片段，需要由草稿提供素材，单独的轨道上不支持
+	GapPlaceholder = track.GapPlaceholder
+)
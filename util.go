@@ -0,0 +1,118 @@
+// Code generated by apigen; DO NOT EDIT.
+
+package capcut
+
+import (
+	"github.com/zhangshican/go-capcut/internal/util"
+	"reflect"
+)
+
+// JsonExportable 定义可导出为JSON的类型
+// 对应Python的JsonExportable类型别名
+type JsonExportable = util.JsonExportable
+
+// JSONExportable 接口定义可导出JSON的对象
+type JSONExportable = util.JSONExportable
+
+// JSONImportable 接口定义可从JSON导入的对象
+type JSONImportable = util.JSONImportable
+
+// ProvideCtorDefaults 为结构体类型提供默认值
+// 对应Python的provide_ctor_defaults函数
+func ProvideCtorDefaults(t reflect.Type) (map[string]interface{}, error) {
+	return util.ProvideCtorDefaults(t)
+}
+
+// AssignAttrWithJSON 根据JSON数据为对象属性赋值
+// 对应Python的assign_attr_with_json函数
+func AssignAttrWithJSON(obj interface{}, attrs []string, jsonData map[string]interface{}) error {
+	return util.AssignAttrWithJSON(obj, attrs, jsonData)
+}
+
+// ExportAttrToJSON 将对象属性导出为JSON数据
+// 对应Python的export_attr_to_json函数
+func ExportAttrToJSON(obj interface{}, attrs []string) (map[string]interface{}, error) {
+	return util.ExportAttrToJSON(obj, attrs)
+}
+
+// HexToRGB 将十六进制颜色代码转换为RGB元组 (范围0.0-1.0)
+// 对应根目录util.py的hex_to_rgb函数
+func HexToRGB(hexColor string) (r, g, b float64, err error) {
+	return util.HexToRGB(hexColor)
+}
+
+// IsWindowsPath 检测路径是否为Windows风格
+// 对应根目录util.py的is_windows_path函数
+func IsWindowsPath(path string) bool {
+	return util.IsWindowsPath(path)
+}
+
+// DraftPathPlaceholder 剪映草稿中代表草稿文件夹的占位符，以其开头的素材路径相对于草稿文件夹，
+// 如"##_draftpath_placeholder_0E685133-18CE-45ED-8CB8-2904A212EC80_##/media/a.mp4"
+const (
+	DraftPathPlaceholder = util.DraftPathPlaceholder
+)
+
+// IsDraftRelativePath 检测路径是否为以DraftPathPlaceholder开头的草稿相对路径
+func IsDraftRelativePath(path string) bool {
+	return util.IsDraftRelativePath(path)
+}
+
+// URLToHash 将URL转换为固定长度的哈希字符串
+// 对应根目录util.py的url_to_hash函数
+func URLToHash(url string, length int) string {
+	return util.URLToHash(url, length)
+}
+
+// ToInt64 将JSON中解析出的数值（float64、json.Number、整数类型等）转换为int64
+func ToInt64(value interface{}) (int64, bool) {
+	return util.ToInt64(value)
+}
+
+// ToFloat64 将JSON中解析出的数值（float64、json.Number、整数类型等）转换为float64
+func ToFloat64(value interface{}) (float64, bool) {
+	return util.ToFloat64(value)
+}
+
+// IDGenerator id生成器，决定草稿中新建对象的id
+type IDGenerator = util.IDGenerator
+
+// RandomIDGenerator 随机id生成器，生成UUID v4格式的id，为默认的生成器
+type RandomIDGenerator = util.RandomIDGenerator
+
+// SeededIDGenerator 基于种子的确定性id生成器
+//
+// 第n个id由种子与序号的哈希得到（UUID v5格式），相同的种子总是生成相同的id序列
+type SeededIDGenerator = util.SeededIDGenerator
+
+// NewSeededIDGenerator 创建基于种子的确定性id生成器
+func NewSeededIDGenerator(seed string) *SeededIDGenerator {
+	return util.NewSeededIDGenerator(seed)
+}
+
+// NewID 生成一个随机id，供各类对象的构造函数使用
+//
+// 需要确定性的id时，应通过ScriptFile.SetIDGenerator为草稿设置生成器，导出时新建对象的id会被替换
+func NewID() string {
+	return util.NewID()
+}
+
+// PathRule 路径前缀映射规则，将以From开头的路径改写为以To开头
+//
+// From和To均可使用Windows或类Unix风格的路径。匹配时不区分两种分隔符，且只在完整的路径组成部分上匹配，
+// 如"/srv/media"匹配"/srv/media/a.mp4"而不匹配"/srv/media2/a.mp4"；含盘符的From不区分大小写。
+// 改写后的路径统一使用To的分隔符：To含反斜杠时使用反斜杠，否则使用正斜杠
+type PathRule = util.PathRule
+
+// DraftRelativeRule 返回将草稿文件夹draftDir中的路径改写为以DraftPathPlaceholder开头的草稿相对路径的规则
+func DraftRelativeRule(draftDir string) PathRule {
+	return util.DraftRelativeRule(draftDir)
+}
+
+// PathMapper 按前缀规则改写素材路径，多条规则匹配时使用From最长的规则
+type PathMapper = util.PathMapper
+
+// NewPathMapper 创建路径映射器，忽略From为空的规则
+func NewPathMapper(rules ...PathRule) *PathMapper {
+	return util.NewPathMapper(rules...)
+}